@@ -0,0 +1,81 @@
+package webfram
+
+import (
+	"maps"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Accepts restricts the request bodies accepted by this handler to the given media types, e.g. "application/json".
+// A media type can be a wildcard such as "image/*". Requests with a body of another Content-Type are rejected
+// with 415 Unsupported Media Type before the handler and its middlewares run; requests without a body are served.
+// The request body of the handler's OpenAPI operation documents exactly these media types, so the accepted types
+// are declared once: documented media types keep their TypeInfo, and the others get the TypeInfo of the first
+// documented one. Call it before or after OpenAPIOperation.
+func (h *HandlerConfig) Accepts(mediaTypes ...string) *HandlerConfig {
+	h.accepts = mediaTypes
+	return h
+}
+
+// acceptsMiddleware rejects requests whose body has a media type other than the accepted ones.
+func acceptsMiddleware(accepted []string) AppMiddleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(w ResponseWriter, r *Request) {
+			if r.ContentLength == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if !slices.ContainsFunc(accepted, func(a string) bool { return mediaTypeMatches(a, mediaType) }) {
+				w.Error(http.StatusUnsupportedMediaType, http.StatusText(http.StatusUnsupportedMediaType))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// mediaTypeMatches reports whether mediaType matches the accepted media type, which can be "type/*" or "*/*".
+func mediaTypeMatches(accepted, mediaType string) bool {
+	if mediaType == "" {
+		return false
+	}
+
+	if strings.EqualFold(accepted, mediaType) || accepted == "*/*" {
+		return true
+	}
+
+	prefix, isWildcard := strings.CutSuffix(accepted, "/*")
+	mainType, _, _ := strings.Cut(mediaType, "/")
+	return isWildcard && strings.EqualFold(prefix, mainType)
+}
+
+// acceptedRequestBody returns a copy of body whose content lists exactly the accepted media types.
+// Media types missing from body get the TypeInfo of the first documented one, in sorted order.
+func acceptedRequestBody(body *RequestBody, accepted []string) *RequestBody {
+	var rb RequestBody
+	if body != nil {
+		rb = *body
+	}
+
+	var fallback TypeInfo
+	if documented := slices.Sorted(maps.Keys(rb.Content)); len(documented) > 0 {
+		fallback = rb.Content[documented[0]]
+	}
+
+	content := make(map[string]TypeInfo, len(accepted))
+	for _, mediaType := range accepted {
+		if typeInfo, ok := rb.Content[mediaType]; ok {
+			content[mediaType] = typeInfo
+		} else {
+			content[mediaType] = fallback
+		}
+	}
+	rb.Content = content
+
+	return &rb
+}
@@ -0,0 +1,121 @@
+package webfram
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type acceptsPayload struct {
+	Name string `json:"name" xml:"name"`
+}
+
+func setupAcceptsMux(t *testing.T) *ServeMux {
+	t.Helper()
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+
+	Configure(&Config{
+		OpenAPI: &OpenAPI{
+			Enabled: true,
+			Config:  &OpenAPIConfig{Info: &Info{Title: "Test API", Version: "1.0.0"}},
+		},
+	})
+
+	mux := NewServeMux()
+	mux.HandleFunc("POST /items", func(w ResponseWriter, _ *Request) {
+		w.NoContent()
+	}).OpenAPIOperation(OperationConfig{
+		RequestBody: &RequestBody{
+			Required: true,
+			Content: map[string]TypeInfo{
+				"application/json": {TypeHint: &acceptsPayload{}},
+				"text/plain":       {},
+			},
+		},
+	}).Accepts("application/json", "application/xml")
+	mux.HandleFunc("PUT /images/{id}", func(w ResponseWriter, _ *Request) {
+		w.NoContent()
+	}).Accepts("image/*")
+
+	setupOpenAPIEndpoints(mux)
+	registerHandlers(mux)
+
+	return mux
+}
+
+func TestAccepts_RejectsUnsupportedMediaTypes(t *testing.T) {
+	mux := setupAcceptsMux(t)
+
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		contentType string
+		body        string
+		expected    int
+	}{
+		{"accepted", http.MethodPost, "/items", "application/json; charset=utf-8", `{"name":"a"}`, http.StatusNoContent},
+		{"second accepted", http.MethodPost, "/items", "application/xml", `<a/>`, http.StatusNoContent},
+		{"not accepted", http.MethodPost, "/items", "text/plain", "a", http.StatusUnsupportedMediaType},
+		{"missing content type", http.MethodPost, "/items", "", "a", http.StatusUnsupportedMediaType},
+		{"no body", http.MethodPost, "/items", "", "", http.StatusNoContent},
+		{"wildcard", http.MethodPut, "/images/1", "image/png", "png", http.StatusNoContent},
+		{"wildcard mismatch", http.MethodPut, "/images/1", "application/pdf", "pdf", http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Errorf("Expected status %d, got %d", tt.expected, rec.Code)
+			}
+		})
+	}
+}
+
+func TestAccepts_DocumentsExactlyTheAcceptedMediaTypes(t *testing.T) {
+	mux := setupAcceptsMux(t)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", http.NoBody))
+
+	var doc struct {
+		Paths map[string]map[string]struct {
+			RequestBody struct {
+				Required bool                      `json:"required"`
+				Content  map[string]map[string]any `json:"content"`
+			} `json:"requestBody"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("Failed to decode OpenAPI document: %v", err)
+	}
+
+	body := doc.Paths["/items"]["post"].RequestBody
+	var documented []string
+	for mediaType := range body.Content {
+		documented = append(documented, mediaType)
+	}
+	slices.Sort(documented)
+
+	if want := []string{"application/json", "application/xml"}; !slices.Equal(documented, want) {
+		t.Fatalf("Expected request body media types %v, got %v", want, documented)
+	}
+	if !body.Required {
+		t.Error("Expected the request body to stay required")
+	}
+	if body.Content["application/xml"]["schema"] == nil {
+		t.Error("Expected application/xml to reuse the documented schema")
+	}
+}
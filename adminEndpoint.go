@@ -0,0 +1,34 @@
+package webfram
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+
+	"github.com/bondowe/webfram/security"
+)
+
+// ErrEndpointUnprotected is the panic value of ListenAndServe when an operator endpoint, such as DebugConfig
+// or I18nReload, is enabled without a security configuration requiring authentication.
+var ErrEndpointUnprotected = errors.New(
+	"endpoint requires authentication: set its Security, ServeMux.UseSecurity or Config.Security")
+
+// setupAdminEndpoint registers handler for pattern on mux, unless mux already has a handler for it, for the
+// operator endpoint called name. The endpoint is authenticated with sc, or the mux and App security otherwise.
+// It must be called before the handlers of mux are registered.
+// Panics with ErrEndpointUnprotected if the endpoint would be reachable without authentication.
+func setupAdminEndpoint(mux *ServeMux, name, pattern string, sc *security.Config, handler HandlerFunc) {
+	if mux.hasPattern(pattern) {
+		return
+	}
+
+	securityConfig := cmp.Or(sc, mux.securityConfig, mux.getApp().securityConfig)
+	if securityConfig == nil || len(securityConfigMiddlewares(securityConfig)) == 0 {
+		panic(fmt.Errorf("%s %w", name, ErrEndpointUnprotected))
+	}
+
+	hc := mux.HandleFunc(pattern, handler)
+	if sc != nil {
+		hc.UseSecurity(*sc)
+	}
+}
@@ -44,16 +44,22 @@
 package webfram
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
+	"mime"
 	"net/http"
+	"net/netip"
 	"os"
 	"regexp"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/bondowe/webfram/internal/bind"
@@ -71,6 +77,47 @@ import (
 )
 
 type (
+	// App holds the configuration, global middlewares and registered handlers of an application.
+	// Apps are created with New, so that differently configured servers can coexist in one process.
+	// The package-level functions (Configure, Use, NewServeMux, ListenAndServe, ...) operate on a
+	// default App. Telemetry metrics are process-wide and shared by all apps.
+	App struct {
+		configured               bool
+		telemetryConfig          *Telemetry
+		securityConfigs          []security.Config
+		securityConfig           *security.Config
+		securitySchemes          map[string]security.Config
+		assetsFS                 fs.FS
+		middlewares              []AppMiddleware
+		openAPIConfig            *OpenAPI
+		debugConfig              *DebugConfig
+		i18nReload               *I18nReload
+		templates                *template.Templates
+		i18nCatalogs             *i18n.Catalogs
+		templateDir              string
+		i18nMessagesDir          string
+		jsonpCallbackParamName   string
+		jsonpContentType         string
+		jsonpSafeCallback        bool
+		jsonpCallbackMaxLength   int
+		jsonpDisallowedCallbacks []string
+		allowMethodOverride      bool
+		deprecationWarningHeader bool
+		validateResponses        bool
+		maxUploadSize            int64
+		multipartMaxMemory       int64
+		decompressRequests       bool
+		maxDecompressedBodySize  int64
+		trustedProxies           []netip.Prefix
+		staticFS                 fs.FS
+		staticURLPath            string
+		assetFingerprints        sync.Map // static file name -> content hash
+		handlerConfigs           []*HandlerConfig
+		onError                  func(*Request, int, error)
+		onValidationError        func(*Request, *ValidationErrors)
+		activeServers            atomic.Int32
+	}
+
 	contextKey string
 	// Middleware is a generic middleware function that wraps handlers.
 	Middleware[H any] = func(H) H
@@ -94,10 +141,15 @@ type (
 	}
 	// SSEPayloadFunc is a function that generates SSE payloads.
 	SSEPayloadFunc func() SSEPayload
+	// SSEContextPayloadFunc is a function that generates SSE payloads from a source that may fail or block.
+	// The context is the request context, canceled when the client disconnects.
+	SSEContextPayloadFunc func(ctx context.Context) (SSEPayload, error)
 	// SSEDisconnectFunc is called when an SSE connection is closed.
 	SSEDisconnectFunc func()
 	// SSEErrorFunc is called when an SSE error occurs.
 	SSEErrorFunc func(error)
+	// SSEBackpressurePolicy determines what happens when a client's SSE buffer is full.
+	SSEBackpressurePolicy int
 
 	// sseWriter interface for testability.
 	sseWriter interface {
@@ -116,10 +168,13 @@ type (
 	SSEHandler struct {
 		headers        map[string]string
 		payloadFunc    SSEPayloadFunc
+		ctxPayloadFunc SSEContextPayloadFunc
 		disconnectFunc SSEDisconnectFunc
 		errorFunc      SSEErrorFunc
 		writerFactory  func(http.ResponseWriter) sseWriter
 		interval       time.Duration
+		bufferSize     int
+		policy         SSEBackpressurePolicy
 	}
 
 	// ValidationError represents a single field validation error.
@@ -135,6 +190,18 @@ type (
 		Errors  []ValidationError `json:"errors" xml:"errors"           form:"errors"`
 	}
 
+	// DecodeError is returned by BindJSON when the request body is not valid JSON or does not match
+	// the target type, e.g. a string sent for an int field.
+	DecodeError struct {
+		// Field is the JSON path of the offending field (e.g., "address.zip"), empty if the
+		// error applies to the whole body.
+		Field string
+		// Message describes the error, localized with the request's i18n printer when available.
+		Message string
+		// Err is the underlying encoding/json error.
+		Err error
+	}
+
 	// Templates configures template settings for the framework.
 	Templates struct {
 		// Dir is the directory where template files are located.
@@ -145,6 +212,10 @@ type (
 		HTMLTemplateExtension string
 		// TextTemplateExtension is the file extension for text templates.
 		TextTemplateExtension string
+		// Layouts are the base names of additional layouts (e.g., "admin" for admin.go.html files) that
+		// can be selected per render with ResponseWriter.HTMLWithLayout. They are looked up in the template
+		// directories like the default layout.
+		Layouts []string
 	}
 
 	// Telemetry configures telemetry settings for the framework.
@@ -155,13 +226,17 @@ type (
 		Collectors []prometheus.Collector
 		// URLPath is the HTTP path for the metrics endpoint (e.g., "GET /metrics").
 		URLPath string
-		// Addr is the optional address for a separate telemetry server (e.g., ":9090").
-		// If empty or equal to the main server address, telemetry runs on the main server.
+		// Addr is the optional address for a separate telemetry server (e.g., ":9090"), started and
+		// shut down with the main server. If empty or on the same port as the main server address
+		// (e.g., "0.0.0.0:8080" and ":8080"), telemetry runs on the main server.
 		Addr string
 		// Enabled indicates whether telemetry is enabled.
 		Enabled bool
 		// HandlerOpts are options for the Prometheus HTTP handler.
 		HandlerOpts promhttp.HandlerOpts
+		// StatusClassifier returns the "status" label of the request metrics for a response status code,
+		// e.g. to report 404 apart from other 4xx responses. Defaults to the status class ("2xx", "4xx", ...).
+		StatusClassifier func(statusCode int) string
 	}
 
 	// I18nMessages configures internationalization message settings.
@@ -170,6 +245,28 @@ type (
 		Dir string
 		// SupportedLanguages is a list of supported language tags.
 		SupportedLanguages []string
+		// Matcher overrides the strategy used to match the Accept-Language header against
+		// the supported languages. Defaults to language.NewMatcher(SupportedLanguages).
+		Matcher language.Matcher
+		// Fallback lists the languages, in order, whose messages are used when a message is missing
+		// in the requested language and its parent languages (e.g., []string{"en"}).
+		// Without a fallback, missing messages are shown as their message ID.
+		Fallback []string
+		// Reload configures an endpoint reloading the message files at runtime, as ReloadI18n does.
+		Reload *I18nReload
+	}
+
+	// I18nReload configures the endpoint reloading the i18n message files at runtime, so that fixed
+	// translations are served without a restart. It accepts POST requests and responds with 204 No Content,
+	// or 500 Internal Server Error if a message file cannot be read, keeping the current messages.
+	I18nReload struct {
+		// Enabled registers the endpoint. It is disabled by default.
+		Enabled bool
+		// URLPath is the HTTP path of the endpoint (default: "POST /i18n/reload").
+		URLPath string
+		// Security authenticates the requests to the endpoint, overriding the ServeMux and Config security.
+		// The endpoint is only registered if one of them requires authentication.
+		Security *security.Config
 	}
 
 	// Assets configures static assets and their locations.
@@ -180,6 +277,17 @@ type (
 		Templates *Templates
 		// I18nMessages configures internationalization message settings.
 		I18nMessages *I18nMessages
+		// Static configures the static files served by ServeMux.StaticAssets with fingerprinted URLs.
+		Static *StaticAssets
+	}
+
+	// StaticAssets configures static files served with content-addressed URLs for cache busting.
+	// Templates reference them with the asset function, e.g. {{asset "js/main.js"}}.
+	StaticAssets struct {
+		// Dir is the directory of the assets file system containing the static files (default "assets/static").
+		Dir string
+		// URLPath is the URL path prefix the static files are served under (default "/static/").
+		URLPath string
 	}
 
 	// OpenAPI configures OpenAPI documentation settings.
@@ -191,6 +299,10 @@ type (
 		URLPath string
 		// Enabled indicates whether OpenAPI documentation is enabled.
 		Enabled bool
+		// ServerFromRequest advertises the scheme and host the document was requested from as the
+		// server URL when Config.Servers is empty, so the document works behind any hostname.
+		// The X-Forwarded-Proto and X-Forwarded-Host headers are honored for requests from Config.TrustedProxies.
+		ServerFromRequest bool
 	}
 
 	// Tag represents an OpenAPI tag definition.
@@ -516,7 +628,8 @@ type (
 		Servers []Server
 		// Tags is a list of OpenAPI tags.
 		Tags []Tag
-		// Security is a list of security requirements for the OpenAPI document.
+		// Security is a list of security requirements for the OpenAPI document. It applies to every
+		// operation whose OperationConfig.Security is nil; an empty list marks an operation as public.
 		Security []map[string][]string
 		// ExternalDocs provides external documentation for the OpenAPI document.
 		ExternalDocs *ExternalDocs
@@ -530,6 +643,11 @@ type (
 		Telemetry *Telemetry
 		// Security configures security settings for the framework.
 		Security *security.Config
+		// SecuritySchemes maps the names of the security schemes of the OpenAPI document to the authentication
+		// enforcing them. When set, routes documented with an OperationConfig enforce its Security requirements
+		// (or those of the OpenAPI document if it has none) instead of Security and ServeMux.UseSecurity:
+		// a request must satisfy all the schemes of at least one requirement.
+		SecuritySchemes map[string]security.Config
 		// I18nMessages configures internationalization message settings.
 		I18nMessages *I18nMessages
 		// Assets configures static assets and their locations.
@@ -538,19 +656,92 @@ type (
 		OpenAPI *OpenAPI
 		// JSONPCallbackParamName is the name of the query parameter for JSONP callbacks.
 		JSONPCallbackParamName string
+		// JSONPContentType is the Content-Type of JSONP responses (default: "application/javascript").
+		JSONPContentType string
+		// JSONPSafeCallback wraps JSONP output as "typeof cb === 'function' && cb(...)"
+		// so the response does nothing if the callback is not defined.
+		JSONPSafeCallback bool
+		// JSONPCallbackMaxLength is the maximum length of JSONP callback names (default: 64).
+		// Requests with a longer callback name are rejected with 400 Bad Request.
+		JSONPCallbackMaxLength int
+		// JSONPDisallowedCallbacks are callback names rejected with 400 Bad Request, such as "__proto__",
+		// "constructor" or "eval". Names are case-sensitive, like JavaScript identifiers.
+		JSONPDisallowedCallbacks []string
+		// AllowMethodOverride enables POST requests to be treated as PUT, PATCH or DELETE
+		// via the X-HTTP-Method-Override header, or the "_method" field of URL-encoded forms.
+		AllowMethodOverride bool
+		// DeprecationWarningHeader adds Deprecation (and Link) response headers to deprecated operations.
+		DeprecationWarningHeader bool
+		// ValidateResponses validates the JSON responses of the routes documented with an OperationConfig
+		// against the schemas of its Responses, and logs the mismatches, such as undeclared or missing
+		// properties and wrong types, as warnings. Responses are sent unchanged. Intended for development
+		// and tests only: every response is buffered and decoded again.
+		ValidateResponses bool
+		// MaxUploadSize is the maximum size in bytes of a multipart request body read by
+		// BindForm, Request.FormFile and Request.SaveUploadedFile (default: 32 MiB).
+		MaxUploadSize int64
+		// MultipartMaxMemory is the number of bytes of a multipart form kept in memory by
+		// BindForm and Request.FormFile; larger files are stored in temporary files (default: 10 MiB).
+		MultipartMaxMemory int64
+		// OnError is called with the request, status code and error when a handler responds with
+		// ResponseWriter.Error or when the Recovery middleware recovers from a panic, so error
+		// reporting (logging, error trackers, ...) can be centralized. It is called before the error
+		// response is written. The request is nil if the ResponseWriter is not bound to a request.
+		OnError func(r *Request, statusCode int, err error)
+		// OnValidationError is called with the request and its validation errors when a binder (BindJSON,
+		// BindForm, BindQuery, BindAll, PatchJSON, BindCSV, ...) finds invalid data, so validation failures
+		// can be logged or tracked by client without changing every handler. It is called once per bind call
+		// that yields validation errors, before the binder returns, and must not modify the errors.
+		OnValidationError func(r *Request, errs *ValidationErrors)
+		// DecompressRequests makes the body binders (BindJSON, BindXML, BindForm, BindCSV, PatchJSON, ...)
+		// decompress request bodies sent with "Content-Encoding: gzip" or "deflate". Other encodings are
+		// rejected with ErrUnsupportedMediaType.
+		DecompressRequests bool
+		// MaxDecompressedBodySize is the maximum size in bytes of a decompressed request body, which
+		// guards against decompression bombs (default: 10 MiB).
+		MaxDecompressedBodySize int64
+		// TrustedProxies lists the IP addresses and CIDR ranges (e.g., "10.0.0.0/8") of the reverse proxies
+		// whose X-Forwarded-For and X-Real-IP headers are used by Request.ClientIP. The headers of other
+		// peers are ignored, since any client can set them.
+		TrustedProxies []string
+		// DebugConfig configures an endpoint returning the effective, non-secret configuration of the App.
+		DebugConfig *DebugConfig
+	}
+
+	// DebugConfig configures the endpoint returning the effective configuration of the App as JSON:
+	// template and i18n settings, telemetry and OpenAPI endpoints, security schemes and middleware counts.
+	// Secrets such as credentials and keys are never included.
+	DebugConfig struct {
+		// Enabled registers the endpoint. It is disabled by default.
+		Enabled bool
+		// URLPath is the HTTP path of the endpoint (default: "GET /debug/config").
+		URLPath string
+		// Security authenticates the requests to the endpoint, overriding the ServeMux and Config security.
+		// The endpoint is only registered if one of them requires authentication.
+		Security *security.Config
 	}
 )
 
 const (
 	jsonpCallbackMethodNameKey   contextKey = "jsonpCallbackMethodName"
+	appKey                       contextKey = "app"
 	defaultTelemetryURLPath      string     = "GET /metrics"
 	defaultOpenAPIURLPath        string     = "GET /openapi.json"
+	defaultDebugConfigURLPath    string     = "GET /debug/config"
 	defaultTemplateDir           string     = "assets/templates"
 	defaultLayoutBaseName        string     = "layout"
 	defaultHTMLTemplateExtension string     = ".go.html"
 	defaultTextTemplateExtension string     = ".go.txt"
 	defaultI18nMessagesDir       string     = "assets/locales"
+	defaultI18nReloadURLPath     string     = "POST /i18n/reload"
 	defaultI18nFuncName          string     = "T"
+	defaultJSONPContentType      string     = "application/javascript"
+	defaultJSONPCallbackMaxLen   int        = 64
+	defaultMaxUploadSize         int64      = 32 << 20
+	defaultMultipartMaxMemory    int64      = 10 << 20
+	defaultMaxDecompressedSize   int64      = 10 << 20
+	defaultStaticDir             string     = "assets/static"
+	defaultStaticURLPath         string     = "/static/"
 
 	// Security scheme types.
 	securitySchemeTypeHTTP          = "http"
@@ -565,21 +756,58 @@ const (
 	httpAuthSchemeDigest = "digest"
 )
 
+const (
+	// SSEDropOldest discards the oldest buffered event to make room for the new one.
+	SSEDropOldest SSEBackpressurePolicy = iota
+	// SSEDropNewest discards the new event, keeping the buffered ones.
+	SSEDropNewest
+	// SSEDisconnect closes the connection to the slow client.
+	SSEDisconnect
+)
+
 //nolint:gochecknoglobals // Package-level state for framework configuration and middleware
 var (
-	appConfigured            = false
-	telemetryConfig          *Telemetry
-	securityConfigs          = []security.Config{}
-	securityConfig           *security.Config
-	assetsFS                 fs.FS
-	appMiddlewares           []AppMiddleware
-	openAPIConfig            *OpenAPI
-	jsonpCallbackParamName   string
+	defaultApp               = newApp()
 	jsonpCallbackNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
 	defaultLanguage          = language.English
 
 	// ErrMethodNotAllowed is returned when an HTTP method is not allowed for a route.
 	ErrMethodNotAllowed = errors.New("method not allowed")
+
+	// ErrUnsupportedMediaType is returned when the request Content-Type is not supported by a binder.
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+
+	// ErrEmptyBody is wrapped by the errors of the body binders (BindJSON, BindXML, BindForm, PatchJSON, ...)
+	// when the request body is empty. Use errors.Is to detect it.
+	ErrEmptyBody = errors.New("request body is empty")
+
+	// ErrMalformedBody is wrapped by the errors of the body binders when the request body cannot be decoded,
+	// e.g. for invalid JSON, type mismatches or unknown fields. Use errors.Is to detect it.
+	ErrMalformedBody = errors.New("malformed request body")
+
+	// ErrBodyTooLarge is wrapped by the errors of the body binders and upload helpers when the request body
+	// exceeds a size limit, such as MaxUploadSize or an http.MaxBytesReader. Use errors.Is to detect it.
+	ErrBodyTooLarge = errors.New("request body too large")
+
+	// ErrSlowConsumer is passed to the SSE error function when a client's event buffer is full
+	// and the backpressure policy is applied. Use errors.Is to detect it.
+	ErrSlowConsumer = errors.New("sse: slow consumer")
+
+	// ErrSkipEvent can be returned, possibly wrapped, by an SSEContextPayloadFunc to skip the current
+	// event without closing the connection. The error is still passed to the SSE error function.
+	ErrSkipEvent = errors.New("sse: event skipped")
+
+	// ErrMissingJSONField is returned, wrapped, by BindJSONField when the JSON body has no value for the key.
+	ErrMissingJSONField = bind.ErrMissingField
+
+	// ErrNotJSONArray is returned, wrapped, by BindJSONArray when the JSON body is not an array.
+	ErrNotJSONArray = bind.ErrNotArray
+
+	// ErrI18nNotConfigured is returned by ReloadI18n when no i18n message files are configured.
+	ErrI18nNotConfigured = i18n.ErrNotConfigured
+
+	// ErrPatchTestFailed is returned, wrapped in a *JSONPatchError, by PatchJSON when a test operation fails.
+	ErrPatchTestFailed = errors.New("json patch test failed")
 )
 
 //nolint:revive,staticcheck // receiver underscore is intentional for interface
@@ -860,7 +1088,8 @@ func (m *SSEHandler) ServeHTTP(w ResponseWriter, r *Request) {
 		w.Header().Set(k, v)
 	}
 
-	clientDisconnected := r.Context().Done()
+	ctx := r.Context()
+	clientDisconnected := ctx.Done()
 
 	var sseW sseWriter
 	if m.writerFactory != nil {
@@ -875,6 +1104,11 @@ func (m *SSEHandler) ServeHTTP(w ResponseWriter, r *Request) {
 		}
 	}
 
+	if m.bufferSize > 0 {
+		m.serveBuffered(ctx, sseW)
+		return
+	}
+
 	t := time.NewTicker(m.interval)
 	defer t.Stop()
 
@@ -884,74 +1118,185 @@ func (m *SSEHandler) ServeHTTP(w ResponseWriter, r *Request) {
 			m.disconnectFunc()
 			return
 		case <-t.C:
-			msgWritten := false
-			payload := m.payloadFunc()
-
-			if payload.ID != "" {
-				_, err := fmt.Fprintf(sseW, "id: %s\n", payload.ID)
-				if err != nil {
-					m.errorFunc(err)
-					return
-				}
-				msgWritten = true
+			payload, err := m.nextPayload(ctx)
+			if ctx.Err() != nil {
+				m.disconnectFunc()
+				return
 			}
-			if payload.Event != "" {
-				_, err := fmt.Fprintf(sseW, "event: %s\n", payload.Event)
-				if err != nil {
-					m.errorFunc(err)
-					return
+			if err != nil {
+				m.errorFunc(err)
+				if errors.Is(err, ErrSkipEvent) {
+					continue
 				}
-				msgWritten = true
+				return
 			}
-			if len(payload.Comments) > 0 {
-				for _, comment := range payload.Comments {
-					_, err := fmt.Fprintf(sseW, ": %s\n", comment)
-					if err != nil {
-						m.errorFunc(err)
-						return
-					}
-				}
-				msgWritten = true
+			if err := writeSSEPayload(sseW, payload); err != nil {
+				m.errorFunc(err)
+				return
 			}
-			if payload.Data != nil {
-				_, err := fmt.Fprintf(sseW, "data: %s\n", payload.Data)
-				if err != nil {
-					m.errorFunc(err)
-					return
-				}
-				msgWritten = true
+		}
+	}
+}
+
+// nextPayload generates the next payload with the context-aware payload function if set, or payloadFunc.
+func (m *SSEHandler) nextPayload(ctx context.Context) (SSEPayload, error) {
+	if m.ctxPayloadFunc != nil {
+		return m.ctxPayloadFunc(ctx)
+	}
+	return m.payloadFunc(), nil
+}
+
+// serveBuffered generates payloads on a separate goroutine and queues them in a per-client buffer,
+// so a slow client does not delay the event source. When the buffer is full, the backpressure
+// policy is applied and errorFunc is called with ErrSlowConsumer.
+func (m *SSEHandler) serveBuffered(ctx context.Context, sseW sseWriter) {
+	buffer := make(chan SSEPayload, m.bufferSize)
+	errs := make(chan error, 1)
+	stop := make(chan struct{})
+	defer close(stop)
+
+	go m.producePayloads(ctx, buffer, errs, stop)
+
+	for {
+		select {
+		case <-ctx.Done():
+			m.disconnectFunc()
+			return
+		case err := <-errs:
+			m.errorFunc(err)
+			if !errors.Is(err, ErrSkipEvent) && (!errors.Is(err, ErrSlowConsumer) || m.policy == SSEDisconnect) {
+				return
 			}
-			if payload.Retry > 0 {
-				_, err := fmt.Fprintf(sseW, "retry: %d\n", int(payload.Retry.Milliseconds()))
-				if err != nil {
-					m.errorFunc(err)
-					return
-				}
-				msgWritten = true
+		case payload := <-buffer:
+			if err := writeSSEPayload(sseW, payload); err != nil {
+				m.errorFunc(err)
+				return
 			}
+		}
+	}
+}
+
+// producePayloads generates a payload on every tick and queues it in buffer until stop is closed.
+// Payload function errors are sent on errs, and production stops unless the event is skipped.
+// Slow consumer notifications are sent on errs without blocking; pending notifications are not duplicated.
+func (m *SSEHandler) producePayloads(
+	ctx context.Context,
+	buffer chan SSEPayload,
+	errs chan<- error,
+	stop <-chan struct{},
+) {
+	t := time.NewTicker(m.interval)
+	defer t.Stop()
 
-			if msgWritten {
-				_, err := fmt.Fprintf(sseW, "\n")
-				if err != nil {
-					m.errorFunc(err)
+	for {
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+			payload, err := m.nextPayload(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				select {
+				case errs <- err:
+				case <-stop:
 					return
 				}
+				if errors.Is(err, ErrSkipEvent) {
+					continue
+				}
+				return
+			}
 
-				err = sseW.Flush()
-				if err != nil {
-					m.errorFunc(err)
-					return
+			select {
+			case buffer <- payload:
+				continue
+			default:
+			}
+
+			switch m.policy {
+			case SSEDropOldest:
+				select {
+				case <-buffer:
+				default:
+				}
+				select {
+				case buffer <- payload:
+				default:
 				}
+			case SSEDropNewest:
+			case SSEDisconnect:
+				select {
+				case errs <- fmt.Errorf("%w: buffer of %d events full, disconnecting", ErrSlowConsumer, m.bufferSize):
+				case <-stop:
+				}
+				return
+			}
+
+			select {
+			case errs <- fmt.Errorf("%w: buffer of %d events full, dropping event", ErrSlowConsumer, m.bufferSize):
+			default:
 			}
 		}
 	}
 }
 
-func configureTelemetry(cfg *Config) {
+// writeSSEPayload writes a single event to the client and flushes it.
+// Nothing is written if the payload has no fields set.
+func writeSSEPayload(sseW sseWriter, payload SSEPayload) error {
+	msgWritten := false
+
+	if payload.ID != "" {
+		if _, err := fmt.Fprintf(sseW, "id: %s\n", payload.ID); err != nil {
+			return err
+		}
+		msgWritten = true
+	}
+	if payload.Event != "" {
+		if _, err := fmt.Fprintf(sseW, "event: %s\n", payload.Event); err != nil {
+			return err
+		}
+		msgWritten = true
+	}
+	if len(payload.Comments) > 0 {
+		for _, comment := range payload.Comments {
+			if _, err := fmt.Fprintf(sseW, ": %s\n", comment); err != nil {
+				return err
+			}
+		}
+		msgWritten = true
+	}
+	if payload.Data != nil {
+		if _, err := fmt.Fprintf(sseW, "data: %s\n", payload.Data); err != nil {
+			return err
+		}
+		msgWritten = true
+	}
+	if payload.Retry > 0 {
+		if _, err := fmt.Fprintf(sseW, "retry: %d\n", int(payload.Retry.Milliseconds())); err != nil {
+			return err
+		}
+		msgWritten = true
+	}
+
+	if !msgWritten {
+		return nil
+	}
+
+	if _, err := fmt.Fprintf(sseW, "\n"); err != nil {
+		return err
+	}
+
+	return sseW.Flush()
+}
+
+func (a *App) configureTelemetry(cfg *Config) {
 	if cfg == nil || cfg.Telemetry == nil || !cfg.Telemetry.Enabled {
 		return
 	}
-	telemetryConfig = cfg.Telemetry
+	telemetryConfig := cfg.Telemetry
+	a.telemetryConfig = telemetryConfig
 
 	telemetry.ConfigureTelemetry(telemetryConfig.UseDefaultRegistry, telemetryConfig.Collectors...)
 
@@ -962,56 +1307,81 @@ func configureTelemetry(cfg *Config) {
 	}
 }
 
-func configureSecurity(cfg *Config) {
-	if cfg == nil || cfg.Security == nil {
+func (a *App) configureSecurity(cfg *Config) {
+	if cfg == nil {
 		return
 	}
 
-	securityConfig = cfg.Security
+	a.securitySchemes = cfg.SecuritySchemes
 
-	if securityConfig != nil {
-		securityConfigs = append(securityConfigs, *securityConfig)
+	if cfg.Security == nil {
+		return
+	}
+
+	a.securityConfig = cfg.Security
+
+	if a.securityConfig != nil {
+		a.securityConfigs = append(a.securityConfigs, *a.securityConfig)
 	}
 }
 
-func configureOpenAPI(cfg *Config) {
+func (a *App) configureOpenAPI(cfg *Config) {
 	if cfg == nil || cfg.OpenAPI == nil || !cfg.OpenAPI.Enabled {
 		return
 	}
-	openAPIConfig = cfg.OpenAPI
+	openAPIConfig := cfg.OpenAPI
+	a.openAPIConfig = openAPIConfig
 
-	openAPIConfig.internalConfig = &openapi.Config{
+	openAPIConfig.internalConfig = newOpenAPIDocument(openAPIConfig.Config)
+	openAPIConfig.URLPath = getURLPathPattern(openAPIConfig.URLPath, defaultOpenAPIURLPath)
+}
+
+// newOpenAPIDocument creates an OpenAPI document without operations from the given configuration.
+func newOpenAPIDocument(cfg *OpenAPIConfig) *openapi.Config {
+	doc := &openapi.Config{
 		Components: &openapi.Components{},
 	}
 
-	if openAPIConfig.Config != nil {
-		openAPIConfig.internalConfig.Servers = mapServers(openAPIConfig.Config.Servers)
-		openAPIConfig.internalConfig.Tags = mapOpenAPITags(openAPIConfig.Config.Tags)
+	if cfg == nil {
+		return doc
+	}
 
-		openAPIConfig.internalConfig.Security = openAPIConfig.Config.Security
+	doc.Servers = mapServers(cfg.Servers)
+	doc.Tags = mapOpenAPITags(cfg.Tags)
+	doc.Security = cfg.Security
 
-		if openAPIConfig.Config.Components != nil && len(openAPIConfig.Config.Components.SecuritySchemes) > 0 {
-			openAPIConfig.internalConfig.Components.SecuritySchemes = make(
-				map[string]openapi.SecuritySchemeOrRef,
-				len(openAPIConfig.Config.Components.SecuritySchemes),
-			)
+	if cfg.Components != nil && len(cfg.Components.SecuritySchemes) > 0 {
+		doc.Components.SecuritySchemes = make(map[string]openapi.SecuritySchemeOrRef, len(cfg.Components.SecuritySchemes))
 
-			for key, scheme := range openAPIConfig.Config.Components.SecuritySchemes {
-				openAPIConfig.internalConfig.Components.SecuritySchemes[key] = openapi.SecuritySchemeOrRef{
-					SecurityScheme: mapSecurityScheme(scheme),
-				}
+		for key, scheme := range cfg.Components.SecuritySchemes {
+			doc.Components.SecuritySchemes[key] = openapi.SecuritySchemeOrRef{
+				SecurityScheme: mapSecurityScheme(scheme),
 			}
 		}
-
-		mapOpenAPIInfo(openAPIConfig.Config)
-		mapOpenAPIExternalDocs(openAPIConfig.Config)
 	}
 
-	if openAPIConfig.URLPath == "" {
-		openAPIConfig.URLPath = defaultOpenAPIURLPath
-	} else if openAPIConfig.URLPath[0:4] != "GET " {
-		openAPIConfig.URLPath = "GET " + openAPIConfig.URLPath
+	doc.Info = mapOpenAPIInfo(cfg)
+	doc.ExternalDocs = mapOpenAPIExternalDocs(cfg)
+
+	return doc
+}
+
+// getURLPathPattern returns the pattern serving an endpoint, prefixed with "GET " if needed,
+// or defaultURLPath if urlPath is empty.
+func getURLPathPattern(urlPath, defaultURLPath string) string {
+	return getMethodURLPathPattern(http.MethodGet, urlPath, defaultURLPath)
+}
+
+// getMethodURLPathPattern returns the pattern serving an endpoint, prefixed with method if needed,
+// or defaultURLPath if urlPath is empty.
+func getMethodURLPathPattern(method, urlPath, defaultURLPath string) string {
+	if urlPath == "" {
+		return defaultURLPath
+	}
+	if !strings.HasPrefix(urlPath, method+" ") {
+		return method + " " + urlPath
 	}
+	return urlPath
 }
 
 func mapSecurityScheme(scheme SecurityScheme) *openapi.SecurityScheme {
@@ -1128,12 +1498,12 @@ func mapOAuthFlows(flows []OAuthFlow) *openapi.OAuthFlows {
 	return &mappedFlows
 }
 
-func mapOpenAPIInfo(config *OpenAPIConfig) {
+func mapOpenAPIInfo(config *OpenAPIConfig) *openapi.Info {
 	if config.Info == nil {
-		return
+		return nil
 	}
 
-	openAPIConfig.internalConfig.Info = &openapi.Info{
+	info := &openapi.Info{
 		Title:          config.Info.Title,
 		Summary:        config.Info.Summary,
 		Description:    config.Info.Description,
@@ -1142,7 +1512,7 @@ func mapOpenAPIInfo(config *OpenAPIConfig) {
 	}
 
 	if config.Info.Contact != nil {
-		openAPIConfig.internalConfig.Info.Contact = &openapi.Contact{
+		info.Contact = &openapi.Contact{
 			Name:  config.Info.Contact.Name,
 			URL:   config.Info.Contact.URL,
 			Email: config.Info.Contact.Email,
@@ -1150,20 +1520,22 @@ func mapOpenAPIInfo(config *OpenAPIConfig) {
 	}
 
 	if config.Info.License != nil {
-		openAPIConfig.internalConfig.Info.License = &openapi.License{
+		info.License = &openapi.License{
 			Name:       config.Info.License.Name,
 			Identifier: config.Info.License.Identifier,
 			URL:        config.Info.License.URL,
 		}
 	}
+
+	return info
 }
 
-func mapOpenAPIExternalDocs(config *OpenAPIConfig) {
+func mapOpenAPIExternalDocs(config *OpenAPIConfig) *openapi.ExternalDocs {
 	if config.ExternalDocs == nil {
-		return
+		return nil
 	}
 
-	openAPIConfig.internalConfig.ExternalDocs = &openapi.ExternalDocs{
+	return &openapi.ExternalDocs{
 		Description: config.ExternalDocs.Description,
 		URL:         config.ExternalDocs.URL,
 	}
@@ -1194,7 +1566,7 @@ func mapOpenAPITags(ts []Tag) []openapi.Tag {
 	return tags
 }
 
-func configureTemplate(cfg *Config) {
+func (a *App) configureTemplate(cfg *Config) {
 	var dir string
 	var layoutBaseName string
 	var htmlTemplateExtension string
@@ -1210,28 +1582,41 @@ func configureTemplate(cfg *Config) {
 		dir, layoutBaseName, htmlTemplateExtension, textTemplateExtension = getTemplateConfig(cfg)
 	}
 
-	stat, err := fs.Stat(assetsFS, dir)
+	stat, err := fs.Stat(a.assetsFS, dir)
 	if err != nil || !stat.IsDir() {
 		return
 	}
-	templateFS, err := fs.Sub(assetsFS, dir)
+	templateFS, err := fs.Sub(a.assetsFS, dir)
 
 	if err != nil {
 		return
 	}
 
+	a.templateDir = dir
+
 	tmplConfig := &template.Config{
 		FS:                    templateFS,
 		LayoutBaseName:        layoutBaseName,
 		HTMLTemplateExtension: htmlTemplateExtension,
 		TextTemplateExtension: textTemplateExtension,
 		I18nFuncName:          defaultI18nFuncName,
+		Funcs: map[string]any{
+			"asset": a.assetPath,
+			// Replaced per request when the CSP middleware generated a nonce.
+			"cspNonce": func() string { return "" },
+			// Replaced per request to sort for the request language.
+			sortStringsFuncName: sortStringsFunc(context.WithValue(context.Background(), appKey, a)),
+			safeFuncName:        safeHTMLFunc,
+		},
+	}
+	if cfg != nil && cfg.Assets != nil && cfg.Assets.Templates != nil {
+		tmplConfig.Layouts = cfg.Assets.Templates.Layouts
 	}
 
-	template.Configure(tmplConfig)
+	a.templates = template.New(tmplConfig)
 }
 
-func configureI18n(cfg *Config) {
+func (a *App) configureI18n(cfg *Config) {
 	var dir string
 	var supportedLanguages []language.Tag
 
@@ -1242,61 +1627,213 @@ func configureI18n(cfg *Config) {
 		dir = getI18nMessagesDir(cfg)
 	}
 
-	supportedLanguages = getSupportedLanguages(cfg, dir)
+	supportedLanguages = a.getSupportedLanguages(cfg, dir)
 
-	stat, err := fs.Stat(assetsFS, dir)
+	stat, err := fs.Stat(a.assetsFS, dir)
 	if err != nil || !stat.IsDir() {
 		return
 	}
-	i18nMessagesFS, err := fs.Sub(assetsFS, dir)
+	i18nMessagesFS, err := fs.Sub(a.assetsFS, dir)
 
 	if err != nil {
 		return
 	}
 
+	a.i18nMessagesDir = dir
+
 	i18nConfig := &i18n.Config{
 		FS:                 i18nMessagesFS,
 		SupportedLanguages: supportedLanguages,
+		Matcher:            getI18nMatcher(cfg),
+		Fallback:           getI18nFallback(cfg),
 	}
 
-	i18n.Configure(i18nConfig)
+	a.i18nCatalogs = i18n.New(i18nConfig)
+
+	if cfg != nil && cfg.Assets != nil && cfg.Assets.I18nMessages != nil {
+		a.configureI18nReload(cfg.Assets.I18nMessages.Reload)
+	}
 }
 
-func configureJSONP(cfg *Config) {
+func (a *App) configureJSONP(cfg *Config) {
 	if cfg != nil {
-		if cfg.JSONPCallbackParamName != "" {
-			matched := jsonpCallbackNamePattern.MatchString(cfg.JSONPCallbackParamName)
-			if !matched {
-				panic(fmt.Errorf(
-					"invalid JSONP callback param name: %q. "+
-						"Must start with a letter or underscore and only contain alphanumeric characters and underscores",
-					cfg.JSONPCallbackParamName))
-			}
+		if err := validateJSONPCallbackParamName(cfg.JSONPCallbackParamName); err != nil {
+			panic(err)
 		}
-		jsonpCallbackParamName = cfg.JSONPCallbackParamName
+		a.jsonpCallbackParamName = cfg.JSONPCallbackParamName
+		a.jsonpContentType = getValueOrDefault(cfg.JSONPContentType, defaultJSONPContentType)
+		a.jsonpSafeCallback = cfg.JSONPSafeCallback
+		a.jsonpCallbackMaxLength = getValueOrDefault(cfg.JSONPCallbackMaxLength, defaultJSONPCallbackMaxLen)
+		a.jsonpDisallowedCallbacks = slices.Clone(cfg.JSONPDisallowedCallbacks)
+	}
+}
+
+// validateJSONPCallback returns an error if name is not a valid JSONP callback name: it must be a JavaScript
+// identifier of letters, digits and underscores, not longer than JSONPCallbackMaxLength and not disallowed.
+func (a *App) validateJSONPCallback(name string) error {
+	switch {
+	// Checked first, so that long names are not echoed in the error.
+	case len(name) > a.jsonpCallbackMaxLength:
+		return fmt.Errorf("invalid JSONP callback method name: longer than %d characters", a.jsonpCallbackMaxLength)
+	case !jsonpCallbackNamePattern.MatchString(name):
+		return fmt.Errorf(
+			"invalid JSONP callback method name: %q. "+
+				"Must start with a letter or underscore and only contain alphanumeric characters and underscores",
+			name)
+	case slices.Contains(a.jsonpDisallowedCallbacks, name):
+		return fmt.Errorf("invalid JSONP callback method name: %q is not allowed", name)
+	default:
+		return nil
+	}
+}
+
+func (a *App) configureMethodOverride(cfg *Config) {
+	a.allowMethodOverride = cfg != nil && cfg.AllowMethodOverride
+}
+
+func (a *App) configureDeprecation(cfg *Config) {
+	a.deprecationWarningHeader = cfg != nil && cfg.DeprecationWarningHeader
+}
+
+func (a *App) configureResponseValidation(cfg *Config) {
+	a.validateResponses = cfg != nil && cfg.ValidateResponses
+}
+
+func (a *App) configureUploads(cfg *Config) {
+	a.maxUploadSize = defaultMaxUploadSize
+	a.multipartMaxMemory = defaultMultipartMaxMemory
+
+	if cfg == nil {
+		return
+	}
+
+	if cfg.MaxUploadSize > 0 {
+		a.maxUploadSize = cfg.MaxUploadSize
+	}
+	if cfg.MultipartMaxMemory > 0 {
+		a.multipartMaxMemory = cfg.MultipartMaxMemory
+	}
+}
+
+func (a *App) configureOnError(cfg *Config) {
+	if cfg == nil {
+		return
+	}
+
+	a.onError = cfg.OnError
+	a.onValidationError = cfg.OnValidationError
+}
+
+// reportValidationErrors passes the validation errors of a bind call to the OnValidationError hook of the App
+// serving the request, if one is configured and there are errors.
+func reportValidationErrors(r *Request, errs *ValidationErrors) {
+	if errs == nil || !errs.Any() {
+		return
+	}
+
+	if onValidationError := appFromContext(r.Context()).onValidationError; onValidationError != nil {
+		onValidationError(r, errs)
+	}
+}
+
+func (a *App) configureDecompression(cfg *Config) {
+	a.maxDecompressedBodySize = defaultMaxDecompressedSize
+
+	if cfg == nil {
+		return
+	}
+
+	a.decompressRequests = cfg.DecompressRequests
+	if cfg.MaxDecompressedBodySize > 0 {
+		a.maxDecompressedBodySize = cfg.MaxDecompressedBodySize
+	}
+}
+
+// newApp returns an unconfigured App with default settings.
+func newApp() *App {
+	return &App{
+		assetsFS:                getAssetsFS(nil),
+		securityConfigs:         []security.Config{},
+		jsonpContentType:        defaultJSONPContentType,
+		jsonpCallbackMaxLength:  defaultJSONPCallbackMaxLen,
+		maxUploadSize:           defaultMaxUploadSize,
+		multipartMaxMemory:      defaultMultipartMaxMemory,
+		maxDecompressedBodySize: defaultMaxDecompressedSize,
+		staticURLPath:           defaultStaticURLPath,
+	}
+}
+
+// New creates an App configured with the provided configuration, independent of the default App
+// used by the package-level functions. Pass nil to use default configuration values.
+// Panics if the configuration is invalid (see Config.Validate).
+func New(cfg *Config) *App {
+	a := newApp()
+	a.configure(cfg)
+
+	return a
+}
+
+// appFromContext returns the App serving the request, or the default App if the request
+// was not routed by a ServeMux of another App.
+func appFromContext(ctx context.Context) *App {
+	if a, ok := ctx.Value(appKey).(*App); ok {
+		return a
+	}
+
+	return defaultApp
+}
+
+func (a *App) configure(cfg *Config) {
+	if a.configured {
+		panic("app already configured")
 	}
+	if err := cfg.Validate(); err != nil {
+		panic(fmt.Errorf("invalid configuration: %w", err))
+	}
+	a.configured = true
+	a.assetsFS = getAssetsFS(cfg)
+
+	a.configureTelemetry(cfg)
+	a.configureSecurity(cfg)
+	a.configureOpenAPI(cfg)
+	a.configureStaticAssets(cfg)
+	a.configureTemplate(cfg)
+	a.configureI18n(cfg)
+	a.configureJSONP(cfg)
+	a.configureMethodOverride(cfg)
+	a.configureDeprecation(cfg)
+	a.configureResponseValidation(cfg)
+	a.configureUploads(cfg)
+	a.configureOnError(cfg)
+	a.configureDecompression(cfg)
+	a.configureTrustedProxies(cfg)
+	a.configureDebugConfig(cfg)
 }
 
 // Configure initializes the webfram application with the provided configuration.
-// It sets up templates, i18n messages, OpenAPI documentation, and JSONP callback handling.
+// It sets up templates, i18n messages, OpenAPI documentation, JSONP callback handling, method override and upload limits.
 // This function must be called only once before using the framework. Calling it multiple times will panic.
+// Panics if the configuration is invalid (see Config.Validate).
 // Pass nil to use default configuration values.
 func Configure(cfg *Config) {
-	if appConfigured {
-		panic("app already configured")
+	defaultApp.configure(cfg)
+}
+
+// Reset replaces the default App with a new, unconfigured one, so that Configure can be called again.
+// It clears the configuration set by Configure, the global middlewares registered with Use and the
+// handlers registered on all ServeMux instances of the default App, as well as the templates and
+// i18n messages. It is intended for tests and tools embedding the framework; ServeMux instances
+// created before Reset must not be used afterwards.
+// Panics if a server started with ListenAndServe is running.
+func Reset() {
+	if defaultApp.activeServers.Load() > 0 {
+		panic("cannot reset app configuration while a server is running")
 	}
-	appConfigured = true
-	assetsFS = getAssetsFS(cfg)
 
-	configureTelemetry(cfg)
-	configureSecurity(cfg)
-	configureOpenAPI(cfg)
-	configureTemplate(cfg)
-	configureI18n(cfg)
-	configureJSONP(cfg)
+	defaultApp = newApp()
 }
 
-// Use registers a global middleware that will be applied to all handlers.
+// Use registers a global middleware of the default App that will be applied to all handlers.
 // Accepts either AppMiddleware (func(Handler) Handler) or StandardMiddleware (func(http.Handler) http.Handler).
 // Middlewares are executed in the order they are registered.
 func Use[H AppMiddleware | StandardMiddleware](mw H) {
@@ -1304,12 +1841,26 @@ func Use[H AppMiddleware | StandardMiddleware](mw H) {
 		return
 	}
 
-	switch v := any(mw).(type) {
+	defaultApp.Use(mw)
+}
+
+// Use registers a middleware that will be applied to all handlers of this App.
+// Accepts either AppMiddleware (func(Handler) Handler) or StandardMiddleware (func(http.Handler) http.Handler).
+// Middlewares are executed in the order they are registered.
+// Panics if an unsupported middleware type is provided.
+func (a *App) Use(mw interface{}) {
+	if mw == nil {
+		return
+	}
+
+	switch v := mw.(type) {
 	case AppMiddleware:
-		appMiddlewares = append(appMiddlewares, v)
+		a.middlewares = append(a.middlewares, v)
 	case StandardMiddleware:
 		adaptedMw := adaptHTTPMiddleware(v)
-		appMiddlewares = append(appMiddlewares, adaptedMw)
+		a.middlewares = append(a.middlewares, adaptedMw)
+	default:
+		panic(errors.New("unsupported middleware type"))
 	}
 }
 
@@ -1325,10 +1876,47 @@ func SSE(
 	errorFunc SSEErrorFunc,
 	interval time.Duration,
 	headers map[string]string,
+) *SSEHandler {
+	if payloadFunc == nil {
+		panic(errors.New("SSE payload function must not be nil"))
+	}
+
+	h := newSSEHandler(disconnectFunc, errorFunc, interval, headers)
+	h.payloadFunc = payloadFunc
+
+	return h
+}
+
+// SSEWithContext creates a Server-Sent Events handler like SSE, with a payload function that receives
+// the request context, so it can respect its deadline and stop when the client disconnects.
+// An error returned by payloadFunc is passed to errorFunc and closes the connection, unless it wraps
+// ErrSkipEvent, in which case the event is skipped and streaming continues.
+// Panics if payloadFunc is nil or interval is non-positive.
+func SSEWithContext(
+	payloadFunc SSEContextPayloadFunc,
+	disconnectFunc SSEDisconnectFunc,
+	errorFunc SSEErrorFunc,
+	interval time.Duration,
+	headers map[string]string,
+) *SSEHandler {
+	if payloadFunc == nil {
+		panic(errors.New("SSE payload function must not be nil"))
+	}
+
+	h := newSSEHandler(disconnectFunc, errorFunc, interval, headers)
+	h.ctxPayloadFunc = payloadFunc
+
+	return h
+}
+
+func newSSEHandler(
+	disconnectFunc SSEDisconnectFunc,
+	errorFunc SSEErrorFunc,
+	interval time.Duration,
+	headers map[string]string,
 ) *SSEHandler {
 	h := &SSEHandler{
 		interval:       interval,
-		payloadFunc:    payloadFunc,
 		headers:        headers,
 		disconnectFunc: disconnectFunc,
 		errorFunc:      errorFunc,
@@ -1337,9 +1925,6 @@ func SSE(
 	if h.interval <= 0 {
 		panic(errors.New("SSE interval must be greater than zero"))
 	}
-	if h.payloadFunc == nil {
-		panic(errors.New("SSE payload function must not be nil"))
-	}
 	if h.disconnectFunc == nil {
 		h.disconnectFunc = func() {}
 	}
@@ -1353,60 +1938,210 @@ func SSE(
 	return h
 }
 
+// WithBuffer decouples event generation from writing by queueing up to size events per client.
+// When a slow client lets the buffer fill up, the policy decides whether the oldest or the newest
+// event is dropped, or the client is disconnected; in every case the error function is called
+// with an error wrapping ErrSlowConsumer. A size of zero writes events synchronously (the default).
+// Panics if size is negative.
+func (m *SSEHandler) WithBuffer(size int, policy SSEBackpressurePolicy) *SSEHandler {
+	if size < 0 {
+		panic(errors.New("SSE buffer size must not be negative"))
+	}
+
+	m.bufferSize = size
+	m.policy = policy
+
+	return m
+}
+
 // Any returns true if there are any validation errors in the collection.
 func (errs *ValidationErrors) Any() bool {
 	return len(errs.Errors) > 0
 }
 
 // BindForm parses form data from the request and binds it to the provided type T.
+// Values are read from the URL query string and the URL-encoded body, like http.Request.FormValue,
+// so GET and POST forms bind alike; a value in the body takes precedence over one in the query string.
+// Multipart bodies are parsed like in FormFile, so their files remain available through FormFile and
+// MultipartFiles, and their raw fields through MultipartValues.
 // It validates the data according to struct tags (validate, errmsg) and returns validation errors if any.
-// Returns the bound data, validation errors (nil if valid), and a parsing error (nil if successful).
+// Returns the bound data, validation errors (nil if valid), and a parsing error (nil if successful), which wraps
+// ErrMalformedBody or ErrBodyTooLarge depending on its cause.
 func BindForm[T any](r *Request) (T, *ValidationErrors, error) {
+	val, vErrors, err := bindForm[T](r)
+	reportValidationErrors(r, vErrors)
+
+	return val, vErrors, err
+}
+
+// bindForm is BindForm without the OnValidationError hook, for the binders built on it.
+func bindForm[T any](r *Request) (T, *ValidationErrors, error) {
+	if err := decompressBody(r); err != nil {
+		var zero T
+		return zero, &ValidationErrors{}, classifyBodyError(err)
+	}
+
+	if isMultipartRequest(r) {
+		if err := r.parseMultipartForm(); err != nil {
+			var zero T
+			return zero, &ValidationErrors{}, classifyBodyError(err)
+		}
+	}
+
 	val, valErrors, err := bind.Form[T](r.Request)
 
 	vErrors := &ValidationErrors{}
 	for _, err := range valErrors {
-		vErrors.Errors = append(vErrors.Errors, ValidationError{
-			Field: err.Field,
-			Error: err.Error,
-		})
+		vErrors.Errors = append(vErrors.Errors, validationError(r, &err))
 	}
 
-	return val, vErrors, err
+	return val, vErrors, classifyBodyError(err)
 }
 
 // BindJSON parses JSON from the request body and binds it to the provided type T.
 // If validate is true, validates the data according to struct tags (validate, errmsg).
 // Returns the bound data, validation errors (nil if valid or validation disabled), and a parsing error (nil if successful).
+// Syntax errors, type mismatches and unknown fields are returned as a *DecodeError with a localized message.
+// Parsing errors wrap ErrEmptyBody, ErrMalformedBody or ErrBodyTooLarge depending on their cause.
+// If the request context is canceled while the body is being read, the error wraps the context error.
 func BindJSON[T any](r *Request, validate bool) (T, *ValidationErrors, error) {
+	val, vErrors, err := bindJSON[T](r, validate)
+	reportValidationErrors(r, vErrors)
+
+	return val, vErrors, err
+}
+
+// bindJSON is BindJSON without the OnValidationError hook, for the binders built on it.
+func bindJSON[T any](r *Request, validate bool) (T, *ValidationErrors, error) {
+	if err := decompressBody(r); err != nil {
+		var zero T
+		return zero, &ValidationErrors{}, classifyBodyError(err)
+	}
+
 	val, valErrors, err := bind.JSON[T](r.Request, validate)
+	if err != nil {
+		err = classifyBodyError(localizeDecodeError(r, err))
+	}
 
 	vErrors := &ValidationErrors{}
 	for _, err := range valErrors {
-		vErrors.Errors = append(vErrors.Errors, ValidationError{
-			Field: err.Field,
-			Error: err.Error,
-		})
+		vErrors.Errors = append(vErrors.Errors, validationError(r, &err))
+	}
+
+	return val, vErrors, err
+}
+
+// BindJSONField parses a JSON object from the request body and binds the value of its top-level key to the
+// provided type T, for payloads wrapped like {"data": {...}}. Other top-level keys are ignored.
+// If validate is true, validates the data according to struct tags (validate, errmsg); validation errors
+// are reported with the key as prefix (e.g., "data.name").
+// Returns an error wrapping ErrMissingJSONField if the key is absent or null. Other errors are as for BindJSON.
+func BindJSONField[T any](r *Request, key string, validate bool) (T, *ValidationErrors, error) {
+	if err := decompressBody(r); err != nil {
+		var zero T
+		return zero, &ValidationErrors{}, classifyBodyError(err)
 	}
 
+	val, valErrors, err := bind.JSONField[T](r.Request, key, validate)
+	if err != nil {
+		err = classifyBodyError(localizeDecodeError(r, err))
+	}
+
+	vErrors := &ValidationErrors{}
+	for _, err := range valErrors {
+		vErrors.Errors = append(vErrors.Errors, validationError(r, &err))
+	}
+	reportValidationErrors(r, vErrors)
+
+	return val, vErrors, err
+}
+
+// BindRawJSON binds the raw JSON of a deferred json.RawMessage field of a bound payload to the provided type T,
+// for polymorphic payloads decoded according to a discriminator:
+//
+//	switch event.Type {
+//	case "circle":
+//		circle, valErrors, err := app.BindRawJSON[Circle](r, event.Shape, "shape", true)
+//		...
+//	}
+//
+// It decodes and validates like BindJSON; validation and decode errors are reported with field as prefix
+// (e.g., "shape.radius"), unless it is empty.
+func BindRawJSON[T any](r *Request, raw json.RawMessage, field string, validate bool) (T, *ValidationErrors, error) {
+	val, valErrors, err := bind.RawJSON[T](raw, field, validate)
+	if err != nil {
+		err = classifyBodyError(localizeDecodeError(r, err))
+	}
+
+	vErrors := &ValidationErrors{}
+	for _, err := range valErrors {
+		vErrors.Errors = append(vErrors.Errors, validationError(r, &err))
+	}
+	reportValidationErrors(r, vErrors)
+
 	return val, vErrors, err
 }
 
+// Error returns the localized error message.
+func (e *DecodeError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the underlying encoding/json error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// localizeDecodeError converts a JSON decoding error into a *DecodeError whose message is translated
+// with the request's i18n printer. Other errors are returned unchanged.
+func localizeDecodeError(r *Request, err error) error {
+	msg, field, ok := bind.JSONDecodeError(err)
+	if !ok {
+		return err
+	}
+
+	var args []any
+	if field != "" {
+		args = append(args, field)
+	}
+
+	var message string
+	if printer, hasPrinter := i18n.PrinterFromContext(r.Context()); hasPrinter {
+		message = printer.Sprintf(msg, args...)
+	} else {
+		message = fmt.Sprintf(msg, args...)
+	}
+
+	return &DecodeError{Field: field, Message: message, Err: err}
+}
+
 // BindXML parses XML from the request body and binds it to the provided type T.
 // If validate is true, validates the data according to struct tags (validate, errmsg).
 // Returns the bound data, validation errors (nil if valid or validation disabled), and a parsing error (nil if successful).
+// Parsing errors wrap ErrEmptyBody, ErrMalformedBody or ErrBodyTooLarge depending on their cause.
+// If the request context is canceled while the body is being read, the error wraps the context error.
 func BindXML[T any](r *Request, validate bool) (T, *ValidationErrors, error) {
+	val, vErrors, err := bindXML[T](r, validate)
+	reportValidationErrors(r, vErrors)
+
+	return val, vErrors, err
+}
+
+// bindXML is BindXML without the OnValidationError hook, for the binders built on it.
+func bindXML[T any](r *Request, validate bool) (T, *ValidationErrors, error) {
+	if err := decompressBody(r); err != nil {
+		var zero T
+		return zero, &ValidationErrors{}, classifyBodyError(err)
+	}
+
 	val, valErrors, err := bind.XML[T](r.Request, validate)
 
 	vErrors := &ValidationErrors{}
 	for _, err := range valErrors {
-		vErrors.Errors = append(vErrors.Errors, ValidationError{
-			Field: err.Field,
-			Error: err.Error,
-		})
+		vErrors.Errors = append(vErrors.Errors, validationError(r, &err))
 	}
 
-	return val, vErrors, err
+	return val, vErrors, classifyBodyError(err)
 }
 
 // BindPath parses URL path parameters from the request and binds them to the provided type T.
@@ -1415,14 +2150,19 @@ func BindXML[T any](r *Request, validate bool) (T, *ValidationErrors, error) {
 // Struct fields should use the "form" tag to specify parameter names.
 // Returns the bound data and validation errors (nil if valid).
 func BindPath[T any](r *Request) (T, *ValidationErrors) {
+	val, vErrors := bindPath[T](r)
+	reportValidationErrors(r, vErrors)
+
+	return val, vErrors
+}
+
+// bindPath is BindPath without the OnValidationError hook, for the binders built on it.
+func bindPath[T any](r *Request) (T, *ValidationErrors) {
 	val, valErrors, _ := bind.Path[T](r.Request)
 
 	vErrors := &ValidationErrors{}
 	for _, err := range valErrors {
-		vErrors.Errors = append(vErrors.Errors, ValidationError{
-			Field: err.Field,
-			Error: err.Error,
-		})
+		vErrors.Errors = append(vErrors.Errors, validationError(r, &err))
 	}
 
 	return val, vErrors
@@ -1434,14 +2174,19 @@ func BindPath[T any](r *Request) (T, *ValidationErrors) {
 // Supports slices for multi-value query parameters.
 // Returns the bound data, validation errors (nil if valid), and a parsing error (nil if successful).
 func BindQuery[T any](r *Request) (T, *ValidationErrors, error) {
+	val, vErrors, err := bindQuery[T](r)
+	reportValidationErrors(r, vErrors)
+
+	return val, vErrors, err
+}
+
+// bindQuery is BindQuery without the OnValidationError hook, for the binders built on it.
+func bindQuery[T any](r *Request) (T, *ValidationErrors, error) {
 	val, valErrors, err := bind.Query[T](r.Request)
 
 	vErrors := &ValidationErrors{}
 	for _, err := range valErrors {
-		vErrors.Errors = append(vErrors.Errors, ValidationError{
-			Field: err.Field,
-			Error: err.Error,
-		})
+		vErrors.Errors = append(vErrors.Errors, validationError(r, &err))
 	}
 
 	return val, vErrors, err
@@ -1456,11 +2201,9 @@ func BindCookie[T any](r *Request) (T, *ValidationErrors, error) {
 
 	vErrors := &ValidationErrors{}
 	for _, err := range valErrors {
-		vErrors.Errors = append(vErrors.Errors, ValidationError{
-			Field: err.Field,
-			Error: err.Error,
-		})
+		vErrors.Errors = append(vErrors.Errors, validationError(r, &err))
 	}
+	reportValidationErrors(r, vErrors)
 
 	return val, vErrors, err
 }
@@ -1475,17 +2218,94 @@ func BindHeader[T any](r *Request) (T, *ValidationErrors, error) {
 
 	vErrors := &ValidationErrors{}
 	for _, err := range valErrors {
+		vErrors.Errors = append(vErrors.Errors, validationError(r, &err))
+	}
+	reportValidationErrors(r, vErrors)
+
+	return val, vErrors, err
+}
+
+// ValidateOnly decodes and validates the request body as T, discarding the bound value. It is meant for
+// endpoints that only check a payload, such as previews or "check as you type" forms.
+// The binder is chosen from the Content-Type header: BindJSON for JSON (the default when the header is absent),
+// BindXML for XML and BindForm for form data.
+// Returns the validation errors, and a parsing error (nil if successful) that wraps ErrUnsupportedMediaType
+// for other content types.
+func ValidateOnly[T any](r *Request) (*ValidationErrors, error) {
+	_, valErrors, err := bindBody[T](r, true)
+	reportValidationErrors(r, valErrors)
+
+	return valErrors, err
+}
+
+// BindAll binds the path parameters of the request to P, its query parameters to Q and its body to B, for
+// endpoints that take all three. The body binder is chosen from the Content-Type header as by ValidateOnly.
+// Validation errors of the three are aggregated, with their source as prefix of the field name
+// (e.g., "body.name"). Path and query parameters are always validated, as by
+// BindPath and BindQuery; validate applies to JSON and XML bodies, as for BindJSON and BindXML.
+// Returns the bound values, the validation errors, and the first parsing error (nil if successful), which
+// wraps ErrUnsupportedMediaType for unsupported body content types.
+func BindAll[P, Q, B any](r *Request, validate bool) (P, Q, B, *ValidationErrors, error) {
+	var body B
+
+	path, pathErrors := bindPath[P](r)
+	vErrors := &ValidationErrors{}
+	appendSourceValidationErrors(vErrors, "path", pathErrors)
+
+	query, queryErrors, err := bindQuery[Q](r)
+	if err != nil {
+		reportValidationErrors(r, vErrors)
+		return path, query, body, vErrors, err
+	}
+	appendSourceValidationErrors(vErrors, "query", queryErrors)
+
+	body, bodyErrors, err := bindBody[B](r, validate)
+	appendSourceValidationErrors(vErrors, "body", bodyErrors)
+	reportValidationErrors(r, vErrors)
+
+	return path, query, body, vErrors, err
+}
+
+// bindBody binds the request body to T with the binder matching the Content-Type header: BindJSON for JSON
+// (the default when the header is absent), BindXML for XML and BindForm for form data.
+// Returns an error wrapping ErrUnsupportedMediaType, and nil validation errors, for other content types.
+func bindBody[T any](r *Request, validate bool) (T, *ValidationErrors, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	switch {
+	case mediaType == "", mediaType == "application/json", strings.HasSuffix(mediaType, "+json"):
+		return bindJSON[T](r, validate)
+	case slices.Contains(mediaTypesXML, mediaType):
+		return bindXML[T](r, validate)
+	case isFormContentType(mediaType):
+		return bindForm[T](r)
+	default:
+		var zero T
+		return zero, nil, fmt.Errorf("%w: %q", ErrUnsupportedMediaType, mediaType)
+	}
+}
+
+// appendSourceValidationErrors appends the validation errors of a binding source to vErrors,
+// prefixing their field names with the source.
+func appendSourceValidationErrors(vErrors *ValidationErrors, source string, errs *ValidationErrors) {
+	if errs == nil {
+		return
+	}
+
+	for _, err := range errs.Errors {
 		vErrors.Errors = append(vErrors.Errors, ValidationError{
-			Field: err.Field,
+			Field: source + "." + err.Field,
 			Error: err.Error,
 		})
 	}
-
-	return val, vErrors, err
 }
 
 // PatchJSON applies JSON Patch (RFC 6902) operations to the provided data.
 // The request must use PATCH method and have Content-Type application/json-patch+json.
+// The patch is atomic: operations are applied in order to a copy of the data, and t is only updated once all of
+// them succeeded. An operation that cannot be applied, including a failed test operation, aborts the patch and is
+// returned as a *JSONPatchError; failed test operations wrap ErrPatchTestFailed. Errors reading or decoding the
+// patch wrap ErrEmptyBody, ErrMalformedBody or ErrBodyTooLarge depending on their cause.
 // If validate is true, validates the patched data according to struct tags.
 // Returns validation errors (empty if valid or validation disabled) and a parsing/application error (nil if successful).
 func PatchJSON[T any](r *Request, t *T, validate bool) ([]ValidationError, error) {
@@ -1494,60 +2314,80 @@ func PatchJSON[T any](r *Request, t *T, validate bool) ([]ValidationError, error
 	}
 
 	if r.Header.Get("Content-Type") != "application/json-patch+json" {
-		return nil, errors.New("invalid Content-Type header, expected application/json-patch+json")
+		return nil, fmt.Errorf("%w: invalid Content-Type header, expected application/json-patch+json", ErrUnsupportedMediaType)
+	}
+
+	if err := decompressBody(r); err != nil {
+		return nil, classifyBodyError(err)
 	}
 
 	body, err := io.ReadAll(r.Body)
 
 	if err != nil {
-		return nil, err
+		return nil, classifyBodyError(err)
+	}
+
+	if len(body) == 0 {
+		return nil, classifyBodyError(io.EOF)
 	}
 
 	patch, err := jsonpatch.DecodePatch(body)
 
 	if err != nil {
-		return nil, err
+		return nil, classifyBodyError(err)
 	}
 
-	original, err := json.Marshal(*t)
+	doc, err := json.Marshal(*t)
 
 	if err != nil {
 		return nil, err
 	}
 
-	modified, err := patch.Apply(original)
-
-	if err != nil {
-		return nil, err
+	for i, op := range patch {
+		if doc, err = (jsonpatch.Patch{op}).Apply(doc); err != nil {
+			return nil, newJSONPatchError(i, op, err)
+		}
 	}
 
-	err = json.Unmarshal(modified, t)
+	// Decode into a copy of t whose JSON fields are cleared: decoding into t would keep the fields and map
+	// keys removed by the patch, and decoding into a zero value would lose the fields JSON cannot hold.
+	patched := patchTarget(t)
+	err = json.Unmarshal(doc, &patched)
 
 	if err != nil {
-		return nil, err
+		return nil, classifyBodyError(err)
 	}
 
+	*t = patched
+
 	if validate {
 		validationErrors := bind.ValidateJSON(t)
 
 		vErrors := []ValidationError{}
 		for _, err := range validationErrors {
-			vErrors = append(vErrors, ValidationError{
-				Field: err.Field,
-				Error: err.Error,
-			})
+			vErrors = append(vErrors, validationError(r, &err))
 		}
+		reportValidationErrors(r, &ValidationErrors{Errors: vErrors})
+
 		return vErrors, nil
 	}
 
 	return nil, nil
 }
 
-// GetI18nPrinter creates a message printer for the specified language tag.
+// GetI18nPrinter returns a message printer for the specified language tag.
 // The printer can be used to format messages according to the configured i18n catalogs.
+// Printers are cached per language and are safe for concurrent use.
 // Returns a printer that will use the best available language match from configured catalogs.
 func GetI18nPrinter(tag language.Tag) *message.Printer {
-	return i18n.GetI18nPrinter(tag)
+	return defaultApp.GetI18nPrinter(tag)
+}
+
+// GetI18nPrinter returns a message printer for the specified language tag.
+// The printer uses the message catalogs of the app, like the package-level GetI18nPrinter does for the
+// default App.
+func (a *App) GetI18nPrinter(tag language.Tag) *message.Printer {
+	return a.i18nCatalogs.GetI18nPrinter(tag)
 }
 
 func getValueOrDefault[T comparable](value, defaultValue T) T {
@@ -1583,7 +2423,27 @@ func getI18nMessagesDir(cfg *Config) string {
 	return getValueOrDefault(cfg.Assets.I18nMessages.Dir, defaultI18nMessagesDir)
 }
 
-func getSupportedLanguages(cfg *Config, localesDir string) []language.Tag {
+func getI18nMatcher(cfg *Config) language.Matcher {
+	if cfg == nil || cfg.Assets == nil || cfg.Assets.I18nMessages == nil {
+		return nil
+	}
+	return cfg.Assets.I18nMessages.Matcher
+}
+
+func getI18nFallback(cfg *Config) []language.Tag {
+	if cfg == nil || cfg.Assets == nil || cfg.Assets.I18nMessages == nil {
+		return nil
+	}
+
+	fallback := make([]language.Tag, 0, len(cfg.Assets.I18nMessages.Fallback))
+	for _, lang := range cfg.Assets.I18nMessages.Fallback {
+		fallback = append(fallback, language.MustParse(lang))
+	}
+
+	return fallback
+}
+
+func (a *App) getSupportedLanguages(cfg *Config, localesDir string) []language.Tag {
 	var langs []string
 	// TODO: Consider refactoring to reduce complexity (currently ignored for clarity)
 	//nolint:nestif // Nested if-else structure is intentional for auto-detection logic
@@ -1591,7 +2451,7 @@ func getSupportedLanguages(cfg *Config, localesDir string) []language.Tag {
 		cfg.Assets == nil ||
 		cfg.Assets.I18nMessages == nil ||
 		len(cfg.Assets.I18nMessages.SupportedLanguages) == 0 {
-		entries, err := fs.ReadDir(assetsFS, localesDir)
+		entries, err := fs.ReadDir(a.assetsFS, localesDir)
 		if err != nil {
 			return []language.Tag{defaultLanguage}
 		}
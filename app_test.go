@@ -10,10 +10,12 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/bondowe/webfram/security"
@@ -26,6 +28,9 @@ var testI18nFS2 embed.FS
 //go:embed testdata/templates/*.go.html
 var testTemplatesFS2 embed.FS
 
+//go:embed testdata/locales/*.json testdata/templates/*.go.html
+var testAssetsFS embed.FS
+
 // Test helper structs.
 type testUser struct {
 	Name  string `json:"name"  xml:"name"  form:"name"  validate:"required,minlength=2"`
@@ -35,12 +40,7 @@ type testUser struct {
 
 // resetAppConfig resets all global app configuration to initial state.
 func resetAppConfig() {
-	appConfigured = false
-	appMiddlewares = nil
-	openAPIConfig = nil
-	securityConfig = nil
-	securityConfigs = nil
-	jsonpCallbackParamName = ""
+	Reset()
 }
 
 // setupTestConfig is a helper that sets up test configuration.
@@ -95,7 +95,7 @@ func TestConfigure_Success(t *testing.T) {
 	cfg := &Config{
 		JSONPCallbackParamName: "callback",
 		Assets: &Assets{
-			FS: testI18nFS2,
+			FS: testAssetsFS,
 			I18nMessages: &I18nMessages{
 				Dir: "testdata/locales",
 			},
@@ -107,12 +107,12 @@ func TestConfigure_Success(t *testing.T) {
 
 	Configure(cfg)
 
-	if !appConfigured {
-		t.Error("Expected appConfigured to be true")
+	if !defaultApp.configured {
+		t.Error("Expected configured to be true")
 	}
 
-	if jsonpCallbackParamName != "callback" {
-		t.Errorf("Expected jsonpCallbackParamName to be 'callback', got %q", jsonpCallbackParamName)
+	if defaultApp.jsonpCallbackParamName != "callback" {
+		t.Errorf("Expected jsonpCallbackParamName to be 'callback', got %q", defaultApp.jsonpCallbackParamName)
 	}
 }
 
@@ -122,8 +122,8 @@ func TestConfigure_WithNilConfig(t *testing.T) {
 	// Should not panic with nil config
 	Configure(nil)
 
-	if !appConfigured {
-		t.Error("Expected appConfigured to be true even with nil config")
+	if !defaultApp.configured {
+		t.Error("Expected configured to be true even with nil config")
 	}
 }
 
@@ -133,8 +133,8 @@ func TestConfigure_WithMinimalConfig(t *testing.T) {
 	cfg := &Config{}
 	Configure(cfg)
 
-	if !appConfigured {
-		t.Error("Expected appConfigured to be true")
+	if !defaultApp.configured {
+		t.Error("Expected configured to be true")
 	}
 }
 
@@ -153,6 +153,162 @@ func TestConfigure_PanicsWhenCalledTwice(t *testing.T) {
 	Configure(cfg)
 }
 
+func TestReset(t *testing.T) {
+	resetAppConfig()
+
+	Configure(&Config{JSONPCallbackParamName: "callback", MaxUploadSize: 1024})
+	Use(func(next Handler) Handler { return next })
+	NewServeMux().HandleFunc("GET /users", func(_ ResponseWriter, _ *Request) {})
+
+	Reset()
+
+	if defaultApp.configured || defaultApp.middlewares != nil || defaultApp.handlerConfigs != nil {
+		t.Error("Expected configuration, middlewares and handlers to be cleared")
+	}
+	if defaultApp.jsonpCallbackParamName != "" || defaultApp.maxUploadSize != defaultMaxUploadSize {
+		t.Error("Expected settings to be restored to their defaults")
+	}
+
+	// Configure can be called again after Reset.
+	Configure(&Config{JSONPCallbackParamName: "cb"})
+
+	if defaultApp.jsonpCallbackParamName != "cb" {
+		t.Errorf("Expected JSONP callback param name 'cb', got %q", defaultApp.jsonpCallbackParamName)
+	}
+}
+
+func TestReset_PanicsWhileServing(t *testing.T) {
+	resetAppConfig()
+
+	defaultApp.activeServers.Add(1)
+	defer defaultApp.activeServers.Add(-1)
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic when resetting while a server is running")
+		}
+	}()
+
+	Reset()
+}
+
+func TestNew_IndependentApps(t *testing.T) {
+	resetAppConfig()
+
+	newAppMux := func(a *App, paramName string) *ServeMux {
+		a.Use(func(next Handler) Handler {
+			return HandlerFunc(func(w ResponseWriter, r *Request) {
+				w.Header().Set("X-App", paramName)
+				next.ServeHTTP(w, r)
+			})
+		})
+
+		mux := a.NewServeMux()
+		mux.HandleFunc("GET /data", func(w ResponseWriter, r *Request) {
+			_ = w.JSON(r.Context(), map[string]string{"app": paramName})
+		})
+		registerHandlers(mux)
+
+		return mux
+	}
+
+	mux1 := newAppMux(New(&Config{JSONPCallbackParamName: "callback"}), "callback")
+	mux2 := newAppMux(New(&Config{JSONPCallbackParamName: "cb", JSONPContentType: "text/javascript"}), "cb")
+
+	rec := httptest.NewRecorder()
+	mux1.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/data?callback=fn&cb=other", http.NoBody))
+
+	if got := rec.Body.String(); !strings.HasPrefix(got, "fn(") {
+		t.Errorf("Expected first app to use the 'callback' param, got %q", got)
+	}
+	if rec.Header().Get("X-App") != "callback" || rec.Header().Get("Content-Type") != defaultJSONPContentType {
+		t.Errorf("Unexpected headers for first app: %v", rec.Header())
+	}
+
+	rec = httptest.NewRecorder()
+	mux2.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/data?callback=fn&cb=other", http.NoBody))
+
+	if got := rec.Body.String(); !strings.HasPrefix(got, "other(") {
+		t.Errorf("Expected second app to use the 'cb' param, got %q", got)
+	}
+	if rec.Header().Get("X-App") != "cb" || rec.Header().Get("Content-Type") != "text/javascript" {
+		t.Errorf("Unexpected headers for second app: %v", rec.Header())
+	}
+
+	if defaultApp.configured || len(defaultApp.middlewares) != 0 || len(defaultApp.handlerConfigs) != 0 {
+		t.Error("Expected the default app to be unaffected")
+	}
+}
+
+func TestApp_ListenAndServe_PanicsForForeignMux(t *testing.T) {
+	resetAppConfig()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic when serving a mux of another app")
+		}
+	}()
+
+	New(nil).ListenAndServe(":0", NewServeMux(), nil)
+}
+
+func TestNew_AppsKeepTheirOwnTemplatesAndI18nMessages(t *testing.T) {
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+
+	assetsConfig := func(name, translation string) *Config {
+		return &Config{
+			Assets: &Assets{
+				FS: fstest.MapFS{
+					"assets/templates/page.go.html": {
+						Data: []byte(`<h1>` + name + `</h1><p>{{T "Hello"}}</p><script src="{{asset "app.js"}}"></script>`),
+					},
+					"assets/locales/messages.fr.json": {
+						Data: []byte(`{"language":"fr","messages":[{"id":"Hello","message":"Hello","translation":"` +
+							translation + `"}]}`),
+					},
+					"assets/static/app.js": {Data: []byte(`console.log("` + name + `");`)},
+				},
+				Static: &StaticAssets{URLPath: "/" + name + "/"},
+			},
+		}
+	}
+
+	// The default App and other apps find templates and i18n messages in their own assets.
+	Configure(assetsConfig("first", "Bonjour"))
+	second := New(assetsConfig("second", "Salut"))
+
+	render := func(mux *ServeMux) string {
+		mux.HandleFunc("GET /", func(w ResponseWriter, r *Request) {
+			if err := w.HTML(r.Context(), "page", nil); err != nil {
+				t.Errorf("HTML failed: %v", err)
+			}
+		})
+		registerHandlers(mux)
+
+		req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+		req.Header.Set("Accept-Language", "fr")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		return rec.Body.String()
+	}
+
+	if body := render(NewServeMux()); !strings.HasPrefix(body, `<h1>first</h1><p>Bonjour</p><script src="/first/`) {
+		t.Errorf("Expected the templates, messages and assets of the default App, got %q", body)
+	}
+	if body := render(second.NewServeMux()); !strings.HasPrefix(body, `<h1>second</h1><p>Salut</p><script src="/second/`) {
+		t.Errorf("Expected the templates, messages and assets of the second app, got %q", body)
+	}
+
+	if err := second.ReloadI18n(); err != nil {
+		t.Errorf("Unexpected error reloading the messages of the second app: %v", err)
+	}
+	if err := New(nil).ReloadI18n(); !errors.Is(err, ErrI18nNotConfigured) {
+		t.Errorf("Expected ErrI18nNotConfigured for an app without i18n messages, got %v", err)
+	}
+}
+
 func TestConfigure_InvalidJSONPCallbackName(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -206,48 +362,101 @@ func TestConfigure_ValidJSONPCallbackNames(t *testing.T) {
 
 			Configure(cfg)
 
-			if jsonpCallbackParamName != tt.callbackName {
-				t.Errorf("Expected %q, got %q", tt.callbackName, jsonpCallbackParamName)
+			if defaultApp.jsonpCallbackParamName != tt.callbackName {
+				t.Errorf("Expected %q, got %q", tt.callbackName, defaultApp.jsonpCallbackParamName)
 			}
 		})
 	}
 }
 
+func TestJSONPCallback_LengthAndDisallowedNames(t *testing.T) {
+	a := New(&Config{
+		JSONPCallbackParamName:   "callback",
+		JSONPCallbackMaxLength:   16,
+		JSONPDisallowedCallbacks: []string{"__proto__", "eval"},
+	})
+
+	mux := a.NewServeMux()
+	mux.HandleFunc("GET /data", func(w ResponseWriter, r *Request) {
+		_ = w.JSON(r.Context(), map[string]string{"message": "hello"})
+	})
+	registerHandlers(mux)
+
+	tests := []struct {
+		name           string
+		callback       string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"valid", "myCallback", http.StatusOK, "myCallback("},
+		{"max length", strings.Repeat("a", 16), http.StatusOK, strings.Repeat("a", 16) + "("},
+		{"too long", strings.Repeat("a", 17), http.StatusBadRequest, "longer than 16 characters"},
+		{"reserved name", "__proto__", http.StatusBadRequest, `"__proto__" is not allowed`},
+		{"disallowed name", "eval", http.StatusBadRequest, `"eval" is not allowed`},
+		{"case-sensitive", "Eval", http.StatusOK, "Eval("},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/data?callback="+tt.callback, http.NoBody))
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+			if !strings.Contains(rec.Body.String(), tt.expectedBody) {
+				t.Errorf("Expected body containing %q, got %q", tt.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestJSONPCallback_DefaultMaxLength(t *testing.T) {
+	a := New(&Config{JSONPCallbackParamName: "callback"})
+
+	if err := a.validateJSONPCallback(strings.Repeat("a", defaultJSONPCallbackMaxLen)); err != nil {
+		t.Errorf("Expected callback of the default max length to be valid, got %v", err)
+	}
+	if err := a.validateJSONPCallback(strings.Repeat("a", defaultJSONPCallbackMaxLen+1)); err == nil {
+		t.Error("Expected callback longer than the default max length to be rejected")
+	}
+}
+
 // =============================================================================
 // configureOpenAPI Tests
 // =============================================================================
 
 func TestConfigureOpenAPI_NilConfig(t *testing.T) {
-	openAPIConfig = nil
-	configureOpenAPI(nil)
+	defaultApp.openAPIConfig = nil
+	defaultApp.configureOpenAPI(nil)
 
-	if openAPIConfig != nil {
+	if defaultApp.openAPIConfig != nil {
 		t.Error("Expected openAPIConfig to remain nil")
 	}
 }
 
 func TestConfigureOpenAPI_NilOpenAPIConfig(t *testing.T) {
-	openAPIConfig = nil
+	defaultApp.openAPIConfig = nil
 	cfg := &Config{}
-	configureOpenAPI(cfg)
+	defaultApp.configureOpenAPI(cfg)
 
-	if openAPIConfig != nil {
+	if defaultApp.openAPIConfig != nil {
 		t.Error("Expected openAPIConfig to remain nil")
 	}
 }
 
 func TestConfigureOpenAPI_DisabledEndpoint(t *testing.T) {
-	openAPIConfig = &OpenAPI{Enabled: false}
+	defaultApp.openAPIConfig = &OpenAPI{Enabled: false}
 	cfg := &Config{
 		OpenAPI: &OpenAPI{
 			Enabled: false,
 			Config:  &OpenAPIConfig{},
 		},
 	}
-	configureOpenAPI(cfg)
+	defaultApp.configureOpenAPI(cfg)
 
 	// Should not override when disabled
-	if openAPIConfig.Enabled {
+	if defaultApp.openAPIConfig.Enabled {
 		t.Error("Expected endpoint to remain disabled")
 	}
 }
@@ -255,7 +464,7 @@ func TestConfigureOpenAPI_DisabledEndpoint(t *testing.T) {
 func TestConfigureOpenAPI_WithDefaultURL(t *testing.T) {
 	// Set up initial state - the function checks openAPIConfig.EndpointEnabled
 	// so we need to initialize it first
-	openAPIConfig = &OpenAPI{Enabled: true}
+	defaultApp.openAPIConfig = &OpenAPI{Enabled: true}
 
 	cfg := &Config{
 		OpenAPI: &OpenAPI{
@@ -268,23 +477,23 @@ func TestConfigureOpenAPI_WithDefaultURL(t *testing.T) {
 			},
 		},
 	}
-	configureOpenAPI(cfg)
+	defaultApp.configureOpenAPI(cfg)
 
-	if openAPIConfig == nil {
+	if defaultApp.openAPIConfig == nil {
 		t.Fatal("Expected openAPIConfig to be set")
 	}
 
-	if openAPIConfig.URLPath != defaultOpenAPIURLPath {
-		t.Errorf("Expected URLPath %q, got %q", defaultOpenAPIURLPath, openAPIConfig.URLPath)
+	if defaultApp.openAPIConfig.URLPath != defaultOpenAPIURLPath {
+		t.Errorf("Expected URLPath %q, got %q", defaultOpenAPIURLPath, defaultApp.openAPIConfig.URLPath)
 	}
 
-	if openAPIConfig.internalConfig.Components == nil {
+	if defaultApp.openAPIConfig.internalConfig.Components == nil {
 		t.Error("Expected Components to be initialized")
 	}
 }
 
 func TestConfigureOpenAPI_WithCustomURL(t *testing.T) {
-	openAPIConfig = &OpenAPI{Enabled: true}
+	defaultApp.openAPIConfig = &OpenAPI{Enabled: true}
 
 	cfg := &Config{
 		OpenAPI: &OpenAPI{
@@ -293,16 +502,16 @@ func TestConfigureOpenAPI_WithCustomURL(t *testing.T) {
 			Config:  &OpenAPIConfig{},
 		},
 	}
-	configureOpenAPI(cfg)
+	defaultApp.configureOpenAPI(cfg)
 
 	expectedPath := "GET /api/spec.json"
-	if openAPIConfig.URLPath != expectedPath {
-		t.Errorf("Expected URLPath %q, got %q", expectedPath, openAPIConfig.URLPath)
+	if defaultApp.openAPIConfig.URLPath != expectedPath {
+		t.Errorf("Expected URLPath %q, got %q", expectedPath, defaultApp.openAPIConfig.URLPath)
 	}
 }
 
 func TestConfigureOpenAPI_URLWithExistingGETPrefix(t *testing.T) {
-	openAPIConfig = &OpenAPI{Enabled: true}
+	defaultApp.openAPIConfig = &OpenAPI{Enabled: true}
 
 	cfg := &Config{
 		OpenAPI: &OpenAPI{
@@ -311,16 +520,16 @@ func TestConfigureOpenAPI_URLWithExistingGETPrefix(t *testing.T) {
 			Config:  &OpenAPIConfig{},
 		},
 	}
-	configureOpenAPI(cfg)
+	defaultApp.configureOpenAPI(cfg)
 
-	if openAPIConfig.URLPath != "GET /custom.json" {
-		t.Errorf("Expected URLPath to remain unchanged, got %q", openAPIConfig.URLPath)
+	if defaultApp.openAPIConfig.URLPath != "GET /custom.json" {
+		t.Errorf("Expected URLPath to remain unchanged, got %q", defaultApp.openAPIConfig.URLPath)
 	}
 }
 
 func TestConfigureOpenAPI_WithGlobalSecurity(t *testing.T) {
 	resetAppConfig()
-	openAPIConfig = &OpenAPI{Enabled: true}
+	defaultApp.openAPIConfig = &OpenAPI{Enabled: true}
 
 	cfg := &Config{
 		OpenAPI: &OpenAPI{
@@ -338,23 +547,23 @@ func TestConfigureOpenAPI_WithGlobalSecurity(t *testing.T) {
 		},
 	}
 
-	configureOpenAPI(cfg)
+	defaultApp.configureOpenAPI(cfg)
 
-	if openAPIConfig.internalConfig.Security == nil {
+	if defaultApp.openAPIConfig.internalConfig.Security == nil {
 		t.Fatal("Expected Security to be initialized")
 	}
 
-	if len(openAPIConfig.internalConfig.Security) != 2 {
-		t.Errorf("Expected 2 security requirements, got %d", len(openAPIConfig.internalConfig.Security))
+	if len(defaultApp.openAPIConfig.internalConfig.Security) != 2 {
+		t.Errorf("Expected 2 security requirements, got %d", len(defaultApp.openAPIConfig.internalConfig.Security))
 	}
 
 	// Verify BasicAuth requirement
-	if _, ok := openAPIConfig.internalConfig.Security[0]["BasicAuth"]; !ok {
+	if _, ok := defaultApp.openAPIConfig.internalConfig.Security[0]["BasicAuth"]; !ok {
 		t.Error("Expected BasicAuth security requirement")
 	}
 
 	// Verify ApiKeyAuth requirement with scopes
-	if scopes, ok := openAPIConfig.internalConfig.Security[1]["ApiKeyAuth"]; !ok {
+	if scopes, ok := defaultApp.openAPIConfig.internalConfig.Security[1]["ApiKeyAuth"]; !ok {
 		t.Error("Expected ApiKeyAuth security requirement")
 	} else if len(scopes) != 2 {
 		t.Errorf("Expected 2 scopes for ApiKeyAuth, got %d", len(scopes))
@@ -365,7 +574,7 @@ func TestConfigureOpenAPI_WithGlobalSecurity(t *testing.T) {
 
 func TestConfigureOpenAPI_WithNilSecurity(t *testing.T) {
 	resetAppConfig()
-	openAPIConfig = &OpenAPI{Enabled: true}
+	defaultApp.openAPIConfig = &OpenAPI{Enabled: true}
 
 	cfg := &Config{
 		OpenAPI: &OpenAPI{
@@ -380,17 +589,17 @@ func TestConfigureOpenAPI_WithNilSecurity(t *testing.T) {
 		},
 	}
 
-	configureOpenAPI(cfg)
+	defaultApp.configureOpenAPI(cfg)
 
 	// Nil Security should remain nil in internal config
-	if openAPIConfig.internalConfig.Security != nil {
+	if defaultApp.openAPIConfig.internalConfig.Security != nil {
 		t.Error("Expected Security to remain nil when not specified")
 	}
 }
 
 func TestConfigureOpenAPI_WithEmptySecurity(t *testing.T) {
 	resetAppConfig()
-	openAPIConfig = &OpenAPI{Enabled: true}
+	defaultApp.openAPIConfig = &OpenAPI{Enabled: true}
 
 	cfg := &Config{
 		OpenAPI: &OpenAPI{
@@ -405,15 +614,15 @@ func TestConfigureOpenAPI_WithEmptySecurity(t *testing.T) {
 		},
 	}
 
-	configureOpenAPI(cfg)
+	defaultApp.configureOpenAPI(cfg)
 
 	// Empty Security should be mapped to internal config
-	if openAPIConfig.internalConfig.Security == nil {
+	if defaultApp.openAPIConfig.internalConfig.Security == nil {
 		t.Error("Expected Security to be initialized even when empty")
 	}
 
-	if len(openAPIConfig.internalConfig.Security) != 0 {
-		t.Errorf("Expected 0 security requirements, got %d", len(openAPIConfig.internalConfig.Security))
+	if len(defaultApp.openAPIConfig.internalConfig.Security) != 0 {
+		t.Errorf("Expected 0 security requirements, got %d", len(defaultApp.openAPIConfig.internalConfig.Security))
 	}
 }
 
@@ -423,28 +632,28 @@ func TestConfigureOpenAPI_WithEmptySecurity(t *testing.T) {
 
 func TestConfigureSecurity_NilConfig(t *testing.T) {
 	resetAppConfig()
-	configureSecurity(nil)
+	defaultApp.configureSecurity(nil)
 
-	if securityConfig != nil {
+	if defaultApp.securityConfig != nil {
 		t.Error("Expected securityConfig to remain nil")
 	}
 
-	if len(securityConfigs) != 0 {
-		t.Errorf("Expected securityConfigs to be empty, got %d", len(securityConfigs))
+	if len(defaultApp.securityConfigs) != 0 {
+		t.Errorf("Expected securityConfigs to be empty, got %d", len(defaultApp.securityConfigs))
 	}
 }
 
 func TestConfigureSecurity_NilSecurityConfig(t *testing.T) {
 	resetAppConfig()
 	cfg := &Config{}
-	configureSecurity(cfg)
+	defaultApp.configureSecurity(cfg)
 
-	if securityConfig != nil {
+	if defaultApp.securityConfig != nil {
 		t.Error("Expected securityConfig to remain nil")
 	}
 
-	if len(securityConfigs) != 0 {
-		t.Errorf("Expected securityConfigs to be empty, got %d", len(securityConfigs))
+	if len(defaultApp.securityConfigs) != 0 {
+		t.Errorf("Expected securityConfigs to be empty, got %d", len(defaultApp.securityConfigs))
 	}
 }
 
@@ -460,27 +669,27 @@ func TestConfigureSecurity_WithSecurityConfig(t *testing.T) {
 		},
 	}
 
-	configureSecurity(cfg)
+	defaultApp.configureSecurity(cfg)
 
-	if securityConfig == nil {
+	if defaultApp.securityConfig == nil {
 		t.Fatal("Expected securityConfig to be set")
 	}
 
-	if !securityConfig.AllowAnonymousAuth {
+	if !defaultApp.securityConfig.AllowAnonymousAuth {
 		t.Error("Expected AllowAnonymousAuth to be true")
 	}
 
-	if securityConfig.APIKeyAuth == nil {
+	if defaultApp.securityConfig.APIKeyAuth == nil {
 		t.Error("Expected APIKeyAuth to be set")
-	} else if securityConfig.APIKeyAuth.KeyName != "X-API-Key" {
-		t.Errorf("Expected KeyName 'X-API-Key', got %q", securityConfig.APIKeyAuth.KeyName)
+	} else if defaultApp.securityConfig.APIKeyAuth.KeyName != "X-API-Key" {
+		t.Errorf("Expected KeyName 'X-API-Key', got %q", defaultApp.securityConfig.APIKeyAuth.KeyName)
 	}
 
-	if len(securityConfigs) != 1 {
-		t.Errorf("Expected 1 config in securityConfigs, got %d", len(securityConfigs))
+	if len(defaultApp.securityConfigs) != 1 {
+		t.Errorf("Expected 1 config in securityConfigs, got %d", len(defaultApp.securityConfigs))
 	}
 
-	if securityConfigs[0].AllowAnonymousAuth != true {
+	if defaultApp.securityConfigs[0].AllowAnonymousAuth != true {
 		t.Error("Expected first config to have AllowAnonymousAuth true")
 	}
 }
@@ -494,7 +703,7 @@ func TestConfigureSecurity_MultipleCalls(t *testing.T) {
 			AllowAnonymousAuth: true,
 		},
 	}
-	configureSecurity(cfg1)
+	defaultApp.configureSecurity(cfg1)
 
 	// Second call
 	cfg2 := &Config{
@@ -505,26 +714,26 @@ func TestConfigureSecurity_MultipleCalls(t *testing.T) {
 			},
 		},
 	}
-	configureSecurity(cfg2)
+	defaultApp.configureSecurity(cfg2)
 
-	if securityConfig == nil {
+	if defaultApp.securityConfig == nil {
 		t.Fatal("Expected securityConfig to be set")
 	}
 
 	// Should be set to the last config
-	if securityConfig.AllowAnonymousAuth {
+	if defaultApp.securityConfig.AllowAnonymousAuth {
 		t.Error("Expected AllowAnonymousAuth to be false (from second call)")
 	}
 
-	if len(securityConfigs) != 2 {
-		t.Errorf("Expected 2 configs in securityConfigs, got %d", len(securityConfigs))
+	if len(defaultApp.securityConfigs) != 2 {
+		t.Errorf("Expected 2 configs in securityConfigs, got %d", len(defaultApp.securityConfigs))
 	}
 
-	if securityConfigs[0].AllowAnonymousAuth != true {
+	if defaultApp.securityConfigs[0].AllowAnonymousAuth != true {
 		t.Error("Expected first config to have AllowAnonymousAuth true")
 	}
 
-	if securityConfigs[1].AllowAnonymousAuth != false {
+	if defaultApp.securityConfigs[1].AllowAnonymousAuth != false {
 		t.Error("Expected second config to have AllowAnonymousAuth false")
 	}
 }
@@ -534,13 +743,13 @@ func TestConfigureSecurity_MultipleCalls(t *testing.T) {
 // =============================================================================
 
 func TestConfigureTemplate_NilConfig(_ *testing.T) {
-	configureTemplate(nil)
+	defaultApp.configureTemplate(nil)
 	// Should not panic
 }
 
 func TestConfigureTemplate_NilTemplateConfig(_ *testing.T) {
 	cfg := &Config{}
-	configureTemplate(cfg)
+	defaultApp.configureTemplate(cfg)
 	// Should not panic
 }
 
@@ -550,7 +759,7 @@ func TestConfigureTemplate_NilFS(_ *testing.T) {
 			Templates: &Templates{},
 		},
 	}
-	configureTemplate(cfg)
+	defaultApp.configureTemplate(cfg)
 	// Should not panic
 }
 
@@ -563,7 +772,7 @@ func TestConfigureTemplate_WithDefaults(_ *testing.T) {
 			},
 		},
 	}
-	configureTemplate(cfg)
+	defaultApp.configureTemplate(cfg)
 	// Should use default values without panicking
 }
 
@@ -579,7 +788,7 @@ func TestConfigureTemplate_WithCustomValues(_ *testing.T) {
 			},
 		},
 	}
-	configureTemplate(cfg)
+	defaultApp.configureTemplate(cfg)
 	// Should accept custom values without panicking
 }
 
@@ -593,7 +802,7 @@ func TestConfigureTemplate_NonExistentDirectory(_ *testing.T) {
 		},
 	}
 	// Should not panic, just return early when directory doesn't exist
-	configureTemplate(cfg)
+	defaultApp.configureTemplate(cfg)
 }
 
 func TestConfigureTemplate_DirectoryIsFile(_ *testing.T) {
@@ -606,7 +815,7 @@ func TestConfigureTemplate_DirectoryIsFile(_ *testing.T) {
 		},
 	}
 	// Should not panic, just return early when path is not a directory
-	configureTemplate(cfg)
+	defaultApp.configureTemplate(cfg)
 }
 
 // =============================================================================
@@ -614,13 +823,13 @@ func TestConfigureTemplate_DirectoryIsFile(_ *testing.T) {
 // =============================================================================
 
 func TestConfigureI18n_NilConfig(_ *testing.T) {
-	configureI18n(nil)
+	defaultApp.configureI18n(nil)
 	// Should not panic
 }
 
 func TestConfigureI18n_NilI18nConfig(_ *testing.T) {
 	cfg := &Config{}
-	configureI18n(cfg)
+	defaultApp.configureI18n(cfg)
 	// Should not panic
 }
 
@@ -630,7 +839,7 @@ func TestConfigureI18n_NilFS(_ *testing.T) {
 			I18nMessages: &I18nMessages{},
 		},
 	}
-	configureI18n(cfg)
+	defaultApp.configureI18n(cfg)
 	// Should not panic
 }
 
@@ -643,7 +852,7 @@ func TestConfigureI18n_WithFS(_ *testing.T) {
 			},
 		},
 	}
-	configureI18n(cfg)
+	defaultApp.configureI18n(cfg)
 	// Should configure without panicking
 }
 
@@ -657,7 +866,7 @@ func TestConfigureI18n_NonExistentDirectory(_ *testing.T) {
 		},
 	}
 	// Should not panic, just return early when directory doesn't exist
-	configureI18n(cfg)
+	defaultApp.configureI18n(cfg)
 }
 
 func TestConfigureI18n_WithCustomDirectory(_ *testing.T) {
@@ -669,18 +878,83 @@ func TestConfigureI18n_WithCustomDirectory(_ *testing.T) {
 			},
 		},
 	}
-	configureI18n(cfg)
+	defaultApp.configureI18n(cfg)
 	// Should configure with custom directory without panicking
 }
 
+func TestConfigureI18n_CustomMatcher(t *testing.T) {
+	resetAppConfig()
+
+	Configure(&Config{
+		Assets: &Assets{
+			FS: testI18nFS2,
+			I18nMessages: &I18nMessages{
+				Dir:                "testdata/locales",
+				SupportedLanguages: []string{"en", "fr"},
+				Matcher:            language.NewMatcher([]language.Tag{language.French}),
+			},
+		},
+	})
+
+	tag := defaultApp.parseAcceptLanguage("de-DE,de;q=0.9,en;q=0.8")
+	base, _ := tag.Base()
+	if base.String() != "fr" {
+		t.Errorf("Expected custom matcher to select 'fr', got %v", base)
+	}
+}
+
+func TestConfigureI18n_DefaultMatcher(t *testing.T) {
+	resetAppConfig()
+
+	Configure(&Config{
+		Assets: &Assets{
+			FS: testI18nFS2,
+			I18nMessages: &I18nMessages{
+				Dir:                "testdata/locales",
+				SupportedLanguages: []string{"en", "fr"},
+			},
+		},
+	})
+
+	tag := defaultApp.parseAcceptLanguage("de-DE,de;q=0.9,en;q=0.8")
+	base, _ := tag.Base()
+	if base.String() != "en" {
+		t.Errorf("Expected default matcher to select 'en', got %v", base)
+	}
+}
+
+func TestConfigureI18n_Fallback(t *testing.T) {
+	resetAppConfig()
+
+	Configure(&Config{
+		Assets: &Assets{
+			FS: testI18nFS2,
+			I18nMessages: &I18nMessages{
+				Dir:      "testdata/locales",
+				Fallback: []string{"fr"},
+			},
+		},
+	})
+
+	i18nConfig, _ := defaultApp.i18nCatalogs.Configuration()
+	if len(i18nConfig.Fallback) != 1 || i18nConfig.Fallback[0] != language.French {
+		t.Errorf("Expected fallback [fr], got %v", i18nConfig.Fallback)
+	}
+
+	// The English messages have no "welcome" entry, so the French one is used
+	if got := GetI18nPrinter(language.English).Sprintf("welcome"); got != "Bienvenue" {
+		t.Errorf("Expected fallback translation 'Bienvenue', got %q", got)
+	}
+}
+
 // =============================================================================
 // GetSupportedLanguages Tests
 // =============================================================================
 
 func TestGetSupportedLanguages_FromConfig(t *testing.T) {
 	// Set global assetsFS for the test
-	assetsFS = testI18nFS2
-	defer func() { assetsFS = nil }()
+	defaultApp.assetsFS = testI18nFS2
+	defer func() { defaultApp.assetsFS = nil }()
 
 	cfg := &Config{
 		Assets: &Assets{
@@ -692,7 +966,7 @@ func TestGetSupportedLanguages_FromConfig(t *testing.T) {
 		},
 	}
 
-	langs := getSupportedLanguages(cfg, "testdata/locales")
+	langs := defaultApp.getSupportedLanguages(cfg, "testdata/locales")
 
 	if len(langs) != 3 {
 		t.Fatalf("Expected 3 languages, got %d", len(langs))
@@ -709,11 +983,11 @@ func TestGetSupportedLanguages_FromConfig(t *testing.T) {
 
 func TestGetSupportedLanguages_AutoDetectFromFiles(t *testing.T) {
 	// Set global assetsFS for the test
-	assetsFS = testI18nFS2
-	defer func() { assetsFS = nil }()
+	defaultApp.assetsFS = testI18nFS2
+	defer func() { defaultApp.assetsFS = nil }()
 
 	// Pass nil config to trigger auto-detection
-	langs := getSupportedLanguages(nil, "testdata/locales")
+	langs := defaultApp.getSupportedLanguages(nil, "testdata/locales")
 
 	// Should detect en, es, fr, de from testdata/locales directory
 	if len(langs) < 1 {
@@ -737,8 +1011,8 @@ func TestGetSupportedLanguages_AutoDetectFromFiles(t *testing.T) {
 
 func TestGetSupportedLanguages_EmptyConfig(t *testing.T) {
 	// Set global assetsFS for the test
-	assetsFS = testI18nFS2
-	defer func() { assetsFS = nil }()
+	defaultApp.assetsFS = testI18nFS2
+	defer func() { defaultApp.assetsFS = nil }()
 
 	cfg := &Config{
 		Assets: &Assets{
@@ -751,7 +1025,7 @@ func TestGetSupportedLanguages_EmptyConfig(t *testing.T) {
 	}
 
 	// Should auto-detect when list is empty
-	langs := getSupportedLanguages(cfg, "testdata/locales")
+	langs := defaultApp.getSupportedLanguages(cfg, "testdata/locales")
 
 	if len(langs) < 1 {
 		t.Fatal("Expected auto-detection when SupportedLanguages is empty")
@@ -760,8 +1034,8 @@ func TestGetSupportedLanguages_EmptyConfig(t *testing.T) {
 
 func TestGetSupportedLanguages_InvalidDirectory(t *testing.T) {
 	// Set global assetsFS for the test
-	assetsFS = testI18nFS2
-	defer func() { assetsFS = nil }()
+	defaultApp.assetsFS = testI18nFS2
+	defer func() { defaultApp.assetsFS = nil }()
 
 	cfg := &Config{
 		Assets: &Assets{
@@ -773,7 +1047,7 @@ func TestGetSupportedLanguages_InvalidDirectory(t *testing.T) {
 		},
 	}
 
-	langs := getSupportedLanguages(cfg, "nonexistent")
+	langs := defaultApp.getSupportedLanguages(cfg, "nonexistent")
 
 	// Should return default language (English)
 	if len(langs) != 1 {
@@ -788,8 +1062,8 @@ func TestGetSupportedLanguages_InvalidDirectory(t *testing.T) {
 
 func TestGetSupportedLanguages_NoValidFiles(t *testing.T) {
 	// Set global assetsFS to a filesystem with no valid message files
-	assetsFS = testTemplatesFS2
-	defer func() { assetsFS = nil }()
+	defaultApp.assetsFS = testTemplatesFS2
+	defer func() { defaultApp.assetsFS = nil }()
 
 	// Create a test filesystem with no valid message files
 	cfg := &Config{
@@ -802,7 +1076,7 @@ func TestGetSupportedLanguages_NoValidFiles(t *testing.T) {
 		},
 	}
 
-	langs := getSupportedLanguages(cfg, "testdata/templates")
+	langs := defaultApp.getSupportedLanguages(cfg, "testdata/templates")
 
 	// Should return default language when no valid files found
 	if len(langs) != 1 {
@@ -832,15 +1106,15 @@ func TestUse_WithAppMiddleware(t *testing.T) {
 
 	Use(mw)
 
-	if len(appMiddlewares) != 1 {
-		t.Errorf("Expected 1 middleware, got %d", len(appMiddlewares))
+	if len(defaultApp.middlewares) != 1 {
+		t.Errorf("Expected 1 middleware, got %d", len(defaultApp.middlewares))
 	}
 
 	// Test that middleware is functional
 	handler := HandlerFunc(func(w ResponseWriter, _ *Request) {
 		w.WriteHeader(http.StatusOK)
 	})
-	wrapped := appMiddlewares[0](handler)
+	wrapped := defaultApp.middlewares[0](handler)
 
 	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
 	rec := httptest.NewRecorder()
@@ -870,8 +1144,8 @@ func TestUse_WithStandardMiddleware(t *testing.T) {
 
 	Use(mw)
 
-	if len(appMiddlewares) != 1 {
-		t.Errorf("Expected 1 middleware, got %d", len(appMiddlewares))
+	if len(defaultApp.middlewares) != 1 {
+		t.Errorf("Expected 1 middleware, got %d", len(defaultApp.middlewares))
 	}
 
 	// Test that adapted middleware works
@@ -881,7 +1155,7 @@ func TestUse_WithStandardMiddleware(t *testing.T) {
 		}
 		w.WriteHeader(http.StatusOK)
 	})
-	wrapped := appMiddlewares[0](handler)
+	wrapped := defaultApp.middlewares[0](handler)
 
 	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
 	rec := httptest.NewRecorder()
@@ -904,8 +1178,8 @@ func TestUse_WithNilMiddleware(t *testing.T) {
 
 	Use[AppMiddleware](nil)
 
-	if len(appMiddlewares) != 0 {
-		t.Errorf("Expected 0 middlewares after adding nil, got %d", len(appMiddlewares))
+	if len(defaultApp.middlewares) != 0 {
+		t.Errorf("Expected 0 middlewares after adding nil, got %d", len(defaultApp.middlewares))
 	}
 }
 
@@ -931,8 +1205,8 @@ func TestUse_MultipleMiddlewares(t *testing.T) {
 	Use(mw1)
 	Use(mw2)
 
-	if len(appMiddlewares) != 2 {
-		t.Errorf("Expected 2 middlewares, got %d", len(appMiddlewares))
+	if len(defaultApp.middlewares) != 2 {
+		t.Errorf("Expected 2 middlewares, got %d", len(defaultApp.middlewares))
 	}
 
 	handler := HandlerFunc(func(_ ResponseWriter, _ *Request) {
@@ -940,8 +1214,8 @@ func TestUse_MultipleMiddlewares(t *testing.T) {
 	})
 
 	var wrapped Handler = handler
-	for i := len(appMiddlewares) - 1; i >= 0; i-- {
-		wrapped = appMiddlewares[i](wrapped)
+	for i := len(defaultApp.middlewares) - 1; i >= 0; i-- {
+		wrapped = defaultApp.middlewares[i](wrapped)
 	}
 
 	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
@@ -1797,6 +2071,73 @@ func TestBindJSON_MalformedJSON(t *testing.T) {
 	}
 }
 
+func TestBindJSON_DecodeError(t *testing.T) {
+	resetAppConfig()
+
+	tests := []struct {
+		body    string
+		field   string
+		message string
+	}{
+		{`{"name": "Jo", "age": "ten"}`, "age", "age must be a number"},
+		{`{"name": 42}`, "name", "name must be a string"},
+		{`{"age": 1.5}`, "age", "age is not a valid number for this field"},
+		{`{"nickname": "jo"}`, "nickname", "unknown field nickname"},
+		{`["jo"]`, "body", "body must be an object"},
+		{`{"name": "Jo"`, "", "request body is not valid JSON"},
+		{`{invalid json}`, "", "request body is not valid JSON"},
+	}
+
+	for _, tt := range tests {
+		r := NewTestRequest(http.MethodPost, "/test", strings.NewReader(tt.body))
+
+		_, _, err := BindJSON[testUser](r, false)
+
+		var decodeErr *DecodeError
+		if !errors.As(err, &decodeErr) {
+			t.Errorf("Body %s: expected *DecodeError, got %T: %v", tt.body, err, err)
+			continue
+		}
+		if decodeErr.Field != tt.field || decodeErr.Error() != tt.message {
+			t.Errorf("Body %s: expected %q (field %q), got %q (field %q)",
+				tt.body, tt.message, tt.field, decodeErr.Error(), decodeErr.Field)
+		}
+	}
+}
+
+func TestBindJSON_DecodeError_Localized(t *testing.T) {
+	resetAppConfig()
+	Configure(&Config{
+		Assets: &Assets{
+			FS: testI18nFS2,
+			I18nMessages: &I18nMessages{
+				Dir:                "testdata/locales",
+				SupportedLanguages: []string{"fr"},
+			},
+		},
+	})
+
+	r := NewTestRequest(http.MethodPost, "/test", strings.NewReader(`{"age": "dix"}`))
+
+	_, _, err := BindJSON[testUser](r, false)
+
+	if err == nil || err.Error() != "age doit être un nombre" {
+		t.Errorf("Expected localized decode error, got %v", err)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) {
+		t.Error("Expected the underlying encoding/json error to be preserved")
+	}
+
+	w, rec := NewTestResponseWriter()
+	w.BindError(err)
+
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "age doit être un nombre") {
+		t.Errorf("Expected 400 with localized message, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
 func TestBindJSON_EmptyBody(t *testing.T) {
 	resetAppConfig()
 	Configure(&Config{
@@ -2224,6 +2565,72 @@ func TestBindForm_ValidationErrors_ReturnsValidationErrorsStruct(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// ValidateOnly Tests
+// =============================================================================
+
+func TestValidateOnly(t *testing.T) {
+	resetAppConfig()
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		invalid     []string
+	}{
+		{"valid JSON", "application/json", `{"name":"Jo","email":"jo@example.com","age":30}`, nil},
+		{"invalid JSON", "application/json; charset=utf-8", `{"name":"J","email":"jo"}`, []string{"name", "email"}},
+		{"no content type", "", `{"name":"Jo","email":"jo@example.com"}`, nil},
+		{"invalid XML", "application/xml", `<testUser><name>Jo</name></testUser>`, []string{"email"}},
+		{"invalid form", "application/x-www-form-urlencoded", "name=Jo&age=200", []string{"email", "age"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewTestRequest(http.MethodPost, "/users/check", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+
+			valErrors, err := ValidateOnly[testUser](r)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			var fields []string
+			for _, e := range valErrors.Errors {
+				fields = append(fields, strings.ToLower(e.Field))
+			}
+			slices.Sort(fields)
+			fields = slices.Compact(fields)
+			slices.Sort(tt.invalid)
+
+			if !slices.Equal(fields, tt.invalid) {
+				t.Errorf("Expected errors on %v, got %v", tt.invalid, valErrors.Errors)
+			}
+		})
+	}
+}
+
+func TestValidateOnly_Errors(t *testing.T) {
+	resetAppConfig()
+
+	r := NewTestRequest(http.MethodPost, "/users/check", strings.NewReader("name: Jo"))
+	r.Header.Set("Content-Type", "text/x-yaml")
+
+	if _, err := ValidateOnly[testUser](r); !errors.Is(err, ErrUnsupportedMediaType) {
+		t.Errorf("Expected ErrUnsupportedMediaType, got %v", err)
+	}
+
+	r = NewTestRequest(http.MethodPost, "/users/check", strings.NewReader(`{"name":`))
+	r.Header.Set("Content-Type", "application/json")
+
+	var decodeErr *DecodeError
+	if _, err := ValidateOnly[testUser](r); !errors.As(err, &decodeErr) {
+		t.Errorf("Expected *DecodeError, got %v", err)
+	}
+}
+
 // =============================================================================
 // Security Scheme Tests
 // =============================================================================
@@ -2811,6 +3218,118 @@ func TestPatchJSON_MultipleOperations(t *testing.T) {
 	})
 }
 
+func TestPatchJSON_FailedOperationLeavesTargetUnchanged(t *testing.T) {
+	tests := []struct {
+		name     string
+		patch    string
+		index    int
+		testFail bool
+	}{
+		{"test value", `[{"op":"replace","path":"/name","value":"Jane"},{"op":"test","path":"/age","value":99}]`, 1, true},
+		{"test missing path", `[{"op":"replace","path":"/name","value":"Jane"},{"op":"test","path":"/nickname","value":"J"}]`, 1, true},
+		{"remove missing path", `[{"op":"replace","path":"/name","value":"Jane"},{"op":"remove","path":"/nickname"}]`, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupTestConfig(t)
+
+			target := testUser{Name: "John", Email: "john@example.com", Age: 25}
+
+			req := httptest.NewRequest(http.MethodPatch, "/test", strings.NewReader(tt.patch))
+			req.Header.Set("Content-Type", "application/json-patch+json")
+
+			_, err := PatchJSON(&Request{Request: req}, &target, false)
+
+			var patchErr *JSONPatchError
+			if !errors.As(err, &patchErr) || patchErr.Index != tt.index {
+				t.Fatalf("Expected *JSONPatchError for operation %d, got %v", tt.index, err)
+			}
+			if errors.Is(err, ErrPatchTestFailed) != tt.testFail {
+				t.Errorf("Unexpected ErrPatchTestFailed match for %v", err)
+			}
+			if target != (testUser{Name: "John", Email: "john@example.com", Age: 25}) {
+				t.Errorf("Expected target to be unchanged, got %+v", target)
+			}
+		})
+	}
+}
+
+func TestPatchJSON_TestOperationPasses(t *testing.T) {
+	target := testUser{Name: "John", Email: "john@example.com", Age: 25}
+
+	patch := `[{"op":"test","path":"/age","value":25},{"op":"replace","path":"/age","value":26}]`
+	testPatchJSONSuccess(t, &target, patch, false, func(target *testUser) {
+		if target.Age != 26 {
+			t.Errorf("Expected Age 26, got %d", target.Age)
+		}
+	})
+}
+
+func TestPatchJSON_RemoveClearsField(t *testing.T) {
+	type profile struct {
+		Name string            `json:"name"`
+		Tags map[string]string `json:"tags,omitempty"`
+	}
+
+	setupTestConfig(t)
+
+	target := profile{Name: "John", Tags: map[string]string{"a": "1", "b": "2"}}
+
+	req := httptest.NewRequest(http.MethodPatch, "/test", strings.NewReader(`[{"op":"remove","path":"/tags/a"}]`))
+	req.Header.Set("Content-Type", "application/json-patch+json")
+
+	if _, err := PatchJSON(&Request{Request: req}, &target, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := target.Tags["a"]; ok || len(target.Tags) != 1 {
+		t.Errorf("Expected the removed key to be gone, got %v", target.Tags)
+	}
+}
+
+func TestPatchJSON_KeepsFieldsNotInJSON(t *testing.T) {
+	type audit struct {
+		UpdatedBy string `json:"updatedBy"`
+		revision  int
+	}
+	type account struct {
+		Name         string `json:"name"`
+		Nickname     string `json:"nickname,omitempty"`
+		PasswordHash string `json:"-"`
+		Audit        audit  `json:"audit"`
+		loaded       bool
+	}
+
+	setupTestConfig(t)
+
+	target := account{
+		Name:         "John",
+		Nickname:     "Johnny",
+		PasswordHash: "hash",
+		Audit:        audit{UpdatedBy: "admin", revision: 3},
+		loaded:       true,
+	}
+
+	patch := `[{"op":"replace","path":"/name","value":"Jane"},{"op":"remove","path":"/nickname"},` +
+		`{"op":"replace","path":"/audit/updatedBy","value":"jane"}]`
+	req := httptest.NewRequest(http.MethodPatch, "/test", strings.NewReader(patch))
+	req.Header.Set("Content-Type", "application/json-patch+json")
+
+	if _, err := PatchJSON(&Request{Request: req}, &target, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := account{
+		Name:         "Jane",
+		PasswordHash: "hash",
+		Audit:        audit{UpdatedBy: "jane", revision: 3},
+		loaded:       true,
+	}
+	if target != expected {
+		t.Errorf("Expected %+v, got %+v", expected, target)
+	}
+}
+
 // =============================================================================
 // GetI18nPrinter Tests
 // =============================================================================
@@ -3375,6 +3894,54 @@ func TestBindQuery_Success(t *testing.T) {
 	}
 }
 
+func TestBindQuery_Duration(t *testing.T) {
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+	Configure(&Config{
+		Assets: &Assets{
+			FS: testI18nFS2,
+			I18nMessages: &I18nMessages{
+				Dir:                "testdata/locales",
+				SupportedLanguages: []string{"fr"},
+			},
+		},
+	})
+
+	type timeoutParams struct {
+		Timeout time.Duration `form:"timeout"`
+	}
+
+	tests := []struct {
+		query    string
+		expected time.Duration
+		errMsg   string
+	}{
+		{"timeout=5m", 5 * time.Minute, ""},
+		{"timeout=30s", 30 * time.Second, ""},
+		{"timeout=forever", 0, "doit être une durée valide, comme 30s ou 5m"},
+	}
+
+	for _, tt := range tests {
+		r := NewTestRequest(http.MethodGet, "/jobs?"+tt.query, nil)
+
+		result, valErrs, err := BindQuery[timeoutParams](r)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.query, err)
+		}
+
+		if result.Timeout != tt.expected {
+			t.Errorf("%s: expected timeout %v, got %v", tt.query, tt.expected, result.Timeout)
+		}
+
+		switch {
+		case tt.errMsg == "" && valErrs.Any():
+			t.Errorf("%s: unexpected validation errors: %+v", tt.query, valErrs)
+		case tt.errMsg != "" && (len(valErrs.Errors) != 1 || valErrs.Errors[0].Error != tt.errMsg):
+			t.Errorf("%s: expected the localized error %q, got %+v", tt.query, tt.errMsg, valErrs)
+		}
+	}
+}
+
 func TestBindQuery_ValidationError_EnumViolation(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/search?page=1&pageSize=20&sort=invalid&tags=go&search=test", nil)
 	r := &Request{Request: req}
@@ -3462,6 +4029,43 @@ func TestBindQuery_ValidationError_SliceConstraints(t *testing.T) {
 	}
 }
 
+func TestBindQuery_TriStateBool(t *testing.T) {
+	type filterParams struct {
+		Active *bool `form:"active"`
+	}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", "<nil>"},
+		{"?active=null", "<nil>"},
+		{"?active=true", "true"},
+		{"?active=yes", "true"},
+		{"?active=false", "false"},
+		{"?active=0", "false"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			r := &Request{Request: httptest.NewRequest(http.MethodGet, "/users"+tt.query, nil)}
+
+			result, valErrs, err := BindQuery[filterParams](r)
+			if err != nil || len(valErrs.Errors) > 0 {
+				t.Fatalf("Unexpected errors: %v, %v", err, valErrs)
+			}
+
+			got := "<nil>"
+			if result.Active != nil {
+				got = fmt.Sprint(*result.Active)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
 func TestBindQuery_SliceTooManyItems(t *testing.T) {
 	req := httptest.NewRequest(
 		http.MethodGet,
@@ -4027,3 +4631,335 @@ func TestBindHeader_IntSlice(t *testing.T) {
 		t.Errorf("Expected IDs [1, 2, 3], got %v", result.IDs)
 	}
 }
+
+func TestHandlerFunc_ServeHTTP_PreservesRequestContext(t *testing.T) {
+	resetAppConfig()
+	Configure(nil)
+
+	type ctxKey struct{}
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "token"))
+	cancel()
+
+	var gotValue any
+	var gotErr error
+	handler := HandlerFunc(func(_ ResponseWriter, r *Request) {
+		gotValue = r.Context().Value(ctxKey{})
+		gotErr = r.Context().Err()
+	})
+
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/", http.NoBody)
+	handler.ServeHTTP(ResponseWriter{ResponseWriter: httptest.NewRecorder()}, &Request{req})
+
+	if gotValue != "token" {
+		t.Errorf("Expected context value 'token', got %v", gotValue)
+	}
+
+	if !errors.Is(gotErr, context.Canceled) {
+		t.Errorf("Expected canceled context, got %v", gotErr)
+	}
+}
+
+// slowSSEWriter simulates a slow client: Flush blocks until release is closed.
+type slowSSEWriter struct {
+	http.ResponseWriter
+
+	release chan struct{}
+	ids     []string
+	mu      sync.Mutex
+}
+
+func (s *slowSSEWriter) Write(b []byte) (int, error) {
+	if id, ok := strings.CutPrefix(string(b), "id: "); ok {
+		s.mu.Lock()
+		s.ids = append(s.ids, strings.TrimSpace(id))
+		s.mu.Unlock()
+	}
+	return len(b), nil
+}
+
+func (s *slowSSEWriter) Flush() error {
+	<-s.release
+	return nil
+}
+
+func (s *slowSSEWriter) getIDs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.ids)
+}
+
+// slowSSETestHelper runs a buffered SSE handler against a client that is stalled for 30ms.
+// It returns the written event IDs, the errors passed to errorFunc and whether the handler
+// returned before the request context was canceled.
+func slowSSETestHelper(t *testing.T, policy SSEBackpressurePolicy) ([]string, []error, bool) {
+	t.Helper()
+
+	var messageCount atomic.Int32
+	payloadFunc := func() SSEPayload {
+		return SSEPayload{ID: fmt.Sprintf("msg-%d", messageCount.Add(1))}
+	}
+
+	var errsMu sync.Mutex
+	var errs []error
+	errorFunc := func(err error) {
+		errsMu.Lock()
+		errs = append(errs, err)
+		errsMu.Unlock()
+	}
+
+	handler := SSE(payloadFunc, nil, errorFunc, time.Millisecond, nil).WithBuffer(2, policy)
+
+	rec := httptest.NewRecorder()
+	writer := &slowSSEWriter{ResponseWriter: rec, release: make(chan struct{})}
+	handler.writerFactory = func(_ http.ResponseWriter) sseWriter {
+		return writer
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "/sse", http.NoBody).WithContext(ctx)
+
+	done := make(chan struct{})
+	go func() {
+		handler.ServeHTTP(ResponseWriter{ResponseWriter: rec}, &Request{Request: req})
+		close(done)
+	}()
+
+	time.Sleep(30 * time.Millisecond)
+	close(writer.release)
+	time.Sleep(20 * time.Millisecond)
+
+	returnedEarly := false
+	select {
+	case <-done:
+		returnedEarly = true
+	default:
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("SSE handler did not return after the client disconnected")
+	}
+
+	errsMu.Lock()
+	defer errsMu.Unlock()
+	return writer.getIDs(), slices.Clone(errs), returnedEarly
+}
+
+func TestSSE_WithBuffer_PanicsOnNegativeSize(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for negative buffer size")
+		}
+	}()
+
+	SSE(func() SSEPayload { return SSEPayload{} }, nil, nil, time.Second, nil).WithBuffer(-1, SSEDropOldest)
+}
+
+func TestSSE_WithBuffer_DropNewest(t *testing.T) {
+	ids, errs, returnedEarly := slowSSETestHelper(t, SSEDropNewest)
+
+	if returnedEarly {
+		t.Error("Expected connection to stay open with DropNewest policy")
+	}
+
+	if len(errs) == 0 || !errors.Is(errs[0], ErrSlowConsumer) {
+		t.Errorf("Expected ErrSlowConsumer, got %v", errs)
+	}
+
+	if len(ids) < 3 || ids[0] != "msg-1" || ids[1] != "msg-2" || ids[2] != "msg-3" {
+		t.Errorf("Expected buffered events msg-1..msg-3 to be kept, got %v", ids)
+	}
+}
+
+func TestSSE_WithBuffer_DropOldest(t *testing.T) {
+	ids, errs, returnedEarly := slowSSETestHelper(t, SSEDropOldest)
+
+	if returnedEarly {
+		t.Error("Expected connection to stay open with DropOldest policy")
+	}
+
+	if len(errs) == 0 || !errors.Is(errs[0], ErrSlowConsumer) {
+		t.Errorf("Expected ErrSlowConsumer, got %v", errs)
+	}
+
+	if len(ids) < 2 || ids[0] != "msg-1" || ids[1] == "msg-2" {
+		t.Errorf("Expected oldest buffered events to be dropped, got %v", ids)
+	}
+}
+
+func TestSSE_WithBuffer_Disconnect(t *testing.T) {
+	_, errs, returnedEarly := slowSSETestHelper(t, SSEDisconnect)
+
+	if !returnedEarly {
+		t.Error("Expected slow client to be disconnected")
+	}
+
+	if len(errs) != 1 || !errors.Is(errs[0], ErrSlowConsumer) {
+		t.Errorf("Expected a single ErrSlowConsumer, got %v", errs)
+	}
+}
+
+func TestSSE_WithBuffer_FastClient(t *testing.T) {
+	var messageCount atomic.Int32
+	payloadFunc := func() SSEPayload {
+		return SSEPayload{ID: fmt.Sprintf("msg-%d", messageCount.Add(1))}
+	}
+
+	errorCalled := atomic.Bool{}
+	handler := SSE(payloadFunc, nil, func(error) { errorCalled.Store(true) }, 5*time.Millisecond, nil).
+		WithBuffer(4, SSEDisconnect)
+
+	rec := httptest.NewRecorder()
+	mockWriter := &mockSSEWriter{ResponseWriter: rec}
+	handler.writerFactory = func(_ http.ResponseWriter) sseWriter {
+		return mockWriter
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "/sse", http.NoBody).WithContext(ctx)
+
+	go handler.ServeHTTP(ResponseWriter{ResponseWriter: rec}, &Request{Request: req})
+
+	time.Sleep(30 * time.Millisecond)
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+
+	if errorCalled.Load() {
+		t.Error("Expected no error for a client keeping up with events")
+	}
+
+	if calls := mockWriter.getCalls(); !slices.Contains(calls, "id: msg-1\n") {
+		t.Errorf("Expected 'id: msg-1' to be written, got calls: %v", calls)
+	}
+}
+
+// runContextSSE serves an SSE handler created with SSEWithContext until it returns or 60ms elapse.
+// It returns the mock writer, the errors passed to errorFunc and whether the handler returned early.
+func runContextSSE(t *testing.T, payloadFunc SSEContextPayloadFunc, bufferSize int) (*mockSSEWriter, []error, bool) {
+	t.Helper()
+
+	var errsMu sync.Mutex
+	var errs []error
+	errorFunc := func(err error) {
+		errsMu.Lock()
+		errs = append(errs, err)
+		errsMu.Unlock()
+	}
+
+	handler := SSEWithContext(payloadFunc, nil, errorFunc, 5*time.Millisecond, nil).
+		WithBuffer(bufferSize, SSEDropOldest)
+
+	rec := httptest.NewRecorder()
+	mockWriter := &mockSSEWriter{ResponseWriter: rec}
+	handler.writerFactory = func(_ http.ResponseWriter) sseWriter {
+		return mockWriter
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "/sse", http.NoBody).WithContext(ctx)
+
+	done := make(chan struct{})
+	go func() {
+		handler.ServeHTTP(ResponseWriter{ResponseWriter: rec}, &Request{Request: req})
+		close(done)
+	}()
+
+	returnedEarly := false
+	select {
+	case <-done:
+		returnedEarly = ctx.Err() == nil
+	case <-time.After(time.Second):
+		t.Fatal("SSE handler did not return after the client disconnected")
+	}
+
+	errsMu.Lock()
+	defer errsMu.Unlock()
+	return mockWriter, slices.Clone(errs), returnedEarly
+}
+
+func TestSSEWithContext_PanicsOnNilPayloadFunc(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for nil payload function")
+		}
+	}()
+
+	SSEWithContext(nil, nil, nil, time.Second, nil)
+}
+
+func TestSSEWithContext_ReceivesRequestContext(t *testing.T) {
+	for _, bufferSize := range []int{0, 4} {
+		t.Run(fmt.Sprintf("buffer %d", bufferSize), func(t *testing.T) {
+			var messageCount atomic.Int32
+			payloadFunc := func(ctx context.Context) (SSEPayload, error) {
+				if _, ok := ctx.Deadline(); !ok {
+					return SSEPayload{}, errors.New("expected request context deadline")
+				}
+				return SSEPayload{ID: fmt.Sprintf("msg-%d", messageCount.Add(1))}, nil
+			}
+
+			mockWriter, errs, returnedEarly := runContextSSE(t, payloadFunc, bufferSize)
+
+			if returnedEarly || len(errs) > 0 {
+				t.Fatalf("Expected stream to run until disconnect without errors, got %v", errs)
+			}
+			if calls := mockWriter.getCalls(); !slices.Contains(calls, "id: msg-1\n") {
+				t.Errorf("Expected 'id: msg-1' to be written, got calls: %v", calls)
+			}
+		})
+	}
+}
+
+func TestSSEWithContext_SkipEvent(t *testing.T) {
+	for _, bufferSize := range []int{0, 4} {
+		t.Run(fmt.Sprintf("buffer %d", bufferSize), func(t *testing.T) {
+			var messageCount atomic.Int32
+			payloadFunc := func(_ context.Context) (SSEPayload, error) {
+				n := messageCount.Add(1)
+				if n == 1 {
+					return SSEPayload{}, fmt.Errorf("backend unavailable: %w", ErrSkipEvent)
+				}
+				return SSEPayload{ID: fmt.Sprintf("msg-%d", n)}, nil
+			}
+
+			mockWriter, errs, returnedEarly := runContextSSE(t, payloadFunc, bufferSize)
+
+			if returnedEarly {
+				t.Error("Expected skipped event not to close the connection")
+			}
+			if len(errs) != 1 || !errors.Is(errs[0], ErrSkipEvent) {
+				t.Errorf("Expected a single ErrSkipEvent, got %v", errs)
+			}
+			calls := mockWriter.getCalls()
+			if slices.Contains(calls, "id: msg-1\n") || !slices.Contains(calls, "id: msg-2\n") {
+				t.Errorf("Expected first event to be skipped and the second written, got calls: %v", calls)
+			}
+		})
+	}
+}
+
+func TestSSEWithContext_ErrorClosesConnection(t *testing.T) {
+	for _, bufferSize := range []int{0, 4} {
+		t.Run(fmt.Sprintf("buffer %d", bufferSize), func(t *testing.T) {
+			backendErr := errors.New("backend failed")
+			payloadFunc := func(_ context.Context) (SSEPayload, error) {
+				return SSEPayload{}, backendErr
+			}
+
+			_, errs, returnedEarly := runContextSSE(t, payloadFunc, bufferSize)
+
+			if !returnedEarly {
+				t.Error("Expected error to close the connection")
+			}
+			if len(errs) != 1 || !errors.Is(errs[0], backendErr) {
+				t.Errorf("Expected the payload error to be reported once, got %v", errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package webfram
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+)
+
+const (
+	// immutableCacheControl is used for fingerprinted static files, whose URL changes with their content.
+	immutableCacheControl = "public, max-age=31536000, immutable"
+	// fingerprintLength is the number of hex characters of the content hash in fingerprinted file names.
+	fingerprintLength = 16
+)
+
+func (a *App) configureStaticAssets(cfg *Config) {
+	dir := defaultStaticDir
+	a.staticURLPath = defaultStaticURLPath
+
+	if cfg != nil && cfg.Assets != nil && cfg.Assets.Static != nil {
+		dir = getValueOrDefault(cfg.Assets.Static.Dir, dir)
+		a.staticURLPath = getValueOrDefault(cfg.Assets.Static.URLPath, a.staticURLPath)
+	}
+	if !strings.HasSuffix(a.staticURLPath, "/") {
+		a.staticURLPath += "/"
+	}
+
+	stat, err := fs.Stat(a.assetsFS, dir)
+	if err != nil || !stat.IsDir() {
+		return
+	}
+	a.staticFS, _ = fs.Sub(a.assetsFS, dir)
+}
+
+// StaticAssets registers a handler serving the static files configured with Assets.Static under its URL path.
+// Files requested by the fingerprinted path returned by the asset template function are cached by browsers
+// for a year, since the path changes whenever the content does; other files are served with the default
+// static content caching. A path with an outdated fingerprint is served with the current content, without caching.
+// Pre-compressed siblings of the files ("name.br" or "name.gz") are served to clients accepting their encoding.
+// Returns a HandlerConfig that can be used to further configure the handler.
+func (m *ServeMux) StaticAssets() *HandlerConfig {
+	a := m.getApp()
+
+	return m.HandleFunc("GET "+a.staticURLPath+"{path...}", func(w ResponseWriter, r *Request) {
+		name := r.PathValue("path")
+		cacheControl := staticContentCacheControl
+
+		if original, fingerprint, ok := splitFingerprint(name); ok {
+			if current, err := a.assetFingerprint(original); err == nil {
+				name = original
+				cacheControl = "no-cache"
+				if current == fingerprint {
+					cacheControl = immutableCacheControl
+				}
+			}
+		}
+
+		if a.staticFS == nil {
+			http.NotFound(w.ResponseWriter, r.Request)
+			return
+		}
+		if stat, err := fs.Stat(a.staticFS, name); err != nil || stat.IsDir() {
+			http.NotFound(w.ResponseWriter, r.Request)
+			return
+		}
+
+		w.Header().Set("Cache-Control", cacheControl)
+		if servePrecompressedFS(w.ResponseWriter, r.Request, a.staticFS, name) {
+			return
+		}
+		http.ServeFileFS(w.ResponseWriter, r.Request, a.staticFS, name)
+	})
+}
+
+// assetPath returns the URL path of the static file name with a fingerprint of its content inserted
+// before the extension, e.g. "/static/js/main.3b9f1c0a2d4e5f67.js". It is the asset template function.
+func (a *App) assetPath(name string) (string, error) {
+	name = strings.TrimPrefix(name, "/")
+
+	fingerprint, err := a.assetFingerprint(name)
+	if err != nil {
+		return "", err
+	}
+
+	ext := path.Ext(name)
+	return a.staticURLPath + strings.TrimSuffix(name, ext) + "." + fingerprint + ext, nil
+}
+
+// assetFingerprint returns the content hash of the static file name. Hashes are computed once per file,
+// as the assets file system is expected not to change while serving.
+func (a *App) assetFingerprint(name string) (string, error) {
+	if fingerprint, ok := a.assetFingerprints.Load(name); ok {
+		return fingerprint.(string), nil //nolint:errcheck,forcetypeassert // only strings are stored
+	}
+
+	if a.staticFS == nil {
+		return "", errors.New("static assets are not configured")
+	}
+
+	f, err := a.staticFS.Open(name)
+	if err != nil {
+		return "", fmt.Errorf("asset %q: %w", name, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("asset %q: %w", name, err)
+	}
+
+	fingerprint := hex.EncodeToString(h.Sum(nil))[:fingerprintLength]
+	a.assetFingerprints.Store(name, fingerprint)
+
+	return fingerprint, nil
+}
+
+// splitFingerprint returns the original name and the fingerprint of a fingerprinted static file name.
+func splitFingerprint(name string) (string, string, bool) {
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+
+	// Files without extension have the fingerprint as extension.
+	if fingerprint := strings.TrimPrefix(ext, "."); isFingerprint(fingerprint) {
+		return base, fingerprint, true
+	}
+
+	i := strings.LastIndexByte(base, '.')
+	if i < 0 || !isFingerprint(base[i+1:]) {
+		return "", "", false
+	}
+
+	return base[:i] + ext, base[i+1:], true
+}
+
+func isFingerprint(s string) bool {
+	if len(s) != fingerprintLength {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
@@ -0,0 +1,119 @@
+package webfram
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func setupStaticAssets(t *testing.T) *ServeMux {
+	t.Helper()
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+
+	Configure(&Config{
+		Assets: &Assets{
+			FS: fstest.MapFS{
+				"static/js/main.js":        {Data: []byte(`console.log("main");`)},
+				"static/js/main.js.gz":     {Data: []byte("gzipped")},
+				"static/LICENSE":           {Data: []byte("MIT")},
+				"templates/layout.go.html": {Data: []byte(`{{template "content" .}}`)},
+				"templates/page.go.html":   {Data: []byte(`{{define "content"}}<script src="{{asset "js/main.js"}}"></script>{{end}}`)},
+			},
+			Templates: &Templates{Dir: "templates"},
+			Static:    &StaticAssets{Dir: "static", URLPath: "/assets"},
+		},
+	})
+
+	mux := NewServeMux()
+	mux.StaticAssets()
+	registerHandlers(mux)
+
+	return mux
+}
+
+func TestAssetPath(t *testing.T) {
+	setupStaticAssets(t)
+
+	assetPath, err := defaultApp.assetPath("js/main.js")
+	if err != nil {
+		t.Fatalf("assetPath failed: %v", err)
+	}
+	if !strings.HasPrefix(assetPath, "/assets/js/main.") || !strings.HasSuffix(assetPath, ".js") ||
+		len(assetPath) != len("/assets/js/main..js")+fingerprintLength {
+		t.Errorf("Expected fingerprinted path, got %q", assetPath)
+	}
+
+	if _, err = defaultApp.assetPath("js/missing.js"); err == nil {
+		t.Error("Expected error for missing asset")
+	}
+
+	w, rec := NewTestResponseWriter()
+	if err = w.HTML(context.Background(), "page", nil); err != nil {
+		t.Fatalf("HTML failed: %v", err)
+	}
+	if expected := `<script src="` + assetPath + `"></script>`; rec.Body.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, rec.Body.String())
+	}
+}
+
+func TestServeMux_StaticAssets(t *testing.T) {
+	mux := setupStaticAssets(t)
+
+	mainJS, _ := defaultApp.assetPath("js/main.js")
+	license, _ := defaultApp.assetPath("LICENSE")
+
+	tests := []struct {
+		name         string
+		path         string
+		status       int
+		cacheControl string
+		body         string
+	}{
+		{"fingerprinted", mainJS, http.StatusOK, immutableCacheControl, `console.log("main");`},
+		{"fingerprinted without extension", license, http.StatusOK, immutableCacheControl, "MIT"},
+		{"plain", "/assets/js/main.js", http.StatusOK, staticContentCacheControl, `console.log("main");`},
+		{"outdated fingerprint", "/assets/js/main.0123456789abcdef.js", http.StatusOK, "no-cache", `console.log("main");`},
+		{"missing", "/assets/js/missing.js", http.StatusNotFound, "", ""},
+		{"directory", "/assets/js", http.StatusNotFound, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+
+			if rec.Code != tt.status {
+				t.Fatalf("Expected status %d, got %d", tt.status, rec.Code)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			if cc := rec.Header().Get("Cache-Control"); cc != tt.cacheControl {
+				t.Errorf("Expected Cache-Control %q, got %q", tt.cacheControl, cc)
+			}
+			if rec.Body.String() != tt.body {
+				t.Errorf("Expected body %q, got %q", tt.body, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestStaticAssets_Precompressed(t *testing.T) {
+	mux := setupStaticAssets(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/assets/js/main.js", http.NoBody)
+	req.Header.Set("Accept-Encoding", "gzip, br")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Body.String() != "gzipped" || rec.Header().Get("Content-Encoding") != "gzip" {
+		t.Errorf("Expected the gzip variant, got %q with encoding %q", rec.Body.String(), rec.Header().Get("Content-Encoding"))
+	}
+	if rec.Header().Get("Cache-Control") != staticContentCacheControl {
+		t.Errorf("Expected static caching, got %q", rec.Header().Get("Cache-Control"))
+	}
+}
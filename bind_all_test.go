@@ -0,0 +1,104 @@
+package webfram
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type (
+	bindAllPath struct {
+		ID int `form:"id" validate:"required,min=1"`
+	}
+
+	bindAllQuery struct {
+		Page int `form:"page" validate:"min=1"`
+	}
+
+	bindAllBody struct {
+		Name string `json:"name" xml:"name" form:"name" validate:"required"`
+	}
+)
+
+func newBindAllRequest(target, contentType, body string) *Request {
+	req := httptest.NewRequest(http.MethodPut, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	req.SetPathValue("id", "42")
+	return &Request{Request: req}
+}
+
+func TestBindAll_JSONBody(t *testing.T) {
+	r := newBindAllRequest("/items/42?page=2", "application/json", `{"name":"widget"}`)
+
+	path, query, body, valErrs, err := BindAll[bindAllPath, bindAllQuery, bindAllBody](r, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if valErrs.Any() {
+		t.Fatalf("Unexpected validation errors: %+v", valErrs)
+	}
+	if path.ID != 42 || query.Page != 2 || body.Name != "widget" {
+		t.Errorf("Unexpected binding: %+v %+v %+v", path, query, body)
+	}
+}
+
+func TestBindAll_ChoosesBinderByContentType(t *testing.T) {
+	tests := []struct {
+		contentType string
+		body        string
+	}{
+		{"application/xml", `<bindAllBody><name>widget</name></bindAllBody>`},
+		{"application/x-www-form-urlencoded", "name=widget"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			r := newBindAllRequest("/items/42?page=1", tt.contentType, tt.body)
+
+			_, _, body, valErrs, err := BindAll[bindAllPath, bindAllQuery, bindAllBody](r, true)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if valErrs.Any() {
+				t.Fatalf("Unexpected validation errors: %+v", valErrs)
+			}
+			if body.Name != "widget" {
+				t.Errorf("Expected name 'widget', got %q", body.Name)
+			}
+		})
+	}
+}
+
+func TestBindAll_AggregatesValidationErrorsWithSourcePrefixes(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPut, "/items/0?page=0", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("id", "0")
+	r := &Request{Request: req}
+
+	_, _, _, valErrs, err := BindAll[bindAllPath, bindAllQuery, bindAllBody](r, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	sources := map[string]bool{}
+	for _, ve := range valErrs.Errors {
+		source, _, _ := strings.Cut(ve.Field, ".")
+		sources[source] = true
+	}
+	for _, source := range []string{"path", "query", "body"} {
+		if !sources[source] {
+			t.Errorf("Expected a validation error prefixed with %q, got %+v", source, valErrs.Errors)
+		}
+	}
+}
+
+func TestBindAll_UnsupportedMediaType(t *testing.T) {
+	r := newBindAllRequest("/items/42", "text/plain", "widget")
+
+	_, _, _, _, err := BindAll[bindAllPath, bindAllQuery, bindAllBody](r, true)
+	if !errors.Is(err, ErrUnsupportedMediaType) {
+		t.Errorf("Expected ErrUnsupportedMediaType, got %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package webfram
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// bodyError is an error of a body binder tagged with the sentinel error of its cause, such as ErrEmptyBody.
+// Its message is the one of the underlying error, which remains available through errors.Is and errors.As.
+type bodyError struct {
+	cause error
+	err   error
+}
+
+func (e *bodyError) Error() string {
+	return e.err.Error()
+}
+
+func (e *bodyError) Unwrap() []error {
+	return []error{e.cause, e.err}
+}
+
+// classifyBodyError tags an error returned while reading or decoding a request body with ErrBodyTooLarge,
+// ErrEmptyBody or ErrMalformedBody. Unsupported media types, disallowed methods and context errors are
+// returned unchanged, as are errors that are already tagged.
+func classifyBodyError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+
+	switch {
+	case err == nil,
+		errors.Is(err, ErrBodyTooLarge), errors.Is(err, ErrEmptyBody), errors.Is(err, ErrMalformedBody),
+		errors.Is(err, ErrUnsupportedMediaType), errors.Is(err, ErrMethodNotAllowed),
+		errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return err
+	case errors.As(err, &maxBytesErr), errors.Is(err, ErrUploadTooLarge):
+		return &bodyError{cause: ErrBodyTooLarge, err: err}
+	case errors.Is(err, io.EOF):
+		return &bodyError{cause: ErrEmptyBody, err: err}
+	default:
+		return &bodyError{cause: ErrMalformedBody, err: err}
+	}
+}
@@ -0,0 +1,140 @@
+package webfram
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestBinders_BodyErrorSentinels(t *testing.T) {
+	resetAppConfig()
+	Configure(nil)
+
+	type payload struct {
+		Name string `json:"name" xml:"name" form:"name"`
+		Age  int    `json:"age" xml:"age" form:"age"`
+	}
+
+	newRequest := func(method, contentType, body string, limit int64) *Request {
+		r := NewTestRequest(method, "/", strings.NewReader(body))
+		r.Header.Set("Content-Type", contentType)
+		if limit > 0 {
+			r.Body = http.MaxBytesReader(nil, r.Body, limit)
+		}
+		return r
+	}
+
+	bindJSON := func(body string, limit int64) error {
+		_, _, err := BindJSON[payload](newRequest(http.MethodPost, "application/json", body, limit), false)
+		return err
+	}
+	bindXML := func(body string) error {
+		_, _, err := BindXML[payload](newRequest(http.MethodPost, "application/xml", body, 0), false)
+		return err
+	}
+	bindForm := func(body string, limit int64) error {
+		_, _, err := BindForm[payload](newRequest(http.MethodPost, "application/x-www-form-urlencoded", body, limit))
+		return err
+	}
+	patchJSON := func(body string) error {
+		var p payload
+		_, err := PatchJSON(newRequest(http.MethodPatch, "application/json-patch+json", body, 0), &p, false)
+		return err
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		sentinel error
+	}{
+		{"empty JSON", bindJSON("", 0), ErrEmptyBody},
+		{"malformed JSON", bindJSON(`{"name":`, 0), ErrMalformedBody},
+		{"JSON type mismatch", bindJSON(`{"age":"x"}`, 0), ErrMalformedBody},
+		{"JSON unknown field", bindJSON(`{"nickname":"x"}`, 0), ErrMalformedBody},
+		{"JSON too large", bindJSON(`{"name":"`+strings.Repeat("x", 100)+`"}`, 16), ErrBodyTooLarge},
+		{"empty XML", bindXML(""), ErrEmptyBody},
+		{"malformed XML", bindXML("<payload><name>"), ErrMalformedBody},
+		{"form too large", bindForm("name="+strings.Repeat("x", 100), 16), ErrBodyTooLarge},
+		{"empty patch", patchJSON(""), ErrEmptyBody},
+		{"malformed patch", patchJSON(`[{"op":`), ErrMalformedBody},
+	}
+
+	sentinels := []error{ErrEmptyBody, ErrMalformedBody, ErrBodyTooLarge}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, sentinel := range sentinels {
+				if got, want := errors.Is(tt.err, sentinel), sentinel == tt.sentinel; got != want {
+					t.Errorf("errors.Is(%v, %v) = %v, want %v", tt.err, sentinel, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBindJSON_BodyErrorKeepsCause(t *testing.T) {
+	resetAppConfig()
+	Configure(nil)
+
+	type payload struct {
+		Age int `json:"age"`
+	}
+
+	r := NewTestRequest(http.MethodPost, "/", strings.NewReader(`{"age":"x"}`))
+	_, _, err := BindJSON[payload](r, false)
+
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("Expected a *DecodeError, got %T: %v", err, err)
+	}
+	if err.Error() != decodeErr.Message {
+		t.Errorf("Expected the message of the decode error %q, got %q", decodeErr.Message, err.Error())
+	}
+
+	r = NewTestRequest(http.MethodPost, "/", strings.NewReader(""))
+	_, _, err = BindJSON[payload](r, false)
+	if !errors.Is(err, io.EOF) {
+		t.Errorf("Expected the error of an empty body to wrap io.EOF, got %v", err)
+	}
+}
+
+func TestBinders_BodyErrorUnchanged(t *testing.T) {
+	resetAppConfig()
+	Configure(nil)
+
+	type payload struct {
+		Name string `json:"name"`
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r := NewTestRequest(http.MethodPost, "/", strings.NewReader(`{"name":"x"}`))
+	r.Request = r.WithContext(ctx)
+	_, _, err := BindJSON[payload](r, false)
+	if !errors.Is(err, context.Canceled) || errors.Is(err, ErrMalformedBody) {
+		t.Errorf("Expected the context error only, got %v", err)
+	}
+
+	r = NewTestRequest(http.MethodPatch, "/", strings.NewReader(`[]`))
+	r.Header.Set("Content-Type", "application/json")
+	var p payload
+	_, err = PatchJSON(r, &p, false)
+	if !errors.Is(err, ErrUnsupportedMediaType) || errors.Is(err, ErrMalformedBody) {
+		t.Errorf("Expected ErrUnsupportedMediaType only, got %v", err)
+	}
+}
+
+func TestUploadError_BodyTooLarge(t *testing.T) {
+	err := uploadError(&http.MaxBytesError{Limit: 16})
+
+	if !errors.Is(err, ErrUploadTooLarge) || !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("Expected ErrUploadTooLarge and ErrBodyTooLarge, got %v", err)
+	}
+	if err.Error() != "upload too large: limit is 16 bytes" {
+		t.Errorf("Unexpected message %q", err.Error())
+	}
+}
@@ -0,0 +1,312 @@
+package webfram
+
+import (
+	"bytes"
+	"cmp"
+	"container/list"
+	"context"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultCacheMaxBody is the largest response body cached by default.
+	defaultCacheMaxBody = 1 << 20
+	// defaultCacheMaxEntries is the default capacity of the in-memory cache store.
+	defaultCacheMaxEntries = 1000
+)
+
+type (
+	// CacheOptions configures the Cache middleware.
+	CacheOptions struct {
+		// Store holds the cached responses. Defaults to an in-memory LRU store of 1000 responses
+		// created for the middleware.
+		Store CacheStore
+		// VaryHeaders are the request headers whose values are part of the cache key, such as
+		// "Accept-Language" when the response depends on them. The headers listed in the Vary header
+		// of a response are part of the key too, so a response is cached for each of their values.
+		// Requests with an Authorization or Cookie header are not cached unless it is one of VaryHeaders.
+		VaryHeaders []string
+		// MaxBodySize is the largest response body cached, in bytes. Defaults to 1 MiB.
+		MaxBodySize int
+	}
+
+	// CacheStore stores the responses cached by the Cache middleware. Implementations must be safe
+	// for concurrent use; a shared store, such as Redis, lets several instances share responses.
+	CacheStore interface {
+		// Get returns the response stored under key, and whether it was found and has not expired.
+		Get(ctx context.Context, key string) (*CachedResponse, bool, error)
+		// Set stores the response under key for ttl.
+		Set(ctx context.Context, key string, resp *CachedResponse, ttl time.Duration) error
+	}
+
+	// CachedResponse is a response stored by the Cache middleware. The responses of a URL with a Vary header
+	// are stored under keys including the values of the request headers they vary on, and the key of the URL
+	// holds a CachedResponse without StatusCode, whose Vary header lists these request headers.
+	CachedResponse struct {
+		StatusCode int
+		Header     http.Header
+		Body       []byte
+		// StoredAt is when the response was generated, used for the Age header.
+		StoredAt time.Time
+	}
+
+	// memoryCacheStore is a CacheStore keeping the most recently used responses in memory.
+	memoryCacheStore struct {
+		maxEntries int
+		now        func() time.Time
+		mu         sync.Mutex
+		entries    map[string]*list.Element
+		lru        *list.List // most recently used first
+	}
+
+	memoryCacheEntry struct {
+		key     string
+		resp    *CachedResponse
+		expires time.Time
+	}
+)
+
+// cacheableStatusCodes are the status codes cacheable by default (RFC 9110, section 15.1).
+//
+//nolint:gochecknoglobals // read-only lookup table
+var cacheableStatusCodes = []int{
+	http.StatusOK, http.StatusNonAuthoritativeInfo, http.StatusNoContent,
+	http.StatusMultipleChoices, http.StatusMovedPermanently, http.StatusPermanentRedirect,
+	http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusGone, http.StatusRequestURITooLong,
+	http.StatusNotImplemented,
+}
+
+// Cache creates middleware caching the responses of expensive GET and HEAD endpoints for ttl. Responses are keyed
+// by method, host, URL and the values of opts.VaryHeaders and of the request headers listed in their Vary header,
+// and served from the cache with an Age header until they
+// expire, without running the handler. Unlike Singleflight, which only shares a response between concurrent
+// requests, Cache serves it to later requests too.
+// Requests with Cache-Control: no-store bypass the cache, and requests with Cache-Control: no-cache run the handler
+// and refresh the cached response. Requests accepting text/event-stream, upgrade requests and requests with
+// credentials, an Authorization or Cookie header not listed in opts.VaryHeaders, are never cached.
+// Responses are not cached if their status code is not cacheable by default (e.g., 500), they were flushed or
+// streamed, set cookies, have Cache-Control: no-store or private, Vary: *, a body larger than opts.MaxBodySize,
+// or the handler panicked.
+func Cache(ttl time.Duration, opts CacheOptions) AppMiddleware {
+	store := opts.Store
+	if store == nil {
+		store = NewMemoryCacheStore(0)
+	}
+	maxBody := cmp.Or(opts.MaxBodySize, defaultCacheMaxBody)
+
+	return func(next Handler) Handler {
+		return HandlerFunc(func(w ResponseWriter, r *Request) {
+			if !isCacheableRequest(r, opts.VaryHeaders) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := r.Method + " " + requestKey(r, opts.VaryHeaders)
+			requestCacheControl := r.Header.Get("Cache-Control")
+
+			if !hasCacheDirective(requestCacheControl, "no-cache") {
+				if resp, found := lookupCachedResponse(r, store, key, opts.VaryHeaders); found &&
+					time.Since(resp.StoredAt) < ttl {
+					resp.writeTo(&w)
+					return
+				}
+			}
+
+			rec := &teeRecorder{ResponseWriter: w.ResponseWriter, maxBody: maxBody}
+			storedAt := time.Now()
+
+			next.ServeHTTP(ResponseWriter{ResponseWriter: rec, statusCode: w.statusCode, request: w.request}, r)
+
+			if resp := cacheableResponse(rec, storedAt); resp != nil {
+				storeCachedResponse(r, store, key, resp, ttl, opts.VaryHeaders)
+			}
+		})
+	}
+}
+
+// NewMemoryCacheStore returns a CacheStore keeping up to maxEntries responses in memory, evicting the least
+// recently used ones; maxEntries defaults to 1000. Responses are not shared between instances of the application.
+func NewMemoryCacheStore(maxEntries int) CacheStore {
+	if maxEntries <= 0 {
+		maxEntries = defaultCacheMaxEntries
+	}
+
+	return &memoryCacheStore{
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+func isCacheableRequest(r *Request, varyHeaders []string) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	if r.Header.Get("Upgrade") != "" || strings.Contains(r.Header.Get("Accept"), mediaTypeTextEventStream) {
+		return false
+	}
+	// Responses to requests with credentials are only cached per credentials.
+	for _, name := range []string{"Authorization", "Cookie"} {
+		if r.Header.Get(name) != "" && !containsHeaderName(varyHeaders, name) {
+			return false
+		}
+	}
+
+	return !hasCacheDirective(r.Header.Get("Cache-Control"), "no-store")
+}
+
+func containsHeaderName(names []string, name string) bool {
+	return slices.ContainsFunc(names, func(n string) bool { return strings.EqualFold(n, name) })
+}
+
+// lookupCachedResponse returns the response cached for r under key, or under the key of its variant if the
+// responses of the URL have a Vary header.
+func lookupCachedResponse(r *Request, store CacheStore, key string, varyHeaders []string) (*CachedResponse, bool) {
+	resp, found, err := store.Get(r.Context(), key)
+	if err == nil && found && resp.StatusCode == 0 {
+		key = cacheVariantKey(r, varyHeaders, resp.Header.Values("Vary"))
+		resp, found, err = store.Get(r.Context(), key)
+	}
+	if err != nil {
+		slog.WarnContext(r.Context(), "cache lookup failed", "key", key, "error", err)
+		return nil, false
+	}
+
+	return resp, found
+}
+
+// storeCachedResponse stores the response to r under key, or under the key of its variant if the response has
+// a Vary header, in which case the request headers it varies on are stored under key.
+func storeCachedResponse(
+	r *Request, store CacheStore, key string, resp *CachedResponse, ttl time.Duration, varyHeaders []string,
+) {
+	ctx := context.WithoutCancel(r.Context())
+
+	if vary := responseVaryHeaders(resp.Header, varyHeaders); len(vary) > 0 {
+		variants := &CachedResponse{Header: http.Header{"Vary": vary}, StoredAt: resp.StoredAt}
+		if err := store.Set(ctx, key, variants, ttl); err != nil {
+			slog.WarnContext(r.Context(), "cache store failed", "key", key, "error", err)
+			return
+		}
+		key = cacheVariantKey(r, varyHeaders, vary)
+	}
+
+	if err := store.Set(ctx, key, resp, ttl); err != nil {
+		slog.WarnContext(r.Context(), "cache store failed", "key", key, "error", err)
+	}
+}
+
+// responseVaryHeaders returns the sorted request header names listed in the Vary header of a response that are
+// not already part of the cache key.
+func responseVaryHeaders(header http.Header, varyHeaders []string) []string {
+	var names []string
+
+	for _, value := range header.Values("Vary") {
+		for name := range strings.SplitSeq(value, ",") {
+			name = http.CanonicalHeaderKey(strings.TrimSpace(name))
+			if name != "" && !containsHeaderName(varyHeaders, name) && !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	slices.Sort(names)
+	return names
+}
+
+// cacheVariantKey returns the cache key of the variant of a response for r, which varies on the request
+// headers of both varyHeaders and responseVary.
+func cacheVariantKey(r *Request, varyHeaders, responseVary []string) string {
+	return r.Method + " " + requestKey(r, slices.Concat(varyHeaders, responseVary))
+}
+
+// hasCacheDirective reports whether a Cache-Control header value contains the directive, ignoring its argument.
+func hasCacheDirective(cacheControl, directive string) bool {
+	for part := range strings.SplitSeq(cacheControl, ",") {
+		name, _, _ := strings.Cut(part, "=")
+		if strings.EqualFold(strings.TrimSpace(name), directive) {
+			return true
+		}
+	}
+	return false
+}
+
+// cacheableResponse returns the recorded response, or nil if it must not be cached.
+func cacheableResponse(rec *teeRecorder, storedAt time.Time) *CachedResponse {
+	if rec.streamed {
+		return nil
+	}
+
+	status, header := rec.recorded()
+
+	contentType := header.Get("Content-Type")
+	cacheControl := header.Get("Cache-Control")
+	if !slices.Contains(cacheableStatusCodes, status) ||
+		len(header.Values("Set-Cookie")) > 0 ||
+		hasCacheDirective(cacheControl, "no-store") || hasCacheDirective(cacheControl, "private") ||
+		slices.Contains(header.Values("Vary"), "*") ||
+		strings.HasPrefix(contentType, mediaTypeTextEventStream) ||
+		strings.HasPrefix(contentType, mediaTypeJSONSeq) {
+		return nil
+	}
+
+	return &CachedResponse{StatusCode: status, Header: header, Body: bytes.Clone(rec.body.Bytes()), StoredAt: storedAt}
+}
+
+func (resp *CachedResponse) writeTo(w *ResponseWriter) {
+	maps.Copy(w.Header(), resp.Header.Clone())
+	w.Header().Set("Age", strconv.Itoa(int(time.Since(resp.StoredAt).Seconds())))
+	w.WriteHeader(resp.StatusCode)
+	_, _ = w.Write(resp.Body)
+}
+
+func (s *memoryCacheStore) Get(_ context.Context, key string) (*CachedResponse, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	entry := elem.Value.(*memoryCacheEntry) //nolint:errcheck,forcetypeassert // only entries are stored
+	if !s.now().Before(entry.expires) {
+		s.lru.Remove(elem)
+		delete(s.entries, key)
+		return nil, false, nil
+	}
+
+	s.lru.MoveToFront(elem)
+	return entry.resp, true, nil
+}
+
+func (s *memoryCacheStore) Set(_ context.Context, key string, resp *CachedResponse, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry := &memoryCacheEntry{key: key, resp: resp, expires: s.now().Add(ttl)}
+
+	if elem, ok := s.entries[key]; ok {
+		elem.Value = entry
+		s.lru.MoveToFront(elem)
+		return nil
+	}
+
+	s.entries[key] = s.lru.PushFront(entry)
+
+	if s.lru.Len() > s.maxEntries {
+		oldest := s.lru.Back()
+		s.lru.Remove(oldest)
+		delete(s.entries, oldest.Value.(*memoryCacheEntry).key) //nolint:errcheck,forcetypeassert // only entries are stored
+	}
+
+	return nil
+}
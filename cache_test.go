@@ -0,0 +1,174 @@
+package webfram
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newCountingHandler(calls *atomic.Int32, configure func(w ResponseWriter)) Handler {
+	return HandlerFunc(func(w ResponseWriter, _ *Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "text/plain")
+		if configure != nil {
+			configure(w)
+		}
+		_, _ = w.Write([]byte("report"))
+	})
+}
+
+func TestCache_ServesCachedResponse(t *testing.T) {
+	var calls atomic.Int32
+	handler := Cache(time.Minute, CacheOptions{})(newCountingHandler(&calls, nil))
+
+	first := serveTestRequest(handler, NewTestRequest(http.MethodGet, "/reports?id=1", nil))
+	second := serveTestRequest(handler, NewTestRequest(http.MethodGet, "/reports?id=1", nil))
+	other := serveTestRequest(handler, NewTestRequest(http.MethodGet, "/reports?id=2", nil))
+
+	if calls.Load() != 2 {
+		t.Errorf("Expected handler to run for each URL once, ran %d times", calls.Load())
+	}
+	if second.Body.String() != "report" || second.Header().Get("Content-Type") != "text/plain" {
+		t.Errorf("Expected cached response, got %q %v", second.Body.String(), second.Header())
+	}
+	if second.Header().Get("Age") != "0" || first.Header().Get("Age") != "" || other.Header().Get("Age") != "" {
+		t.Errorf("Expected only the cached response to have an Age header")
+	}
+}
+
+func TestCache_Expires(t *testing.T) {
+	var calls atomic.Int32
+	handler := Cache(20*time.Millisecond, CacheOptions{})(newCountingHandler(&calls, nil))
+
+	serveTestRequest(handler, NewTestRequest(http.MethodGet, "/reports", nil))
+	time.Sleep(30 * time.Millisecond)
+	serveTestRequest(handler, NewTestRequest(http.MethodGet, "/reports", nil))
+
+	if calls.Load() != 2 {
+		t.Errorf("Expected the expired response to be regenerated, handler ran %d times", calls.Load())
+	}
+}
+
+func TestCache_RequestDirectives(t *testing.T) {
+	var calls atomic.Int32
+	handler := Cache(time.Minute, CacheOptions{VaryHeaders: []string{"Accept-Language"}})(newCountingHandler(&calls, nil))
+
+	newRequest := func(cacheControl, lang string) *Request {
+		r := NewTestRequest(http.MethodGet, "/reports", nil)
+		r.Header.Set("Cache-Control", cacheControl)
+		r.Header.Set("Accept-Language", lang)
+		return r
+	}
+
+	serveTestRequest(handler, newRequest("no-store", "en")) // not stored
+	serveTestRequest(handler, newRequest("", "en"))         // stored
+	serveTestRequest(handler, newRequest("no-cache", "en")) // refreshed
+	serveTestRequest(handler, newRequest("", "en"))         // cached
+	serveTestRequest(handler, newRequest("", "fr"))         // other variant
+
+	if calls.Load() != 4 {
+		t.Errorf("Expected the handler to run 4 times, ran %d times", calls.Load())
+	}
+}
+
+func TestCache_VariesOnResponseVaryHeader(t *testing.T) {
+	var calls atomic.Int32
+	handler := Cache(time.Minute, CacheOptions{})(HandlerFunc(func(w ResponseWriter, r *Request) {
+		calls.Add(1)
+		w.Header().Set("Vary", "Accept-Encoding, accept")
+		_, _ = w.Write([]byte(r.Header.Get("Accept") + " " + r.Header.Get("Accept-Encoding")))
+	}))
+
+	newRequest := func(accept, encoding string) *Request {
+		r := NewTestRequest(http.MethodGet, "/reports", nil)
+		r.Header.Set("Accept", accept)
+		r.Header.Set("Accept-Encoding", encoding)
+		return r
+	}
+
+	tests := []struct {
+		accept, encoding string
+		calls            int32
+	}{
+		{"text/csv", "gzip", 1},
+		{"application/json", "gzip", 2}, // other variant
+		{"text/csv", "gzip", 2},         // cached
+		{"application/json", "gzip", 2}, // cached
+		{"text/csv", "br", 3},           // other variant
+	}
+
+	for _, tt := range tests {
+		rec := serveTestRequest(handler, newRequest(tt.accept, tt.encoding))
+
+		if want := tt.accept + " " + tt.encoding; rec.Body.String() != want {
+			t.Errorf("Expected the %q variant, got %q", want, rec.Body.String())
+		}
+		if calls.Load() != tt.calls {
+			t.Errorf("Expected the handler to have run %d times for %q, ran %d times", tt.calls, tt.accept, calls.Load())
+		}
+	}
+}
+
+func TestCache_SkipsUncacheable(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(w ResponseWriter)
+		request   func() *Request
+	}{
+		{"server error", func(w ResponseWriter) { w.WriteHeader(http.StatusInternalServerError) }, nil},
+		{"no-store", func(w ResponseWriter) { w.Header().Set("Cache-Control", "private, max-age=60") }, nil},
+		{"cookie", func(w ResponseWriter) { w.Header().Set("Set-Cookie", "a=b") }, nil},
+		{"flushed", func(w ResponseWriter) { w.Flush() }, nil},
+		{"sse", func(w ResponseWriter) { w.Header().Set("Content-Type", "text/event-stream") }, nil},
+		{"post", nil, func() *Request { return NewTestRequest(http.MethodPost, "/reports", nil) }},
+		{"authorization", nil, func() *Request {
+			r := NewTestRequest(http.MethodGet, "/reports", nil)
+			r.Header.Set("Authorization", "Bearer token")
+			return r
+		}},
+		{"request cookie", nil, func() *Request {
+			r := NewTestRequest(http.MethodGet, "/reports", nil)
+			r.Header.Set("Cookie", "session=secret")
+			return r
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			handler := Cache(time.Minute, CacheOptions{})(newCountingHandler(&calls, tt.configure))
+
+			newRequest := tt.request
+			if newRequest == nil {
+				newRequest = func() *Request { return NewTestRequest(http.MethodGet, "/reports", nil) }
+			}
+
+			serveTestRequest(handler, newRequest())
+			serveTestRequest(handler, newRequest())
+
+			if calls.Load() != 2 {
+				t.Errorf("Expected the response not to be cached, handler ran %d times", calls.Load())
+			}
+		})
+	}
+}
+
+func TestMemoryCacheStore_EvictsLeastRecentlyUsed(t *testing.T) {
+	store := NewMemoryCacheStore(2)
+	resp := &CachedResponse{StatusCode: http.StatusOK}
+
+	_ = store.Set(t.Context(), "a", resp, time.Minute)
+	_ = store.Set(t.Context(), "b", resp, time.Minute)
+	_, _, _ = store.Get(t.Context(), "a")
+	_ = store.Set(t.Context(), "c", resp, time.Minute)
+
+	if _, found, _ := store.Get(t.Context(), "b"); found {
+		t.Error("Expected the least recently used entry to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, found, _ := store.Get(t.Context(), key); !found {
+			t.Errorf("Expected %q to be kept", key)
+		}
+	}
+}
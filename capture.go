@@ -0,0 +1,60 @@
+package webfram
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+)
+
+const capturedBodyKey contextKey = "capturedBody"
+
+// capturedBody restores the bytes consumed during capture ahead of the remaining body,
+// while still closing the original body.
+type capturedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// CaptureBody creates middleware that captures up to maxBytes of the request body
+// so it can be logged or inspected without consuming it.
+// The captured bytes are available via BodyFromContext, and r.Body is reset so that
+// downstream handlers and binders such as BindJSON still read the full body.
+// Bodies larger than maxBytes are captured partially but passed through intact.
+// Panics if maxBytes is not positive.
+func CaptureBody(maxBytes int64) AppMiddleware {
+	if maxBytes <= 0 {
+		panic("CaptureBody maxBytes must be greater than zero")
+	}
+
+	return func(next Handler) Handler {
+		return HandlerFunc(func(w ResponseWriter, r *Request) {
+			if r.Body == nil || r.Body == http.NoBody {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			captured, err := io.ReadAll(io.LimitReader(r.Body, maxBytes))
+			if err != nil {
+				w.Error(http.StatusBadRequest, err.Error())
+				return
+			}
+
+			r.Body = capturedBody{
+				Reader: io.MultiReader(bytes.NewReader(captured), r.Body),
+				Closer: r.Body,
+			}
+
+			ctx := context.WithValue(r.Context(), capturedBodyKey, captured)
+
+			next.ServeHTTP(w, &Request{r.WithContext(ctx)})
+		})
+	}
+}
+
+// BodyFromContext returns the request body bytes captured by CaptureBody.
+// Returns false if the body was not captured.
+func BodyFromContext(ctx context.Context) ([]byte, bool) {
+	body, ok := ctx.Value(capturedBodyKey).([]byte)
+	return body, ok
+}
@@ -0,0 +1,99 @@
+package webfram
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCaptureBody_BindJSONAfterCapture(t *testing.T) {
+	resetAppConfig()
+
+	type payload struct {
+		Name string `json:"name" validate:"required"`
+	}
+
+	body := `{"name":"John"}`
+	var captured []byte
+	var bound payload
+
+	handler := CaptureBody(1024)(HandlerFunc(func(w ResponseWriter, r *Request) {
+		captured, _ = BodyFromContext(r.Context())
+
+		val, valErrors, err := BindJSON[payload](r, true)
+		if err != nil {
+			t.Errorf("BindJSON returned error: %v", err)
+		}
+		if valErrors.Any() {
+			t.Errorf("Unexpected validation errors: %v", valErrors)
+		}
+		bound = val
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(ResponseWriter{ResponseWriter: rec}, &Request{req})
+
+	if string(captured) != body {
+		t.Errorf("Expected captured body %q, got %q", body, captured)
+	}
+	if bound.Name != "John" {
+		t.Errorf("Expected bound name 'John', got %q", bound.Name)
+	}
+}
+
+func TestCaptureBody_TruncatesCaptureOnly(t *testing.T) {
+	resetAppConfig()
+
+	body := "0123456789"
+	var captured, read []byte
+
+	handler := CaptureBody(4)(HandlerFunc(func(_ ResponseWriter, r *Request) {
+		captured, _ = BodyFromContext(r.Context())
+		read, _ = io.ReadAll(r.Body)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	handler.ServeHTTP(ResponseWriter{ResponseWriter: httptest.NewRecorder()}, &Request{req})
+
+	if !bytes.Equal(captured, []byte("0123")) {
+		t.Errorf("Expected captured '0123', got %q", captured)
+	}
+	if string(read) != body {
+		t.Errorf("Expected downstream body %q, got %q", body, read)
+	}
+}
+
+func TestCaptureBody_NoBody(t *testing.T) {
+	resetAppConfig()
+
+	called := false
+	handler := CaptureBody(16)(HandlerFunc(func(_ ResponseWriter, r *Request) {
+		called = true
+		if _, ok := BodyFromContext(r.Context()); ok {
+			t.Error("Expected no captured body for request without body")
+		}
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	handler.ServeHTTP(ResponseWriter{ResponseWriter: httptest.NewRecorder()}, &Request{req})
+
+	if !called {
+		t.Error("Expected next handler to be called")
+	}
+}
+
+func TestCaptureBody_InvalidMaxBytes(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for non-positive maxBytes")
+		}
+	}()
+
+	CaptureBody(0)
+}
@@ -0,0 +1,231 @@
+package webfram
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bondowe/webfram/internal/telemetry"
+)
+
+const (
+	defaultCBFailureRatio      = 0.5
+	defaultCBMinRequests       = 10
+	defaultCBWindow            = 10 * time.Second
+	defaultCBOpenTimeout       = 30 * time.Second
+	defaultCBHalfOpenMaxProbes = 1
+)
+
+const (
+	circuitClosed circuitState = iota
+	circuitHalfOpen
+	circuitOpen
+)
+
+type (
+	// CBOptions configures the CircuitBreaker middleware.
+	CBOptions struct {
+		// FailureRatio is the ratio of failed requests (0 to 1) within Window that opens the breaker.
+		// Defaults to 0.5.
+		FailureRatio float64
+		// MinRequests is the minimum number of requests within Window before FailureRatio is evaluated.
+		// Defaults to 10.
+		MinRequests int
+		// Window is the period over which failures are counted. Counts reset when it elapses.
+		// Defaults to 10 seconds.
+		Window time.Duration
+		// OpenTimeout is how long the breaker stays open before letting probe requests through.
+		// It is also advertised to clients in the Retry-After header. Defaults to 30 seconds.
+		OpenTimeout time.Duration
+		// HalfOpenMaxProbes is the number of concurrent probe requests allowed while half-open.
+		// Defaults to 1.
+		HalfOpenMaxProbes int
+		// IsFailure reports whether a response status code counts as a failure.
+		// Defaults to treating 5xx responses as failures.
+		IsFailure func(statusCode int) bool
+	}
+
+	circuitState int
+
+	// circuitBreaker tracks the state of a single route.
+	circuitBreaker struct {
+		mu          sync.Mutex
+		route       string
+		state       circuitState
+		windowStart time.Time
+		openedAt    time.Time
+		requests    int
+		failures    int
+		probes      int
+	}
+
+	// circuitBreakers holds the per-route breakers of a CircuitBreaker middleware.
+	circuitBreakers struct {
+		opts     CBOptions
+		mu       sync.Mutex
+		breakers map[string]*circuitBreaker
+		now      func() time.Time
+	}
+)
+
+// CircuitBreaker creates middleware that fails fast when a route keeps failing, for instance
+// because an upstream it depends on is down. Requests are tracked per route pattern; once the
+// failure ratio within the window reaches FailureRatio, the breaker opens and requests are
+// rejected with 503 Service Unavailable and a Retry-After header until OpenTimeout elapses.
+// The breaker then lets HalfOpenMaxProbes probe requests through: a successful probe closes it,
+// a failed one opens it again. The state of each breaker is exported as the
+// circuit_breaker_state telemetry gauge. Requests not matched by a ServeMux pattern are passed through.
+func CircuitBreaker(opts CBOptions) AppMiddleware {
+	return newCircuitBreakers(opts).middleware
+}
+
+func (cbs *circuitBreakers) middleware(next Handler) Handler {
+	return HandlerFunc(func(w ResponseWriter, r *Request) {
+		// Requests not matched by a mux pattern are passed through, so that clients cannot create
+		// breakers, and gauge labels, for arbitrary paths.
+		if r.Pattern == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cb := cbs.get(r.Pattern)
+
+		probe, retryAfter, ok := cbs.allow(cb)
+		if !ok {
+			w.RetryAfter(retryAfter)
+			w.Error(http.StatusServiceUnavailable, http.StatusText(http.StatusServiceUnavailable))
+			return
+		}
+
+		// A panicking handler is recorded as a failure.
+		failed := true
+		defer func() {
+			cbs.record(cb, probe, failed)
+		}()
+
+		next.ServeHTTP(w, r)
+
+		statusCode, written := w.StatusCode()
+		if !written {
+			statusCode = http.StatusOK
+		}
+		failed = cbs.opts.IsFailure(statusCode)
+	})
+}
+
+func newCircuitBreakers(opts CBOptions) *circuitBreakers {
+	if opts.FailureRatio <= 0 || opts.FailureRatio > 1 {
+		opts.FailureRatio = defaultCBFailureRatio
+	}
+	opts.MinRequests = getValueOrDefault(opts.MinRequests, defaultCBMinRequests)
+	opts.Window = getValueOrDefault(opts.Window, defaultCBWindow)
+	opts.OpenTimeout = getValueOrDefault(opts.OpenTimeout, defaultCBOpenTimeout)
+	opts.HalfOpenMaxProbes = getValueOrDefault(opts.HalfOpenMaxProbes, defaultCBHalfOpenMaxProbes)
+	if opts.IsFailure == nil {
+		opts.IsFailure = func(statusCode int) bool {
+			return statusCode >= http.StatusInternalServerError
+		}
+	}
+
+	return &circuitBreakers{
+		opts:     opts,
+		breakers: make(map[string]*circuitBreaker),
+		now:      time.Now,
+	}
+}
+
+func (cbs *circuitBreakers) get(route string) *circuitBreaker {
+	cbs.mu.Lock()
+	defer cbs.mu.Unlock()
+
+	cb, ok := cbs.breakers[route]
+	if !ok {
+		cb = &circuitBreaker{route: route, windowStart: cbs.now()}
+		cbs.breakers[route] = cb
+		telemetry.CircuitBreakerState.WithLabelValues(route).Set(float64(circuitClosed))
+	}
+
+	return cb
+}
+
+// allow reports whether the request is a half-open probe, the delay to advertise in Retry-After
+// when the request is rejected, and whether the request may proceed.
+func (cbs *circuitBreakers) allow(cb *circuitBreaker) (bool, time.Duration, bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	now := cbs.now()
+
+	switch cb.state {
+	case circuitOpen:
+		if remaining := cb.openedAt.Add(cbs.opts.OpenTimeout).Sub(now); remaining > 0 {
+			return false, remaining, false
+		}
+		cb.setState(circuitHalfOpen)
+		cb.probes = 0
+		fallthrough
+	case circuitHalfOpen:
+		if cb.probes >= cbs.opts.HalfOpenMaxProbes {
+			return false, cbs.opts.OpenTimeout, false
+		}
+		cb.probes++
+		return true, 0, true
+	default:
+		if now.Sub(cb.windowStart) >= cbs.opts.Window {
+			cb.resetWindow(now)
+		}
+		return false, 0, true
+	}
+}
+
+// record updates the breaker with the outcome of a request that was allowed through.
+func (cbs *circuitBreakers) record(cb *circuitBreaker, probe, failed bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	now := cbs.now()
+
+	if probe {
+		if cb.state != circuitHalfOpen {
+			return
+		}
+		if failed {
+			cb.open(now)
+		} else {
+			cb.setState(circuitClosed)
+			cb.resetWindow(now)
+		}
+		return
+	}
+
+	if cb.state != circuitClosed {
+		return
+	}
+
+	cb.requests++
+	if failed {
+		cb.failures++
+	}
+
+	if cb.requests >= cbs.opts.MinRequests &&
+		float64(cb.failures)/float64(cb.requests) >= cbs.opts.FailureRatio {
+		cb.open(now)
+	}
+}
+
+func (cb *circuitBreaker) open(now time.Time) {
+	cb.setState(circuitOpen)
+	cb.openedAt = now
+	cb.probes = 0
+}
+
+func (cb *circuitBreaker) resetWindow(now time.Time) {
+	cb.windowStart = now
+	cb.requests = 0
+	cb.failures = 0
+}
+
+func (cb *circuitBreaker) setState(state circuitState) {
+	cb.state = state
+	telemetry.CircuitBreakerState.WithLabelValues(cb.route).Set(float64(state))
+}
@@ -0,0 +1,253 @@
+package webfram
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bondowe/webfram/internal/telemetry"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func newTestCircuitBreakers(opts CBOptions) (*circuitBreakers, *time.Time) {
+	cbs := newCircuitBreakers(opts)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cbs.now = func() time.Time { return now }
+	return cbs, &now
+}
+
+func serveCircuitBreaker(handler Handler, pattern string) *httptest.ResponseRecorder {
+	req := NewTestRequest(http.MethodGet, "/upstream", nil)
+	req.Pattern = pattern
+	w, rec := NewTestResponseWriter()
+	handler.ServeHTTP(w, req)
+	return rec
+}
+
+func TestCircuitBreaker_OpensAfterFailureRatio(t *testing.T) {
+	cbs, _ := newTestCircuitBreakers(CBOptions{MinRequests: 4, FailureRatio: 0.5, OpenTimeout: 10 * time.Second})
+
+	status := http.StatusOK
+	calls := 0
+	handler := cbs.middleware(HandlerFunc(func(w ResponseWriter, _ *Request) {
+		calls++
+		w.WriteHeader(status)
+	}))
+
+	serveCircuitBreaker(handler, "GET /cb-open")
+	serveCircuitBreaker(handler, "GET /cb-open")
+	status = http.StatusBadGateway
+	serveCircuitBreaker(handler, "GET /cb-open")
+	serveCircuitBreaker(handler, "GET /cb-open")
+
+	rec := serveCircuitBreaker(handler, "GET /cb-open")
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", rec.Code)
+	}
+
+	if got := rec.Header().Get("Retry-After"); got != "10" {
+		t.Errorf("Expected Retry-After '10', got %q", got)
+	}
+
+	if calls != 4 {
+		t.Errorf("Expected handler to be called 4 times, got %d", calls)
+	}
+
+	if state := testutil.ToFloat64(telemetry.CircuitBreakerState.WithLabelValues("GET /cb-open")); state != 2 {
+		t.Errorf("Expected breaker state gauge 2 (open), got %v", state)
+	}
+}
+
+func TestCircuitBreaker_StaysClosedBelowMinRequests(t *testing.T) {
+	cbs, _ := newTestCircuitBreakers(CBOptions{MinRequests: 5})
+
+	handler := cbs.middleware(HandlerFunc(func(w ResponseWriter, _ *Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	for range 4 {
+		serveCircuitBreaker(handler, "GET /cb-min")
+	}
+
+	if rec := serveCircuitBreaker(handler, "GET /cb-min"); rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected request to reach handler, got %d", rec.Code)
+	}
+}
+
+func TestCircuitBreaker_WindowResetsCounts(t *testing.T) {
+	cbs, now := newTestCircuitBreakers(CBOptions{MinRequests: 2, Window: time.Second})
+
+	handler := cbs.middleware(HandlerFunc(func(w ResponseWriter, _ *Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	serveCircuitBreaker(handler, "GET /cb-window")
+	*now = now.Add(2 * time.Second)
+
+	for range 2 {
+		if rec := serveCircuitBreaker(handler, "GET /cb-window"); rec.Code != http.StatusInternalServerError {
+			t.Errorf("Expected request to reach handler, got %d", rec.Code)
+		}
+	}
+
+	if rec := serveCircuitBreaker(handler, "GET /cb-window"); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected breaker to open, got %d", rec.Code)
+	}
+}
+
+func TestCircuitBreaker_HalfOpenProbe(t *testing.T) {
+	cbs, now := newTestCircuitBreakers(CBOptions{MinRequests: 1, OpenTimeout: 5 * time.Second})
+
+	status := http.StatusServiceUnavailable
+	handler := cbs.middleware(HandlerFunc(func(w ResponseWriter, _ *Request) {
+		w.WriteHeader(status)
+	}))
+
+	serveCircuitBreaker(handler, "GET /cb-probe")
+
+	*now = now.Add(3 * time.Second)
+	rec := serveCircuitBreaker(handler, "GET /cb-probe")
+	if got := rec.Header().Get("Retry-After"); got != "2" {
+		t.Errorf("Expected Retry-After '2', got %q", got)
+	}
+
+	// A failed probe reopens the breaker.
+	*now = now.Add(2 * time.Second)
+	if rec := serveCircuitBreaker(handler, "GET /cb-probe"); rec.Header().Get("Retry-After") != "" {
+		t.Error("Expected probe request to reach handler")
+	}
+	if rec := serveCircuitBreaker(handler, "GET /cb-probe"); rec.Header().Get("Retry-After") != "5" {
+		t.Errorf("Expected breaker to reopen, got Retry-After %q", rec.Header().Get("Retry-After"))
+	}
+
+	// A successful probe closes it.
+	*now = now.Add(5 * time.Second)
+	status = http.StatusOK
+	serveCircuitBreaker(handler, "GET /cb-probe")
+
+	if state := testutil.ToFloat64(telemetry.CircuitBreakerState.WithLabelValues("GET /cb-probe")); state != 0 {
+		t.Errorf("Expected breaker state gauge 0 (closed), got %v", state)
+	}
+
+	if rec := serveCircuitBreaker(handler, "GET /cb-probe"); rec.Code != http.StatusOK {
+		t.Errorf("Expected status 200 after breaker closed, got %d", rec.Code)
+	}
+}
+
+func TestCircuitBreaker_HalfOpenLimitsProbes(t *testing.T) {
+	cbs, now := newTestCircuitBreakers(CBOptions{MinRequests: 1, OpenTimeout: time.Second})
+
+	handler := cbs.middleware(HandlerFunc(func(w ResponseWriter, _ *Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	serveCircuitBreaker(handler, "GET /cb-limit")
+	*now = now.Add(time.Second)
+
+	cb := cbs.get("GET /cb-limit")
+	if probe, _, ok := cbs.allow(cb); !probe || !ok {
+		t.Fatal("Expected first half-open request to be allowed as a probe")
+	}
+	if _, _, ok := cbs.allow(cb); ok {
+		t.Error("Expected concurrent probe to be rejected")
+	}
+}
+
+func TestCircuitBreaker_PerRoute(t *testing.T) {
+	cbs, _ := newTestCircuitBreakers(CBOptions{MinRequests: 1})
+
+	handler := cbs.middleware(HandlerFunc(func(w ResponseWriter, r *Request) {
+		if r.Pattern == "GET /cb-a" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+
+	serveCircuitBreaker(handler, "GET /cb-a")
+
+	if rec := serveCircuitBreaker(handler, "GET /cb-b"); rec.Code != http.StatusOK {
+		t.Errorf("Expected other route to be unaffected, got %d", rec.Code)
+	}
+}
+
+func TestCircuitBreaker_PassesThroughUnmatchedRequests(t *testing.T) {
+	cbs, _ := newTestCircuitBreakers(CBOptions{MinRequests: 1})
+
+	handler := cbs.middleware(HandlerFunc(func(w ResponseWriter, _ *Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	for range 3 {
+		if rec := serveCircuitBreaker(handler, ""); rec.Code != http.StatusInternalServerError {
+			t.Errorf("Expected unmatched requests to reach the handler, got %d", rec.Code)
+		}
+	}
+
+	if len(cbs.breakers) != 0 {
+		t.Errorf("Expected no breaker for unmatched requests, got %d", len(cbs.breakers))
+	}
+}
+
+func TestCircuitBreaker_CustomIsFailure(t *testing.T) {
+	cbs, _ := newTestCircuitBreakers(CBOptions{
+		MinRequests: 1,
+		IsFailure:   func(statusCode int) bool { return statusCode == http.StatusTooManyRequests },
+	})
+
+	status := http.StatusInternalServerError
+	handler := cbs.middleware(HandlerFunc(func(w ResponseWriter, _ *Request) {
+		w.WriteHeader(status)
+	}))
+
+	serveCircuitBreaker(handler, "GET /cb-custom")
+	if rec := serveCircuitBreaker(handler, "GET /cb-custom"); rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected 500 not to count as failure, got %d", rec.Code)
+	}
+
+	status = http.StatusTooManyRequests
+	serveCircuitBreaker(handler, "GET /cb-custom")
+	serveCircuitBreaker(handler, "GET /cb-custom")
+	if rec := serveCircuitBreaker(handler, "GET /cb-custom"); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected breaker to open, got %d", rec.Code)
+	}
+}
+
+func TestCircuitBreaker_PanicCountsAsFailure(t *testing.T) {
+	cbs, _ := newTestCircuitBreakers(CBOptions{MinRequests: 1})
+
+	handler := cbs.middleware(HandlerFunc(func(_ ResponseWriter, _ *Request) {
+		panic("upstream exploded")
+	}))
+
+	func() {
+		defer func() { _ = recover() }()
+		serveCircuitBreaker(handler, "GET /cb-panic")
+	}()
+
+	if rec := serveCircuitBreaker(handler, "GET /cb-panic"); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected breaker to open after panic, got %d", rec.Code)
+	}
+}
+
+func TestCircuitBreaker_Mux(t *testing.T) {
+	resetAppConfig()
+	Configure(nil)
+
+	mux := NewServeMux()
+	mux.Use(CircuitBreaker(CBOptions{MinRequests: 1}))
+	mux.HandleFunc("GET /cb-mux/{id}", func(w ResponseWriter, _ *Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	registerHandlers(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/cb-mux/1", nil))
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/cb-mux/2", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected breaker shared across route parameters, got %d", rec.Code)
+	}
+}
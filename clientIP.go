@@ -0,0 +1,107 @@
+package webfram
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+func (a *App) configureTrustedProxies(cfg *Config) {
+	if cfg == nil {
+		return
+	}
+
+	for _, proxy := range cfg.TrustedProxies {
+		if prefix, err := parseTrustedProxy(proxy); err == nil {
+			a.trustedProxies = append(a.trustedProxies, prefix)
+		}
+	}
+}
+
+// parseTrustedProxy parses an IP address or a CIDR range.
+func parseTrustedProxy(proxy string) (netip.Prefix, error) {
+	if strings.Contains(proxy, "/") {
+		prefix, err := netip.ParsePrefix(proxy)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid CIDR range %q: %w", proxy, err)
+		}
+		return prefix.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(proxy)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid IP address %q: %w", proxy, err)
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// ClientIP returns the IP address of the client that sent the request.
+// When the request comes from a proxy listed in Config.TrustedProxies, the X-Forwarded-For header is read
+// from right to left, skipping trusted proxies, and the first other address is returned; without
+// X-Forwarded-For, the X-Real-IP header is used. A malformed X-Forwarded-For entry stops the search at the
+// last valid address. Requests from other peers get the address of the peer (from RemoteAddr), so headers
+// spoofed by untrusted clients are ignored.
+func (r *Request) ClientIP() string {
+	remote, err := parseIP(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	a := appFromContext(r.Context())
+	if !a.isTrustedProxy(remote) {
+		return remote.String()
+	}
+
+	if forwardedFor := r.Header.Values("X-Forwarded-For"); len(forwardedFor) > 0 {
+		hops := strings.Split(strings.Join(forwardedFor, ","), ",")
+		client := remote
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop, err := parseIP(strings.TrimSpace(hops[i]))
+			if err != nil {
+				break
+			}
+			client = hop
+			if !a.isTrustedProxy(hop) {
+				break
+			}
+		}
+		return client.String()
+	}
+
+	if realIP, err := parseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); err == nil {
+		return realIP.String()
+	}
+
+	return remote.String()
+}
+
+// fromTrustedProxy reports whether the peer that sent the request is listed in Config.TrustedProxies,
+// so that the forwarding headers it set can be honored.
+func (r *Request) fromTrustedProxy() bool {
+	remote, err := parseIP(r.RemoteAddr)
+	return err == nil && appFromContext(r.Context()).isTrustedProxy(remote)
+}
+
+func (a *App) isTrustedProxy(addr netip.Addr) bool {
+	for _, prefix := range a.trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIP parses an IP address, with or without port, as found in RemoteAddr and forwarding headers.
+func parseIP(s string) (netip.Addr, error) {
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return addr.Unmap(), nil
+}
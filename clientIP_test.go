@@ -0,0 +1,71 @@
+package webfram
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestRequest_ClientIP(t *testing.T) {
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+	Configure(&Config{TrustedProxies: []string{"10.0.0.0/8", "192.0.2.1", "2001:db8::/32"}})
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string]string
+		expected   string
+	}{
+		{"direct", "203.0.113.7:51234", nil, "203.0.113.7"},
+		{"untrusted peer with spoofed forwarded for", "203.0.113.7:51234",
+			map[string]string{"X-Forwarded-For": "198.51.100.1"}, "203.0.113.7"},
+		{"untrusted peer with spoofed real ip", "203.0.113.7:51234",
+			map[string]string{"X-Real-IP": "198.51.100.1"}, "203.0.113.7"},
+		{"trusted proxy", "10.1.2.3:8080",
+			map[string]string{"X-Forwarded-For": "198.51.100.1"}, "198.51.100.1"},
+		{"trusted proxy chain", "10.1.2.3:8080",
+			map[string]string{"X-Forwarded-For": "198.51.100.1, 192.0.2.1, 10.0.0.5"}, "198.51.100.1"},
+		{"spoofed entry before real client", "10.1.2.3:8080",
+			map[string]string{"X-Forwarded-For": "1.2.3.4, 198.51.100.1, 10.0.0.5"}, "198.51.100.1"},
+		{"only trusted hops", "10.1.2.3:8080",
+			map[string]string{"X-Forwarded-For": "10.0.0.9, 10.0.0.5"}, "10.0.0.9"},
+		{"malformed entry", "10.1.2.3:8080",
+			map[string]string{"X-Forwarded-For": "unknown, 10.0.0.5"}, "10.0.0.5"},
+		{"real ip", "192.0.2.1:443", map[string]string{"X-Real-IP": "198.51.100.1"}, "198.51.100.1"},
+		{"forwarded for takes precedence over real ip", "192.0.2.1:443",
+			map[string]string{"X-Forwarded-For": "198.51.100.1", "X-Real-IP": "198.51.100.2"}, "198.51.100.1"},
+		{"malformed real ip", "192.0.2.1:443", map[string]string{"X-Real-IP": "unknown"}, "192.0.2.1"},
+		{"ipv6", "[2001:db8::1]:443",
+			map[string]string{"X-Forwarded-For": "2001:db9::7"}, "2001:db9::7"},
+		{"ipv4 mapped", "[::ffff:10.1.2.3]:443",
+			map[string]string{"X-Forwarded-For": "198.51.100.1"}, "198.51.100.1"},
+		{"unparsable remote address", "pipe", map[string]string{"X-Forwarded-For": "198.51.100.1"}, "pipe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewTestRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for name, value := range tt.headers {
+				r.Header.Set(name, value)
+			}
+
+			if ip := r.ClientIP(); ip != tt.expected {
+				t.Errorf("Expected client IP %q, got %q", tt.expected, ip)
+			}
+		})
+	}
+}
+
+func TestRequest_ClientIP_NoTrustedProxies(t *testing.T) {
+	a := New(nil)
+	r := NewTestRequest(http.MethodGet, "/", nil)
+	r.Request = r.WithContext(context.WithValue(r.Context(), appKey, a))
+	r.RemoteAddr = "10.1.2.3:8080"
+	r.Header.Set("X-Forwarded-For", "198.51.100.1")
+
+	if ip := r.ClientIP(); ip != "10.1.2.3" {
+		t.Errorf("Expected forwarding headers to be ignored without trusted proxies, got %q", ip)
+	}
+}
@@ -61,6 +61,10 @@ func main() {
 				Dir:                "assets/locales",
 				SupportedLanguages: []string{"en-GB", "fr-FR", "es-ES"},
 			},
+			Static: &app.StaticAssets{
+				Dir:     "assets/static",
+				URLPath: "/static/",
+			},
 		},
 		JSONPCallbackParamName: "callback", // Enable JSONP support
 		OpenAPI: &app.OpenAPI{
@@ -76,23 +80,18 @@ func main() {
 	mux := app.NewServeMux()
 
 	// Routes
-	mux.HandleFunc("GET /", func(w app.ResponseWriter, r *app.Request) {
+	mux.HandleFuncE("GET /", func(w app.ResponseWriter, r *app.Request) error {
 		user := User{Name: "John Doe", Email: "john@example.com"}
-		err := w.HTML(r.Context(), "home/index", &user)
-		if err != nil {
-			w.Error(http.StatusInternalServerError, err.Error())
-		}
+		return w.HTML(r.Context(), "home/index", &user)
 	})
 
 	// JSON endpoint with JSONP support
-	mux.HandleFunc("GET /users", func(w app.ResponseWriter, r *app.Request) {
+	mux.HandleFuncE("GET /users", func(w app.ResponseWriter, r *app.Request) error {
 		users := []User{
 			{ID: uuid.New(), Name: "John Doe", Email: "john@example.com"},
 			{ID: uuid.New(), Name: "Jane Smith", Email: "jane@example.com"},
 		}
-		if err := w.JSON(r.Context(), users); err != nil {
-			w.Error(http.StatusInternalServerError, err.Error())
-		}
+		return w.JSON(r.Context(), users)
 	}).OpenAPIOperation(app.OperationConfig{
 		OperationID: "listUsers",
 		Summary:     "List all users",
@@ -111,7 +110,7 @@ func main() {
 	})
 
 	// JSON Sequence endpoint
-	mux.HandleFunc("GET /users/json-seq", func(w app.ResponseWriter, r *app.Request) {
+	mux.HandleFuncE("GET /users/json-seq", func(w app.ResponseWriter, r *app.Request) error {
 		users := []User{
 			{ID: uuid.New(), Name: "John Doe", Email: "john@example.com"},
 			{ID: uuid.New(), Name: "Jane Smith", Email: "jane@example.com"},
@@ -120,9 +119,7 @@ func main() {
 			{ID: uuid.New(), Name: "Charlie Davis", Email: "charlie@example.com"},
 			{ID: uuid.New(), Name: "Diana Evans", Email: "diana@example.com"},
 		}
-		if err := w.JSONSeq(r.Context(), users); err != nil {
-			w.Error(http.StatusInternalServerError, err.Error())
-		}
+		return w.JSONSeq(r.Context(), users)
 	}).OpenAPIOperation(app.OperationConfig{
 		OperationID: "listUsersSeq",
 		Summary:     "List all users in JSON Sequence format",
@@ -196,11 +193,15 @@ func main() {
 
 	// Update user with JSON Patch
 	mux.HandleFunc("PATCH /users/{id}", func(w app.ResponseWriter, r *app.Request) {
-		id := r.PathValue("id")
+		id, err := r.PathUUID("id")
+		if err != nil {
+			w.Error(http.StatusBadRequest, err.Error())
+			return
+		}
 
 		// Fetch existing user
 		user := User{
-			ID:    uuid.MustParse(id),
+			ID:    id,
 			Name:  "John Doe",
 			Email: "john@example.com",
 			Role:  "user",
@@ -231,15 +232,13 @@ func main() {
 	})
 
 	// XML endpoint demonstrating XML schema generation with custom tags
-	mux.HandleFunc("GET /users/xml", func(w app.ResponseWriter, r *app.Request) {
+	mux.HandleFuncE("GET /users/xml", func(w app.ResponseWriter, _ *app.Request) error {
 		users := []User{
 			{ID: uuid.New(), Name: "John Doe", Email: "john@example.com", Role: "admin"},
 			{ID: uuid.New(), Name: "Jane Smith", Email: "jane@example.com", Role: "user"},
 		}
 		// Use XMLArray to wrap the slice with a root element for valid XML
-		if err := w.XMLArray(users, "users"); err != nil {
-			w.Error(http.StatusInternalServerError, err.Error())
-		}
+		return w.XMLArray(users, "users")
 	}).OpenAPIOperation(app.OperationConfig{
 		OperationID: "listUsersXML",
 		Summary:     "List all users in XML format",
@@ -295,12 +294,10 @@ func main() {
 	})
 
 	// i18n example
-	mux.HandleFunc("GET /greeting", func(w app.ResponseWriter, r *app.Request) {
+	mux.HandleFuncE("GET /greeting", func(w app.ResponseWriter, r *app.Request) error {
 		printer := app.GetI18nPrinter(language.Spanish)
 		msg := printer.Sprintf("Welcome to %s! Clap %d times.", "WebFram", defaultClapCount)
-		if err := w.JSON(r.Context(), map[string]string{"message": msg}); err != nil {
-			w.Error(http.StatusInternalServerError, err.Error())
-		}
+		return w.JSON(r.Context(), map[string]string{"message": msg})
 	})
 
 	mux.HandleFunc("GET /xml", func(w app.ResponseWriter, r *app.Request) {
@@ -310,8 +307,11 @@ func main() {
 		})
 	})
 
+	// Fingerprinted static files, referenced in templates with {{asset "js/main.js"}}
+	mux.StaticAssets()
+
 	mux.HandleFunc("GET /js", func(w app.ResponseWriter, r *app.Request) {
-		w.ServeFileFS(r, assetsFS, "assets/js/main.js", &app.ServeFileOptions{
+		w.ServeFileFS(r, assetsFS, "assets/static/js/main.js", &app.ServeFileOptions{
 			Inline:   true,
 			Filename: "main-01.js",
 		})
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// maxFuncNameWords limits the number of words of a message used to name its generated function.
+const maxFuncNameWords = 6
+
+// generateMessages writes a Go file declaring a function for every message, which formats it
+// with a printer and takes one typed argument per placeholder, so that a missing message or
+// wrong arguments become compile errors.
+func generateMessages(filename, pkgName string, translations map[string]TranslationInfo) error {
+	src, err := messagesSource(pkgName, translations)
+	if err != nil {
+		return err
+	}
+
+	if mkdirErr := os.MkdirAll(filepath.Dir(filename), 0750); mkdirErr != nil {
+		return fmt.Errorf("error creating directory: %w", mkdirErr)
+	}
+
+	if writeErr := os.WriteFile(filename, src, 0600); writeErr != nil {
+		return fmt.Errorf("error writing file: %w", writeErr)
+	}
+
+	return nil
+}
+
+// messagesSource returns the formatted source of the generated messages file.
+func messagesSource(pkgName string, translations map[string]TranslationInfo) ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteString("// Code generated by webfram-i18n. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkgName)
+	buf.WriteString("import \"golang.org/x/text/message\"\n")
+
+	usedNames := make(map[string]int)
+	for _, msgID := range getSortedMessageIDs(translations) {
+		info := translations[msgID]
+		name := uniqueFuncName(messageFuncName(msgID), usedNames)
+
+		params := []string{"p *message.Printer"}
+		args := []string{strconv.Quote(msgID)}
+		for i, ph := range info.Placeholders {
+			arg := fmt.Sprintf("arg%d", i+1)
+			params = append(params, arg+" "+placeholderGoType(ph.Type))
+			args = append(args, arg)
+		}
+
+		fmt.Fprintf(&buf, "\n// %s formats the message %s.\n", name, strconv.Quote(msgID))
+		fmt.Fprintf(&buf, "func %s(%s) string {\n", name, strings.Join(params, ", "))
+		fmt.Fprintf(&buf, "\treturn p.Sprintf(%s)\n}\n", strings.Join(args, ", "))
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error formatting generated code: %w", err)
+	}
+
+	return src, nil
+}
+
+// messageFuncName derives an exported Go identifier from the words of a message,
+// e.g. "Welcome to %s!" becomes "WelcomeTo". Placeholders are skipped.
+func messageFuncName(msgID string) string {
+	withoutVerbs := placeholderPattern.ReplaceAllString(msgID, " ")
+	words := strings.FieldsFunc(withoutVerbs, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) > maxFuncNameWords {
+		words = words[:maxFuncNameWords]
+	}
+
+	var name strings.Builder
+	for _, word := range words {
+		runes := []rune(word)
+		name.WriteRune(unicode.ToUpper(runes[0]))
+		name.WriteString(string(runes[1:]))
+	}
+
+	if name.Len() == 0 || !unicode.IsUpper([]rune(name.String())[0]) {
+		return "Msg" + name.String()
+	}
+	return name.String()
+}
+
+// uniqueFuncName appends a number to name if it is already used.
+func uniqueFuncName(name string, used map[string]int) string {
+	used[name]++
+	if used[name] == 1 {
+		return name
+	}
+
+	unique := fmt.Sprintf("%s%d", name, used[name])
+	for used[unique] > 0 {
+		used[name]++
+		unique = fmt.Sprintf("%s%d", name, used[name])
+	}
+	used[unique]++
+	return unique
+}
+
+// placeholderGoType returns the Go type of the generated argument for a placeholder type.
+func placeholderGoType(placeholderType string) string {
+	switch placeholderType {
+	case placeholderTypeInt, "float64", "string", "bool":
+		return placeholderType
+	default:
+		return "any"
+	}
+}
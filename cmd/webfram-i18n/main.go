@@ -27,6 +27,14 @@
 //
 //	webfram-i18n -languages "en,fr" -templates ./assets/templates -locales ./assets/locales
 //
+// Also extract log/slog messages:
+//
+//	webfram-i18n -languages "en,fr" -mode code -slog
+//
+// Also generate a typed function for every message:
+//
+//	webfram-i18n -languages "en,fr" -mode code -generate ./messages/messages.go
+//
 // Flags:
 //
 //	-languages    Comma-separated language codes (required, e.g., "en,fr,es")
@@ -34,6 +42,9 @@
 //	-mode         Extraction mode: templates, code, or both (default: both)
 //	-code         Directory containing Go source files (default: current directory)
 //	-locales      Output directory for message files (default: ./locales)
+//	-slog         Also extract messages from slog.Debug/Info/Warn/Error calls (default: false)
+//	-generate     Go file to generate with a typed function for every message (optional)
+//	-package      Package name of the generated Go file (default: name of its directory)
 //
 // The tool generates or updates messages.<lang>.json files with the correct format for
 // WebFram's i18n support, automatically detecting placeholder types (%s, %d, etc.)
@@ -55,8 +66,11 @@ import (
 	"path/filepath"
 	"reflect"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
+
+	"github.com/bondowe/webfram/internal/bind"
 )
 
 // Placeholder represents a placeholder in a translation message.
@@ -106,6 +120,11 @@ const (
 	placeholderTypeInt = "int"
 )
 
+// placeholderPattern matches printf-style format specifiers.
+//
+//nolint:gochecknoglobals // compiled once and shared by extraction and code generation
+var placeholderPattern = regexp.MustCompile(`%([+\-#0 ]*)(\*|\d+)?(\.\*|\.\d+)?([vTtbcdoOqxXUeEfFgGsp%])`)
+
 func main() {
 	config := parseFlags()
 	allTranslations := extractTranslations(config)
@@ -116,6 +135,9 @@ func main() {
 	}
 
 	updateCatalogs(config, allTranslations)
+	if config.generate != "" {
+		generateMessagesFile(config, allTranslations)
+	}
 	printTranslationSummary(allTranslations)
 	log.Println("\n✓ Extraction and merge completed successfully")
 }
@@ -126,6 +148,9 @@ type config struct {
 	templatesDir string
 	localesDir   string
 	languages    []string
+	slog         bool
+	generate     string
+	pkgName      string
 }
 
 func parseFlags() config {
@@ -151,6 +176,21 @@ func parseFlags() config {
 		"",
 		"Comma-separated list of language codes (e.g., en,fr,es,de) - REQUIRED",
 	)
+	slogMode := flag.Bool(
+		"slog",
+		false,
+		"Also extract messages from slog.Debug/Info/Warn/Error calls",
+	)
+	generate := flag.String(
+		"generate",
+		"",
+		"Go file to generate with a typed function for every message (optional)",
+	)
+	pkgName := flag.String(
+		"package",
+		"",
+		"Package name of the generated Go file (default: name of its directory)",
+	)
 	flag.Parse()
 
 	// Validate languages - required parameter
@@ -182,7 +222,23 @@ func parseFlags() config {
 		templatesDir: *templatesDir,
 		localesDir:   *localesDir,
 		languages:    languages,
+		slog:         *slogMode,
+		generate:     *generate,
+		pkgName:      generatedPackageName(*generate, *pkgName),
+	}
+}
+
+// generatedPackageName returns the package name of the generated Go file, defaulting to the name of its directory.
+func generatedPackageName(filename, pkgName string) string {
+	if pkgName != "" || filename == "" {
+		return pkgName
 	}
+
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return "messages"
+	}
+	return filepath.Base(filepath.Dir(abs))
 }
 
 func extractTranslations(cfg config) map[string]TranslationInfo {
@@ -190,9 +246,9 @@ func extractTranslations(cfg config) map[string]TranslationInfo {
 	case "templates":
 		return extractTemplateTranslations(cfg.templatesDir)
 	case "code":
-		return extractCodeTranslations(cfg.codeDir)
+		return extractCodeTranslations(cfg.codeDir, cfg.slog)
 	case "both":
-		return extractBothTranslations(cfg.codeDir, cfg.templatesDir)
+		return extractBothTranslations(cfg.codeDir, cfg.templatesDir, cfg.slog)
 	default:
 		fmt.Fprintf(os.Stderr, "Invalid mode: %s. Use 'templates', 'code', or 'both'\n", cfg.mode)
 		flag.Usage()
@@ -211,17 +267,29 @@ func extractTemplateTranslations(templatesDir string) map[string]TranslationInfo
 	return translations
 }
 
-func extractCodeTranslations(codeDir string) map[string]TranslationInfo {
+func extractCodeTranslations(codeDir string, slogMode bool) map[string]TranslationInfo {
 	log.Println("=== Extracting Code Translations ===")
-	translations, err := extractTranslationsFromGoFiles(codeDir)
+	translations, err := extractTranslationsFromGoFiles(codeDir, slogMode)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	addFrameworkTranslations(translations)
 	return translations
 }
 
-func extractBothTranslations(codeDir, templatesDir string) map[string]TranslationInfo {
+// addFrameworkTranslations adds the messages WebFram itself translates with the request's
+// printer, such as the JSON decoding errors returned by BindJSON and enum validation errors.
+func addFrameworkTranslations(translations map[string]TranslationInfo) {
+	for _, messageID := range slices.Concat(bind.DecodeMessages, bind.ValidationMessages) {
+		translations[messageID] = TranslationInfo{
+			MessageID:    messageID,
+			Placeholders: extractPlaceholders(messageID),
+		}
+	}
+}
+
+func extractBothTranslations(codeDir, templatesDir string, slogMode bool) map[string]TranslationInfo {
 	log.Println("=== Extracting Translations from Templates and Code ===")
 
 	// Extract from templates
@@ -233,7 +301,7 @@ func extractBothTranslations(codeDir, templatesDir string) map[string]Translatio
 	log.Printf("Found %d translations in templates\n", len(templateTranslations))
 
 	// Extract from Go code
-	codeTranslations, err := extractTranslationsFromGoFiles(codeDir)
+	codeTranslations, err := extractTranslationsFromGoFiles(codeDir, slogMode)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error extracting code translations: %v\n", err)
 		os.Exit(1)
@@ -242,6 +310,7 @@ func extractBothTranslations(codeDir, templatesDir string) map[string]Translatio
 		"Found %d translations in Go code (i18n printer calls, log calls, and validation errmsg tags)\n",
 		len(codeTranslations),
 	)
+	addFrameworkTranslations(codeTranslations)
 
 	// Merge both
 	allTranslations := mergeTranslations(templateTranslations, codeTranslations)
@@ -266,6 +335,15 @@ func updateCatalogs(cfg config, allTranslations map[string]TranslationInfo) {
 	}
 }
 
+func generateMessagesFile(cfg config, allTranslations map[string]TranslationInfo) {
+	log.Println("\n=== Generating Message Functions ===")
+	if err := generateMessages(cfg.generate, cfg.pkgName, allTranslations); err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating %s: %v\n", cfg.generate, err)
+		os.Exit(1)
+	}
+	log.Printf("Generated %s: %d functions\n", cfg.generate, len(allTranslations))
+}
+
 // parseLanguages splits a comma-separated string into a slice of language codes.
 func parseLanguages(input string) []string {
 	if input == "" {
@@ -606,7 +684,8 @@ func extractTranslationsFromTemplates(dir string) (map[string]TranslationInfo, e
 
 // extractTranslationsFromGoFiles extracts translations from Go source files.
 // Includes: i18n printer calls, log calls (fmt, log packages), and validation errmsg tags.
-func extractTranslationsFromGoFiles(dir string) (map[string]TranslationInfo, error) {
+// When slogMode is true, slog messages are extracted as well.
+func extractTranslationsFromGoFiles(dir string, slogMode bool) (map[string]TranslationInfo, error) {
 	translations := make(map[string]TranslationInfo)
 
 	err := filepath.Walk(dir, func(path string, info fs.FileInfo, err error) error {
@@ -635,11 +714,15 @@ func extractTranslationsFromGoFiles(dir string) (map[string]TranslationInfo, err
 		// 1. i18n printer calls (printer.Sprintf, etc.)
 		// 2. Log calls (fmt.Printf, log.Printf, etc.)
 		// 3. Struct field tags with errmsg
+		// 4. slog calls (slog.Info, etc.) when slogMode is enabled
 		ast.Inspect(node, func(n ast.Node) bool {
 			switch node := n.(type) {
 			case *ast.CallExpr:
 				// Handle function calls (i18n printer and log calls)
 				handleCallExpr(node, translations)
+				if slogMode {
+					handleSlogCallExpr(node, translations)
+				}
 			case *ast.StructType:
 				// Handle struct field tags
 				handleStructType(node, translations)
@@ -717,6 +800,53 @@ func handleCallExpr(callExpr *ast.CallExpr, translations map[string]TranslationI
 	}
 }
 
+// handleSlogCallExpr processes slog calls to extract the message argument.
+// The remaining arguments are structured key-value pairs and are ignored. Messages
+// containing printf-style verbs are skipped since slog does not format them.
+func handleSlogCallExpr(callExpr *ast.CallExpr, translations map[string]TranslationInfo) {
+	sel, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok || ident.Name != "slog" {
+		return
+	}
+
+	msgIndex, ok := slogMessageIndex(sel.Sel.Name)
+	if !ok || len(callExpr.Args) <= msgIndex {
+		return
+	}
+
+	lit, ok := callExpr.Args[msgIndex].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return
+	}
+
+	messageID := strings.Trim(lit.Value, "`\"")
+	if messageID == "" || len(extractPlaceholders(messageID)) > 0 {
+		return
+	}
+
+	translations[messageID] = TranslationInfo{
+		MessageID: messageID,
+	}
+}
+
+// slogMessageIndex returns the position of the message argument for a slog function.
+// The *Context variants take a context.Context before the message.
+func slogMessageIndex(name string) (int, bool) {
+	switch name {
+	case "Debug", "Info", "Warn", "Error":
+		return 0, true
+	case "DebugContext", "InfoContext", "WarnContext", "ErrorContext":
+		return 1, true
+	default:
+		return 0, false
+	}
+}
+
 // handleStructType processes struct types to extract errmsg tags.
 func handleStructType(structType *ast.StructType, translations map[string]TranslationInfo) {
 	if structType.Fields == nil {
@@ -847,12 +977,7 @@ func printTranslationSummary(translations map[string]TranslationInfo) {
 func extractPlaceholders(message string) []PlaceholderInfo {
 	var placeholders []PlaceholderInfo
 
-	// Pattern to match printf-style format specifiers
-	formatPattern := regexp.MustCompile(
-		`%([+\-#0 ]*)(\*|\d+)?(\.\*|\.\d+)?([vTtbcdoOqxXUeEfFgGsp%])`,
-	)
-
-	matches := formatPattern.FindAllStringSubmatch(message, -1)
+	matches := placeholderPattern.FindAllStringSubmatch(message, -1)
 	for i, match := range matches {
 		if len(match) > 4 { //nolint:mnd // match has at least 5 elements for verb extraction
 			verb := match[4]
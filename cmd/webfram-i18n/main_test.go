@@ -2,8 +2,11 @@ package main
 
 import (
 	"encoding/json"
+	"go/parser"
+	"go/token"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -789,7 +792,7 @@ func main() {
 	_ = os.WriteFile(filepath.Join(tmpDir, "test.go"), []byte(goContent), 0600)
 
 	// Extract translations
-	translations, err := extractTranslationsFromGoFiles(tmpDir)
+	translations, err := extractTranslationsFromGoFiles(tmpDir, false)
 	if err != nil {
 		t.Fatalf("extractTranslationsFromGoFiles failed: %v", err)
 	}
@@ -812,6 +815,69 @@ func main() {
 	}
 }
 
+func TestExtractTranslationsFromGoFiles_Slog(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	goContent := `package main
+
+import (
+    "context"
+    "fmt"
+    "log/slog"
+)
+
+func main() {
+    name := "john"
+    slog.Info("user created", "user", name)
+    slog.Warn("disk almost full")
+    slog.ErrorContext(context.Background(), "request failed", "status", 500)
+    slog.Debug("retry %d of %d", 1, 3)
+    slog.Info(fmt.Sprintf("User %s created", name))
+    slog.Info(name)
+    fmt.Printf("Hello %s", name)
+}
+`
+	_ = os.WriteFile(filepath.Join(tmpDir, "test.go"), []byte(goContent), 0600)
+
+	withoutSlog, err := extractTranslationsFromGoFiles(tmpDir, false)
+	if err != nil {
+		t.Fatalf("extractTranslationsFromGoFiles failed: %v", err)
+	}
+
+	if _, exists := withoutSlog["user created"]; exists {
+		t.Error("Did not expect slog messages without slog mode")
+	}
+
+	translations, err := extractTranslationsFromGoFiles(tmpDir, true)
+	if err != nil {
+		t.Fatalf("extractTranslationsFromGoFiles failed: %v", err)
+	}
+
+	expected := []string{
+		"user created",
+		"disk almost full",
+		"request failed",
+		"User %s created",
+		"Hello %s",
+	}
+	for _, msg := range expected {
+		if _, exists := translations[msg]; !exists {
+			t.Errorf("Expected translation for %q", msg)
+		}
+	}
+
+	unexpected := []string{"user", "status", "retry %d of %d", "john"}
+	for _, msg := range unexpected {
+		if _, exists := translations[msg]; exists {
+			t.Errorf("Did not expect translation for %q", msg)
+		}
+	}
+
+	if len(translations) != len(expected) {
+		t.Errorf("Expected %d translations, got %d", len(expected), len(translations))
+	}
+}
+
 func BenchmarkExtractPlaceholders(b *testing.B) {
 	message := "Hello %s, you have %d new messages and %.2f credits"
 
@@ -834,3 +900,105 @@ func BenchmarkCreateMessage(b *testing.B) {
 		createMessage("Hello %s", info)
 	}
 }
+
+func TestAddFrameworkTranslations(t *testing.T) {
+	translations := map[string]TranslationInfo{"Hello": {MessageID: "Hello"}}
+
+	addFrameworkTranslations(translations)
+
+	if _, ok := translations["Hello"]; !ok {
+		t.Error("Expected existing translations to be kept")
+	}
+
+	info, ok := translations["%s must be a number"]
+	if !ok {
+		t.Fatal("Expected binder decode messages to be added")
+	}
+	if len(info.Placeholders) != 1 || info.Placeholders[0].Type != "string" {
+		t.Errorf("Expected one string placeholder, got %+v", info.Placeholders)
+	}
+
+	if _, ok = translations["request body is not valid JSON"]; !ok {
+		t.Error("Expected message without placeholders to be added")
+	}
+}
+
+func TestMessageFuncName(t *testing.T) {
+	tests := []struct {
+		msgID    string
+		expected string
+	}{
+		{"Welcome to %s!", "WelcomeTo"},
+		{"You have %d new messages", "YouHaveNewMessages"},
+		{"hello world", "HelloWorld"},
+		{"404 page not found", "Msg404PageNotFound"},
+		{"%s", "Msg"},
+		{"one two three four five six seven", "OneTwoThreeFourFiveSix"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.msgID, func(t *testing.T) {
+			if got := messageFuncName(tt.msgID); got != tt.expected {
+				t.Errorf("messageFuncName(%q) = %q, expected %q", tt.msgID, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUniqueFuncName(t *testing.T) {
+	used := make(map[string]int)
+
+	for _, expected := range []string{"Hello", "Hello2", "Hello3"} {
+		if got := uniqueFuncName("Hello", used); got != expected {
+			t.Errorf("Expected %q, got %q", expected, got)
+		}
+	}
+}
+
+func TestGenerateMessages(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "messages", "messages.go")
+
+	translations := map[string]TranslationInfo{
+		"Welcome to %s, you have %d messages": {
+			MessageID:    "Welcome to %s, you have %d messages",
+			Placeholders: extractPlaceholders("Welcome to %s, you have %d messages"),
+		},
+		"Hello": {MessageID: "Hello"},
+		"hello": {MessageID: "hello"},
+	}
+
+	if err := generateMessages(filename, "messages", translations); err != nil {
+		t.Fatalf("generateMessages failed: %v", err)
+	}
+
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), filename, src, 0); err != nil {
+		t.Fatalf("Generated file does not parse: %v\n%s", err, src)
+	}
+
+	for _, expected := range []string{
+		"// Code generated by webfram-i18n. DO NOT EDIT.",
+		"package messages",
+		"func Hello(p *message.Printer) string {",
+		"func Hello2(p *message.Printer) string {",
+		"func WelcomeToYouHaveMessages(p *message.Printer, arg1 string, arg2 int) string {",
+		`return p.Sprintf("Welcome to %s, you have %d messages", arg1, arg2)`,
+	} {
+		if !strings.Contains(string(src), expected) {
+			t.Errorf("Expected generated file to contain %q:\n%s", expected, src)
+		}
+	}
+
+	// The generated calls are extracted again, so the catalog keeps the messages.
+	extracted, err := extractTranslationsFromGoFiles(filepath.Dir(filename), false)
+	if err != nil {
+		t.Fatalf("extractTranslationsFromGoFiles failed: %v", err)
+	}
+	if len(extracted) != len(translations) {
+		t.Errorf("Expected %d translations extracted from generated file, got %d", len(translations), len(extracted))
+	}
+}
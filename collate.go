@@ -0,0 +1,44 @@
+package webfram
+
+import (
+	"context"
+	"slices"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+
+	"github.com/bondowe/webfram/internal/i18n"
+)
+
+// sortStringsFuncName is the name of the template function sorting strings for the request language.
+const sortStringsFuncName = "sortStrings"
+
+// Collator returns a collator comparing and sorting strings according to the rules of the request language
+// (see Language), e.g. to sort names with accented characters the way readers of that language expect:
+//
+//	r.Collator().SortStrings(names)
+//
+// A Collator is not safe for concurrent use; call Collator again for each goroutine.
+func (r *Request) Collator() *collate.Collator {
+	return collate.New(r.Language())
+}
+
+// sortStrings returns a copy of items sorted according to the collation rules of lang.
+func sortStrings(lang language.Tag, items []string) []string {
+	sorted := slices.Clone(items)
+	collate.New(lang).SortStrings(sorted)
+	return sorted
+}
+
+// sortStringsFunc returns the sortStrings template function for the language resolved in ctx, falling back to
+// the first supported language of the App serving the request.
+func sortStringsFunc(ctx context.Context) func(items []string) []string {
+	lang, ok := i18n.LanguageFromContext(ctx)
+
+	return func(items []string) []string {
+		if ok {
+			return sortStrings(lang, items)
+		}
+		return sortStrings(appFromContext(ctx).fallbackLanguage(), items)
+	}
+}
@@ -0,0 +1,46 @@
+package webfram
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	"github.com/bondowe/webfram/internal/i18n"
+)
+
+func TestRequest_Collator(t *testing.T) {
+	req := NewTestRequest(http.MethodGet, "/", nil)
+	req.Request = req.WithContext(i18n.ContextWithLanguage(req.Context(), language.French))
+
+	names := []string{"zèbre", "Éclair", "abricot", "école"}
+	req.Collator().SortStrings(names)
+
+	if want := []string{"abricot", "Éclair", "école", "zèbre"}; !slices.Equal(names, want) {
+		t.Errorf("Expected %v, got %v", want, names)
+	}
+}
+
+func TestSortStringsFunc(t *testing.T) {
+	items := []string{"ö", "z", "o"}
+
+	swedish := sortStringsFunc(i18n.ContextWithLanguage(context.Background(), language.Swedish))(items)
+	if want := []string{"o", "z", "ö"}; !slices.Equal(swedish, want) {
+		t.Errorf("Expected Swedish order %v, got %v", want, swedish)
+	}
+
+	german := sortStringsFunc(i18n.ContextWithLanguage(context.Background(), language.German))(items)
+	if want := []string{"o", "ö", "z"}; !slices.Equal(german, want) {
+		t.Errorf("Expected German order %v, got %v", want, german)
+	}
+
+	if items[0] != "ö" {
+		t.Errorf("Expected the input to be left unchanged, got %v", items)
+	}
+
+	if funcs := requestTemplateFuncs(i18n.ContextWithLanguage(context.Background(), language.German), "T"); funcs[sortStringsFuncName] == nil {
+		t.Error("Expected sortStrings to be bound to the request language")
+	}
+}
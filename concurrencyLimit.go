@@ -0,0 +1,68 @@
+package webfram
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/bondowe/webfram/internal/telemetry"
+)
+
+// ConcurrencyLimit creates middleware capping the number of requests served concurrently to maxConcurrent, to shed
+// load before the service is overwhelmed. Requests beyond the cap wait for a slot for up to queueTimeout, then get
+// 503 Service Unavailable with a Retry-After header of queueTimeout; a zero queueTimeout rejects them at once.
+// Requests whose context is canceled while waiting are dropped without a response.
+// Use it with App.Use for a global cap, or on a mux or route to protect expensive endpoints only.
+// Waiting requests are exported as the concurrency_limit_queued telemetry gauge and rejected requests as the
+// concurrency_limit_rejected_total counter; both are also counted by the active_connections gauge.
+// Panics if maxConcurrent is not positive.
+func ConcurrencyLimit(maxConcurrent int, queueTimeout time.Duration) AppMiddleware {
+	if maxConcurrent <= 0 {
+		panic("ConcurrencyLimit maxConcurrent must be greater than zero")
+	}
+
+	slots := make(chan struct{}, maxConcurrent)
+
+	return func(next Handler) Handler {
+		return HandlerFunc(func(w ResponseWriter, r *Request) {
+			select {
+			case slots <- struct{}{}:
+			default:
+				if !waitForSlot(r, slots, queueTimeout) {
+					if r.Context().Err() != nil {
+						return
+					}
+
+					telemetry.ConcurrencyLimitRejectedTotal.Inc()
+					w.RetryAfter(queueTimeout)
+					w.Error(http.StatusServiceUnavailable, http.StatusText(http.StatusServiceUnavailable))
+					return
+				}
+			}
+			defer func() { <-slots }()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// waitForSlot waits up to timeout for a free slot, and reports whether one was acquired.
+func waitForSlot(r *Request, slots chan struct{}, timeout time.Duration) bool {
+	if timeout <= 0 {
+		return false
+	}
+
+	telemetry.ConcurrencyLimitQueued.Inc()
+	defer telemetry.ConcurrencyLimitQueued.Dec()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case slots <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-r.Context().Done():
+		return false
+	}
+}
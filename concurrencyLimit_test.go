@@ -0,0 +1,82 @@
+package webfram
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/bondowe/webfram/internal/telemetry"
+)
+
+func TestConcurrencyLimit_RejectsAfterQueueTimeout(t *testing.T) {
+	var running, peak atomic.Int32
+	release := make(chan struct{})
+
+	handler := ConcurrencyLimit(2, 30*time.Millisecond)(HandlerFunc(func(w ResponseWriter, _ *Request) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rejectedBefore := testutil.ToFloat64(telemetry.ConcurrencyLimitRejectedTotal)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		close(release)
+	}()
+
+	recs := serveConcurrently(handler, 4, make(chan struct{}), func() *Request {
+		return NewTestRequest(http.MethodGet, "/", nil)
+	})
+
+	rejected := 0
+	for _, rec := range recs {
+		if rec.Code == http.StatusServiceUnavailable {
+			rejected++
+			if rec.Header().Get("Retry-After") != "1" {
+				t.Errorf("Expected Retry-After 1, got %q", rec.Header().Get("Retry-After"))
+			}
+		}
+	}
+
+	if rejected != 2 || peak.Load() != 2 {
+		t.Errorf("Expected 2 rejected requests and 2 concurrent handlers, got %d and %d", rejected, peak.Load())
+	}
+	if got := testutil.ToFloat64(telemetry.ConcurrencyLimitRejectedTotal) - rejectedBefore; got != 2 {
+		t.Errorf("Expected 2 rejections to be counted, got %v", got)
+	}
+}
+
+func TestConcurrencyLimit_QueuedRequestGetsSlot(t *testing.T) {
+	handler := ConcurrencyLimit(1, time.Second)(HandlerFunc(func(w ResponseWriter, _ *Request) {
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, rec := range serveConcurrently(handler, 3, make(chan struct{}), func() *Request {
+		return NewTestRequest(http.MethodGet, "/", nil)
+	}) {
+		if rec.Code != http.StatusOK {
+			t.Errorf("Expected queued requests to be served, got %d", rec.Code)
+		}
+	}
+
+	if queued := testutil.ToFloat64(telemetry.ConcurrencyLimitQueued); queued != 0 {
+		t.Errorf("Expected no queued requests, got %v", queued)
+	}
+}
+
+func TestConcurrencyLimit_PanicsOnInvalidMax(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for zero maxConcurrent")
+		}
+	}()
+	ConcurrencyLimit(0, time.Second)
+}
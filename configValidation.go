@@ -0,0 +1,205 @@
+package webfram
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Validate reports misconfigurations that would otherwise be silently ignored or only surface
+// when serving requests, such as an enabled OpenAPI endpoint without a configuration, a malformed
+// telemetry address or a missing template directory. All problems found are joined in the returned error.
+// Configure calls Validate and panics if it fails. A nil Config is valid.
+func (cfg *Config) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+
+	var errs []error
+
+	if cfg.I18nMessages != nil {
+		errs = append(errs, errors.New("I18nMessages is not used: set Assets.I18nMessages instead"))
+	}
+
+	errs = append(errs, cfg.validateTelemetry()...)
+	errs = append(errs, cfg.validateOpenAPI()...)
+	errs = append(errs, cfg.validateAssets()...)
+
+	if cfg.DebugConfig != nil && cfg.DebugConfig.Enabled {
+		if err := validateGETURLPath(cfg.DebugConfig.URLPath); err != nil {
+			errs = append(errs, fmt.Errorf("DebugConfig.URLPath: %w", err))
+		}
+	}
+
+	if err := validateJSONPCallbackParamName(cfg.JSONPCallbackParamName); err != nil {
+		errs = append(errs, err)
+	}
+	if cfg.JSONPCallbackMaxLength < 0 {
+		errs = append(errs, fmt.Errorf("JSONPCallbackMaxLength must not be negative, got %d", cfg.JSONPCallbackMaxLength))
+	}
+
+	if cfg.MaxUploadSize < 0 {
+		errs = append(errs, fmt.Errorf("MaxUploadSize must not be negative, got %d", cfg.MaxUploadSize))
+	}
+	if cfg.MultipartMaxMemory < 0 {
+		errs = append(errs, fmt.Errorf("MultipartMaxMemory must not be negative, got %d", cfg.MultipartMaxMemory))
+	}
+	if cfg.MaxDecompressedBodySize < 0 {
+		errs = append(errs, fmt.Errorf("MaxDecompressedBodySize must not be negative, got %d", cfg.MaxDecompressedBodySize))
+	}
+
+	for _, proxy := range cfg.TrustedProxies {
+		if _, err := parseTrustedProxy(proxy); err != nil {
+			errs = append(errs, fmt.Errorf("TrustedProxies: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (cfg *Config) validateTelemetry() []error {
+	if cfg.Telemetry == nil || !cfg.Telemetry.Enabled {
+		return nil
+	}
+
+	var errs []error
+
+	if err := validateGETURLPath(cfg.Telemetry.URLPath); err != nil {
+		errs = append(errs, fmt.Errorf("Telemetry.URLPath: %w", err))
+	}
+
+	if addr := cfg.Telemetry.Addr; addr != "" {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			errs = append(errs, fmt.Errorf("Telemetry.Addr %q is malformed, expected host:port (e.g., \":9090\"): %w", addr, err))
+		}
+	}
+
+	return errs
+}
+
+func (cfg *Config) validateOpenAPI() []error {
+	if cfg.OpenAPI == nil || !cfg.OpenAPI.Enabled {
+		return nil
+	}
+
+	var errs []error
+
+	if cfg.OpenAPI.Config == nil {
+		errs = append(errs, errors.New("OpenAPI is enabled but OpenAPI.Config is nil"))
+	}
+
+	if err := validateGETURLPath(cfg.OpenAPI.URLPath); err != nil {
+		errs = append(errs, fmt.Errorf("OpenAPI.URLPath: %w", err))
+	}
+
+	return errs
+}
+
+// validateAssets checks that explicitly configured asset directories exist.
+// The default directories are optional and may be missing.
+func (cfg *Config) validateAssets() []error {
+	if cfg.Assets == nil {
+		return nil
+	}
+
+	var errs []error
+	fsys := getAssetsFS(cfg)
+
+	if tmpl := cfg.Assets.Templates; tmpl != nil && tmpl.Dir != "" {
+		if err := validateAssetsDir(fsys, tmpl.Dir); err != nil {
+			errs = append(errs, fmt.Errorf("Assets.Templates.Dir: %w", err))
+		}
+	}
+
+	if static := cfg.Assets.Static; static != nil {
+		if static.Dir != "" {
+			if err := validateAssetsDir(fsys, static.Dir); err != nil {
+				errs = append(errs, fmt.Errorf("Assets.Static.Dir: %w", err))
+			}
+		}
+		if static.URLPath != "" && !strings.HasPrefix(static.URLPath, "/") {
+			errs = append(errs, fmt.Errorf("Assets.Static.URLPath: %q must be a path starting with \"/\"", static.URLPath))
+		}
+	}
+
+	i18nMessages := cfg.Assets.I18nMessages
+	if i18nMessages == nil {
+		return errs
+	}
+
+	if i18nMessages.Dir != "" {
+		if err := validateAssetsDir(fsys, i18nMessages.Dir); err != nil {
+			errs = append(errs, fmt.Errorf("Assets.I18nMessages.Dir: %w", err))
+		} else if !hasI18nMessageFiles(fsys, i18nMessages.Dir) {
+			errs = append(errs, fmt.Errorf(
+				"Assets.I18nMessages.Dir: %q contains no messages.<lang>.json files", i18nMessages.Dir))
+		}
+	}
+
+	if reload := i18nMessages.Reload; reload != nil && reload.Enabled &&
+		reload.URLPath != "" && !strings.HasPrefix(reload.URLPath, "/") {
+		errs = append(errs, fmt.Errorf(
+			"Assets.I18nMessages.Reload.URLPath: %q must be a path starting with \"/\"", reload.URLPath))
+	}
+
+	for _, lang := range i18nMessages.SupportedLanguages {
+		if _, err := language.Parse(lang); err != nil {
+			errs = append(errs, fmt.Errorf("Assets.I18nMessages.SupportedLanguages: invalid language %q: %w", lang, err))
+		}
+	}
+
+	for _, lang := range i18nMessages.Fallback {
+		if _, err := language.Parse(lang); err != nil {
+			errs = append(errs, fmt.Errorf("Assets.I18nMessages.Fallback: invalid language %q: %w", lang, err))
+		}
+	}
+
+	return errs
+}
+
+// validateGETURLPath checks an endpoint path such as "/metrics" or "GET /metrics".
+// An empty path is valid and replaced by the default.
+func validateGETURLPath(urlPath string) error {
+	if urlPath == "" {
+		return nil
+	}
+
+	path := strings.TrimPrefix(urlPath, "GET ")
+	if !strings.HasPrefix(path, "/") {
+		return fmt.Errorf("%q must be a path starting with \"/\", optionally prefixed with \"GET \"", urlPath)
+	}
+
+	return nil
+}
+
+func validateAssetsDir(fsys fs.FS, dir string) error {
+	stat, err := fs.Stat(fsys, dir)
+	if err != nil {
+		return fmt.Errorf("directory %q not found: %w", dir, err)
+	}
+	if !stat.IsDir() {
+		return fmt.Errorf("%q is not a directory", dir)
+	}
+
+	return nil
+}
+
+func hasI18nMessageFiles(fsys fs.FS, dir string) bool {
+	matches, err := fs.Glob(fsys, dir+"/messages.*.json")
+	return err == nil && len(matches) > 0
+}
+
+func validateJSONPCallbackParamName(name string) error {
+	if name == "" || jsonpCallbackNamePattern.MatchString(name) {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"invalid JSONP callback param name: %q. "+
+			"Must start with a letter or underscore and only contain alphanumeric characters and underscores",
+		name)
+}
@@ -0,0 +1,191 @@
+package webfram
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConfig_Validate_Valid(t *testing.T) {
+	cfgs := []*Config{
+		nil,
+		{},
+		{
+			Telemetry: &Telemetry{Enabled: true, URLPath: "/metrics", Addr: ":9090"},
+			OpenAPI:   &OpenAPI{Enabled: true, URLPath: "GET /openapi.json", Config: &OpenAPIConfig{}},
+			Assets: &Assets{
+				FS:        testAssetsFS,
+				Templates: &Templates{Dir: "testdata/templates"},
+				I18nMessages: &I18nMessages{
+					Dir:                "testdata/locales",
+					SupportedLanguages: []string{"en", "fr"},
+					Fallback:           []string{"en"},
+				},
+			},
+			JSONPCallbackParamName: "callback",
+		},
+		// Disabled sections are not validated.
+		{
+			Telemetry: &Telemetry{Addr: "bad"},
+			OpenAPI:   &OpenAPI{},
+		},
+	}
+
+	for i, cfg := range cfgs {
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Config %d: expected no error, got %v", i, err)
+		}
+	}
+}
+
+func TestConfig_Validate_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *Config
+		expected string
+	}{
+		{
+			"unused top-level i18n messages",
+			&Config{I18nMessages: &I18nMessages{Dir: "locales"}},
+			"set Assets.I18nMessages instead",
+		},
+		{
+			"malformed telemetry address",
+			&Config{Telemetry: &Telemetry{Enabled: true, Addr: "9090"}},
+			`Telemetry.Addr "9090" is malformed`,
+		},
+		{
+			"invalid telemetry path",
+			&Config{Telemetry: &Telemetry{Enabled: true, URLPath: "metrics"}},
+			"Telemetry.URLPath",
+		},
+		{
+			"openapi without config",
+			&Config{OpenAPI: &OpenAPI{Enabled: true}},
+			"OpenAPI.Config is nil",
+		},
+		{
+			"invalid openapi path",
+			&Config{OpenAPI: &OpenAPI{Enabled: true, URLPath: "POST /openapi.json", Config: &OpenAPIConfig{}}},
+			"OpenAPI.URLPath",
+		},
+		{
+			"invalid debug config path",
+			&Config{DebugConfig: &DebugConfig{Enabled: true, URLPath: "debug/config"}},
+			"DebugConfig.URLPath",
+		},
+		{
+			"missing template directory",
+			&Config{Assets: &Assets{FS: testAssetsFS, Templates: &Templates{Dir: "testdata/views"}}},
+			`Assets.Templates.Dir: directory "testdata/views" not found`,
+		},
+		{
+			"template directory is a file",
+			&Config{Assets: &Assets{
+				FS:        testAssetsFS,
+				Templates: &Templates{Dir: "testdata/locales/messages.en.json"},
+			}},
+			"is not a directory",
+		},
+		{
+			"missing static directory",
+			&Config{Assets: &Assets{FS: testAssetsFS, Static: &StaticAssets{Dir: "testdata/static"}}},
+			`Assets.Static.Dir: directory "testdata/static" not found`,
+		},
+		{
+			"relative static URL path",
+			&Config{Assets: &Assets{FS: testAssetsFS, Static: &StaticAssets{URLPath: "static/"}}},
+			`Assets.Static.URLPath: "static/" must be a path starting with "/"`,
+		},
+		{
+			"locales without message files",
+			&Config{Assets: &Assets{FS: testAssetsFS, I18nMessages: &I18nMessages{Dir: "testdata/templates"}}},
+			"contains no messages.<lang>.json files",
+		},
+		{
+			"relative i18n reload URL path",
+			&Config{Assets: &Assets{I18nMessages: &I18nMessages{Reload: &I18nReload{Enabled: true, URLPath: "reload"}}}},
+			`Assets.I18nMessages.Reload.URLPath: "reload" must be a path starting with "/"`,
+		},
+		{
+			"invalid supported language",
+			&Config{Assets: &Assets{I18nMessages: &I18nMessages{SupportedLanguages: []string{"en", "not a language"}}}},
+			`invalid language "not a language"`,
+		},
+		{
+			"invalid fallback language",
+			&Config{Assets: &Assets{I18nMessages: &I18nMessages{Fallback: []string{"xx-???"}}}},
+			"Assets.I18nMessages.Fallback",
+		},
+		{
+			"invalid JSONP param name",
+			&Config{JSONPCallbackParamName: "1cb"},
+			"invalid JSONP callback param name",
+		},
+		{
+			"negative JSONP callback max length",
+			&Config{JSONPCallbackMaxLength: -1},
+			"JSONPCallbackMaxLength must not be negative",
+		},
+		{
+			"negative upload size",
+			&Config{MaxUploadSize: -1},
+			"MaxUploadSize must not be negative",
+		},
+		{
+			"invalid trusted proxy",
+			&Config{TrustedProxies: []string{"10.0.0.0/8", "10.0.0.0/33", "proxy.internal"}},
+			`TrustedProxies: invalid CIDR range "10.0.0.0/33"`,
+		},
+		{
+			"negative decompressed body size",
+			&Config{MaxDecompressedBodySize: -1},
+			"MaxDecompressedBodySize must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if err == nil {
+				t.Fatal("Expected validation error")
+			}
+			if !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected error containing %q, got %q", tt.expected, err.Error())
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_ReportsAllErrors(t *testing.T) {
+	err := (&Config{
+		OpenAPI:       &OpenAPI{Enabled: true},
+		MaxUploadSize: -1,
+	}).Validate()
+
+	if err == nil {
+		t.Fatal("Expected validation error")
+	}
+
+	for _, expected := range []string{"OpenAPI.Config", "MaxUploadSize"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error to mention %q, got %q", expected, err.Error())
+		}
+	}
+}
+
+func TestConfigure_PanicsOnInvalidConfig(t *testing.T) {
+	resetAppConfig()
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("Expected Configure to panic")
+		}
+		err, ok := r.(error)
+		if !ok || !strings.Contains(err.Error(), "invalid configuration") {
+			t.Errorf("Unexpected panic value: %v", r)
+		}
+	}()
+
+	Configure(&Config{OpenAPI: &OpenAPI{Enabled: true}})
+}
@@ -0,0 +1,106 @@
+package webfram
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+func setupCSPMux(t *testing.T, opts CSPOptions, handler HandlerFunc) *ServeMux {
+	t.Helper()
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+
+	Configure(&Config{
+		Assets: &Assets{
+			FS: fstest.MapFS{
+				"templates/layout.go.html": {Data: []byte(`{{template "content" .}}`)},
+				"templates/page.go.html":   {Data: []byte(`{{define "content"}}<script nonce="{{cspNonce}}"></script>{{partial "style" .}}{{end}}`)},
+				"templates/_style.go.html": {Data: []byte(`<style nonce="{{cspNonce}}"></style>`)},
+				"templates/static.go.html": {Data: []byte(`{{define "content"}}nonce:{{cspNonce}}{{end}}`)},
+			},
+			Templates: &Templates{Dir: "templates"},
+		},
+	})
+
+	mux := NewServeMux()
+	mux.Use(CSP(opts))
+	mux.HandleFunc("GET /", handler)
+	registerHandlers(mux)
+
+	return mux
+}
+
+func TestCSP_HeaderAndTemplateNonce(t *testing.T) {
+	var handlerNonce string
+	mux := setupCSPMux(t, CSPOptions{}, func(w ResponseWriter, r *Request) {
+		handlerNonce = r.CSPNonce()
+		if err := w.HTML(r.Context(), "page", nil); err != nil {
+			t.Errorf("HTML failed: %v", err)
+		}
+	})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+	if handlerNonce == "" {
+		t.Fatal("Expected a nonce for the request")
+	}
+
+	expectedPolicy := "default-src 'self'; script-src 'self' 'nonce-" + handlerNonce + "'; style-src 'self' 'nonce-" +
+		handlerNonce + "'; object-src 'none'; base-uri 'self'"
+	if got := rec.Header().Get("Content-Security-Policy"); got != expectedPolicy {
+		t.Errorf("Expected policy %q, got %q", expectedPolicy, got)
+	}
+
+	expectedBody := `<script nonce="` + handlerNonce + `"></script><style nonce="` + handlerNonce + `"></style>`
+	if rec.Body.String() != expectedBody {
+		t.Errorf("Expected body %q, got %q", expectedBody, rec.Body.String())
+	}
+
+	firstNonce := handlerNonce
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	if handlerNonce == firstNonce {
+		t.Error("Expected a new nonce for every request")
+	}
+}
+
+func TestCSP_CustomDirectivesReportOnly(t *testing.T) {
+	var nonce string
+	mux := setupCSPMux(t, CSPOptions{
+		Directives:      []string{"default-src 'none'", "script-src 'strict-dynamic'"},
+		NonceDirectives: []string{"script-src"},
+		ReportOnly:      true,
+	}, func(_ ResponseWriter, r *Request) {
+		nonce = r.CSPNonce()
+	})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+	if rec.Header().Get("Content-Security-Policy") != "" {
+		t.Error("Expected no enforced policy in report-only mode")
+	}
+	expected := "default-src 'none'; script-src 'strict-dynamic' 'nonce-" + nonce + "'"
+	if got := rec.Header().Get("Content-Security-Policy-Report-Only"); got != expected {
+		t.Errorf("Expected report-only policy %q, got %q", expected, got)
+	}
+}
+
+func TestCSP_NonceWithoutMiddleware(t *testing.T) {
+	setupCSPMux(t, CSPOptions{}, func(_ ResponseWriter, _ *Request) {})
+
+	w, rec := NewTestResponseWriter()
+	if err := w.HTML(t.Context(), "static", nil); err != nil {
+		t.Fatalf("HTML failed: %v", err)
+	}
+	if rec.Body.String() != "nonce:" {
+		t.Errorf("Expected empty nonce outside the CSP middleware, got %q", rec.Body.String())
+	}
+
+	r := &Request{Request: httptest.NewRequest(http.MethodGet, "/", http.NoBody)}
+	if r.CSPNonce() != "" {
+		t.Error("Expected no nonce outside the CSP middleware")
+	}
+}
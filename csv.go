@@ -0,0 +1,115 @@
+package webfram
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/bondowe/webfram/internal/bind"
+)
+
+// CSVError is returned by BindCSV when a row of the uploaded CSV is malformed or invalid.
+type CSVError struct {
+	// Line is the line number of the row in the CSV file (the header is line 1).
+	Line int
+	// Errors are the validation errors of the row, if it is invalid.
+	Errors []ValidationError
+	// Err is the parse error, if the row is malformed.
+	Err error
+}
+
+// Error returns a description of the error including the line number.
+func (e *CSVError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("csv line %d: %v", e.Line, e.Err)
+	}
+
+	msgs := make([]string, 0, len(e.Errors))
+	for _, ve := range e.Errors {
+		msgs = append(msgs, ve.Field+" "+ve.Error)
+	}
+
+	return fmt.Sprintf("csv line %d: %s", e.Line, strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the underlying parse error.
+func (e *CSVError) Unwrap() error {
+	return e.Err
+}
+
+// BindCSV parses an uploaded CSV file and calls fn with every row bound to the provided type T and its line number.
+// The CSV is either the request body (Content-Type text/csv) or the first file of a multipart/form-data request,
+// and is read as a stream, so large files are not buffered. The request body is limited to the configured MaxUploadSize.
+// The first line is the header: columns are mapped to struct fields by the "csv" tag (or the field name),
+// case-insensitively, and unknown columns are ignored. Every row is validated according to struct tags (validate, errmsg).
+// Reading stops at the first malformed or invalid row, which is reported as a *CSVError with its line number,
+// or at the first error returned by fn, which is returned as is; rows before it have already been passed to fn.
+// Returns ErrUnsupportedMediaType for other content types, http.ErrMissingFile if a multipart request has no file,
+// ErrUploadTooLarge if the limit is exceeded, and io.EOF if the CSV is empty.
+func BindCSV[T any](r *Request, fn func(row T, lineNo int) error) error {
+	src, err := csvSource(r)
+	if err != nil {
+		return err
+	}
+
+	err = bind.CSV(src, func(row T, line int, valErrors []bind.ValidationError) error {
+		if len(valErrors) > 0 {
+			csvErr := &CSVError{Line: line}
+			for _, ve := range valErrors {
+				csvErr.Errors = append(csvErr.Errors, validationError(r, &ve))
+			}
+			reportValidationErrors(r, &ValidationErrors{Errors: csvErr.Errors})
+			return csvErr
+		}
+
+		return fn(row, line)
+	})
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return &CSVError{Line: parseErr.Line, Err: parseErr.Err}
+	}
+
+	return uploadError(err)
+}
+
+// csvSource returns the reader of the uploaded CSV file.
+func csvSource(r *Request) (io.Reader, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "text/csv" && mediaType != "multipart/form-data" {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedMediaType, mediaType)
+	}
+
+	r.Body = http.MaxBytesReader(nil, r.Body, appFromContext(r.Context()).maxUploadSize)
+	if mediaType == "text/csv" {
+		if err := decompressBody(r); err != nil {
+			return nil, err
+		}
+		return r.Body, nil
+	}
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, http.ErrMissingFile
+		}
+		if err != nil {
+			return nil, uploadError(err)
+		}
+
+		if part.FileName() != "" {
+			return part, nil
+		}
+
+		_ = part.Close()
+	}
+}
@@ -0,0 +1,111 @@
+package webfram
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type csvImportRow struct {
+	Email string `csv:"email" validate:"required,format=email"`
+	Age   int    `csv:"age"   validate:"min=18"`
+}
+
+func newCSVRequest(body string) *Request {
+	r := NewTestRequest(http.MethodPost, "/import", strings.NewReader(body))
+	r.Header.Set("Content-Type", "text/csv")
+	return r
+}
+
+func TestBindCSV(t *testing.T) {
+	r := newCSVRequest("email,age\nann@example.com,30\nbob@example.com,42\n")
+
+	var rows []csvImportRow
+	err := BindCSV(r, func(row csvImportRow, lineNo int) error {
+		if lineNo != len(rows)+2 {
+			t.Errorf("Expected line %d, got %d", len(rows)+2, lineNo)
+		}
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("BindCSV failed: %v", err)
+	}
+
+	if len(rows) != 2 || rows[1].Email != "bob@example.com" || rows[1].Age != 42 {
+		t.Errorf("Unexpected rows: %+v", rows)
+	}
+}
+
+func TestBindCSV_Multipart(t *testing.T) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	_ = mw.WriteField("dryRun", "true")
+	fw, _ := mw.CreateFormFile("file", "users.csv")
+	_, _ = fw.Write([]byte("email,age\nann@example.com,30\n"))
+	_ = mw.Close()
+
+	r := NewTestRequest(http.MethodPost, "/import", &body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+
+	count := 0
+	err := BindCSV(r, func(_ csvImportRow, _ int) error {
+		count++
+		return nil
+	})
+	if err != nil || count != 1 {
+		t.Errorf("Expected 1 row without error, got %d rows and %v", count, err)
+	}
+}
+
+func TestBindCSV_LineErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		line     int
+		parseErr bool
+	}{
+		{"validation", "email,age\nann@example.com,30\nnot-an-email,12\n", 3, false},
+		{"parse", "email,age\nann@example.com,30\nann@example.com,30,extra\n", 3, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count := 0
+			err := BindCSV(newCSVRequest(tt.body), func(_ csvImportRow, _ int) error {
+				count++
+				return nil
+			})
+
+			var csvErr *CSVError
+			if !errors.As(err, &csvErr) {
+				t.Fatalf("Expected *CSVError, got %v", err)
+			}
+			if csvErr.Line != tt.line {
+				t.Errorf("Expected line %d, got %d", tt.line, csvErr.Line)
+			}
+			if (csvErr.Err != nil) != tt.parseErr {
+				t.Errorf("Unexpected parse error: %v", csvErr.Err)
+			}
+			if !tt.parseErr && len(csvErr.Errors) != 2 {
+				t.Errorf("Expected 2 validation errors, got %v", csvErr.Errors)
+			}
+			if count != 1 {
+				t.Errorf("Expected the valid row before the error to be passed to fn, got %d rows", count)
+			}
+		})
+	}
+}
+
+func TestBindCSV_UnsupportedMediaType(t *testing.T) {
+	r := NewTestRequest(http.MethodPost, "/import", strings.NewReader("{}"))
+	r.Header.Set("Content-Type", "application/json")
+
+	err := BindCSV(r, func(_ csvImportRow, _ int) error { return nil })
+	if !errors.Is(err, ErrUnsupportedMediaType) {
+		t.Errorf("Expected ErrUnsupportedMediaType, got %v", err)
+	}
+}
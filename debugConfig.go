@@ -0,0 +1,140 @@
+package webfram
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+)
+
+type (
+	// effectiveConfig is the configuration returned by the DebugConfig endpoint.
+	// It must only hold values that are safe to disclose to operators.
+	effectiveConfig struct {
+		Templates   debugTemplatesConfig   `json:"templates"`
+		I18n        debugI18nConfig        `json:"i18n"`
+		Telemetry   debugTelemetryConfig   `json:"telemetry"`
+		OpenAPI     debugOpenAPIConfig     `json:"openAPI"`
+		Security    debugSecurityConfig    `json:"security"`
+		Middlewares debugMiddlewaresConfig `json:"middlewares"`
+		Routes      []string               `json:"routes"`
+	}
+
+	debugTemplatesConfig struct {
+		Enabled bool     `json:"enabled"`
+		Dir     string   `json:"dir,omitempty"`
+		Layouts []string `json:"layouts,omitempty"`
+	}
+
+	debugI18nConfig struct {
+		Enabled            bool     `json:"enabled"`
+		Dir                string   `json:"dir,omitempty"`
+		SupportedLanguages []string `json:"supportedLanguages,omitempty"`
+		Fallback           []string `json:"fallback,omitempty"`
+	}
+
+	debugTelemetryConfig struct {
+		Enabled bool   `json:"enabled"`
+		URLPath string `json:"urlPath,omitempty"`
+		Addr    string `json:"addr,omitempty"`
+	}
+
+	debugOpenAPIConfig struct {
+		Enabled   bool                   `json:"enabled"`
+		URLPath   string                 `json:"urlPath,omitempty"`
+		Documents []debugOpenAPIDocument `json:"documents,omitempty"`
+	}
+
+	debugOpenAPIDocument struct {
+		Name    string `json:"name"`
+		URLPath string `json:"urlPath"`
+	}
+
+	debugSecurityConfig struct {
+		Configured bool     `json:"configured"`
+		Schemes    []string `json:"schemes,omitempty"`
+	}
+
+	debugMiddlewaresConfig struct {
+		App int `json:"app"`
+		Mux int `json:"mux"`
+	}
+)
+
+func (a *App) configureDebugConfig(cfg *Config) {
+	if cfg == nil || cfg.DebugConfig == nil || !cfg.DebugConfig.Enabled {
+		return
+	}
+
+	a.debugConfig = cfg.DebugConfig
+	a.debugConfig.URLPath = getURLPathPattern(a.debugConfig.URLPath, defaultDebugConfigURLPath)
+}
+
+// setupDebugConfigEndpoint registers the DebugConfig endpoint on mux if it is enabled.
+// See setupAdminEndpoint.
+func setupDebugConfigEndpoint(mux *ServeMux) {
+	app := mux.getApp()
+	debugConfig := app.debugConfig
+	if debugConfig == nil || !debugConfig.Enabled {
+		return
+	}
+
+	setupAdminEndpoint(mux, "DebugConfig", debugConfig.URLPath, debugConfig.Security, func(w ResponseWriter, r *Request) {
+		w.Header().Set("Cache-Control", "no-store")
+		if err := w.JSON(r.Context(), app.effectiveConfig(mux)); err != nil {
+			w.Error(http.StatusInternalServerError, err.Error())
+		}
+	})
+}
+
+// effectiveConfig returns the configuration of the App and mux, without secrets.
+func (a *App) effectiveConfig(mux *ServeMux) effectiveConfig {
+	cfg := effectiveConfig{
+		Security: debugSecurityConfig{
+			Configured: a.securityConfig != nil || mux.securityConfig != nil,
+			Schemes:    slices.Sorted(maps.Keys(a.securitySchemes)),
+		},
+		Middlewares: debugMiddlewaresConfig{App: len(a.middlewares), Mux: len(mux.middlewares)},
+		Routes:      []string{},
+	}
+
+	if tmplConfig, ok := a.templates.Configuration(); ok {
+		cfg.Templates = debugTemplatesConfig{Enabled: true, Dir: a.templateDir, Layouts: tmplConfig.Layouts}
+	}
+
+	if i18nConfig, ok := a.i18nCatalogs.Configuration(); ok {
+		cfg.I18n = debugI18nConfig{Enabled: true, Dir: a.i18nMessagesDir}
+		for _, tag := range i18nConfig.SupportedLanguages {
+			cfg.I18n.SupportedLanguages = append(cfg.I18n.SupportedLanguages, tag.String())
+		}
+		for _, tag := range i18nConfig.Fallback {
+			cfg.I18n.Fallback = append(cfg.I18n.Fallback, tag.String())
+		}
+	}
+
+	if a.telemetryConfig != nil && a.telemetryConfig.Enabled {
+		cfg.Telemetry = debugTelemetryConfig{
+			Enabled: true,
+			URLPath: a.telemetryConfig.URLPath,
+			Addr:    a.telemetryConfig.Addr,
+		}
+	}
+
+	if a.openAPIConfig != nil && a.openAPIConfig.Enabled {
+		cfg.OpenAPI = debugOpenAPIConfig{Enabled: true, URLPath: a.openAPIConfig.URLPath}
+	}
+	for _, document := range mux.openAPIDocuments {
+		cfg.OpenAPI.Documents = append(cfg.OpenAPI.Documents, debugOpenAPIDocument{
+			Name:    document.name,
+			URLPath: document.openAPI.URLPath,
+		})
+	}
+
+	for _, hc := range a.handlerConfigs {
+		if hc.mux == mux {
+			cfg.Routes = append(cfg.Routes, hc.pathPattern)
+		}
+	}
+	slices.Sort(cfg.Routes)
+
+	return cfg
+}
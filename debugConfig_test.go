@@ -0,0 +1,112 @@
+package webfram
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bondowe/webfram/security"
+)
+
+func debugConfigSecurity() *security.Config {
+	return &security.Config{
+		APIKeyAuth: &security.APIKeyAuthConfig{
+			KeyName:      "X-API-Key",
+			KeyLocation:  "header",
+			KeyValidator: func(key string) bool { return key == "ops-secret" },
+		},
+	}
+}
+
+func setupDebugConfigMux(t *testing.T, cfg *Config) *ServeMux {
+	t.Helper()
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+
+	Configure(cfg)
+
+	mux := NewServeMux()
+	mux.Use(func(next Handler) Handler { return next })
+	mux.HandleFunc("GET /users", func(w ResponseWriter, _ *Request) { w.NoContent() })
+
+	setupDebugConfigEndpoint(mux)
+	registerHandlers(mux)
+
+	return mux
+}
+
+func TestDebugConfig_ReturnsEffectiveConfig(t *testing.T) {
+	mux := setupDebugConfigMux(t, &Config{
+		Telemetry:   &Telemetry{Enabled: true, Addr: ":9090"},
+		DebugConfig: &DebugConfig{Enabled: true, Security: debugConfigSecurity()},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/debug/config", http.NoBody)
+	req.Header.Set("X-API-Key", "ops-secret")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if strings.Contains(rec.Body.String(), "ops-secret") {
+		t.Error("Expected the response not to disclose secrets")
+	}
+
+	var cfg effectiveConfig
+	if err := json.Unmarshal(rec.Body.Bytes(), &cfg); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if !cfg.Telemetry.Enabled || cfg.Telemetry.URLPath != "GET /metrics" || cfg.Telemetry.Addr != ":9090" {
+		t.Errorf("Unexpected telemetry config %+v", cfg.Telemetry)
+	}
+	if cfg.OpenAPI.Enabled {
+		t.Error("Expected OpenAPI to be disabled")
+	}
+	if cfg.Middlewares.Mux != 1 {
+		t.Errorf("Expected 1 mux middleware, got %d", cfg.Middlewares.Mux)
+	}
+	if !slices.Contains(cfg.Routes, "GET /users") {
+		t.Errorf("Expected routes to contain 'GET /users', got %v", cfg.Routes)
+	}
+}
+
+func TestDebugConfig_RequiresAuthentication(t *testing.T) {
+	mux := setupDebugConfigMux(t, &Config{
+		DebugConfig: &DebugConfig{Enabled: true, URLPath: "/ops/config", Security: debugConfigSecurity()},
+	})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ops/config", http.NoBody))
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401, got %d", rec.Code)
+	}
+}
+
+func TestDebugConfig_PanicsWithoutSecurity(t *testing.T) {
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, ErrEndpointUnprotected) {
+			t.Errorf("Expected ErrEndpointUnprotected panic, got %v", err)
+		}
+	}()
+
+	setupDebugConfigMux(t, &Config{DebugConfig: &DebugConfig{Enabled: true}})
+}
+
+func TestDebugConfig_DisabledByDefault(t *testing.T) {
+	mux := setupDebugConfigMux(t, nil)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/config", http.NoBody))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", rec.Code)
+	}
+}
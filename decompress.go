@@ -0,0 +1,62 @@
+package webfram
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// decompressedBody closes both the decompressing reader and the original request body.
+type decompressedBody struct {
+	io.Reader
+
+	decompressor io.Closer
+	body         io.Closer
+}
+
+func (b *decompressedBody) Close() error {
+	_ = b.decompressor.Close()
+	return b.body.Close()
+}
+
+// decompressBody replaces the body of a request sent with Content-Encoding gzip or deflate with
+// a decompressing reader limited to MaxDecompressedBodySize, if DecompressRequests is enabled.
+// The Content-Encoding header is removed so the body is only decompressed once.
+// Returns an error wrapping ErrUnsupportedMediaType for other encodings.
+func decompressBody(r *Request) error {
+	app := appFromContext(r.Context())
+	encoding := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding")))
+
+	if !app.decompressRequests || encoding == "" || encoding == "identity" {
+		return nil
+	}
+
+	var decompressor io.ReadCloser
+	var err error
+
+	switch encoding {
+	case "gzip", "x-gzip":
+		decompressor, err = gzip.NewReader(r.Body)
+	case "deflate":
+		decompressor, err = zlib.NewReader(r.Body)
+	default:
+		return fmt.Errorf("%w: content encoding %q", ErrUnsupportedMediaType, encoding)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s request body: %w", encoding, err)
+	}
+
+	r.Body = http.MaxBytesReader(nil, &decompressedBody{
+		Reader:       decompressor,
+		decompressor: decompressor,
+		body:         r.Body,
+	}, app.maxDecompressedBodySize)
+	r.Header.Del("Content-Encoding")
+	r.Header.Del("Content-Length")
+	r.ContentLength = -1
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package webfram
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type decompressPayload struct {
+	Name string `json:"name" validate:"required"`
+}
+
+func compressedJSONRequest(t *testing.T, encoding, body string) *Request {
+	t.Helper()
+
+	var buf bytes.Buffer
+	var zw io.WriteCloser
+	switch encoding {
+	case "gzip":
+		zw = gzip.NewWriter(&buf)
+	case "deflate":
+		zw = zlib.NewWriter(&buf)
+	default:
+		t.Fatalf("unsupported test encoding %q", encoding)
+	}
+	_, _ = zw.Write([]byte(body))
+	_ = zw.Close()
+
+	r := NewTestRequest(http.MethodPost, "/", &buf)
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Content-Encoding", encoding)
+	return r
+}
+
+func TestBindJSON_DecompressRequests(t *testing.T) {
+	resetAppConfig()
+	Configure(&Config{DecompressRequests: true})
+
+	for _, encoding := range []string{"gzip", "deflate"} {
+		t.Run(encoding, func(t *testing.T) {
+			r := compressedJSONRequest(t, encoding, `{"name":"gopher"}`)
+
+			payload, valErrors, err := BindJSON[decompressPayload](r, true)
+			if err != nil {
+				t.Fatalf("BindJSON failed: %v", err)
+			}
+			if valErrors.Any() {
+				t.Errorf("Unexpected validation errors: %v", valErrors.Errors)
+			}
+			if payload.Name != "gopher" {
+				t.Errorf("Expected name 'gopher', got %q", payload.Name)
+			}
+			if r.Header.Get("Content-Encoding") != "" {
+				t.Error("Expected Content-Encoding header to be removed")
+			}
+		})
+	}
+}
+
+func TestBindJSON_DecompressRequests_Disabled(t *testing.T) {
+	resetAppConfig()
+	Configure(nil)
+
+	r := compressedJSONRequest(t, "gzip", `{"name":"gopher"}`)
+	if _, _, err := BindJSON[decompressPayload](r, true); err == nil {
+		t.Error("Expected gzipped body to be rejected when decompression is disabled")
+	}
+}
+
+func TestBindJSON_DecompressRequests_SizeLimit(t *testing.T) {
+	resetAppConfig()
+	Configure(&Config{DecompressRequests: true, MaxDecompressedBodySize: 64})
+
+	r := compressedJSONRequest(t, "gzip", `{"name":"`+strings.Repeat("a", 1024)+`"}`)
+	_, _, err := BindJSON[decompressPayload](r, true)
+
+	var maxBytesErr *http.MaxBytesError
+	if !errors.As(err, &maxBytesErr) {
+		t.Fatalf("Expected *http.MaxBytesError, got %v", err)
+	}
+	if status, _ := bindErrorResponse(err); status != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status 413, got %d", status)
+	}
+}
+
+func TestBindJSON_DecompressRequests_UnsupportedEncoding(t *testing.T) {
+	resetAppConfig()
+	Configure(&Config{DecompressRequests: true})
+
+	r := NewTestRequest(http.MethodPost, "/", strings.NewReader(`{"name":"gopher"}`))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Content-Encoding", "br")
+
+	if _, _, err := BindJSON[decompressPayload](r, true); !errors.Is(err, ErrUnsupportedMediaType) {
+		t.Errorf("Expected ErrUnsupportedMediaType, got %v", err)
+	}
+}
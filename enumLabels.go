@@ -0,0 +1,89 @@
+package webfram
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/language"
+
+	"github.com/bondowe/webfram/internal/bind"
+	"github.com/bondowe/webfram/internal/i18n"
+)
+
+// enumLabelSet holds the labels of the values of an enum, per language.
+type enumLabelSet struct {
+	tags    []language.Tag
+	matcher language.Matcher
+	labels  map[language.Tag]map[string]string
+}
+
+//nolint:gochecknoglobals // Registry of enum labels shared by all Apps
+var enumLabels sync.Map // enum name -> *enumLabelSet
+
+// RegisterEnumLabels registers localized labels for the values of an enum. They replace the raw values in the
+// "must be one of" messages of enum validation errors returned by the binders, in the language of the request
+// (see Request.Language), e.g. "must be one of: Administrator, User" instead of "must be one of: admin, user".
+// name is the name of the field type if it is a defined type (e.g., "Role" for a field of type Role), otherwise
+// the field name. Values without a label are listed as is, and messages overridden with errmsg are kept.
+// Labels are meant to be registered at startup; registering a name again replaces its labels.
+func RegisterEnumLabels(name string, labels map[language.Tag]map[string]string) {
+	tags := slices.SortedFunc(maps.Keys(labels), func(a, b language.Tag) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	enumLabels.Store(name, &enumLabelSet{
+		tags:    tags,
+		matcher: language.NewMatcher(tags),
+		labels:  maps.Clone(labels),
+	})
+}
+
+// enumValueLabels returns the labels of the values of an enum in the language best matching lang, if any.
+func enumValueLabels(name string, lang language.Tag) map[string]string {
+	value, ok := enumLabels.Load(name)
+	if !ok {
+		return nil
+	}
+
+	set, ok := value.(*enumLabelSet)
+	if !ok || len(set.tags) == 0 {
+		return nil
+	}
+
+	_, index, confidence := set.matcher.Match(lang)
+	if confidence == language.No {
+		return nil
+	}
+
+	return set.labels[set.tags[index]]
+}
+
+// validationError converts a binding validation error. The default messages of enum violations and invalid
+// durations are translated with the request's printer; the former lists the labels registered for the enum values.
+func validationError(r *Request, ve *bind.ValidationError) ValidationError {
+	if ve.Enum == "" {
+		msg := ve.Error
+		if printer, ok := i18n.PrinterFromContext(r.Context()); ok && msg == bind.MsgDuration {
+			msg = printer.Sprintf(bind.MsgDuration)
+		}
+		return ValidationError{Field: ve.Field, Error: msg}
+	}
+
+	labels := enumValueLabels(ve.Enum, r.Language())
+	values := make([]string, len(ve.Allowed))
+	for i, value := range ve.Allowed {
+		values[i] = cmp.Or(labels[value], value)
+	}
+	list := strings.Join(values, ", ")
+
+	msg := fmt.Sprintf(bind.MsgEnum, list)
+	if printer, ok := i18n.PrinterFromContext(r.Context()); ok {
+		msg = printer.Sprintf(bind.MsgEnum, list)
+	}
+
+	return ValidationError{Field: ve.Field, Error: msg}
+}
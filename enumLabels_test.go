@@ -0,0 +1,85 @@
+package webfram
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	"github.com/bondowe/webfram/internal/i18n"
+)
+
+type (
+	enumRole string
+
+	enumLabelsUser struct {
+		Role   enumRole `json:"role"   validate:"enum=admin|user|guest"`
+		Status string   `json:"status" validate:"enum=active|inactive"`
+		Plan   string   `json:"plan"   validate:"enum=free|pro"               errmsg:"enum=Unknown plan"`
+	}
+)
+
+func bindEnumLabelsUser(t *testing.T, lang language.Tag, body string) map[string]string {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(i18n.ContextWithLanguage(req.Context(), lang))
+
+	_, valErrs, err := BindJSON[enumLabelsUser](&Request{req}, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	messages := make(map[string]string)
+	for _, ve := range valErrs.Errors {
+		messages[ve.Field] = ve.Error
+	}
+	return messages
+}
+
+func TestRegisterEnumLabels_LocalizesEnumMessages(t *testing.T) {
+	RegisterEnumLabels("enumRole", map[language.Tag]map[string]string{
+		language.English: {"admin": "Administrator", "user": "User", "guest": "Guest"},
+		language.French:  {"admin": "Administrateur", "user": "Utilisateur"},
+	})
+	RegisterEnumLabels("Plan", map[language.Tag]map[string]string{
+		language.English: {"free": "Free", "pro": "Professional"},
+	})
+	t.Cleanup(func() {
+		enumLabels.Delete("enumRole")
+		enumLabels.Delete("Plan")
+	})
+
+	body := `{"role":"root","status":"gone","plan":"gold"}`
+
+	messages := bindEnumLabelsUser(t, language.MustParse("fr-CA"), body)
+	if got, want := messages["role"], "must be one of: Administrateur, Utilisateur, guest"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if got, want := messages["status"], "must be one of: active, inactive"; got != want {
+		t.Errorf("Expected raw values without labels, got %q", got)
+	}
+	if got, want := messages["plan"], "Unknown plan"; got != want {
+		t.Errorf("Expected the errmsg override %q, got %q", want, got)
+	}
+
+	messages = bindEnumLabelsUser(t, language.English, body)
+	if got, want := messages["role"], "must be one of: Administrator, User, Guest"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestRegisterEnumLabels_NoMatchingLanguage(t *testing.T) {
+	RegisterEnumLabels("enumRole", map[language.Tag]map[string]string{
+		language.French: {"admin": "Administrateur"},
+	})
+	t.Cleanup(func() { enumLabels.Delete("enumRole") })
+
+	messages := bindEnumLabelsUser(t, language.Japanese, `{"role":"root","status":"active","plan":"free"}`)
+	if got, want := messages["role"], "must be one of: admin, user, guest"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,102 @@
+package webfram
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Group registers handlers on a ServeMux under a common path prefix, sharing middlewares and
+// default OpenAPI tags.
+type Group struct {
+	mux         *ServeMux
+	prefix      string
+	middlewares []interface{}
+	tags        []string
+}
+
+// Group creates a route group whose patterns are prefixed with the given path (e.g., "/api/users").
+// Panics if prefix does not start with "/".
+func (m *ServeMux) Group(prefix string) *Group {
+	if !strings.HasPrefix(prefix, "/") {
+		panic(`group prefix must start with "/"`)
+	}
+
+	return &Group{
+		mux:    m,
+		prefix: strings.TrimSuffix(prefix, "/"),
+	}
+}
+
+// Use registers middlewares to be applied to all handlers registered on this group.
+// They run after the ServeMux middlewares and before handler-specific middlewares.
+// Accepts either AppMiddleware (func(Handler) Handler) or StandardMiddleware (func(http.Handler) http.Handler).
+// Unsupported middleware type would cause a panic.
+func (g *Group) Use(mdwrs ...interface{}) *Group {
+	g.middlewares = append(g.middlewares, mdwrs...)
+	return g
+}
+
+// OpenAPITags sets the OpenAPI tags applied to the operations of this group.
+// Operations that define their own Tags keep them.
+func (g *Group) OpenAPITags(tags ...string) *Group {
+	g.tags = tags
+	return g
+}
+
+// Handle registers a handler for the given pattern, relative to the group prefix.
+// The pattern can include HTTP method prefix (e.g., "GET /{id}").
+// Returns a HandlerConfig that can be used to further configure the handler.
+func (g *Group) Handle(pattern string, handler Handler) *HandlerConfig {
+	hc := g.mux.Handle(g.pattern(pattern), handler)
+	hc.group = g
+
+	return hc
+}
+
+// HandleFunc registers a handler function for the given pattern, relative to the group prefix.
+// Convenience method that wraps a HandlerFunc and calls Handle.
+func (g *Group) HandleFunc(pattern string, handler HandlerFunc) *HandlerConfig {
+	return g.Handle(pattern, handler)
+}
+
+// pattern prepends the group prefix to the path of the pattern, keeping the method if present.
+func (g *Group) pattern(pattern string) string {
+	method, path, hasMethod := strings.Cut(pattern, " ")
+	if !hasMethod {
+		return g.prefix + pattern
+	}
+
+	return method + " " + g.prefix + strings.TrimLeft(path, " \t")
+}
+
+// openAPIOperation returns the OpenAPI operation of the handler, with the group tags applied
+// when the operation defines none, the request body restricted to the media types it accepts,
+// and the sunset date of the handler.
+func (h *HandlerConfig) openAPIOperation() *OperationConfig {
+	if h.operation == nil {
+		return nil
+	}
+
+	op := *h.operation
+
+	if h.group != nil && len(op.Tags) == 0 && len(h.group.tags) > 0 {
+		op.Tags = slices.Clone(h.group.tags)
+	}
+
+	if len(h.accepts) > 0 {
+		op.RequestBody = acceptedRequestBody(op.RequestBody, h.accepts)
+	}
+
+	if !h.sunset.IsZero() {
+		op.Deprecated = true
+		op.Extensions = maps.Clone(op.Extensions)
+		if op.Extensions == nil {
+			op.Extensions = make(map[string]any)
+		}
+		op.Extensions["x-sunset"] = h.sunset.UTC().Format(time.RFC3339)
+	}
+
+	return &op
+}
@@ -0,0 +1,120 @@
+package webfram
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestGroup_PrefixAndMiddleware(t *testing.T) {
+	resetAppConfig()
+
+	mux := NewServeMux()
+	mux.Use(func(next Handler) Handler {
+		return HandlerFunc(func(w ResponseWriter, r *Request) {
+			w.Header().Add("X-Order", "mux")
+			next.ServeHTTP(w, r)
+		})
+	})
+
+	users := mux.Group("/api/users/")
+	users.Use(func(next Handler) Handler {
+		return HandlerFunc(func(w ResponseWriter, r *Request) {
+			w.Header().Add("X-Order", "group")
+			next.ServeHTTP(w, r)
+		})
+	})
+	users.HandleFunc("GET /{id}", func(w ResponseWriter, r *Request) {
+		w.Header().Add("X-Order", "handler")
+		_, _ = w.Write([]byte(r.PathValue("id")))
+	})
+	registerHandlers(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/users/42", http.NoBody))
+
+	if rec.Body.String() != "42" {
+		t.Errorf("Expected body '42', got %q", rec.Body.String())
+	}
+
+	if got := rec.Header().Values("X-Order"); !slices.Equal(got, []string{"mux", "group", "handler"}) {
+		t.Errorf("Unexpected middleware order: %v", got)
+	}
+}
+
+func TestGroup_Pattern(t *testing.T) {
+	g := (&ServeMux{}).Group("/api")
+
+	tests := map[string]string{
+		"GET /users":  "GET /api/users",
+		"POST  /{$}":  "POST /api/{$}",
+		"/health":     "/api/health",
+		"DELETE /x/y": "DELETE /api/x/y",
+	}
+
+	for pattern, expected := range tests {
+		if got := g.pattern(pattern); got != expected {
+			t.Errorf("pattern(%q) = %q, want %q", pattern, got, expected)
+		}
+	}
+}
+
+func TestGroup_InvalidPrefix(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for prefix without leading slash")
+		}
+	}()
+
+	(&ServeMux{}).Group("api")
+}
+
+func TestGroup_OpenAPITags(t *testing.T) {
+	resetAppConfig()
+	Configure(&Config{
+		OpenAPI: &OpenAPI{
+			Enabled: true,
+			URLPath: "GET /openapi.json",
+			Config: &OpenAPIConfig{
+				Info: &Info{Title: "Test API", Version: "1.0.0"},
+			},
+		},
+	})
+
+	mux := NewServeMux()
+	users := mux.Group("/users").OpenAPITags("User Service")
+	users.HandleFunc("GET /active", func(_ ResponseWriter, _ *Request) {}).OpenAPIOperation(OperationConfig{
+		Summary:   "List active users",
+		Responses: map[string]Response{"200": {Description: "OK"}},
+	})
+	users.HandleFunc("DELETE /{id}", func(_ ResponseWriter, _ *Request) {}).OpenAPIOperation(OperationConfig{
+		Summary:   "Delete user",
+		Tags:      []string{"Admin"},
+		Responses: map[string]Response{"204": {Description: "No Content"}},
+	})
+
+	setupOpenAPIEndpoints(mux)
+	registerHandlers(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", http.NoBody))
+
+	var doc struct {
+		Paths map[string]map[string]struct {
+			Tags []string `json:"tags"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("Failed to parse OpenAPI document: %v", err)
+	}
+
+	if got := doc.Paths["/users/active"]["get"].Tags; !slices.Equal(got, []string{"User Service"}) {
+		t.Errorf("Expected inherited group tags, got %v", got)
+	}
+
+	if got := doc.Paths["/users/{id}"]["delete"].Tags; !slices.Equal(got, []string{"Admin"}) {
+		t.Errorf("Expected operation tags to override group tags, got %v", got)
+	}
+}
@@ -0,0 +1,97 @@
+package webfram
+
+import (
+	"errors"
+	"net/http"
+)
+
+type (
+	// HandlerFuncE is a function that serves HTTP requests and returns an error instead of writing error
+	// responses itself. A returned error is reported to Config.OnError and rendered as an error response:
+	// an *HTTPError sets the status code and message, and any other error results in a 500 Internal Server
+	// Error whose message does not disclose the error. The response is an RFC 9457 problem details object,
+	// a JSON object of the form {"error": "message"} or plain text, depending on the Accept header.
+	// Nothing is written if the handler already wrote a status code.
+	HandlerFuncE func(ResponseWriter, *Request) error
+
+	// HTTPError is an error with an HTTP status code, returned by HandlerFuncE handlers to choose the error response.
+	HTTPError struct {
+		// StatusCode is the HTTP status code of the response.
+		StatusCode int
+		// Message is sent to the client. Defaults to the standard status text.
+		Message string
+		// Err is the underlying error, reported to Config.OnError but not sent to the client.
+		Err error
+	}
+)
+
+// ServeHTTP implements the Handler interface, allowing HandlerFuncE to be used as a Handler.
+func (hf HandlerFuncE) ServeHTTP(w ResponseWriter, r *Request) {
+	HandlerFunc(func(w ResponseWriter, r *Request) {
+		if err := hf(w, r); err != nil {
+			w.handlerError(r, err)
+		}
+	}).ServeHTTP(w, r)
+}
+
+// HandleFuncE registers a handler function returning an error for the given pattern.
+// See HandlerFuncE for how returned errors are rendered.
+// Returns a HandlerConfig that can be used to further configure the handler.
+func (m *ServeMux) HandleFuncE(pattern string, handler HandlerFuncE) *HandlerConfig {
+	return m.Handle(pattern, handler)
+}
+
+// Error returns the message, followed by the underlying error if any.
+func (e *HTTPError) Error() string {
+	msg := e.message()
+	if e.Err != nil {
+		return msg + ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error.
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+func (e *HTTPError) message() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return http.StatusText(e.StatusCode)
+}
+
+// handlerError reports an error returned by a HandlerFuncE and writes the error response.
+func (w *ResponseWriter) handlerError(r *Request, err error) {
+	statusCode := http.StatusInternalServerError
+	message := http.StatusText(statusCode)
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode != 0 {
+		statusCode = httpErr.StatusCode
+		message = httpErr.message()
+	}
+
+	if onError := appFromContext(r.Context()).onError; onError != nil {
+		onError(r, statusCode, err)
+	}
+
+	if _, written := w.StatusCode(); written {
+		return
+	}
+
+	w.Vary("Accept")
+	switch r.NegotiateContentType("application/problem+json", "application/json", "text/plain") {
+	case "application/problem+json":
+		problem := ProblemDetails{Status: statusCode}
+		if message != http.StatusText(statusCode) {
+			problem.Detail = message
+		}
+		w.Problem(problem)
+	case "application/json":
+		w.ErrorJSON(statusCode, message)
+	default:
+		http.Error(w.ResponseWriter, message, statusCode)
+	}
+}
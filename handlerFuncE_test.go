@@ -0,0 +1,131 @@
+package webfram
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func setupHandlerFuncEMux(t *testing.T, reported *[]reportedError, handler HandlerFuncE) *ServeMux {
+	t.Helper()
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+	Configure(&Config{
+		OnError: func(r *Request, statusCode int, err error) {
+			*reported = append(*reported, reportedError{r, statusCode, err})
+		},
+	})
+
+	mux := NewServeMux()
+	mux.HandleFuncE("GET /test", handler)
+	registerHandlers(mux)
+
+	return mux
+}
+
+func TestHandleFuncE_NoError(t *testing.T) {
+	var reported []reportedError
+	mux := setupHandlerFuncEMux(t, &reported, func(w ResponseWriter, _ *Request) error {
+		_, err := w.Write([]byte("ok"))
+		return err
+	})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", http.NoBody))
+
+	if rec.Code != http.StatusOK || rec.Body.String() != "ok" {
+		t.Errorf("Expected 200 ok, got %d %q", rec.Code, rec.Body.String())
+	}
+	if len(reported) != 0 {
+		t.Errorf("Expected no reported errors, got %v", reported)
+	}
+}
+
+func TestHandleFuncE_Error(t *testing.T) {
+	errDB := errors.New("connection refused")
+
+	tests := []struct {
+		name        string
+		err         error
+		accept      string
+		status      int
+		contentType string
+		body        string
+	}{
+		{"internal error as problem", errDB, "", http.StatusInternalServerError,
+			"application/problem+json", `{"title":"Internal Server Error","status":500}`},
+		{"internal error as json", errDB, "application/json", http.StatusInternalServerError,
+			"application/json", `{"error":"Internal Server Error"}`},
+		{"internal error as text", errDB, "text/html, text/plain;q=0.5", http.StatusInternalServerError,
+			"text/plain; charset=utf-8", "Internal Server Error"},
+		{"http error as problem", &HTTPError{StatusCode: http.StatusNotFound, Message: "user not found"},
+			"application/problem+json", http.StatusNotFound,
+			"application/problem+json", `{"title":"Not Found","status":404,"detail":"user not found"}`},
+		{"wrapped http error as json", &HTTPError{StatusCode: http.StatusConflict, Err: errDB},
+			"application/json", http.StatusConflict, "application/json", `{"error":"Conflict"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reported []reportedError
+			mux := setupHandlerFuncEMux(t, &reported, func(_ ResponseWriter, _ *Request) error {
+				return tt.err
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %d, got %d", tt.status, rec.Code)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != tt.contentType {
+				t.Errorf("Expected Content-Type %q, got %q", tt.contentType, ct)
+			}
+			if body := strings.TrimSpace(rec.Body.String()); body != tt.body {
+				t.Errorf("Expected body %q, got %q", tt.body, body)
+			}
+			if strings.Contains(rec.Body.String(), errDB.Error()) {
+				t.Error("Expected the underlying error not to be disclosed")
+			}
+
+			if len(reported) != 1 || reported[0].statusCode != tt.status || !errors.Is(reported[0].err, tt.err) {
+				t.Errorf("Expected the error to be reported with status %d, got %v", tt.status, reported)
+			}
+		})
+	}
+}
+
+func TestHandleFuncE_ErrorAfterWrite(t *testing.T) {
+	var reported []reportedError
+	mux := setupHandlerFuncEMux(t, &reported, func(w ResponseWriter, _ *Request) error {
+		w.WriteHeader(http.StatusAccepted)
+		return errors.New("stream interrupted")
+	})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", http.NoBody))
+
+	if rec.Code != http.StatusAccepted || rec.Body.Len() != 0 {
+		t.Errorf("Expected the written response to be kept, got %d %q", rec.Code, rec.Body.String())
+	}
+	if len(reported) != 1 {
+		t.Errorf("Expected the error to be reported, got %v", reported)
+	}
+}
+
+func TestHTTPError_Error(t *testing.T) {
+	if msg := (&HTTPError{StatusCode: http.StatusNotFound}).Error(); msg != "Not Found" {
+		t.Errorf("Expected status text as message, got %q", msg)
+	}
+
+	err := &HTTPError{StatusCode: http.StatusBadGateway, Message: "upstream failed", Err: errors.New("timeout")}
+	if err.Error() != "upstream failed: timeout" {
+		t.Errorf("Unexpected error message %q", err.Error())
+	}
+}
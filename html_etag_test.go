@@ -0,0 +1,85 @@
+package webfram
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+func TestHTMLWithETag(t *testing.T) {
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+
+	Configure(&Config{
+		Assets: &Assets{
+			FS: fstest.MapFS{
+				"templates/layout.go.html": {Data: []byte(`<main>{{template "content" .}}</main>`)},
+				"templates/about.go.html":  {Data: []byte(`{{define "content"}}About {{.}}{{end}}`)},
+			},
+			Templates: &Templates{Dir: "templates"},
+		},
+	})
+
+	mux := NewServeMux()
+	mux.HandleFunc("GET /about", func(w ResponseWriter, r *Request) {
+		if err := w.HTMLWithETag(r, "about", r.URL.Query().Get("name")); err != nil {
+			w.Error(http.StatusInternalServerError, err.Error())
+		}
+	})
+	mux.HandleFunc("GET /missing", func(w ResponseWriter, r *Request) {
+		if err := w.HTMLWithETag(r, "missing", nil); err == nil {
+			t.Error("Expected error for missing template")
+		}
+	})
+	registerHandlers(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/about?name=us", http.NoBody))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	if rec.Body.String() != "<main>About us</main>" {
+		t.Errorf("Unexpected body: %q", rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/html" {
+		t.Errorf("Expected Content-Type 'text/html', got %q", ct)
+	}
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("Expected ETag header")
+	}
+
+	tests := []struct {
+		name     string
+		target   string
+		expected int
+	}{
+		{"same page", "/about?name=us", http.StatusNotModified},
+		{"different page", "/about?name=them", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, http.NoBody)
+			req.Header.Set("If-None-Match", etag)
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Errorf("Expected status %d, got %d", tt.expected, rec.Code)
+			}
+			if tt.expected == http.StatusNotModified && rec.Body.Len() != 0 {
+				t.Errorf("Expected empty body for 304, got %q", rec.Body.String())
+			}
+		})
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/missing", http.NoBody))
+	if rec.Header().Get("ETag") != "" {
+		t.Error("Expected no ETag when rendering fails")
+	}
+}
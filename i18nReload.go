@@ -0,0 +1,46 @@
+package webfram
+
+import "net/http"
+
+// ReloadI18n re-reads the i18n message files from the configured Assets.I18nMessages directory and atomically
+// replaces the message catalogs used by GetI18nPrinter and I18nMiddleware. Requests being served keep the
+// messages they started with. It is safe to call while serving requests, e.g. from a SIGHUP handler.
+// Files embedded with embed.FS never change: use an os.DirFS to pick up updated translations.
+// Returns ErrI18nNotConfigured if no message files are configured, and the error of the first message file
+// that cannot be read or parsed, in which case the current messages are kept.
+func ReloadI18n() error {
+	return defaultApp.ReloadI18n()
+}
+
+// ReloadI18n re-reads the i18n message files of the app, like the package-level ReloadI18n does for the
+// default App.
+func (a *App) ReloadI18n() error {
+	return a.i18nCatalogs.Reload()
+}
+
+func (a *App) configureI18nReload(reload *I18nReload) {
+	if reload == nil || !reload.Enabled {
+		return
+	}
+
+	a.i18nReload = reload
+	a.i18nReload.URLPath = getMethodURLPathPattern(http.MethodPost, a.i18nReload.URLPath, defaultI18nReloadURLPath)
+}
+
+// setupI18nReloadEndpoint registers the I18nReload endpoint on mux if it is enabled.
+// See setupAdminEndpoint.
+func setupI18nReloadEndpoint(mux *ServeMux) {
+	app := mux.getApp()
+	reload := app.i18nReload
+	if reload == nil || !reload.Enabled {
+		return
+	}
+
+	setupAdminEndpoint(mux, "I18nReload", reload.URLPath, reload.Security, func(w ResponseWriter, _ *Request) {
+		if err := app.ReloadI18n(); err != nil {
+			w.Error(http.StatusInternalServerError, err.Error())
+			return
+		}
+		w.NoContent()
+	})
+}
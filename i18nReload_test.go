@@ -0,0 +1,154 @@
+package webfram
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func writeI18nReloadMessages(t *testing.T, dir, translation string) {
+	t.Helper()
+
+	data := `{"language":"fr","messages":[{"id":"Hello","message":"Hello","translation":"` + translation + `"}]}`
+	if err := os.WriteFile(filepath.Join(dir, "locales", "messages.fr.json"), []byte(data), 0o600); err != nil {
+		t.Fatalf("Failed to write message file: %v", err)
+	}
+}
+
+func setupI18nReloadMux(t *testing.T, reload *I18nReload) (*ServeMux, string) {
+	t.Helper()
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "locales"), 0o700); err != nil {
+		t.Fatalf("Failed to create locales dir: %v", err)
+	}
+	writeI18nReloadMessages(t, dir, "Bonjour")
+
+	Configure(&Config{
+		Assets: &Assets{
+			FS: os.DirFS(dir),
+			I18nMessages: &I18nMessages{
+				Dir:                "locales",
+				SupportedLanguages: []string{"fr"},
+				Reload:             reload,
+			},
+		},
+	})
+
+	mux := NewServeMux()
+	setupI18nReloadEndpoint(mux)
+	registerHandlers(mux)
+
+	return mux, dir
+}
+
+func TestReloadI18n(t *testing.T) {
+	_, dir := setupI18nReloadMux(t, nil)
+
+	if got := GetI18nPrinter(language.French).Sprintf("Hello"); got != "Bonjour" {
+		t.Fatalf("Expected Bonjour, got %q", got)
+	}
+
+	writeI18nReloadMessages(t, dir, "Salut")
+	if err := ReloadI18n(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := GetI18nPrinter(language.French).Sprintf("Hello"); got != "Salut" {
+		t.Errorf("Expected the reloaded translation Salut, got %q", got)
+	}
+}
+
+func TestReloadI18n_NotConfigured(t *testing.T) {
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+	Configure(nil)
+
+	if err := ReloadI18n(); !errors.Is(err, ErrI18nNotConfigured) {
+		t.Errorf("Expected ErrI18nNotConfigured, got %v", err)
+	}
+}
+
+func TestI18nReload_Endpoint(t *testing.T) {
+	mux, dir := setupI18nReloadMux(t, &I18nReload{Enabled: true, Security: debugConfigSecurity()})
+
+	writeI18nReloadMessages(t, dir, "Salut")
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/i18n/reload", http.NoBody))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("Expected status 401 without credentials, got %d", rec.Code)
+	}
+	if got := GetI18nPrinter(language.French).Sprintf("Hello"); got != "Bonjour" {
+		t.Errorf("Expected the messages not to be reloaded, got %q", got)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/i18n/reload", http.NoBody)
+	req.Header.Set("X-API-Key", "ops-secret")
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if got := GetI18nPrinter(language.French).Sprintf("Hello"); got != "Salut" {
+		t.Errorf("Expected the reloaded translation Salut, got %q", got)
+	}
+
+	writeI18nReloadMessages(t, dir, `"broken`)
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500 for a malformed message file, got %d", rec.Code)
+	}
+	if got := GetI18nPrinter(language.French).Sprintf("Hello"); got != "Salut" {
+		t.Errorf("Expected a failed reload to keep the current translations, got %q", got)
+	}
+}
+
+func TestI18nReload_PanicsWithoutSecurity(t *testing.T) {
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, ErrEndpointUnprotected) {
+			t.Errorf("Expected ErrEndpointUnprotected panic, got %v", err)
+		}
+	}()
+
+	setupI18nReloadMux(t, &I18nReload{Enabled: true})
+}
+
+func TestI18nReload_DisabledByDefault(t *testing.T) {
+	mux, _ := setupI18nReloadMux(t, nil)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/i18n/reload", http.NoBody))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", rec.Code)
+	}
+}
+
+func TestI18nReload_URLPath(t *testing.T) {
+	tests := []struct {
+		urlPath string
+		want    string
+	}{
+		{"", "POST /i18n/reload"},
+		{"/ops/i18n", "POST /ops/i18n"},
+		{"POST /ops/i18n", "POST /ops/i18n"},
+	}
+
+	for _, tt := range tests {
+		app := &App{}
+		app.configureI18nReload(&I18nReload{Enabled: true, URLPath: tt.urlPath})
+
+		if app.i18nReload.URLPath != tt.want {
+			t.Errorf("URLPath %q: expected pattern %q, got %q", tt.urlPath, tt.want, app.i18nReload.URLPath)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package webfram
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// idempotencyKeyHeader is the request header holding the idempotency key.
+	idempotencyKeyHeader = "Idempotency-Key"
+	// idempotentReplayedHeader is set on replayed responses.
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	// idempotencyMaxBody is the largest response body stored for replay.
+	idempotencyMaxBody = 1 << 20
+	// defaultIdempotencyTTL is how long the in-memory store keeps keys.
+	defaultIdempotencyTTL = 24 * time.Hour
+	// idempotencySweepInterval is the number of keys the in-memory store records between removals of
+	// expired keys; expired keys are otherwise only replaced when they are looked up.
+	idempotencySweepInterval = 1024
+)
+
+type (
+	// IdempotencyStore stores the responses of requests by idempotency key for the Idempotency middleware.
+	// Implementations must be safe for concurrent use; a shared store, such as Redis, is needed when several
+	// instances of the application serve the same clients.
+	IdempotencyStore interface {
+		// Begin records that the request with key and the SHA-256 hash of its body is being processed.
+		// If key is already known, Begin returns its record unchanged and the request is not processed;
+		// the Response of the record is nil while the first request is in flight. Begin returns a nil
+		// record when key is new, which the store must then record.
+		Begin(ctx context.Context, key, bodyHash string) (*IdempotencyRecord, error)
+		// Complete stores the response of the request with key, to be replayed on duplicates.
+		Complete(ctx context.Context, key string, resp *IdempotentResponse) error
+		// Release forgets key when its response is not stored, so the request can be retried.
+		Release(ctx context.Context, key string) error
+	}
+
+	// IdempotencyRecord is the state of an idempotency key in an IdempotencyStore.
+	IdempotencyRecord struct {
+		// BodyHash is the hex-encoded SHA-256 hash of the body of the first request with the key.
+		BodyHash string
+		// Response is the stored response, or nil while the first request is in flight.
+		Response *IdempotentResponse
+	}
+
+	// IdempotentResponse is a response stored for replay by the Idempotency middleware.
+	IdempotentResponse struct {
+		StatusCode int
+		Header     http.Header
+		Body       []byte
+	}
+
+	// memoryIdempotencyStore is an IdempotencyStore keeping keys in memory until they expire.
+	memoryIdempotencyStore struct {
+		ttl     time.Duration
+		now     func() time.Time
+		mu      sync.Mutex
+		records map[string]*memoryIdempotencyRecord
+		inserts int
+	}
+
+	memoryIdempotencyRecord struct {
+		IdempotencyRecord
+
+		expires time.Time
+	}
+)
+
+// Idempotency creates middleware making requests with an Idempotency-Key header safe to retry, e.g. for
+// payment endpoints. The response of the first request with a key is stored in store and replayed, with an
+// Idempotent-Replayed: true header, to later requests with the same key and body instead of running the
+// handler again. A request reusing a key with a different body, or while the first request is still in
+// flight, is rejected with 409 Conflict. Keys are scoped to the method and path of the request, and to its
+// Authorization header so that a client cannot replay the response of another; with other credentials, such
+// as session cookies, keys must be unique per client, e.g. random UUIDs.
+// Only requests with one of methods are handled; defaults to POST and PATCH. Requests without the header
+// are passed through. The request body is limited to the configured MaxUploadSize to be hashed.
+// Responses with a 5xx status code, flushed or larger than 1 MiB, and panicking handlers, are not stored,
+// and their key is released so that the request can be retried.
+func Idempotency(store IdempotencyStore, methods ...string) AppMiddleware {
+	if len(methods) == 0 {
+		methods = []string{http.MethodPost, http.MethodPatch}
+	}
+
+	return func(next Handler) Handler {
+		return HandlerFunc(func(w ResponseWriter, r *Request) {
+			idempotencyKey := r.Header.Get(idempotencyKeyHeader)
+			if idempotencyKey == "" || !slices.Contains(methods, r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			bodyHash, err := hashRequestBody(r)
+			if err != nil {
+				w.BindError(err)
+				return
+			}
+
+			key := r.Method + " " + r.URL.Path + "\n" + credentialsHash(r) + "\n" + idempotencyKey
+
+			record, err := store.Begin(r.Context(), key, bodyHash)
+			switch {
+			case err != nil:
+				w.Error(http.StatusInternalServerError, err.Error())
+			case record == nil:
+				serveIdempotent(store, key, next, w, r)
+			case record.BodyHash != bodyHash:
+				w.Error(http.StatusConflict, "Idempotency-Key is already used with a different request body")
+			case record.Response == nil:
+				w.Error(http.StatusConflict, "a request with this Idempotency-Key is being processed")
+			default:
+				maps.Copy(w.Header(), record.Response.Header.Clone())
+				w.Header().Set(idempotentReplayedHeader, "true")
+				w.WriteHeader(record.Response.StatusCode)
+				_, _ = w.Write(record.Response.Body)
+			}
+		})
+	}
+}
+
+// NewMemoryIdempotencyStore returns an IdempotencyStore keeping keys in memory for ttl, which defaults
+// to 24 hours. Keys are not shared between instances of the application and are lost on restart.
+func NewMemoryIdempotencyStore(ttl time.Duration) IdempotencyStore {
+	if ttl <= 0 {
+		ttl = defaultIdempotencyTTL
+	}
+
+	return &memoryIdempotencyStore{
+		ttl:     ttl,
+		now:     time.Now,
+		records: make(map[string]*memoryIdempotencyRecord),
+	}
+}
+
+// hashRequestBody returns the hex-encoded SHA-256 hash of the request body, which is restored for the handler.
+func hashRequestBody(r *Request) (string, error) {
+	if err := decompressBody(r); err != nil {
+		return "", err
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, appFromContext(r.Context()).maxUploadSize))
+	if err != nil {
+		return "", err
+	}
+	_ = r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// credentialsHash returns the hex-encoded SHA-256 hash of the Authorization header of the request, or an
+// empty string without it, so that stores do not keep the credentials.
+func credentialsHash(r *Request) string {
+	authorization := r.Header.Get("Authorization")
+	if authorization == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(authorization))
+	return hex.EncodeToString(sum[:])
+}
+
+// serveIdempotent runs the handler of the first request with key and stores its response.
+func serveIdempotent(store IdempotencyStore, key string, next Handler, w ResponseWriter, r *Request) {
+	rec := &teeRecorder{ResponseWriter: w.ResponseWriter, maxBody: idempotencyMaxBody}
+	completed := false
+
+	defer func() {
+		// The request context may be canceled once the response is written.
+		ctx := context.WithoutCancel(r.Context())
+
+		status, header := rec.recorded()
+		if !completed || rec.streamed || status >= http.StatusInternalServerError {
+			_ = store.Release(ctx, key)
+			return
+		}
+
+		resp := &IdempotentResponse{StatusCode: status, Header: header, Body: bytes.Clone(rec.body.Bytes())}
+		if err := store.Complete(ctx, key, resp); err != nil {
+			_ = store.Release(ctx, key)
+		}
+	}()
+
+	next.ServeHTTP(ResponseWriter{ResponseWriter: rec, statusCode: w.statusCode, request: w.request}, r)
+	completed = true
+}
+
+func (s *memoryIdempotencyStore) Begin(_ context.Context, key, bodyHash string) (*IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if record, ok := s.records[key]; ok && now.Before(record.expires) {
+		return &IdempotencyRecord{BodyHash: record.BodyHash, Response: record.Response}, nil
+	}
+
+	s.inserts++
+	if s.inserts%idempotencySweepInterval == 0 {
+		maps.DeleteFunc(s.records, func(_ string, record *memoryIdempotencyRecord) bool {
+			return !now.Before(record.expires)
+		})
+	}
+
+	s.records[key] = &memoryIdempotencyRecord{
+		IdempotencyRecord: IdempotencyRecord{BodyHash: bodyHash},
+		expires:           now.Add(s.ttl),
+	}
+	return nil, nil //nolint:nilnil // nil record means the key is new
+}
+
+func (s *memoryIdempotencyStore) Complete(_ context.Context, key string, resp *IdempotentResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.records[key]; ok {
+		record.Response = resp
+	}
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
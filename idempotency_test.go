@@ -0,0 +1,181 @@
+package webfram
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newIdempotentRequest(key, body string) *Request {
+	r := NewTestRequest(http.MethodPost, "/payments", strings.NewReader(body))
+	if key != "" {
+		r.Header.Set("Idempotency-Key", key)
+	}
+	return r
+}
+
+func serveTestRequest(handler Handler, r *Request) *httptest.ResponseRecorder {
+	w, rec := NewTestResponseWriter()
+	handler.ServeHTTP(w, r)
+	return rec
+}
+
+func TestIdempotency_ReplaysResponse(t *testing.T) {
+	var calls atomic.Int32
+	handler := Idempotency(NewMemoryIdempotencyStore(0))(HandlerFunc(func(w ResponseWriter, r *Request) {
+		calls.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Location", "/payments/1")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(body)
+	}))
+
+	first := serveTestRequest(handler, newIdempotentRequest("abc", `{"amount":10}`))
+	second := serveTestRequest(handler, newIdempotentRequest("abc", `{"amount":10}`))
+
+	if calls.Load() != 1 {
+		t.Errorf("Expected handler to run once, ran %d times", calls.Load())
+	}
+	if first.Body.String() != `{"amount":10}` {
+		t.Errorf("Expected the handler to read the body, got %q", first.Body.String())
+	}
+	if second.Code != http.StatusCreated || second.Body.String() != first.Body.String() ||
+		second.Header().Get("Location") != "/payments/1" {
+		t.Errorf("Expected replayed response, got %d %q %v", second.Code, second.Body.String(), second.Header())
+	}
+	if second.Header().Get("Idempotent-Replayed") != "true" || first.Header().Get("Idempotent-Replayed") != "" {
+		t.Errorf("Expected only the replayed response to be marked")
+	}
+}
+
+func TestIdempotency_Conflicts(t *testing.T) {
+	release := make(chan struct{})
+	handler := Idempotency(NewMemoryIdempotencyStore(0))(HandlerFunc(func(w ResponseWriter, _ *Request) {
+		<-release
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		serveTestRequest(handler, newIdempotentRequest("abc", `{"amount":10}`))
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	if rec := serveTestRequest(handler, newIdempotentRequest("abc", `{"amount":10}`)); rec.Code != http.StatusConflict {
+		t.Errorf("Expected 409 while the first request is in flight, got %d", rec.Code)
+	}
+
+	close(release)
+	<-done
+
+	if rec := serveTestRequest(handler, newIdempotentRequest("abc", `{"amount":99}`)); rec.Code != http.StatusConflict {
+		t.Errorf("Expected 409 for a different body, got %d", rec.Code)
+	}
+}
+
+func TestIdempotency_PassesThrough(t *testing.T) {
+	var calls atomic.Int32
+	handler := Idempotency(NewMemoryIdempotencyStore(0))(HandlerFunc(func(w ResponseWriter, _ *Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serveTestRequest(handler, newIdempotentRequest("", `{}`))
+	serveTestRequest(handler, newIdempotentRequest("", `{}`))
+
+	get := NewTestRequest(http.MethodGet, "/payments", nil)
+	get.Header.Set("Idempotency-Key", "abc")
+	serveTestRequest(handler, get)
+	serveTestRequest(handler, get)
+
+	if calls.Load() != 4 {
+		t.Errorf("Expected requests without a key or with other methods to run the handler, ran %d times", calls.Load())
+	}
+}
+
+func TestIdempotency_ReleasesFailedRequests(t *testing.T) {
+	var calls atomic.Int32
+	handler := Idempotency(NewMemoryIdempotencyStore(0))(HandlerFunc(func(w ResponseWriter, _ *Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	serveTestRequest(handler, newIdempotentRequest("abc", `{}`))
+	if rec := serveTestRequest(handler, newIdempotentRequest("abc", `{}`)); rec.Code != http.StatusCreated {
+		t.Errorf("Expected the retry of a 5xx response to run the handler, got %d", rec.Code)
+	}
+}
+
+func TestMemoryIdempotencyStore_Expires(t *testing.T) {
+	store := NewMemoryIdempotencyStore(time.Minute).(*memoryIdempotencyStore)
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	if record, _ := store.Begin(t.Context(), "k", "h"); record != nil {
+		t.Fatalf("Expected a new key, got %+v", record)
+	}
+	if record, _ := store.Begin(t.Context(), "k", "h"); record == nil {
+		t.Fatal("Expected the key to be recorded")
+	}
+
+	now = now.Add(time.Minute)
+	if record, _ := store.Begin(t.Context(), "k", "h"); record != nil {
+		t.Errorf("Expected the key to expire, got %+v", record)
+	}
+}
+
+func TestMemoryIdempotencyStore_SweepsExpiredKeys(t *testing.T) {
+	store := NewMemoryIdempotencyStore(time.Minute).(*memoryIdempotencyStore)
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	_, _ = store.Begin(t.Context(), "expired", "h")
+	now = now.Add(time.Minute)
+
+	for i := range idempotencySweepInterval - 2 {
+		_, _ = store.Begin(t.Context(), strconv.Itoa(i), "h")
+	}
+	if _, ok := store.records["expired"]; !ok {
+		t.Fatal("Expected expired keys to be kept until the next sweep")
+	}
+
+	_, _ = store.Begin(t.Context(), "last", "h")
+	if _, ok := store.records["expired"]; ok {
+		t.Error("Expected expired keys to be removed by the sweep")
+	}
+	if len(store.records) != idempotencySweepInterval-1 {
+		t.Errorf("Expected %d keys, got %d", idempotencySweepInterval-1, len(store.records))
+	}
+}
+
+func TestIdempotency_ScopesKeysToCredentials(t *testing.T) {
+	var calls atomic.Int32
+	handler := Idempotency(NewMemoryIdempotencyStore(0))(HandlerFunc(func(w ResponseWriter, r *Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+
+	alice := newIdempotentRequest("abc", `{"amount":10}`)
+	alice.Header.Set("Authorization", "Bearer alice")
+	bob := newIdempotentRequest("abc", `{"amount":10}`)
+	bob.Header.Set("Authorization", "Bearer bob")
+
+	serveTestRequest(handler, alice)
+	rec := serveTestRequest(handler, bob)
+
+	if calls.Load() != 2 {
+		t.Errorf("Expected the handler to run for each caller, ran %d times", calls.Load())
+	}
+	if rec.Body.String() != "Bearer bob" || rec.Header().Get("Idempotent-Replayed") != "" {
+		t.Errorf("Expected the response of another caller not to be replayed, got %q", rec.Body.String())
+	}
+}
@@ -0,0 +1,26 @@
+package bind
+
+import (
+	"context"
+	"io"
+)
+
+// contextReader wraps an io.Reader and aborts reading once its context is done.
+// This stops decoding a large request body after the client has gone away.
+type contextReader struct {
+	ctx context.Context //nolint:containedctx // the reader is scoped to a single request
+	r   io.Reader
+}
+
+// newContextReader returns a reader that fails with the context's error once ctx is done.
+func newContextReader(ctx context.Context, r io.Reader) io.Reader {
+	return &contextReader{ctx: ctx, r: r}
+}
+
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return cr.r.Read(p)
+}
@@ -0,0 +1,133 @@
+package bind
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// CSV reads CSV records from r and binds each row to a struct of type T, streaming the input.
+// The first record is the header: columns are mapped to fields by the "csv" tag (or the field name),
+// case-insensitively. Unknown columns are ignored, and fields tagged "-" or without a column keep their zero value.
+// Every row is validated according to the struct tags (validate, errmsg); validation errors use the csv tag as field name.
+// fn is called for every row with the bound value, the line number of the row and its validation errors.
+// Returning an error from fn stops reading and the error is returned.
+// Returns io.EOF if r is empty, and a *csv.ParseError for malformed CSV.
+func CSV[T any](r io.Reader, fn func(row T, line int, errs []ValidationError) error) error {
+	reader := csv.NewReader(r)
+
+	header, err := reader.Read()
+	if err != nil {
+		return err
+	}
+
+	typ := reflect.TypeFor[T]()
+	columns := mapCSVColumns(typ, header)
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		var row T
+		val := reflect.ValueOf(&row).Elem()
+		errs := bindCSVRecord(val, record, columns)
+
+		var valErrors []ValidationError
+		bindValidateRecursive(val, "", &valErrors)
+		renameCSVValidationErrors(typ, valErrors)
+		errs = append(errs, valErrors...)
+
+		if err := fn(row, line, errs); err != nil {
+			return err
+		}
+	}
+}
+
+// mapCSVColumns returns the index of the field of typ bound to each header column, or -1 if there is none.
+func mapCSVColumns(typ reflect.Type, header []string) []int {
+	columns := make([]int, len(header))
+
+	for i, h := range header {
+		columns[i] = -1
+		name := strings.TrimSpace(h)
+
+		for j := range typ.NumField() {
+			fieldType := typ.Field(j)
+			tag := csvFieldName(&fieldType)
+			if fieldType.IsExported() && tag != "-" && strings.EqualFold(tag, name) {
+				columns[i] = j
+				break
+			}
+		}
+	}
+
+	return columns
+}
+
+func csvFieldName(field *reflect.StructField) string {
+	if tag := field.Tag.Get("csv"); tag != "" {
+		return tag
+	}
+	return field.Name
+}
+
+// bindCSVRecord sets the fields of val from the record, after applying the transform tags.
+// Empty cells leave the field unset.
+func bindCSVRecord(val reflect.Value, record []string, columns []int) []ValidationError {
+	var errs []ValidationError
+
+	for i, fieldIndex := range columns {
+		if fieldIndex < 0 {
+			continue
+		}
+
+		cell := transformString(record[i], val.Type().Field(fieldIndex).Tag.Get("transform"))
+		if cell == "" {
+			continue
+		}
+
+		field := val.Field(fieldIndex)
+		converted, err := convertStringToType(cell, field.Type())
+		if err != nil {
+			fieldType := val.Type().Field(fieldIndex)
+			errs = append(errs, ValidationError{Field: csvFieldName(&fieldType), Error: "has an invalid value"})
+			continue
+		}
+
+		if field.Kind() == reflect.Ptr {
+			ptr := reflect.New(field.Type().Elem())
+			ptr.Elem().Set(converted)
+			field.Set(ptr)
+			continue
+		}
+		field.Set(converted)
+	}
+
+	return errs
+}
+
+// renameCSVValidationErrors replaces the field names set by bindValidateRecursive with the csv tags.
+func renameCSVValidationErrors(typ reflect.Type, errs []ValidationError) {
+	for i := range typ.NumField() {
+		fieldType := typ.Field(i)
+		key := fieldType.Tag.Get("json")
+		if key == "" {
+			key = fieldType.Name
+		}
+
+		for j := range errs {
+			if errs[j].Field == key {
+				errs[j].Field = csvFieldName(&fieldType)
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package bind
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+type csvProduct struct {
+	SKU   string   `csv:"sku"   validate:"required"`
+	Name  string   `csv:"Name"`
+	Price float64  `csv:"price" validate:"min=0"`
+	Stock *int     `csv:"stock"`
+	Tags  []string `csv:"tags"`
+	Notes string   `csv:"-"`
+}
+
+func TestCSV_BindsRows(t *testing.T) {
+	data := "SKU,name,price,stock,tags,notes,extra\n" +
+		"A-1,Widget,9.5,3,\"red,blue\",ignored,x\n" +
+		"A-2,Gadget,0,,,,\n"
+
+	var rows []csvProduct
+	var lines []int
+	err := CSV(strings.NewReader(data), func(row csvProduct, line int, errs []ValidationError) error {
+		if len(errs) != 0 {
+			t.Errorf("unexpected validation errors on line %d: %v", line, errs)
+		}
+		rows = append(rows, row)
+		lines = append(lines, line)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(rows) != 2 || lines[0] != 2 || lines[1] != 3 {
+		t.Fatalf("expected rows on lines 2 and 3, got %d rows on lines %v", len(rows), lines)
+	}
+	first := rows[0]
+	if first.SKU != "A-1" || first.Name != "Widget" || first.Price != 9.5 || first.Stock == nil || *first.Stock != 3 {
+		t.Errorf("unexpected first row: %+v", first)
+	}
+	if len(first.Tags) != 2 || first.Tags[1] != "blue" {
+		t.Errorf("unexpected tags: %v", first.Tags)
+	}
+	if first.Notes != "" {
+		t.Errorf("expected field tagged - to be skipped, got %q", first.Notes)
+	}
+	if rows[1].Stock != nil {
+		t.Errorf("expected empty cell to leave pointer nil, got %v", *rows[1].Stock)
+	}
+}
+
+func TestCSV_ValidationErrors(t *testing.T) {
+	data := "sku,price,stock\n,-1,many\n"
+
+	var errs []ValidationError
+	err := CSV(strings.NewReader(data), func(_ csvProduct, _ int, rowErrs []ValidationError) error {
+		errs = rowErrs
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fields := map[string]bool{}
+	for _, e := range errs {
+		fields[e.Field] = true
+	}
+	for _, field := range []string{"sku", "price", "stock"} {
+		if !fields[field] {
+			t.Errorf("expected validation error for %s, got %v", field, errs)
+		}
+	}
+}
+
+func TestCSV_Errors(t *testing.T) {
+	noop := func(csvProduct, int, []ValidationError) error { return nil }
+
+	if err := CSV(strings.NewReader(""), noop); !errors.Is(err, io.EOF) {
+		t.Errorf("expected io.EOF for empty input, got %v", err)
+	}
+
+	var parseErr *csv.ParseError
+	err := CSV(strings.NewReader("sku,price\nA-1,1\nA-2\n"), noop)
+	if !errors.As(err, &parseErr) || parseErr.Line != 3 {
+		t.Errorf("expected parse error on line 3, got %v", err)
+	}
+
+	errStop := errors.New("stop")
+	calls := 0
+	err = CSV(strings.NewReader("sku\nA-1\nA-2\n"), func(csvProduct, int, []ValidationError) error {
+		calls++
+		return errStop
+	})
+	if !errors.Is(err, errStop) || calls != 1 {
+		t.Errorf("expected callback error to stop reading after 1 row, got %v after %d calls", err, calls)
+	}
+}
@@ -0,0 +1,135 @@
+package bind
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Messages describing JSON decoding errors. They are format strings whose only argument is the
+// JSON path of the offending field, and are used as i18n message IDs.
+const (
+	MsgInvalidJSON     = "request body is not valid JSON"
+	MsgUnknownField    = "unknown field %s"
+	MsgExpectedNumber  = "%s must be a number"
+	MsgExpectedString  = "%s must be a string"
+	MsgExpectedBoolean = "%s must be a boolean"
+	MsgExpectedArray   = "%s must be an array"
+	MsgExpectedObject  = "%s must be an object"
+	MsgInvalidNumber   = "%s is not a valid number for this field"
+	MsgInvalidValue    = "%s has an invalid value"
+)
+
+const (
+	bodyFieldPath      = "body"
+	unknownFieldPrefix = "json: unknown field "
+	numberValuePrefix  = "number"
+)
+
+// DecodeMessages lists the messages returned by JSONDecodeError, so they can be added to message catalogs.
+//
+//nolint:gochecknoglobals // Read-only list of message IDs
+var DecodeMessages = []string{
+	MsgInvalidJSON,
+	MsgUnknownField,
+	MsgExpectedNumber,
+	MsgExpectedString,
+	MsgExpectedBoolean,
+	MsgExpectedArray,
+	MsgExpectedObject,
+	MsgInvalidNumber,
+	MsgInvalidValue,
+}
+
+// JSONDecodeError describes a common encoding/json decoding error as a message format and the JSON path
+// of the field it applies to ("" if it applies to the whole body). The message is one of DecodeMessages.
+// Returns false if err is not a syntax error, type mismatch or unknown field.
+func JSONDecodeError(err error) (string, string, bool) {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return MsgInvalidJSON, "", true
+	case errors.As(err, &typeErr):
+		field := typeErr.Field
+		if field == "" {
+			field = bodyFieldPath
+		}
+		return typeMismatchMessage(typeErr), field, true
+	}
+
+	if name, ok := strings.CutPrefix(err.Error(), unknownFieldPrefix); ok {
+		if unquoted, unquoteErr := strconv.Unquote(name); unquoteErr == nil {
+			name = unquoted
+		}
+		return MsgUnknownField, name, true
+	}
+
+	return "", "", false
+}
+
+// typeMismatchMessage returns the message describing the JSON type expected by the target of a type error.
+func typeMismatchMessage(typeErr *json.UnmarshalTypeError) string {
+	typ := typeErr.Type
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil {
+		return MsgInvalidValue
+	}
+
+	switch jsonKind(typ) {
+	case "number":
+		// A JSON number that doesn't fit the field, e.g. 1.5 or 300 for a uint8.
+		if strings.HasPrefix(typeErr.Value, numberValuePrefix) {
+			return MsgInvalidNumber
+		}
+		return MsgExpectedNumber
+	case "string":
+		return MsgExpectedString
+	case "boolean":
+		return MsgExpectedBoolean
+	case "array":
+		return MsgExpectedArray
+	case "object":
+		return MsgExpectedObject
+	default:
+		return MsgInvalidValue
+	}
+}
+
+// jsonKind returns the JSON type a Go type is decoded from, or "" if it has no single JSON type.
+func jsonKind(typ reflect.Type) string {
+	if typ.Implements(reflect.TypeFor[json.Unmarshaler]()) ||
+		reflect.PointerTo(typ).Implements(reflect.TypeFor[json.Unmarshaler]()) {
+		return ""
+	}
+
+	//nolint:exhaustive // Other kinds have no single JSON type
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Slice:
+		// Byte slices are decoded from base64 strings.
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return "string"
+		}
+		return "array"
+	case reflect.Array:
+		return "array"
+	case reflect.Struct, reflect.Map:
+		return "object"
+	default:
+		return ""
+	}
+}
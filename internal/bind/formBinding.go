@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"reflect"
 	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -14,8 +16,8 @@ import (
 )
 
 // Form parses form data from an HTTP request and binds it to a struct of type T.
-// It extracts values from both URL query parameters and POST form data,
-// performs type conversion, and validates the data according to struct tags.
+// It extracts values from both URL query parameters and POST form data (body values take precedence),
+// normalizes values according to transform tags, performs type conversion, and validates the data according to struct tags.
 // Returns the populated struct, validation errors (if any), and a decoding error (if parsing fails).
 func Form[T any](r *http.Request) (T, []ValidationError, error) {
 	var result T
@@ -57,7 +59,9 @@ func bindRecursive(
 			key = prefix + "." + tag
 		}
 
-		values := form[key]
+		// Normalize the submitted values before they are converted and validated
+		submitted := transformValues(form[key], fieldType.Tag.Get("transform"))
+		values := submitted
 		kind := field.Kind()
 
 		isTimeField := field.Type() == reflect.TypeOf(time.Time{})
@@ -69,6 +73,23 @@ func bindRecursive(
 			continue
 		}
 
+		if kind == reflect.Slice && isStructSliceElem(field.Type().Elem()) {
+			if err := bindStructSlice(form, field, &fieldType, key, errors); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Tri-state booleans: absent, empty or "null" values leave the field nil
+		if field.Type() == reflect.TypeFor[*bool]() {
+			var value string
+			if len(values) > 0 {
+				value = values[0]
+			}
+			field.Set(reflect.ValueOf(parseOptionalBool(value)))
+			continue
+		}
+
 		// Validate that the validation rules are applicable to this field type
 		validateFieldTypeRules(&fieldType, kind, field.Type())
 
@@ -76,6 +97,15 @@ func bindRecursive(
 			values = []string{""}
 		}
 
+		if field.Type() == reflect.TypeFor[time.Duration]() {
+			if v, err := validateDurationFieldString(&fieldType, values[0]); err != nil {
+				*errors = append(*errors, *err)
+			} else {
+				field.SetInt(int64(v))
+			}
+			continue
+		}
+
 		// Validate first value
 		if err := validateField(&fieldType, values[0], kind); err != nil {
 			*errors = append(*errors, *err)
@@ -84,7 +114,7 @@ func bindRecursive(
 		// Bind values
 		switch kind {
 		case reflect.String:
-			field.SetString(values[0])
+			field.SetString(normalizeEnumCI(fieldType.Tag.Get("validate"), values[0]))
 		case reflect.Int:
 			iv, _ := strconv.Atoi(values[0])
 			field.SetInt(int64(iv))
@@ -94,16 +124,20 @@ func bindRecursive(
 		case reflect.Bool:
 			field.SetBool(values[0] == "true")
 		case reflect.Slice:
-			if errs := validateSliceLength(&fieldType, values); errs != nil {
+			// Collect every submitted value (e.g. <select multiple>); an empty
+			// submission binds to an empty slice rather than a single "" item.
+			items := submitted
+
+			if errs := validateSliceLength(&fieldType, items); errs != nil {
 				*errors = append(*errors, *errs)
 			}
 
-			if errs := validateUniqueItems(&fieldType, values); errs != nil {
+			if errs := validateUniqueItems(&fieldType, items); errs != nil {
 				*errors = append(*errors, *errs)
 			}
 
 			// Use the shared bindSliceField function to avoid code duplication
-			if err := bindSliceField(field, fieldType, values, errors); err != nil {
+			if err := bindSliceField(field, fieldType, items, errors); err != nil {
 				return err
 			}
 		case reflect.Map:
@@ -194,16 +228,115 @@ func bindRecursive(
 	return nil
 }
 
-func validateUniqueItems(fieldType *reflect.StructField, values []string) *ValidationError {
-	if !strings.Contains(fieldType.Tag.Get("validate"), "uniqueItems") {
-		return nil
+// isStructSliceElem reports whether a slice element type is a struct bound from indexed form fields.
+func isStructSliceElem(elemType reflect.Type) bool {
+	return elemType.Kind() == reflect.Struct && elemType != reflect.TypeOf(time.Time{})
+}
+
+// bindStructSlice binds indexed form fields such as "items[0][name]" and "items[1][name]"
+// into a slice of structs. Items are ordered by index; gaps in the indices are dropped.
+func bindStructSlice(
+	form map[string][]string,
+	field reflect.Value,
+	fieldType *reflect.StructField,
+	key string,
+	errors *[]ValidationError,
+) error {
+	itemForms := make(map[int]map[string][]string)
+
+	for formKey, formValues := range form {
+		index, subKey, ok := parseIndexedFormKey(formKey, key)
+		if !ok {
+			continue
+		}
+
+		if itemForms[index] == nil {
+			itemForms[index] = make(map[string][]string)
+		}
+		itemForms[index][subKey] = formValues
+	}
+
+	indices := make([]int, 0, len(itemForms))
+	for index := range itemForms {
+		indices = append(indices, index)
 	}
-	itemMap := make(map[string]bool)
-	for _, v := range values {
-		if itemMap[v] {
-			return &ValidationError{Field: fieldType.Name, Error: "must have unique items"}
+	sort.Ints(indices)
+
+	items := reflect.MakeSlice(field.Type(), 0, len(indices))
+	for _, index := range indices {
+		item := reflect.New(field.Type().Elem()).Elem()
+		if err := bindRecursive(itemForms[index], item, "", errors); err != nil {
+			return err
 		}
-		itemMap[v] = true
+		items = reflect.Append(items, item)
+	}
+	field.Set(items)
+
+	if items.Len() == 0 && slices.Contains(strings.Split(fieldType.Tag.Get("validate"), ","), ruleRequired) {
+		msg := getErrorMessage(fieldType, ruleRequired, "is required")
+		*errors = append(*errors, ValidationError{Field: fieldType.Name, Error: msg})
+	}
+
+	if err := validateSliceLength(fieldType, items.Interface()); err != nil {
+		*errors = append(*errors, *err)
+	}
+
+	return nil
+}
+
+// parseIndexedFormKey splits a form key of the form "key[index][field]" (or "key[index].field")
+// into the item index and the field key within the item.
+func parseIndexedFormKey(formKey, key string) (int, string, bool) {
+	rest, found := strings.CutPrefix(formKey, key+"[")
+	if !found {
+		return 0, "", false
+	}
+
+	indexStr, rest, found := strings.Cut(rest, "]")
+	if !found {
+		return 0, "", false
+	}
+
+	index, err := strconv.Atoi(indexStr)
+	if err != nil || index < 0 {
+		return 0, "", false
+	}
+
+	var subKey string
+	switch {
+	case strings.HasPrefix(rest, "["):
+		name, remainder, closed := strings.Cut(rest[1:], "]")
+		if !closed {
+			return 0, "", false
+		}
+		subKey = name + remainder
+	case strings.HasPrefix(rest, "."):
+		subKey = rest[1:]
+	}
+
+	if subKey == "" {
+		return 0, "", false
+	}
+
+	return index, subKey, true
+}
+
+func validateUniqueItems(fieldType *reflect.StructField, values []string) *ValidationError {
+	for _, rule := range strings.Split(fieldType.Tag.Get("validate"), ",") {
+		rule = strings.TrimSpace(rule)
+		if !isUniqueRule(rule) {
+			continue
+		}
+
+		itemMap := make(map[string]bool)
+		for _, v := range values {
+			if itemMap[v] {
+				msg := getErrorMessage(fieldType, rule, "must have unique items")
+				return &ValidationError{Field: fieldType.Name, Error: msg}
+			}
+			itemMap[v] = true
+		}
+		return nil
 	}
 	return nil
 }
@@ -285,6 +418,26 @@ func validateTimeFieldString(
 	return v, nil
 }
 
+// validateDurationFieldString parses the value of a time.Duration field, such as "30s" or "1h30m", with
+// time.ParseDuration. An empty value is the zero duration, rejected if the field is required.
+func validateDurationFieldString(field *reflect.StructField, value string) (time.Duration, *ValidationError) {
+	if value == "" {
+		if hasValidationRule(field.Tag.Get("validate"), ruleRequired) {
+			msg := getErrorMessage(field, ruleRequired, "is required")
+			return 0, &ValidationError{Field: field.Name, Error: msg}
+		}
+		return 0, nil
+	}
+
+	v, err := time.ParseDuration(value)
+	if err != nil {
+		msg := getErrorMessage(field, "duration", MsgDuration)
+		return 0, &ValidationError{Field: field.Name, Error: msg}
+	}
+
+	return v, nil
+}
+
 func validateTimeSliceFieldString(
 	field *reflect.StructField,
 	values []string,
@@ -366,6 +519,10 @@ func validateField(field *reflect.StructField, value string, kind reflect.Kind)
 			msg := getErrorMessage(field, "required", "is required")
 			return &ValidationError{Field: field.Name, Error: msg}
 
+		case rule == ruleRequiredNotBlank && kind == reflect.String && strings.TrimSpace(value) == "":
+			msg := getErrorMessage(field, ruleRequiredNotBlank, "is required")
+			return &ValidationError{Field: field.Name, Error: msg}
+
 		case strings.HasPrefix(rule, ruleEquals+"=") && IsIntType(kind):
 			expected, _ := strconv.Atoi(strings.TrimPrefix(rule, ruleEquals+"="))
 			val, err := strconv.Atoi(value)
@@ -514,12 +671,15 @@ func validateField(field *reflect.StructField, value string, kind reflect.Kind)
 				}
 			}
 			if !found {
-				msg := getErrorMessage(
-					field,
-					"enum",
-					fmt.Sprintf("must be one of: %s", strings.Join(allowed, ", ")),
-				)
-				return &ValidationError{Field: field.Name, Error: msg}
+				verr := enumValidationError(field, ruleEnum, field.Name, allowed)
+				return &verr
+			}
+
+		case strings.HasPrefix(rule, ruleEnumCI+"=") && kind == reflect.String:
+			allowed := strings.Split(strings.TrimPrefix(rule, ruleEnumCI+"="), "|")
+			if _, ok := matchEnumFold(value, allowed); !ok {
+				verr := enumValidationError(field, ruleEnumCI, field.Name, allowed)
+				return &verr
 			}
 		}
 	}
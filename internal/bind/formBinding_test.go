@@ -3,6 +3,7 @@ package bind
 import (
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -111,6 +112,119 @@ func TestFormBinding_EqualsValidation_String(t *testing.T) {
 	}
 }
 
+func TestFormBinding_QueryAndBody(t *testing.T) {
+	type Search struct {
+		Query string   `form:"q"    validate:"required"`
+		Page  int      `form:"page"`
+		Tags  []string `form:"tag"`
+	}
+
+	// GET forms are bound from the query string.
+	r, _ := http.NewRequest(http.MethodGet, "/search?q=go&page=2&tag=web&tag=http", http.NoBody)
+	res, errs, err := Form[Search](r)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v, %v", err, errs)
+	}
+	if res.Query != "go" || res.Page != 2 || !slices.Equal(res.Tags, []string{"web", "http"}) {
+		t.Errorf("expected values from the query string, got %+v", res)
+	}
+
+	// Body values take precedence over query values; slices collect both, body values first.
+	r = newPost(url.Values{"q": {"body"}, "tag": {"form"}})
+	r.URL.RawQuery = "q=query&page=3&tag=url"
+	res, errs, err = Form[Search](r)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v, %v", err, errs)
+	}
+	if res.Query != "body" || res.Page != 3 || !slices.Equal(res.Tags, []string{"form", "url"}) {
+		t.Errorf("expected body values to take precedence over query values, got %+v", res)
+	}
+}
+
+func TestFormBinding_RequiredNotBlank(t *testing.T) {
+	type T struct {
+		Name  string `form:"name"  validate:"required_notblank"`
+		Title string `form:"title" validate:"required"`
+	}
+
+	tests := []struct {
+		name      string
+		value     string
+		expectErr bool
+	}{
+		{"missing", "", true},
+		{"spaces", "   ", true},
+		{"tabs_and_newlines", "\t\n ", true},
+		{"value", "  Ada  ", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs, err := Form[T](newPost(url.Values{"name": {tt.value}, "title": {"   "}}))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// Plain required still accepts whitespace-only values.
+			if tt.expectErr && (len(errs) != 1 || errs[0].Field != "Name") {
+				t.Errorf("expected a single error for Name, got: %v", errs)
+			}
+			if !tt.expectErr && len(errs) > 0 {
+				t.Errorf("expected no errors, got: %v", errs)
+			}
+		})
+	}
+}
+
+func TestFormBinding_EnumCIValidation(t *testing.T) {
+	type T struct {
+		Theme string `form:"theme" validate:"enum_ci=light|dark"`
+		Mode  string `form:"mode"  validate:"enum=light|dark"`
+	}
+
+	tests := []struct {
+		name      string
+		theme     string
+		expected  string
+		expectErr bool
+	}{
+		{"canonical", "dark", "dark", false},
+		{"upper_case", "DARK", "dark", false},
+		{"mixed_case", "LiGhT", "light", false},
+		{"invalid", "blue", "blue", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newPost(url.Values{"theme": {tt.theme}, "mode": {"light"}})
+
+			res, errs, err := Form[T](req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.expectErr && len(errs) == 0 {
+				t.Error("expected validation error but got none")
+			}
+			if !tt.expectErr && len(errs) > 0 {
+				t.Errorf("expected no errors, got: %v", errs)
+			}
+			if res.Theme != tt.expected {
+				t.Errorf("expected Theme %q, got %q", tt.expected, res.Theme)
+			}
+		})
+	}
+
+	// Strict enum remains case-sensitive.
+	_, errs, err := Form[T](newPost(url.Values{"theme": {"dark"}, "mode": {"Light"}}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) != 1 || errs[0].Field != "Mode" {
+		t.Errorf("expected a single enum error for Mode, got: %v", errs)
+	}
+}
+
 func TestFormBinding_EqualsValidation_Int(t *testing.T) {
 	type T struct {
 		Count int `form:"count" validate:"equals=42"`
@@ -259,6 +373,72 @@ func TestFormBinding_UUIDAndTime(t *testing.T) {
 	}
 }
 
+func TestFormBinding_Duration(t *testing.T) {
+	type T struct {
+		Timeout  time.Duration `form:"timeout" validate:"required"`
+		Interval time.Duration `form:"interval"`
+	}
+
+	tests := []struct {
+		query    string
+		timeout  time.Duration
+		expected []string
+	}{
+		{"timeout=5m", 5 * time.Minute, nil},
+		{"timeout=30s&interval=1h30m", 30 * time.Second, nil},
+		{"timeout=soon", 0, []string{"Timeout: " + MsgDuration}},
+		{"timeout=30", 0, []string{"Timeout: " + MsgDuration}},
+		{"interval=1s", 0, []string{"Timeout: is required"}},
+	}
+
+	describe := func(errs []ValidationError) []string {
+		var messages []string
+		for _, e := range errs {
+			messages = append(messages, e.Field+": "+e.Error)
+		}
+		return messages
+	}
+
+	for _, tt := range tests {
+		req, _ := http.NewRequest(http.MethodGet, "/?"+tt.query, nil)
+
+		res, errs, err := Query[T](req)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.query, err)
+		}
+		if !slices.Equal(describe(errs), tt.expected) {
+			t.Errorf("%s: expected errors %v, got %v", tt.query, tt.expected, errs)
+		}
+		if res.Timeout != tt.timeout {
+			t.Errorf("%s: expected timeout %v, got %v", tt.query, tt.timeout, res.Timeout)
+		}
+	}
+
+	res, errs, _ := Form[T](newPost(url.Values{"timeout": {"5m"}, "interval": {"30s"}}))
+	if len(errs) != 0 || res.Timeout != 5*time.Minute || res.Interval != 30*time.Second {
+		t.Errorf("expected form durations 5m and 30s, got %v, %v (%v)", res.Timeout, res.Interval, errs)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("timeout", "30s")
+	res, errs, _ = Header[T](req)
+	if len(errs) != 0 || res.Timeout != 30*time.Second {
+		t.Errorf("expected header duration 30s, got %v (%v)", res.Timeout, errs)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, "/?timeout=5m", nil)
+	res, errs, _ = Bind[T](req, true)
+	if len(errs) != 0 || res.Timeout != 5*time.Minute {
+		t.Errorf("expected bound duration 5m, got %v (%v)", res.Timeout, errs)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, "/?timeout=later", nil)
+	_, errs, _ = Bind[T](req, true)
+	if !slices.Contains(describe(errs), "Timeout: "+MsgDuration) {
+		t.Errorf("expected a duration error, got %v", errs)
+	}
+}
+
 func TestFormBinding_MapBindingAndValidation(t *testing.T) {
 	type M struct {
 		Meta map[string]int `form:"metadata" validate:"minItems=1"`
@@ -308,3 +488,150 @@ func TestFormBinding_NestedStruct(t *testing.T) {
 		t.Fatalf("nested field not bound correctly, got: %q", res.Child.Field)
 	}
 }
+
+func TestFormBinding_MultiSelect(t *testing.T) {
+	type S struct {
+		Colors []string  `form:"colors" validate:"minItems=1,maxItems=3,unique"`
+		Sizes  []int     `form:"sizes"`
+		Prices []float64 `form:"prices"`
+		Flags  []bool    `form:"flags"`
+	}
+
+	values := url.Values{
+		"colors": {"red", "blue", "green"},
+		"sizes":  {"38", "40"},
+		"prices": {"9.99", "19.5"},
+		"flags":  {"true", "0", "yes"},
+	}
+
+	res, errs, err := Form[S](newPost(values))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("unexpected validation errors: %#v", errs)
+	}
+
+	if len(res.Colors) != 3 || res.Colors[0] != "red" || res.Colors[1] != "blue" || res.Colors[2] != "green" {
+		t.Errorf("unexpected colors: %#v", res.Colors)
+	}
+	if len(res.Sizes) != 2 || res.Sizes[0] != 38 || res.Sizes[1] != 40 {
+		t.Errorf("unexpected sizes: %#v", res.Sizes)
+	}
+	if len(res.Prices) != 2 || res.Prices[0] != 9.99 || res.Prices[1] != 19.5 {
+		t.Errorf("unexpected prices: %#v", res.Prices)
+	}
+	if len(res.Flags) != 3 || !res.Flags[0] || res.Flags[1] || !res.Flags[2] {
+		t.Errorf("unexpected flags: %#v", res.Flags)
+	}
+}
+
+func TestFormBinding_MultiSelectItemsValidation(t *testing.T) {
+	type S struct {
+		Colors []string `form:"colors" validate:"minItems=1,maxItems=2,unique" errmsg:"unique=Colors must not repeat"`
+		Sizes  []int    `form:"sizes"`
+	}
+
+	tests := []struct {
+		name     string
+		values   url.Values
+		expected string
+	}{
+		{"none selected", url.Values{}, "must have at least 1 items"},
+		{"too many", url.Values{"colors": {"red", "blue", "green"}}, "must have at most 2 items"},
+		{"duplicates", url.Values{"colors": {"red", "red"}}, "Colors must not repeat"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, errs, err := Form[S](newPost(tt.values))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(errs) != 1 || errs[0].Field != "Colors" || errs[0].Error != tt.expected {
+				t.Fatalf("expected single Colors error %q, got %#v", tt.expected, errs)
+			}
+			if len(res.Sizes) != 0 {
+				t.Errorf("expected empty Sizes, got %#v", res.Sizes)
+			}
+		})
+	}
+}
+
+func TestFormBinding_IndexedStructSlice(t *testing.T) {
+	type LineItem struct {
+		Name string `form:"name" validate:"required"`
+		Qty  int    `form:"qty"  validate:"min=1"`
+	}
+	type Order struct {
+		Customer string     `form:"customer"`
+		Items    []LineItem `form:"items"    validate:"required,maxItems=5"`
+	}
+
+	values := url.Values{
+		"customer":       {"Alice"},
+		"items[0][name]": {"Widget"},
+		"items[0][qty]":  {"2"},
+		"items[1][name]": {"Gadget"},
+		"items[1][qty]":  {"5"},
+	}
+
+	res, errs, err := Form[Order](newPost(values))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("unexpected validation errors: %#v", errs)
+	}
+
+	if res.Customer != "Alice" {
+		t.Errorf("expected customer Alice, got %q", res.Customer)
+	}
+	if len(res.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d: %#v", len(res.Items), res.Items)
+	}
+	if res.Items[0] != (LineItem{Name: "Widget", Qty: 2}) {
+		t.Errorf("unexpected first item: %#v", res.Items[0])
+	}
+	if res.Items[1] != (LineItem{Name: "Gadget", Qty: 5}) {
+		t.Errorf("unexpected second item: %#v", res.Items[1])
+	}
+}
+
+func TestFormBinding_IndexedStructSlice_OrderAndValidation(t *testing.T) {
+	type LineItem struct {
+		Name string `form:"name" validate:"required"`
+		Qty  int    `form:"qty"`
+	}
+	type Order struct {
+		Items []LineItem `form:"items" validate:"required"`
+	}
+
+	values := url.Values{
+		"items[10].name": {"Last"},
+		"items[2][name]": {"First"},
+		"items[5][qty]":  {"3"},
+		"items[x][name]": {"Ignored"},
+	}
+
+	res, errs, err := Form[Order](newPost(values))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(res.Items) != 3 || res.Items[0].Name != "First" || res.Items[1].Qty != 3 || res.Items[2].Name != "Last" {
+		t.Fatalf("unexpected items: %#v", res.Items)
+	}
+
+	if len(errs) != 1 || errs[0].Field != "Name" {
+		t.Fatalf("expected single required error for the item without a name, got %#v", errs)
+	}
+
+	_, errs, err = Form[Order](newPost(url.Values{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) != 1 || errs[0].Field != "Items" || errs[0].Error != "is required" {
+		t.Fatalf("expected required error for empty items, got %#v", errs)
+	}
+}
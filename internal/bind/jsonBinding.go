@@ -1,11 +1,48 @@
 package bind
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"reflect"
 )
 
+var (
+	// ErrMissingField is returned by JSONField when the body has no value for the key.
+	ErrMissingField = errors.New("missing JSON field")
+
+	// ErrNotArray is returned by JSONArray when the body is not a JSON array.
+	ErrNotArray = errors.New("JSON body is not an array")
+)
+
+// rawJSONType is the type of deferred JSON fields, bound with their raw JSON instead of being decoded.
+//
+//nolint:gochecknoglobals // immutable reflect type
+var rawJSONType = reflect.TypeFor[json.RawMessage]()
+
+// isRawJSONEmpty reports whether a deferred JSON field has no value: absent or JSON null.
+func isRawJSONEmpty(raw json.RawMessage) bool {
+	return len(raw) == 0 || bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+}
+
+// ArrayElementError is returned by JSONArray when an element of the array cannot be decoded.
+type ArrayElementError struct {
+	Index int
+	Err   error
+}
+
+func (e *ArrayElementError) Error() string {
+	return fmt.Sprintf("element %d: %v", e.Index, e.Err)
+}
+
+func (e *ArrayElementError) Unwrap() error {
+	return e.Err
+}
+
 // ValidateJSON validates a struct according to its validation tags.
 // It recursively checks all fields and nested structs for compliance with constraints
 // such as required, min, max, pattern, format, etc.
@@ -20,25 +57,132 @@ func ValidateJSON[T any](data *T) []ValidationError {
 }
 
 // JSON parses JSON from an HTTP request body and binds it to a struct of type T.
+// String fields are normalized according to their transform tags (e.g., `transform:"trim,lower"`) after decoding.
 // If validate is true, performs validation according to struct tags after decoding.
 // Returns the populated struct, validation errors (if validation is enabled), and a decoding error (if parsing fails).
+// Reading stops with the context's error if the request context is done before the body is fully read.
 func JSON[T any](r *http.Request, validate bool) (T, []ValidationError, error) {
 	var result T
-	decoder := json.NewDecoder(r.Body)
+	decoder := json.NewDecoder(newContextReader(r.Context(), r.Body))
 	decoder.DisallowUnknownFields()
 
 	if err := decoder.Decode(&result); err != nil {
 		return result, nil, err
 	}
 
+	val := reflect.ValueOf(&result).Elem()
+	transformRecursive(val)
+
 	if !validate {
 		return result, nil, nil
 	}
 
-	val := reflect.ValueOf(&result).Elem()
 	errors := []ValidationError{}
 
 	bindValidateRecursive(val, "", &errors)
 
 	return result, errors, nil
 }
+
+// JSONField parses a JSON object from an HTTP request body and binds the value of its top-level key to a struct
+// of type T, e.g. the "data" key of {"data": {...}}. Other top-level keys are ignored.
+// If validate is true, performs validation according to struct tags after decoding; the field names of validation
+// and type errors are prefixed with the key. Returns an error wrapping ErrMissingField if the key is absent or null.
+func JSONField[T any](r *http.Request, key string, validate bool) (T, []ValidationError, error) {
+	var result T
+	var body map[string]json.RawMessage
+
+	if err := json.NewDecoder(newContextReader(r.Context(), r.Body)).Decode(&body); err != nil {
+		return result, nil, err
+	}
+
+	raw, ok := body[key]
+	if !ok || bytes.Equal(raw, []byte("null")) {
+		return result, nil, fmt.Errorf("%w %q", ErrMissingField, key)
+	}
+
+	return RawJSON[T](raw, key, validate)
+}
+
+// RawJSON binds the raw JSON of a deferred json.RawMessage field to a struct of type T, e.g. once the
+// discriminator of a polymorphic payload is known. It decodes, transforms and validates like JSON;
+// the field names of validation and type errors are prefixed with prefix, if not empty.
+func RawJSON[T any](raw json.RawMessage, prefix string, validate bool) (T, []ValidationError, error) {
+	var result T
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&result); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if prefix != "" && errors.As(err, &typeErr) {
+			typeErr.Field = joinFieldPath(prefix, typeErr.Field)
+		}
+		return result, nil, err
+	}
+
+	val := reflect.ValueOf(&result).Elem()
+	transformRecursive(val)
+
+	if !validate {
+		return result, nil, nil
+	}
+
+	errors := []ValidationError{}
+
+	bindValidateRecursive(val, prefix, &errors)
+
+	return result, errors, nil
+}
+
+// JSONArray reads a JSON array from r and binds each element to a value of type T, streaming the input:
+// elements are decoded one at a time, so the whole array is never held in memory.
+// Every element is normalized according to its transform tags and validated according to struct tags.
+// fn is called for every element with the bound value, its index and its validation errors.
+// Returning an error from fn stops reading and the error is returned.
+// Returns io.EOF if r is empty, an error wrapping ErrNotArray if the body is not an array,
+// and an *ArrayElementError for the first malformed element.
+func JSONArray[T any](ctx context.Context, r io.Reader, fn func(item T, index int, errs []ValidationError) error) error {
+	decoder := json.NewDecoder(newContextReader(ctx, r))
+	decoder.DisallowUnknownFields()
+
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("%w: got %v", ErrNotArray, token)
+	}
+
+	index := 0
+	for ; decoder.More(); index++ {
+		var item T
+		if err := decoder.Decode(&item); err != nil {
+			return &ArrayElementError{Index: index, Err: err}
+		}
+
+		val := reflect.ValueOf(&item).Elem()
+		transformRecursive(val)
+
+		errs := []ValidationError{}
+		bindValidateRecursive(val, "", &errs)
+
+		if err := fn(item, index, errs); err != nil {
+			return err
+		}
+	}
+
+	// Read the closing bracket, which reports a truncated array.
+	if _, err := decoder.Token(); err != nil {
+		return &ArrayElementError{Index: index, Err: err}
+	}
+
+	return nil
+}
+
+func joinFieldPath(prefix, field string) string {
+	if field == "" {
+		return prefix
+	}
+	return prefix + "." + field
+}
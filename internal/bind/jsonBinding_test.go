@@ -2,8 +2,13 @@ package bind
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
 )
 
@@ -94,3 +99,336 @@ func TestValidateJSON_PointerInput(t *testing.T) {
 		t.Fatalf("expected no validation errors for simple payload, got: %v", errs)
 	}
 }
+
+// cancelingReader simulates a client that disconnects mid-upload:
+// the context is canceled as soon as the first chunk has been read.
+type cancelingReader struct {
+	chunks [][]byte
+	cancel context.CancelFunc
+	reads  int
+}
+
+func (c *cancelingReader) Read(p []byte) (int, error) {
+	if c.reads >= len(c.chunks) {
+		return 0, io.EOF
+	}
+	n := copy(p, c.chunks[c.reads])
+	c.reads++
+	c.cancel()
+	return n, nil
+}
+
+func TestJSONDecode_ContextCanceledMidRead(t *testing.T) {
+	type payload struct {
+		Name string `json:"name"`
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	body := &cancelingReader{
+		chunks: [][]byte{[]byte(`{"name":`), []byte(`"Alice"}`)},
+		cancel: cancel,
+	}
+	req := httptest.NewRequestWithContext(ctx, http.MethodPost, "/", body)
+
+	_, _, err := JSON[payload](req, false)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+	if body.reads != 1 {
+		t.Fatalf("expected reading to stop after the first chunk, got %d reads", body.reads)
+	}
+}
+
+func TestJSONPointerFields_AbsentVsZero(t *testing.T) {
+	type payload struct {
+		Name  *string `json:"name" validate:"minlength=2"`
+		Age   *int    `json:"age"  validate:"min=0,max=150"`
+		Admin *bool   `json:"admin"`
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"age":0,"admin":false}`))
+
+	got, errs, err := JSON[payload](req, true)
+	if err != nil {
+		t.Fatalf("expected no error decoding JSON, got: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("expected absent and zero pointer fields to be valid, got: %v", errs)
+	}
+	if got.Name != nil {
+		t.Errorf("expected absent Name to be nil, got %q", *got.Name)
+	}
+	if got.Age == nil || *got.Age != 0 {
+		t.Errorf("expected Age to be set to 0, got %v", got.Age)
+	}
+	if got.Admin == nil || *got.Admin {
+		t.Errorf("expected Admin to be set to false, got %v", got.Admin)
+	}
+}
+
+func TestJSONPointerFields_Validation(t *testing.T) {
+	type address struct {
+		City string `json:"city" validate:"required"`
+	}
+	type payload struct {
+		Name    *string  `json:"name"    validate:"required,minlength=2"`
+		Age     *int     `json:"age"     validate:"required,max=150"`
+		Address *address `json:"address"`
+	}
+
+	tests := []struct {
+		body     string
+		expected map[string]bool
+	}{
+		{`{"address":{"city":"Paris"}}`, map[string]bool{"name": true, "age": true}},
+		{`{"name":"","age":0}`, map[string]bool{"name": true}},
+		{`{"name":"Jo","age":200,"address":{}}`, map[string]bool{"age": true, "address.city": true}},
+		{`{"name":"Jo","age":0}`, map[string]bool{}},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.body))
+
+		_, errs, err := JSON[payload](req, true)
+		if err != nil {
+			t.Fatalf("expected no error decoding %s, got: %v", tt.body, err)
+		}
+
+		fields := map[string]bool{}
+		for _, e := range errs {
+			fields[e.Field] = true
+		}
+		if len(fields) != len(tt.expected) {
+			t.Errorf("body %s: expected errors on %v, got %v", tt.body, tt.expected, errs)
+			continue
+		}
+		for field := range tt.expected {
+			if !fields[field] {
+				t.Errorf("body %s: expected error on %q, got %v", tt.body, field, errs)
+			}
+		}
+	}
+}
+
+func TestJSONRequiredNotBlank(t *testing.T) {
+	type payload struct {
+		Name     string  `json:"name"     validate:"required_notblank"`
+		Nickname *string `json:"nickname" validate:"required_notblank"`
+	}
+
+	tests := []struct {
+		body     string
+		expected []string
+	}{
+		{`{"name":"   ","nickname":"   "}`, []string{"name", "nickname"}},
+		{`{"name":""}`, []string{"name", "nickname"}},
+		{`{"name":" Ada ","nickname":"ada"}`, nil},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.body))
+		_, errs, err := JSON[payload](req, true)
+		if err != nil {
+			t.Fatalf("body %s: expected no error decoding JSON, got: %v", tt.body, err)
+		}
+
+		fields := make([]string, 0, len(errs))
+		for _, e := range errs {
+			fields = append(fields, e.Field)
+		}
+		if !slices.Equal(fields, tt.expected) {
+			t.Errorf("body %s: expected errors on %v, got %v", tt.body, tt.expected, errs)
+		}
+	}
+}
+
+func TestJSONEnumCI_NormalizesValue(t *testing.T) {
+	type payload struct {
+		Theme string `json:"theme" validate:"enum_ci=light|dark"`
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"theme":"Dark"}`))
+	got, errs, err := JSON[payload](req, true)
+	if err != nil {
+		t.Fatalf("expected no error decoding JSON, got: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("expected no validation errors, got: %v", errs)
+	}
+	if got.Theme != "dark" {
+		t.Fatalf("expected Theme to be normalized to dark, got: %s", got.Theme)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"theme":"sepia"}`))
+	_, errs, err = JSON[payload](req, true)
+	if err != nil {
+		t.Fatalf("expected no error decoding JSON, got: %v", err)
+	}
+	if len(errs) != 1 || errs[0].Error != "must be one of: light, dark" {
+		t.Fatalf("expected enum_ci validation error, got: %v", errs)
+	}
+}
+
+func TestJSONField(t *testing.T) {
+	type payload struct {
+		Name string `json:"name" validate:"required"`
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"data":{"name":"Alice"},"meta":{}}`))
+	got, errs, err := JSONField[payload](req, "data", true)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("expected no validation errors, got: %v", errs)
+	}
+	if got.Name != "Alice" {
+		t.Errorf("expected name Alice, got %q", got.Name)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"data":{}}`))
+	_, errs, _ = JSONField[payload](req, "data", true)
+	if len(errs) != 1 || errs[0].Field != "data.name" {
+		t.Errorf("expected validation error for data.name, got: %v", errs)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{}`))
+	if _, _, err = JSONField[payload](req, "data", true); !errors.Is(err, ErrMissingField) {
+		t.Errorf("expected ErrMissingField, got: %v", err)
+	}
+}
+
+func TestJSONRawMessageFields(t *testing.T) {
+	type payload struct {
+		Type     string           `json:"type"     validate:"required"`
+		Shape    json.RawMessage  `json:"shape"    validate:"required,minItems=100"`
+		Metadata *json.RawMessage `json:"metadata" validate:"maxItems=1"`
+	}
+
+	tests := []struct {
+		body     string
+		expected []string
+	}{
+		{`{"type":"circle","shape":{"radius":2},"metadata":{"tags":["a","b"]}}`, nil},
+		{`{"type":"circle"}`, []string{"shape"}},
+		{`{"type":"circle","shape":null}`, []string{"shape"}},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.body))
+		_, errs, err := JSON[payload](req, true)
+		if err != nil {
+			t.Fatalf("body %s: expected no error decoding JSON, got: %v", tt.body, err)
+		}
+
+		fields := make([]string, 0, len(errs))
+		for _, e := range errs {
+			fields = append(fields, e.Field)
+		}
+		if !slices.Equal(fields, tt.expected) {
+			t.Errorf("body %s: expected errors on %v, got %v", tt.body, tt.expected, errs)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"type":"circle","shape":{ "radius" : 2 }}`))
+	got, _, _ := JSON[payload](req, true)
+	if string(got.Shape) != `{ "radius" : 2 }` {
+		t.Errorf("expected the raw JSON of shape, got %s", got.Shape)
+	}
+}
+
+func TestRawJSON(t *testing.T) {
+	type circle struct {
+		Radius float64 `json:"radius" validate:"min=1"`
+	}
+
+	got, errs, err := RawJSON[circle](json.RawMessage(`{"radius":2}`), "shape", true)
+	if err != nil || len(errs) != 0 || got.Radius != 2 {
+		t.Fatalf("expected radius 2 without errors, got %v, %v, %v", got, errs, err)
+	}
+
+	_, errs, _ = RawJSON[circle](json.RawMessage(`{"radius":0}`), "shape", true)
+	if len(errs) != 1 || errs[0].Field != "shape.radius" {
+		t.Errorf("expected validation error for shape.radius, got: %v", errs)
+	}
+
+	_, _, err = RawJSON[circle](json.RawMessage(`{"radius":"big"}`), "shape", true)
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) || typeErr.Field != "shape.radius" {
+		t.Errorf("expected type error for shape.radius, got: %v", err)
+	}
+
+	if _, _, err = RawJSON[circle](json.RawMessage(`{"side":2}`), "", true); err == nil {
+		t.Error("expected unknown field error")
+	}
+}
+
+func TestJSONArray_StreamsElements(t *testing.T) {
+	type item struct {
+		Name string `json:"name" validate:"required"`
+	}
+
+	var names []string
+	var invalid []int
+	err := JSONArray(context.Background(), bytes.NewBufferString(`[{"name":"a"},{"name":""},{"name":"c"}]`),
+		func(it item, index int, errs []ValidationError) error {
+			if len(errs) > 0 {
+				invalid = append(invalid, index)
+			}
+			names = append(names, it.Name)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !slices.Equal(names, []string{"a", "", "c"}) || !slices.Equal(invalid, []int{1}) {
+		t.Errorf("unexpected elements %v with invalid indexes %v", names, invalid)
+	}
+}
+
+func TestJSONArray_Errors(t *testing.T) {
+	noop := func(struct{ A int }, int, []ValidationError) error { return nil }
+
+	if err := JSONArray(context.Background(), bytes.NewBufferString(`{"a":1}`), noop); !errors.Is(err, ErrNotArray) {
+		t.Errorf("expected ErrNotArray, got %v", err)
+	}
+
+	err := JSONArray(context.Background(), bytes.NewBufferString(`[{"A":1},{"A":"x"}]`), noop)
+	var elemErr *ArrayElementError
+	if !errors.As(err, &elemErr) || elemErr.Index != 1 {
+		t.Errorf("expected an *ArrayElementError for element 1, got %v", err)
+	}
+
+	err = JSONArray(context.Background(), bytes.NewBufferString(`[{"A":1}`), noop)
+	if !errors.As(err, &elemErr) || elemErr.Index != 1 {
+		t.Errorf("expected an *ArrayElementError for a truncated array, got %v", err)
+	}
+}
+
+func TestJSONArray_NonStructElements(t *testing.T) {
+	var names []string
+	err := JSONArray(context.Background(), bytes.NewBufferString(`["a","b"]`),
+		func(name string, _ int, errs []ValidationError) error {
+			if len(errs) > 0 {
+				t.Errorf("unexpected validation errors for %q: %v", name, errs)
+			}
+			names = append(names, name)
+			return nil
+		})
+	if err != nil || !slices.Equal(names, []string{"a", "b"}) {
+		t.Errorf("unexpected elements %v, %v", names, err)
+	}
+
+	var objects []map[string]any
+	err = JSONArray(context.Background(), bytes.NewBufferString(`[{"a":1},{}]`),
+		func(object map[string]any, _ int, _ []ValidationError) error {
+			objects = append(objects, object)
+			return nil
+		})
+	if err != nil || len(objects) != 2 || objects[0]["a"] != float64(1) {
+		t.Errorf("unexpected elements %v, %v", objects, err)
+	}
+}
@@ -211,6 +211,10 @@ func bindFieldFromSource(
 		return nil
 	}
 
+	transform := fieldType.Tag.Get("transform")
+	value = transformString(value, transform)
+	values = transformValues(values, transform)
+
 	// Handle slice types
 	if kind == reflect.Slice && !isTimeField {
 		if len(values) == 0 {
@@ -349,8 +353,25 @@ func bindBasicType(
 			}
 		}
 	case reflect.Bool:
-		field.SetBool(value == "true" || value == "1" || value == "yes")
+		field.SetBool(ParseBool(value))
+	}
+}
+
+// ParseBool reports whether value is a truthy string ("true", "1" or "yes").
+// Any other value, including the empty string, is false.
+func ParseBool(value string) bool {
+	return value == "true" || value == "1" || value == "yes"
+}
+
+// parseOptionalBool parses a tri-state boolean for *bool fields: nil if value is empty or "null",
+// otherwise whether it is truthy according to ParseBool.
+func parseOptionalBool(value string) *bool {
+	if value == "" || value == "null" {
+		return nil
 	}
+
+	b := ParseBool(value)
+	return &b
 }
 
 // bindSingleValueWithoutValidation binds a single string value to a field without validation.
@@ -390,6 +411,18 @@ func bindSingleValueWithoutValidation(
 		return nil
 	}
 
+	if field.Type() == reflect.TypeFor[time.Duration]() {
+		if value != "" {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				*errors = append(*errors, ValidationError{Field: fieldType.Name, Error: MsgDuration})
+			} else {
+				field.SetInt(int64(d))
+			}
+		}
+		return nil
+	}
+
 	if isUUIDField {
 		if value != "" {
 			u, err := uuid.Parse(value)
@@ -559,6 +592,22 @@ func bindSingleValue(
 	isTimeField := field.Type() == reflect.TypeOf(time.Time{})
 	isUUIDField := field.Type() == reflect.TypeOf(uuid.UUID{})
 
+	value = transformString(value, fieldType.Tag.Get("transform"))
+
+	if field.Type() == reflect.TypeFor[*bool]() {
+		field.Set(reflect.ValueOf(parseOptionalBool(value)))
+		return
+	}
+
+	if field.Type() == reflect.TypeFor[time.Duration]() {
+		if v, err := validateDurationFieldString(&fieldType, value); err != nil {
+			*errors = append(*errors, *err)
+		} else {
+			field.SetInt(int64(v))
+		}
+		return
+	}
+
 	// Validate first
 	if err := validateField(&fieldType, value, kind); err != nil {
 		*errors = append(*errors, *err)
@@ -717,6 +766,13 @@ func bindSliceField(
 			floatSlice = append(floatSlice, fv)
 		}
 		field.Set(reflect.ValueOf(floatSlice))
+
+	case reflect.Bool:
+		boolSlice := make([]bool, 0, len(values))
+		for _, v := range values {
+			boolSlice = append(boolSlice, ParseBool(v))
+		}
+		field.Set(reflect.ValueOf(boolSlice))
 	}
 
 	return nil
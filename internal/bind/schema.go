@@ -1,11 +1,14 @@
 package bind
 
 import (
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bondowe/webfram/openapi"
@@ -15,9 +18,75 @@ import (
 const dateTimeFormat = "date-time"
 const xmlNodeTypeElement = "element"
 const xmlNodeTypeAttribute = "attribute"
+const xmlSchemaNameSuffix = ".XML"
+
+// schemaKey identifies the component schema generated for a struct type.
+type schemaKey struct {
+	typ reflect.Type
+	xml bool
+}
+
+//nolint:gochecknoglobals // Lock of the component name registries and compiled pattern
+var (
+	// schemaNamesMu guards the component name registries of all Components.
+	schemaNamesMu sync.Mutex
+	// invalidComponentNameChars matches characters not allowed in OpenAPI component names.
+	invalidComponentNameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+)
+
+// schemaComponentName returns the component name for the struct type, reusing the name assigned
+// when the type was first generated so that shared types are only defined once.
+// Names default to the type name qualified by its package name (e.g., "main.User", or "main.User.XML"
+// for XML schemas). If that name is already used by a different type, the full import path is used
+// instead, so that same-named types from different packages never overwrite each other.
+func schemaComponentName(typ reflect.Type, isXML bool, components *openapi.Components) string {
+	schemaNamesMu.Lock()
+	defer schemaNamesMu.Unlock()
+
+	// The names are registered on the components, so they are released with them.
+	names := components.ComponentNames()
+
+	key := schemaKey{typ: typ, xml: isXML}
+	if name, found := names[key]; found {
+		return name
+	}
+
+	suffix := ""
+	if isXML {
+		suffix = xmlSchemaNameSuffix
+	}
+
+	taken := make(map[string]bool, len(names))
+	for _, name := range names {
+		taken[name] = true
+	}
+
+	name := sanitizeComponentName(typ.String()) + suffix
+	if taken[name] && typ.PkgPath() != "" {
+		name = sanitizeComponentName(typ.PkgPath()+"."+typ.Name()) + suffix
+	}
+	for i, base := 2, name; taken[name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+
+	names[key] = name
+	return name
+}
+
+// sanitizeComponentName replaces characters not allowed in OpenAPI component names
+// (such as "/" in import paths or brackets in generic type names) with underscores.
+func sanitizeComponentName(name string) string {
+	return invalidComponentNameChars.ReplaceAllString(name, "_")
+}
 
 // generateMockData creates mock data for the given type for use in examples.
 func generateMockData(typ reflect.Type) any {
+	return generateMockValue(typ, map[reflect.Type]bool{})
+}
+
+// generateMockValue creates mock data for the given type. Struct types already being generated
+// are tracked in visiting so that self-referential types produce a zero value instead of recursing.
+func generateMockValue(typ reflect.Type, visiting map[reflect.Type]bool) any {
 	if typ.Kind() == reflect.Ptr {
 		typ = typ.Elem()
 	}
@@ -29,12 +98,18 @@ func generateMockData(typ reflect.Type) any {
 	if typ == reflect.TypeOf(uuid.UUID{}) {
 		return uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
 	}
+	if typ == rawJSONType {
+		return json.RawMessage("{}")
+	}
 
 	switch typ.Kind() {
 	case reflect.Struct:
-		return generateMockStruct(typ)
+		if visiting[typ] {
+			return nil
+		}
+		return generateMockStructValue(typ, visiting)
 	case reflect.Slice:
-		return generateMockSlice(typ)
+		return generateMockSliceValue(typ, visiting)
 	case reflect.String:
 		return "example"
 	case reflect.Int:
@@ -69,6 +144,13 @@ func generateMockData(typ reflect.Type) any {
 }
 
 func generateMockStruct(typ reflect.Type) any {
+	return generateMockStructValue(typ, map[reflect.Type]bool{})
+}
+
+func generateMockStructValue(typ reflect.Type, visiting map[reflect.Type]bool) any {
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
 	mockValue := reflect.New(typ).Elem()
 
 	for i := range typ.NumField() {
@@ -82,36 +164,47 @@ func generateMockStruct(typ reflect.Type) any {
 			continue
 		}
 
-		fieldType := field.Type
-		if fieldType.Kind() == reflect.Ptr {
-			fieldType = fieldType.Elem()
-		}
-
-		mockData := generateMockData(fieldType)
-		if mockData != nil {
-			mockValue.Field(i).Set(reflect.ValueOf(mockData))
+		if mockData := generateMockValue(field.Type, visiting); mockData != nil {
+			mockValue.Field(i).Set(mockReflectValue(field.Type, mockData))
 		}
 	}
 
 	return mockValue.Interface()
 }
 
-func generateMockSlice(typ reflect.Type) any {
-	elemType := typ.Elem()
-	if elemType.Kind() == reflect.Ptr {
-		elemType = elemType.Elem()
-	}
-
-	mockElem := generateMockData(elemType)
+func generateMockSliceValue(typ reflect.Type, visiting map[reflect.Type]bool) any {
+	mockElem := generateMockValue(typ.Elem(), visiting)
 	if mockElem == nil {
 		return nil
 	}
 
 	slice := reflect.MakeSlice(typ, 1, 1)
-	slice.Index(0).Set(reflect.ValueOf(mockElem))
+	slice.Index(0).Set(mockReflectValue(typ.Elem(), mockElem))
 	return slice.Interface()
 }
 
+// mockReflectValue returns mockData as a value assignable to typ, allocating a pointer if typ is one
+// and converting to named types such as "type Status string".
+func mockReflectValue(typ reflect.Type, mockData any) reflect.Value {
+	elemType := typ
+	if typ.Kind() == reflect.Ptr {
+		elemType = typ.Elem()
+	}
+
+	value := reflect.ValueOf(mockData)
+	if value.Type() != elemType {
+		value = value.Convert(elemType)
+	}
+
+	if typ.Kind() != reflect.Ptr {
+		return value
+	}
+
+	ptr := reflect.New(elemType)
+	ptr.Elem().Set(value)
+	return ptr
+}
+
 // generateXMLExample generates an XML example string for the given type.
 func generateXMLExample(t any, xmlRootName string) string {
 	mockData := generateMockData(reflect.TypeOf(t))
@@ -217,26 +310,8 @@ func GenerateJSONSchema(t any, components *openapi.Components) *openapi.SchemaOr
 
 	switch typ.Kind() {
 	case reflect.Struct:
-		typName := typ.String()
-		// Check if schema already exists in components
-		if _, ok := components.Schemas[typName]; !ok {
-			// Create the schema for the struct and add it to components
-			structSchema := &openapi.Schema{
-				Type:       "object",
-				Title:      typName,
-				Properties: make(map[string]openapi.SchemaOrRef),
-				Required:   []string{},
-			}
-
-			// Add to components first to handle circular references
-			components.Schemas[typName] = *structSchema
-
-			// Generate properties
-			generateSchemaForStruct(typ, structSchema, components)
-
-			// Update the schema in components with generated properties
-			components.Schemas[typName] = *structSchema
-		}
+		typName := schemaComponentName(typ, false, components)
+		registerStructSchema(typName, typ, components)
 
 		// Return a schema that references the component
 		schemaOrRef = &openapi.SchemaOrRef{
@@ -271,7 +346,7 @@ func GenerateXMLSchema(t any, xmlRootName string, components *openapi.Components
 
 	switch typ.Kind() {
 	case reflect.Struct:
-		typName := typ.String() + ".XML"
+		typName := schemaComponentName(typ, true, components)
 		// Check if schema already exists in components
 		if _, ok := components.Schemas[typName]; !ok {
 			// Create the schema for the struct and add it to components
@@ -460,7 +535,7 @@ func generateXMLSchemaForField(field *reflect.StructField, components *openapi.C
 
 	case fieldType.Kind() == reflect.Struct:
 		// Handle nested structs by adding them to components
-		typName := fieldType.String() + ".XML"
+		typName := schemaComponentName(fieldType, true, components)
 
 		if components.Schemas == nil {
 			components.Schemas = make(map[string]openapi.Schema)
@@ -597,7 +672,7 @@ func generateXMLSchemaForSliceElement(field *reflect.StructField, components *op
 
 	case elemType.Kind() == reflect.Struct:
 		// Handle nested structs in arrays by adding them to components
-		typName := elemType.String() + ".XML"
+		typName := schemaComponentName(elemType, true, components)
 
 		if components.Schemas == nil {
 			components.Schemas = make(map[string]openapi.Schema)
@@ -744,6 +819,10 @@ func generateSchemaForField(field *reflect.StructField, components *openapi.Comp
 
 	// Determine the JSON schema type
 	switch {
+	case fieldType == rawJSONType:
+		// Deferred JSON can be any value, described by an empty schema.
+		return &openapi.SchemaOrRef{Schema: &openapi.Schema{}}
+
 	case fieldType == reflect.TypeOf(time.Time{}):
 		schema := &openapi.Schema{
 			Type:   "string",
@@ -762,7 +841,7 @@ func generateSchemaForField(field *reflect.StructField, components *openapi.Comp
 
 	case fieldType.Kind() == reflect.Struct:
 		// Handle nested structs by adding them to components
-		typName := fieldType.String()
+		typName := schemaComponentName(fieldType, false, components)
 
 		if components.Schemas == nil {
 			components.Schemas = make(map[string]openapi.Schema)
@@ -853,7 +932,7 @@ func generateSchemaForSliceElement(field *reflect.StructField, components *opena
 
 	case elemType.Kind() == reflect.Struct:
 		// Handle nested structs in arrays by adding them to components
-		typName := elemType.String()
+		typName := schemaComponentName(elemType, false, components)
 
 		if components.Schemas == nil {
 			components.Schemas = make(map[string]openapi.Schema)
@@ -1010,7 +1089,7 @@ func isFieldRequired(field *reflect.StructField) bool {
 
 	rules := strings.Split(validateTag, ",")
 	for _, rule := range rules {
-		if strings.TrimSpace(rule) == ruleRequired {
+		if rule = strings.TrimSpace(rule); rule == ruleRequired || rule == ruleRequiredNotBlank {
 			return true
 		}
 	}
@@ -1055,6 +1134,10 @@ func applyValidationRules(field *reflect.StructField, schema *openapi.Schema, ki
 			maxLen, _ := strconv.Atoi(strings.TrimPrefix(rule, "maxlength="))
 			schema.MaxLength = &maxLen
 
+		case rule == ruleRequiredNotBlank && kind == reflect.String && schema.Pattern == "":
+			// Require at least one non-whitespace character.
+			schema.Pattern = `\S`
+
 		case strings.HasPrefix(rule, "regexp=") && kind == reflect.String:
 			pattern := strings.TrimPrefix(rule, "regexp=")
 			schema.Pattern = pattern
@@ -1064,6 +1147,13 @@ func applyValidationRules(field *reflect.StructField, schema *openapi.Schema, ki
 			for _, val := range enumValues {
 				schema.Enum = append(schema.Enum, strings.TrimSpace(val))
 			}
+
+		case strings.HasPrefix(rule, ruleEnumCI+"=") && kind == reflect.String:
+			// Document the canonical values, which are the ones bound values are normalized to.
+			enumValues := strings.Split(strings.TrimPrefix(rule, ruleEnumCI+"="), "|")
+			for _, val := range enumValues {
+				schema.Enum = append(schema.Enum, strings.TrimSpace(val))
+			}
 		}
 	}
 }
@@ -1087,7 +1177,7 @@ func applySliceValidationRules(field *reflect.StructField, schema *openapi.Schem
 			maxItems, _ := strconv.Atoi(strings.TrimPrefix(rule, "maxItems="))
 			schema.MaxItems = &maxItems
 
-		case rule == "uniqueItems":
+		case isUniqueRule(rule):
 			schema.UniqueItems = true
 		}
 	}
@@ -613,3 +613,218 @@ func TestGenerateXMLSchema_SliceExamples(t *testing.T) {
 		t.Fatalf("expected example to contain XMLUser elements, got: %s", exampleStr)
 	}
 }
+
+type Team struct {
+	Lead    Address   `json:"lead"    xml:"lead"`
+	Members []Address `json:"members" xml:"members"`
+}
+
+type Page[T any] struct {
+	Items []T `json:"items"`
+	Total int `json:"total"`
+}
+
+func TestGenerateSchema_ReusesSharedComponents(t *testing.T) {
+	components := &openapi.Components{}
+
+	GenerateJSONSchema(Person{}, components)
+	GenerateJSONSchema(Team{}, components)
+	ref := GenerateJSONSchema(Address{}, components)
+	GenerateXMLSchema(Team{}, "team", components)
+	xmlRef := GenerateXMLSchema(Address{}, "address", components)
+
+	if ref.Ref != "#/components/schemas/bind.Address" {
+		t.Errorf("expected ref to bind.Address, got %s", ref.Ref)
+	}
+	if xmlRef.Ref != "#/components/schemas/bind.Address.XML" {
+		t.Errorf("expected ref to bind.Address.XML, got %s", xmlRef.Ref)
+	}
+
+	var addressSchemas []string
+	for name := range components.Schemas {
+		if strings.Contains(name, "Address") {
+			addressSchemas = append(addressSchemas, name)
+		}
+	}
+	if len(addressSchemas) != 2 {
+		t.Errorf("expected one JSON and one XML Address component, got %v", addressSchemas)
+	}
+
+	team := components.Schemas["bind.Team"]
+	if team.Properties["lead"].Ref != "#/components/schemas/bind.Address" {
+		t.Errorf("expected lead to reference bind.Address, got %+v", team.Properties["lead"])
+	}
+	if team.Properties["members"].Schema.Items.Ref != "#/components/schemas/bind.Address" {
+		t.Errorf("expected members items to reference bind.Address, got %+v", team.Properties["members"].Schema.Items)
+	}
+}
+
+func TestGenerateSchema_SameNameDifferentTypes(t *testing.T) {
+	components := &openapi.Components{}
+
+	first := func() any {
+		type Item struct {
+			Name string `json:"name"`
+		}
+		return Item{}
+	}()
+	second := func() any {
+		type Item struct {
+			Price float64 `json:"price"`
+		}
+		return Item{}
+	}()
+
+	firstRef := GenerateJSONSchema(first, components)
+	secondRef := GenerateJSONSchema(second, components)
+
+	if firstRef.Ref == secondRef.Ref {
+		t.Fatalf("expected distinct components for distinct types, both got %s", firstRef.Ref)
+	}
+
+	if firstRef.Ref != "#/components/schemas/bind.Item" {
+		t.Errorf("expected first type to keep the short name, got %s", firstRef.Ref)
+	}
+
+	qualified := "github.com_bondowe_webfram_internal_bind.Item"
+	if !strings.HasPrefix(secondRef.Ref, "#/components/schemas/"+qualified) {
+		t.Errorf("expected second type to use its package-qualified name, got %s", secondRef.Ref)
+	}
+
+	if _, ok := components.Schemas["bind.Item"].Properties["name"]; !ok {
+		t.Error("expected first component to keep its own properties")
+	}
+
+	// Regenerating either type reuses its component
+	if again := GenerateJSONSchema(second, components); again.Ref != secondRef.Ref {
+		t.Errorf("expected regenerated ref %s, got %s", secondRef.Ref, again.Ref)
+	}
+	if len(components.Schemas) != 2 {
+		t.Errorf("expected 2 components, got %d", len(components.Schemas))
+	}
+
+	// The names are registered on the components, so other components start afresh
+	if names := components.ComponentNames(); len(names) != 2 {
+		t.Errorf("expected 2 registered names, got %v", names)
+	}
+	if ref := GenerateJSONSchema(second, &openapi.Components{}); ref.Ref != "#/components/schemas/bind.Item" {
+		t.Errorf("expected the short name in new components, got %s", ref.Ref)
+	}
+}
+
+func TestGenerateSchema_GenericTypeName(t *testing.T) {
+	components := &openapi.Components{}
+
+	ref := GenerateJSONSchema(Page[Address]{}, components)
+
+	name := strings.TrimPrefix(ref.Ref, "#/components/schemas/")
+	if strings.ContainsAny(name, "[]/ ") {
+		t.Errorf("expected a valid component name, got %q", name)
+	}
+
+	if _, ok := components.Schemas[name]; !ok {
+		t.Errorf("expected component %q to be registered", name)
+	}
+}
+
+type Comment struct {
+	Parent  *Comment  `json:"parent,omitempty"  xml:"parent,omitempty"`
+	Text    string    `json:"text"              xml:"text"`
+	Replies []Comment `json:"replies,omitempty" xml:"replies>comment,omitempty"`
+}
+
+type TreeNode struct {
+	Name     string      `json:"name"     xml:"name"`
+	Children []*TreeNode `json:"children" xml:"children>node"`
+	Owner    *TreeOwner  `json:"owner"    xml:"owner"`
+}
+
+type TreeOwner struct {
+	Name  string      `json:"name"  xml:"name"`
+	Nodes []*TreeNode `json:"nodes" xml:"nodes>node"`
+}
+
+func TestGenerateJSONSchema_SelfReferential(t *testing.T) {
+	components := &openapi.Components{}
+
+	ref := GenerateJSONSchema(Comment{}, components)
+
+	if ref.Ref != "#/components/schemas/bind.Comment" {
+		t.Fatalf("expected ref to bind.Comment, got %s", ref.Ref)
+	}
+
+	comment := components.Schemas["bind.Comment"]
+
+	if comment.Properties["parent"].Ref != ref.Ref {
+		t.Errorf("expected parent to reference bind.Comment, got %+v", comment.Properties["parent"])
+	}
+
+	replies := comment.Properties["replies"]
+	if replies.Schema == nil || replies.Schema.Items == nil || replies.Schema.Items.Ref != ref.Ref {
+		t.Errorf("expected replies items to reference bind.Comment, got %+v", replies)
+	}
+
+	if _, ok := comment.Properties["text"]; !ok {
+		t.Error("expected the in-progress definition to be completed with all properties")
+	}
+
+	if len(components.Schemas) != 1 {
+		t.Errorf("expected a single component, got %d", len(components.Schemas))
+	}
+}
+
+func TestGenerateSchema_MutuallyRecursive(t *testing.T) {
+	components := &openapi.Components{}
+
+	GenerateJSONSchema(TreeNode{}, components)
+	xmlRef := GenerateXMLSchema(TreeNode{}, "tree", components)
+
+	owner := components.Schemas["bind.TreeOwner"]
+	if owner.Properties["nodes"].Schema.Items.Ref != "#/components/schemas/bind.TreeNode" {
+		t.Errorf("expected owner nodes to reference bind.TreeNode, got %+v", owner.Properties["nodes"])
+	}
+
+	if xmlRef.Ref != "#/components/schemas/bind.TreeNode.XML" {
+		t.Fatalf("expected ref to bind.TreeNode.XML, got %s", xmlRef.Ref)
+	}
+
+	example, ok := components.Schemas["bind.TreeNode.XML"].Example.(string)
+	if !ok || !strings.Contains(example, "<tree>") {
+		t.Errorf("expected an XML example for the recursive type, got %v", components.Schemas["bind.TreeNode.XML"].Example)
+	}
+}
+
+func TestGenerateXMLSchema_SelfReferential(t *testing.T) {
+	components := &openapi.Components{}
+
+	ref := GenerateXMLSchema(Comment{}, "comment", components)
+
+	if ref.Ref != "#/components/schemas/bind.Comment.XML" {
+		t.Fatalf("expected ref to bind.Comment.XML, got %s", ref.Ref)
+	}
+
+	if components.Schemas["bind.Comment.XML"].Properties["parent"].Ref != ref.Ref {
+		t.Errorf("expected parent to reference bind.Comment.XML")
+	}
+}
+
+type commentStatus string
+
+type ModeratedComment struct {
+	Status commentStatus `xml:"status"`
+	Author *Address      `xml:"author"`
+	Thread []*Comment    `xml:"thread>comment"`
+}
+
+func TestGenerateXMLSchema_ExampleWithPointersAndNamedTypes(t *testing.T) {
+	components := &openapi.Components{}
+
+	GenerateXMLSchema(ModeratedComment{}, "moderated", components)
+
+	example, _ := components.Schemas["bind.ModeratedComment.XML"].Example.(string)
+	for _, expected := range []string{"<moderated>", "<status>example</status>", "<author>", "<thread>"} {
+		if !strings.Contains(example, expected) {
+			t.Errorf("expected example to contain %q, got:\n%s", expected, example)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package bind
+
+import (
+	"reflect"
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// Transforms supported by the transform struct tag, e.g. `transform:"trim,lower"`.
+const (
+	transformTrim  = "trim"
+	transformLower = "lower"
+	transformUpper = "upper"
+	transformTitle = "title"
+)
+
+// transformString applies the comma-separated transforms of a transform tag to s, in order.
+// Unknown transforms are ignored.
+func transformString(s, tag string) string {
+	if tag == "" {
+		return s
+	}
+
+	for name := range strings.SplitSeq(tag, ",") {
+		switch strings.TrimSpace(name) {
+		case transformTrim:
+			s = strings.TrimSpace(s)
+		case transformLower:
+			s = strings.ToLower(s)
+		case transformUpper:
+			s = strings.ToUpper(s)
+		case transformTitle:
+			s = cases.Title(language.Und).String(s)
+		}
+	}
+
+	return s
+}
+
+// transformValues returns a copy of values with the transforms of a transform tag applied.
+// values is returned as is if the tag is empty.
+func transformValues(values []string, tag string) []string {
+	if tag == "" || len(values) == 0 {
+		return values
+	}
+
+	transformed := make([]string, len(values))
+	for i, value := range values {
+		transformed[i] = transformString(value, tag)
+	}
+
+	return transformed
+}
+
+// transformRecursive applies the transform tags of the fields of a decoded struct to their string values,
+// including pointers to strings and string slices, and to the fields of nested structs.
+// Values that are not structs, such as the slices and maps bound by BindJSON[[]T] or BindJSON[map[string]T],
+// have no tags: their elements are transformed if they are structs.
+func transformRecursive(val reflect.Value) {
+	if val.Kind() != reflect.Struct {
+		transformValue(val, "")
+		return
+	}
+
+	typ := val.Type()
+
+	for i := range val.NumField() {
+		field := val.Field(i)
+		fieldType := typ.Field(i)
+		if !fieldType.IsExported() {
+			continue
+		}
+
+		transformValue(field, fieldType.Tag.Get("transform"))
+	}
+}
+
+func transformValue(val reflect.Value, tag string) {
+	//nolint:exhaustive // only strings, structs and containers of them are transformed
+	switch val.Kind() {
+	case reflect.String:
+		if tag != "" {
+			val.SetString(transformString(val.String(), tag))
+		}
+	case reflect.Ptr:
+		if !val.IsNil() {
+			transformValue(val.Elem(), tag)
+		}
+	case reflect.Slice, reflect.Array:
+		if val.Type() == rawJSONType {
+			return
+		}
+		for i := range val.Len() {
+			transformValue(val.Index(i), tag)
+		}
+	case reflect.Map:
+		transformMapValues(val, tag)
+	case reflect.Struct:
+		transformRecursive(val)
+	}
+}
+
+// transformMapValues transforms the values of a map, which are not addressable and are replaced by
+// transformed copies. Values of interface types, e.g. those of a map[string]any, are left unchanged.
+func transformMapValues(val reflect.Value, tag string) {
+	elemType := val.Type().Elem()
+	if elemType.Kind() == reflect.Interface {
+		return
+	}
+
+	iter := val.MapRange()
+	for iter.Next() {
+		elem := reflect.New(elemType).Elem()
+		elem.Set(iter.Value())
+		transformValue(elem, tag)
+		val.SetMapIndex(iter.Key(), elem)
+	}
+}
@@ -0,0 +1,139 @@
+package bind
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestTransformString(t *testing.T) {
+	tests := []struct {
+		input string
+		tag   string
+		want  string
+	}{
+		{"  John@Example.COM ", "trim,lower", "john@example.com"},
+		{" abc ", "trim,upper", "ABC"},
+		{"jane DOE", "lower,title", "Jane Doe"},
+		{" keep ", "", " keep "},
+		{" keep ", "unknown", " keep "},
+	}
+
+	for _, tt := range tests {
+		if got := transformString(tt.input, tt.tag); got != tt.want {
+			t.Errorf("transformString(%q, %q) = %q, want %q", tt.input, tt.tag, got, tt.want)
+		}
+	}
+}
+
+func TestFormBinding_TransformBeforeValidation(t *testing.T) {
+	type Signup struct {
+		Email string   `form:"email" transform:"trim,lower" validate:"required,format=email"`
+		Tags  []string `form:"tags"  transform:"trim,upper"`
+	}
+
+	req := newPost(url.Values{
+		"email": {"  John@Example.COM "},
+		"tags":  {" a ", "b "},
+	})
+
+	res, errs, err := Form[Signup](req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("expected no validation errors, got: %#v", errs)
+	}
+	if res.Email != "john@example.com" {
+		t.Errorf("expected transformed email, got %q", res.Email)
+	}
+	if strings.Join(res.Tags, ",") != "A,B" {
+		t.Errorf("expected transformed tags, got %v", res.Tags)
+	}
+}
+
+func TestJSONBinding_TransformBeforeValidation(t *testing.T) {
+	type Address struct {
+		City string `json:"city" transform:"trim,title"`
+	}
+	type Signup struct {
+		Email   string   `json:"email"   transform:"trim,lower" validate:"required,format=email"`
+		Nick    *string  `json:"nick"    transform:"trim"`
+		Address *Address `json:"address"`
+	}
+
+	body := `{"email":"  John@Example.COM ","nick":" jd ","address":{"city":" new york "}}`
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+
+	got, errs, err := JSON[Signup](req, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("expected no validation errors, got: %v", errs)
+	}
+	if got.Email != "john@example.com" {
+		t.Errorf("expected transformed email, got %q", got.Email)
+	}
+	if got.Nick == nil || *got.Nick != "jd" {
+		t.Errorf("expected transformed nick, got %v", got.Nick)
+	}
+	if got.Address == nil || got.Address.City != "New York" {
+		t.Errorf("expected transformed city, got %v", got.Address)
+	}
+}
+
+func TestJSONBinding_WithoutTransformFailsValidation(t *testing.T) {
+	type Signup struct {
+		Email string `json:"email" validate:"required,format=email"`
+	}
+
+	body := `{"email":"  John@Example.COM "}`
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+
+	_, errs, err := JSON[Signup](req, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) == 0 {
+		t.Fatal("expected a validation error for an untransformed email")
+	}
+}
+
+func TestJSONBinding_NonStructTypes(t *testing.T) {
+	type tag struct {
+		Name string `json:"name" transform:"trim,lower"`
+	}
+
+	decode := func(body string) *http.Request {
+		return httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+	}
+
+	objects, errs, err := JSON[[]map[string]any](decode(`[{"a":1},{"b":" x "}]`), true)
+	if err != nil || len(errs) != 0 || len(objects) != 2 || objects[1]["b"] != " x " {
+		t.Errorf("unexpected result for []map[string]any: %v, %v, %v", objects, errs, err)
+	}
+
+	object, errs, err := JSON[map[string]any](decode(`{"a":"b"}`), true)
+	if err != nil || len(errs) != 0 || object["a"] != "b" {
+		t.Errorf("unexpected result for map[string]any: %v, %v, %v", object, errs, err)
+	}
+
+	tags, _, err := JSON[[]tag](decode(`[{"name":" Go "}]`), true)
+	if err != nil || len(tags) != 1 || tags[0].Name != "go" {
+		t.Errorf("expected the elements of []tag to be transformed, got %v, %v", tags, err)
+	}
+
+	byID, _, err := JSON[map[string]tag](decode(`{"1":{"name":" Go "}}`), true)
+	if err != nil || byID["1"].Name != "go" {
+		t.Errorf("expected the values of map[string]tag to be transformed, got %v, %v", byID, err)
+	}
+
+	names, _, err := XML[[]string](decode(`<names>a</names>`), true)
+	if err != nil || len(names) != 1 || names[0] != "a" {
+		t.Errorf("unexpected result for XML []string: %v, %v", names, err)
+	}
+}
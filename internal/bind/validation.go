@@ -18,11 +18,30 @@ type ValidationError struct {
 	XMLName xml.Name `json:"-"     xml:"validationError" form:"-"`
 	Field   string   `json:"field" xml:"field"           form:"field"`
 	Error   string   `json:"error" xml:"error"           form:"error"`
+	// Enum is the name of the enum whose values are listed in Allowed, set for enum violations reported
+	// with the default MsgEnum message so it can be localized.
+	Enum    string   `json:"-"     xml:"-"               form:"-"`
+	Allowed []string `json:"-"     xml:"-"               form:"-"`
+}
+
+// MsgEnum is the default message of enum violations. Its only argument is the list of allowed values.
+const MsgEnum = "must be one of: %s"
+
+// MsgDuration is the default message of time.Duration fields whose value cannot be parsed by time.ParseDuration.
+const MsgDuration = "must be a valid duration, such as 30s or 5m"
+
+// ValidationMessages lists the validation messages that can be localized, so they can be added to message catalogs.
+//
+//nolint:gochecknoglobals // Read-only list of message IDs
+var ValidationMessages = []string{
+	MsgEnum,
+	MsgDuration,
 }
 
 const (
 	// Validation rule names.
 	ruleRequired          = "required"
+	ruleRequiredNotBlank  = "required_notblank"
 	ruleEquals            = "equals"
 	ruleMin               = "min"
 	ruleMax               = "max"
@@ -32,9 +51,11 @@ const (
 	ruleMinItems          = "minItems"
 	ruleMaxItems          = "maxItems"
 	ruleUniqueItems       = "uniqueItems"
+	ruleUnique            = "unique"
 	rulePattern           = "pattern"
 	ruleFormat            = "format"
 	ruleEnum              = "enum"
+	ruleEnumCI            = "enum_ci"
 	ruleEmptyItemsAllowed = "emptyItemsAllowed"
 
 	// Format types.
@@ -61,6 +82,9 @@ func isValidationRuleValidForType(rule string, kind reflect.Kind, fieldType refl
 	case ruleRequired:
 		return nil
 
+	case ruleRequiredNotBlank:
+		return validateStringOnlyRule(ruleName, kind)
+
 	case ruleEmptyItemsAllowed:
 		return validateSliceOnlyRule(ruleName, kind)
 
@@ -73,7 +97,7 @@ func isValidationRuleValidForType(rule string, kind reflect.Kind, fieldType refl
 	case ruleMinItems, ruleMaxItems:
 		return validateCollectionRule(ruleName, kind)
 
-	case ruleUniqueItems:
+	case ruleUniqueItems, ruleUnique:
 		return validateSliceOnlyRule(ruleName, kind)
 
 	case rulePattern:
@@ -85,6 +109,9 @@ func isValidationRuleValidForType(rule string, kind reflect.Kind, fieldType refl
 	case ruleEnum:
 		return validateEnumRule(kind, typeInfo)
 
+	case ruleEnumCI:
+		return validateStringOnlyRule(ruleName, kind)
+
 	case ruleEquals:
 		return validateEqualsRule(kind)
 
@@ -135,6 +162,17 @@ func validateSliceOnlyRule(ruleName string, kind reflect.Kind) error {
 	return nil
 }
 
+func validateStringOnlyRule(ruleName string, kind reflect.Kind) error {
+	if kind != reflect.String {
+		return fmt.Errorf(
+			"validation rule '%s' can only be applied to string fields, but field is %s",
+			ruleName,
+			kind,
+		)
+	}
+	return nil
+}
+
 func validateNumericRule(ruleName string, kind reflect.Kind, info fieldTypeInfo) error {
 	if !IsIntType(kind) && !IsFloatType(kind) && !info.isSliceOfInt && !info.isSliceOfFloat {
 		return fmt.Errorf(
@@ -222,6 +260,11 @@ func validateFieldTypeRules(field *reflect.StructField, kind reflect.Kind, field
 
 //nolint:gocognit,gocyclo,cyclop,funlen // high complexity inherent to validation
 func bindValidateRecursive(val reflect.Value, prefix string, errors *[]ValidationError) {
+	// Validation rules are struct tags: values of other kinds, e.g. a bound []string, have none.
+	if val.Kind() != reflect.Struct {
+		return
+	}
+
 	typ := val.Type()
 
 	for i := range val.NumField() {
@@ -240,11 +283,40 @@ func bindValidateRecursive(val reflect.Value, prefix string, errors *[]Validatio
 		}
 		key += name
 
+		// Pointer fields are optional: nil means the field was absent, so only the required
+		// rule applies. A present value is validated like a non-pointer field, even if it is zero.
+		isPointer := kind == reflect.Ptr
+		if isPointer {
+			if field.IsNil() {
+				validate := fieldType.Tag.Get("validate")
+				switch {
+				case hasValidationRule(validate, ruleRequired):
+					msg := getErrorMessage(&fieldType, ruleRequired, "is required")
+					*errors = append(*errors, ValidationError{Field: key, Error: msg})
+				case hasValidationRule(validate, ruleRequiredNotBlank):
+					msg := getErrorMessage(&fieldType, ruleRequiredNotBlank, "is required")
+					*errors = append(*errors, ValidationError{Field: key, Error: msg})
+				}
+				continue
+			}
+			field = field.Elem()
+			kind = field.Kind()
+		}
+
 		if kind == reflect.Struct && field.Type() != reflect.TypeOf(time.Time{}) {
 			bindValidateRecursive(field, key, errors)
 			continue
 		}
 
+		// Deferred JSON is validated once decoded by the handler: only the required rule applies.
+		if field.Type() == rawJSONType {
+			if hasValidationRule(fieldType.Tag.Get("validate"), ruleRequired) && isRawJSONEmpty(field.Bytes()) {
+				msg := getErrorMessage(&fieldType, ruleRequired, "is required")
+				*errors = append(*errors, ValidationError{Field: key, Error: msg})
+			}
+			continue
+		}
+
 		// Validate that the validation rules are applicable to this field type
 		validateFieldTypeRules(&fieldType, kind, field.Type())
 
@@ -257,11 +329,17 @@ func bindValidateRecursive(val reflect.Value, prefix string, errors *[]Validatio
 		for _, rule := range rules {
 			switch {
 			case rule == ruleRequired:
-				if isEmpty(field) {
+				if !isPointer && isEmpty(field) {
 					msg := getErrorMessage(&fieldType, ruleRequired, "is required")
 					*errors = append(*errors, ValidationError{Field: key, Error: msg})
 				}
 
+			case rule == ruleRequiredNotBlank && kind == reflect.String:
+				if strings.TrimSpace(field.String()) == "" {
+					msg := getErrorMessage(&fieldType, ruleRequiredNotBlank, "is required")
+					*errors = append(*errors, ValidationError{Field: key, Error: msg})
+				}
+
 			case strings.HasPrefix(rule, ruleEquals+"=") && IsIntType(kind):
 				val, _ := strconv.Atoi(strings.TrimPrefix(rule, ruleEquals+"="))
 				if getIntValue(field) != int64(val) {
@@ -378,9 +456,9 @@ func bindValidateRecursive(val reflect.Value, prefix string, errors *[]Validatio
 					*errors = append(*errors, ValidationError{Field: key, Error: msg})
 				}
 
-			case strings.HasPrefix(rule, ruleUniqueItems) && kind == reflect.Slice:
+			case isUniqueRule(rule) && kind == reflect.Slice:
 				if !hasUniqueItems(field) {
-					msg := getErrorMessage(&fieldType, ruleUniqueItems, "must have unique items")
+					msg := getErrorMessage(&fieldType, rule, "must have unique items")
 					*errors = append(*errors, ValidationError{Field: key, Error: msg})
 				}
 
@@ -423,12 +501,17 @@ func bindValidateRecursive(val reflect.Value, prefix string, errors *[]Validatio
 					}
 				}
 				if !found {
-					msg := getErrorMessage(
-						&fieldType,
-						ruleEnum,
-						fmt.Sprintf("must be one of: %s", strings.Join(allowed, ", ")),
-					)
-					*errors = append(*errors, ValidationError{Field: key, Error: msg})
+					*errors = append(*errors, enumValidationError(&fieldType, ruleEnum, key, allowed))
+				}
+
+			case strings.HasPrefix(rule, ruleEnumCI+"=") && kind == reflect.String:
+				allowed := strings.Split(strings.TrimPrefix(rule, ruleEnumCI+"="), "|")
+				if canonical, ok := matchEnumFold(field.String(), allowed); ok {
+					if field.CanSet() {
+						field.SetString(canonical)
+					}
+				} else {
+					*errors = append(*errors, enumValidationError(&fieldType, ruleEnumCI, key, allowed))
 				}
 
 			case strings.HasPrefix(rule, ruleEnum+"=") && IsIntType(kind):
@@ -442,12 +525,7 @@ func bindValidateRecursive(val reflect.Value, prefix string, errors *[]Validatio
 					}
 				}
 				if !found {
-					msg := getErrorMessage(
-						&fieldType,
-						ruleEnum,
-						fmt.Sprintf("must be one of: %s", strings.Join(allowed, ", ")),
-					)
-					*errors = append(*errors, ValidationError{Field: key, Error: msg})
+					*errors = append(*errors, enumValidationError(&fieldType, ruleEnum, key, allowed))
 				}
 
 			case strings.HasPrefix(rule, ruleEnum+"=") && IsFloatType(kind):
@@ -461,12 +539,7 @@ func bindValidateRecursive(val reflect.Value, prefix string, errors *[]Validatio
 					}
 				}
 				if !found {
-					msg := getErrorMessage(
-						&fieldType,
-						ruleEnum,
-						fmt.Sprintf("must be one of: %s", strings.Join(allowed, ", ")),
-					)
-					*errors = append(*errors, ValidationError{Field: key, Error: msg})
+					*errors = append(*errors, enumValidationError(&fieldType, ruleEnum, key, allowed))
 				}
 			}
 		}
@@ -503,6 +576,12 @@ func bindValidateRecursive(val reflect.Value, prefix string, errors *[]Validatio
 	}
 }
 
+// isUniqueRule reports whether rule rejects duplicate slice items.
+// "unique" is accepted as a shorthand for "uniqueItems".
+func isUniqueRule(rule string) bool {
+	return rule == ruleUniqueItems || rule == ruleUnique
+}
+
 func hasUniqueItems(field reflect.Value) bool {
 	itemMap := make(map[interface{}]bool)
 	for i := range field.Len() {
@@ -572,6 +651,39 @@ func validateUUIDSliceField(field *reflect.StructField, values []uuid.UUID) []Va
 	return errors
 }
 
+// hasValidationRule reports whether the comma-separated validate tag contains the given rule.
+// matchEnumFold returns the allowed value that matches value case-insensitively.
+func matchEnumFold(value string, allowed []string) (string, bool) {
+	for _, a := range allowed {
+		if strings.EqualFold(value, a) {
+			return a, true
+		}
+	}
+	return "", false
+}
+
+// normalizeEnumCI returns the canonical casing of value if the validate tag has an enum_ci rule
+// that matches it, and value unchanged otherwise.
+func normalizeEnumCI(validate, value string) string {
+	for rule := range strings.SplitSeq(validate, ",") {
+		if list, ok := strings.CutPrefix(strings.TrimSpace(rule), ruleEnumCI+"="); ok {
+			if canonical, found := matchEnumFold(value, strings.Split(list, "|")); found {
+				return canonical
+			}
+		}
+	}
+	return value
+}
+
+func hasValidationRule(validate, rule string) bool {
+	for _, r := range strings.Split(validate, ",") {
+		if strings.TrimSpace(r) == rule {
+			return true
+		}
+	}
+	return false
+}
+
 func isEmpty(v reflect.Value) bool {
 	if v.Type() == reflect.TypeOf(uuid.UUID{}) {
 		if val, ok := v.Interface().(uuid.UUID); ok {
@@ -599,6 +711,33 @@ func isEmpty(v reflect.Value) bool {
 	}
 }
 
+// enumValidationError returns the validation error of a value that is not one of allowed. Unless the
+// errmsg tag overrides the message, the error records the enum name and its allowed values.
+func enumValidationError(field *reflect.StructField, rule, key string, allowed []string) ValidationError {
+	fallback := fmt.Sprintf(MsgEnum, strings.Join(allowed, ", "))
+	msg := getErrorMessage(field, rule, fallback)
+	if msg != fallback {
+		return ValidationError{Field: key, Error: msg}
+	}
+
+	return ValidationError{Field: key, Error: msg, Enum: EnumName(field), Allowed: allowed}
+}
+
+// EnumName returns the name used to look up the labels of the enum values of a field: the name of its
+// type if it is a defined type (e.g., Role for a field of type Role), otherwise the field name.
+func EnumName(field *reflect.StructField) string {
+	typ := field.Type
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ.PkgPath() != "" && typ.Name() != "" {
+		return typ.Name()
+	}
+
+	return field.Name
+}
+
 func getErrorMessage(field *reflect.StructField, rule, fallback string) string {
 	tag := field.Tag.Get("errmsg")
 	if tag == "" {
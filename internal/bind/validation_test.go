@@ -136,6 +136,27 @@ func TestUniqueItemsValidation(t *testing.T) {
 	}
 }
 
+func TestUniqueValidation(t *testing.T) {
+	type S struct {
+		Items []int `json:"items" validate:"unique" errmsg:"unique=Items must not repeat"`
+	}
+
+	errs := runValidate(S{Items: []int{1, 2, 1}})
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %d: %+v", len(errs), errs)
+	}
+
+	if e := findByField(errs, "items"); e == nil {
+		t.Errorf("expected error for field 'items'")
+	} else if e.Error != "Items must not repeat" {
+		t.Errorf("unexpected error message for items: %s", e.Error)
+	}
+
+	if errs = runValidate(S{Items: []int{1, 2, 3}}); len(errs) != 0 {
+		t.Errorf("expected no errors for unique items, got %+v", errs)
+	}
+}
+
 func TestFormatEmailValidation(t *testing.T) {
 	type E struct {
 		Email string `json:"email" validate:"format=email" errmsg:"format=Please enter a valid email address"`
@@ -315,9 +336,13 @@ func TestValidationRuleValidForType(t *testing.T) {
 		{"minlength on int", "minlength=5", reflect.Int, reflect.TypeOf(0), true},
 		{"minItems on string", "minItems=1", reflect.String, reflect.TypeOf(""), true},
 		{"uniqueItems on non-slice", "uniqueItems", reflect.String, reflect.TypeOf(""), true},
+		{"unique on non-slice", "unique", reflect.String, reflect.TypeOf(""), true},
+		{"valid unique on slice", "unique", reflect.Slice, reflect.TypeOf([]string{}), false},
 		{"pattern on int", "pattern=\\d+", reflect.Int, reflect.TypeOf(0), true},
 		{"format on int", "format=email", reflect.Int, reflect.TypeOf(0), true},
 		{"enum on bool", "enum=true|false", reflect.Bool, reflect.TypeOf(false), true},
+		{"enum_ci on int", "enum_ci=1|2", reflect.Int, reflect.TypeOf(0), true},
+		{"valid enum_ci on string", "enum_ci=a|b", reflect.String, reflect.TypeOf(""), false},
 		{"valid min on int", "min=5", reflect.Int, reflect.TypeOf(0), false},
 		{"unknown rule", "unknownRule=value", reflect.String, reflect.TypeOf(""), true},
 	}
@@ -573,3 +598,27 @@ func TestEqualsValidation_WithOtherRules(t *testing.T) {
 		t.Errorf("expected no errors for valid combined validation, got: %+v", errs)
 	}
 }
+
+func TestEnumValidationError_RecordsEnumValues(t *testing.T) {
+	type Role string
+	type T struct {
+		Role   Role   `validate:"enum=admin|user"`
+		Status string `validate:"enum=on|off"`
+		Plan   string `validate:"enum=free|pro"   errmsg:"enum=Unknown plan"`
+	}
+
+	errs := runValidate(&T{Role: "root", Status: "x", Plan: "gold"})
+	if len(errs) != 3 {
+		t.Fatalf("expected 3 errors, got %#v", errs)
+	}
+
+	if errs[0].Enum != "Role" || len(errs[0].Allowed) != 2 || errs[0].Error != "must be one of: admin, user" {
+		t.Errorf("unexpected enum error for defined type: %#v", errs[0])
+	}
+	if errs[1].Enum != "Status" {
+		t.Errorf("expected the field name as enum name, got %q", errs[1].Enum)
+	}
+	if errs[2].Enum != "" || errs[2].Error != "Unknown plan" {
+		t.Errorf("expected errmsg override without enum values, got %#v", errs[2])
+	}
+}
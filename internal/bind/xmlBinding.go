@@ -8,21 +8,25 @@ import (
 )
 
 // XML parses XML from an HTTP request body and binds it to a struct of type T.
+// String fields are normalized according to their transform tags after decoding.
 // If validate is true, performs validation according to struct tags after decoding.
 // Returns the populated struct, validation errors (if validation is enabled), and a decoding error (if parsing fails).
+// Reading stops with the context's error if the request context is done before the body is fully read.
 func XML[T any](r *http.Request, validate bool) (T, []ValidationError, error) {
 	var result T
-	decoder := xml.NewDecoder(r.Body)
+	decoder := xml.NewDecoder(newContextReader(r.Context(), r.Body))
 	err := decoder.Decode(&result)
 	if err != nil {
 		return result, nil, fmt.Errorf("failed to decode XML: %w", err)
 	}
 
+	val := reflect.ValueOf(&result).Elem()
+	transformRecursive(val)
+
 	if !validate {
 		return result, nil, nil
 	}
 
-	val := reflect.ValueOf(&result).Elem()
 	errors := []ValidationError{}
 
 	bindValidateRecursive(val, "", &errors)
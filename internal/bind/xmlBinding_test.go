@@ -1,6 +1,8 @@
 package bind
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strings"
 	"testing"
@@ -67,3 +69,22 @@ func TestXMLDecode_ValidateTrue_ErrSliceNonNil(t *testing.T) {
 		t.Fatalf("unexpected decoded value: %+v", got)
 	}
 }
+
+func TestXMLDecode_ContextCanceledMidRead(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	body := &cancelingReader{
+		chunks: [][]byte{[]byte(`<person><Name>`), []byte(`John</Name></person>`)},
+		cancel: cancel,
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/", body)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	_, _, err = XML[person](req, false)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+}
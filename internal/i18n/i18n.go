@@ -4,11 +4,13 @@ package i18n
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/fs"
 	"log/slog"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"golang.org/x/text/language"
 	"golang.org/x/text/message"
@@ -20,7 +22,11 @@ type (
 	// Config holds i18n configuration.
 	Config struct {
 		FS                 fs.FS
+		Matcher            language.Matcher
 		SupportedLanguages []language.Tag
+		// Fallback lists the languages, in order, whose messages are used when a message is missing
+		// in a language and in its parent languages (e.g., "fr-CA" falls back to "fr" first).
+		Fallback []language.Tag
 	}
 
 	// MessageFile represents the structure of the JSON message files.
@@ -50,37 +56,87 @@ type (
 
 const (
 	i18nPrinterKey contextKey = "i18nPrinter"
+	languageKey    contextKey = "language"
 )
 
-//nolint:gochecknoglobals // Package-level state for i18n configuration and message catalog
-var (
-	config     *Config
-	msgCatalog catalog.Catalog
-)
+// ErrNotConfigured is returned by Reload when no message files are configured.
+var ErrNotConfigured = errors.New("i18n messages are not configured")
+
+// Catalogs holds the message catalogs loaded from an i18n configuration and the printers created from them.
+type Catalogs struct {
+	config   *Config
+	catalog  catalog.Catalog
+	printers sync.Map // map[string]*message.Printer - key: language tag string
+	// mu guards catalog and printers, so that a reload never leaves a printer of the
+	// replaced catalog in the cache.
+	mu sync.RWMutex
+}
+
+// New initializes the message catalogs with the provided configuration.
+// It loads all message catalogs found in the configured filesystem; without a filesystem,
+// the catalogs are empty and messages are printed untranslated.
+func New(cfg *Config) *Catalogs {
+	cs := &Catalogs{config: cfg}
+	cs.loadI18nCatalogs()
+	return cs
+}
+
+// Reload re-reads the message files from the configured file system and replaces the message catalogs
+// and cached printers at once. Printers already returned, such as those of requests being served,
+// keep the previous messages. Returns ErrNotConfigured if cs is nil or has no file system,
+// and the error of the first message file that cannot be read or parsed, keeping the current catalogs.
+func (cs *Catalogs) Reload() error {
+	if cs == nil || cs.config == nil || cs.config.FS == nil {
+		return ErrNotConfigured
+	}
+
+	builder, err := buildCatalog(cs.config)
+	if err != nil {
+		return err
+	}
 
-// Configure initializes the internationalization system with the provided configuration.
-// It sets up the filesystem and base path for locale files, then loads all message catalogs.
-// Panics if locales directory or filesystem is missing.
-func Configure(cfg *Config) {
-	config = cfg
-	loadI18nCatalogs()
+	cs.setCatalog(builder)
+	return nil
 }
 
-// Configuration returns the current i18n configuration.
-// Returns the config and true if i18n is configured, or an empty config and false if not configured.
-func Configuration() (Config, bool) {
-	if config == nil {
+// Configuration returns the i18n configuration.
+// Returns the config and true if i18n is configured, or an empty config and false if cs is nil.
+func (cs *Catalogs) Configuration() (Config, bool) {
+	if cs == nil || cs.config == nil {
 		return Config{}, false
 	}
-	return *config, true
+	return *cs.config, true
 }
 
-// GetI18nPrinter creates a message printer for the given language tag
-// GetI18nPrinter creates a message printer for the specified language tag.
+// GetI18nPrinter returns a message printer for the specified language tag.
 // The printer can be used to translate messages according to the loaded message catalogs.
-// Returns a printer configured for the given language tag.
-func GetI18nPrinter(langTag language.Tag) *message.Printer {
-	p := message.NewPrinter(langTag, message.Catalog(msgCatalog))
+// Printers are cached per language tag and shared between goroutines: a message.Printer is
+// immutable once created and keeps its per-call formatting state in an internal pool,
+// so concurrent Sprintf calls on the same printer are safe.
+// The cache is reset whenever the message catalogs are (re)loaded.
+// If cs is nil, the printer prints messages untranslated.
+func (cs *Catalogs) GetI18nPrinter(langTag language.Tag) *message.Printer {
+	if cs == nil {
+		return message.NewPrinter(langTag, message.Catalog(catalog.NewBuilder()))
+	}
+
+	key := langTag.String()
+
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+
+	if cached, ok := cs.printers.Load(key); ok {
+		if p, pOk := cached.(*message.Printer); pOk {
+			return p
+		}
+	}
+
+	p := message.NewPrinter(langTag, message.Catalog(cs.catalog))
+	actual, _ := cs.printers.LoadOrStore(key, p)
+
+	if cachedPrinter, ok := actual.(*message.Printer); ok {
+		return cachedPrinter
+	}
 	return p
 }
 
@@ -98,16 +154,51 @@ func PrinterFromContext(ctx context.Context) (*message.Printer, bool) {
 	return printer, ok
 }
 
-func loadI18nCatalogs() {
-	if config == nil || config.FS == nil {
+// ContextWithLanguage stores the language resolved for a request in the context.
+// Returns a new context containing the language, which can be retrieved later with LanguageFromContext.
+func ContextWithLanguage(ctx context.Context, tag language.Tag) context.Context {
+	return context.WithValue(ctx, languageKey, tag)
+}
+
+// LanguageFromContext retrieves the language resolved for a request from the context.
+// Returns the language and true if found, or language.Und and false if not present.
+func LanguageFromContext(ctx context.Context) (language.Tag, bool) {
+	tag, ok := ctx.Value(languageKey).(language.Tag)
+	return tag, ok
+}
+
+func (cs *Catalogs) loadI18nCatalogs() {
+	if cs.config == nil || cs.config.FS == nil {
 		slog.Default().Warn("i18n config not set, skipping catalog loading")
+		cs.setCatalog(catalog.NewBuilder())
 		return
 	}
 
+	builder, err := buildCatalog(cs.config)
+	if err != nil {
+		slog.Default().Error("Error loading i18n catalogs", "error", err)
+	}
+
+	cs.setCatalog(builder)
+}
+
+// setCatalog replaces the message catalog and clears the printers created from the previous one.
+func (cs *Catalogs) setCatalog(cat catalog.Catalog) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	cs.catalog = cat
+	cs.printers.Clear()
+}
+
+// buildCatalog loads the messages.<lang>.json files of cfg.FS into a new catalog, with the fallback
+// messages of cfg.Fallback. On error, the catalog holds the messages loaded before the failing file.
+func buildCatalog(cfg *Config) (*catalog.Builder, error) {
 	builder := catalog.NewBuilder()
+	loaded := make(map[language.Tag]map[string]string)
 
 	// Walk through the file system to find all message files
-	err := fs.WalkDir(config.FS, ".", func(path string, d fs.DirEntry, err error) error {
+	err := fs.WalkDir(cfg.FS, ".", func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -129,24 +220,31 @@ func loadI18nCatalogs() {
 		}
 
 		// Load messages from the file
-		data, err := fs.ReadFile(config.FS, path)
+		data, err := fs.ReadFile(cfg.FS, path)
 		if err != nil {
 			return fmt.Errorf("error reading file %s: %w", path, err)
 		}
 
-		if loadErr := loadJSONMessages(builder, langTag, data); loadErr != nil {
-			return fmt.Errorf("error loading messages from %s: %w", path, loadErr)
+		messages, parseErr := parseJSONMessages(data)
+		if parseErr != nil {
+			return fmt.Errorf("error loading messages from %s: %w", path, parseErr)
+		}
+
+		if loaded[langTag] == nil {
+			loaded[langTag] = make(map[string]string, len(messages))
+		}
+		for id, translation := range messages {
+			loaded[langTag][id] = translation
+			_ = builder.SetString(langTag, id, translation)
 		}
 
 		slog.Default().Info("Loaded messages for language", "language", langTag, "path", path)
 		return nil
 	})
 
-	if err != nil {
-		slog.Default().Error("Error loading i18n catalogs", "error", err)
-	}
+	applyFallbacks(builder, loaded, cfg.Fallback)
 
-	msgCatalog = builder
+	return builder, err
 }
 
 func extractLangTagFromFilename(filePath string) language.Tag {
@@ -166,22 +264,96 @@ func extractLangTagFromFilename(filePath string) language.Tag {
 
 // loadJSONMessages loads messages from JSON data into the catalog builder.
 func loadJSONMessages(builder *catalog.Builder, tag language.Tag, data []byte) error {
+	messages, err := parseJSONMessages(data)
+	if err != nil {
+		return err
+	}
+
+	for id, translation := range messages {
+		// The ID is the key, and the translated message is the value
+		_ = builder.SetString(tag, id, translation)
+	}
+
+	return nil
+}
+
+// parseJSONMessages parses a message file and returns the translations keyed by message ID.
+func parseJSONMessages(data []byte) (map[string]string, error) {
 	var msgFile MessageFile
 	if err := json.Unmarshal(data, &msgFile); err != nil {
-		return fmt.Errorf("error parsing JSON: %w", err)
+		return nil, fmt.Errorf("error parsing JSON: %w", err)
 	}
 
+	messages := make(map[string]string, len(msgFile.Messages))
 	for _, entry := range msgFile.Messages {
 		// Use the translation if available, otherwise use the message itself
 		translation := entry.Message
 		if entry.Translation != "" {
 			translation = entry.Translation
 		}
+		messages[entry.ID] = translation
+	}
 
-		// Add the message to the catalog
-		// The ID is the key, and the translated message is the value
-		_ = builder.SetString(tag, entry.ID, translation)
+	return messages, nil
+}
+
+// applyFallbacks adds the messages missing in each loaded language, taking them from the first
+// fallback language that has them. A message is only considered missing if neither the language
+// nor any of its parents defines it, so "fr-CA" keeps using "fr" before the fallback chain.
+// Languages without any message file resolve to the root language, which gets the fallback
+// messages as well.
+func applyFallbacks(builder *catalog.Builder, loaded map[language.Tag]map[string]string, fallback []language.Tag) {
+	if len(fallback) == 0 {
+		return
 	}
 
-	return nil
+	tags := make([]language.Tag, 0, len(loaded)+1)
+	for tag := range loaded {
+		tags = append(tags, tag)
+	}
+	if _, ok := loaded[language.Und]; !ok {
+		tags = append(tags, language.Und)
+	}
+
+	for _, tag := range tags {
+		for _, fb := range fallback {
+			for id := range lookupMessages(loaded, fb) {
+				if _, found := lookupMessage(loaded, tag, id); found {
+					continue
+				}
+
+				translation, _ := lookupMessage(loaded, fb, id)
+				if loaded[tag] == nil {
+					loaded[tag] = make(map[string]string)
+				}
+				loaded[tag][id] = translation
+				_ = builder.SetString(tag, id, translation)
+			}
+		}
+	}
+}
+
+// lookupMessage finds the message for id in the language or its parents.
+func lookupMessage(loaded map[language.Tag]map[string]string, tag language.Tag, id string) (string, bool) {
+	for ; ; tag = tag.Parent() {
+		if translation, ok := loaded[tag][id]; ok {
+			return translation, true
+		}
+		if tag == language.Und {
+			return "", false
+		}
+	}
+}
+
+// lookupMessages returns the IDs of all messages available in the language or its parents.
+func lookupMessages(loaded map[language.Tag]map[string]string, tag language.Tag) map[string]struct{} {
+	ids := make(map[string]struct{})
+	for ; ; tag = tag.Parent() {
+		for id := range loaded[tag] {
+			ids[id] = struct{}{}
+		}
+		if tag == language.Und {
+			return ids
+		}
+	}
 }
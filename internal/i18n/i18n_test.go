@@ -4,8 +4,11 @@ import (
 	"context"
 	"embed"
 	"encoding/json"
+	"errors"
 	"strings"
+	"sync"
 	"testing"
+	"testing/fstest"
 
 	"golang.org/x/text/language"
 	"golang.org/x/text/message/catalog"
@@ -14,43 +17,34 @@ import (
 //go:embed testdata/locales/*.json
 var testFS embed.FS
 
-func resetI18nConfig() {
-	config = nil
-	msgCatalog = nil
-}
-
-func TestConfigure(t *testing.T) {
-	resetI18nConfig()
-
+func TestNew(t *testing.T) {
 	cfg := &Config{
 		FS: testFS,
 	}
 
-	Configure(cfg)
+	cs := New(cfg)
 
-	if config == nil {
+	if cs.config == nil {
 		t.Fatal("Config was not set")
 	}
 
-	if config.FS == nil {
+	if cs.config.FS == nil {
 		t.Error("FS was not set in config")
 	}
 
-	if msgCatalog == nil {
+	if cs.catalog == nil {
 		t.Error("Message catalog was not initialized")
 	}
 }
 
 func TestConfiguration(t *testing.T) {
-	resetI18nConfig()
-
 	cfg := &Config{
 		FS: testFS,
 	}
 
-	Configure(cfg)
+	cs := New(cfg)
 
-	result, ok := Configuration()
+	result, ok := cs.Configuration()
 
 	if !ok {
 		t.Error("Expected valid configuration")
@@ -63,13 +57,11 @@ func TestConfiguration(t *testing.T) {
 }
 
 func TestGetI18nPrinter(t *testing.T) {
-	resetI18nConfig()
-
 	cfg := &Config{
 		FS: testFS,
 	}
 
-	Configure(cfg)
+	cs := New(cfg)
 
 	tests := []struct {
 		tag  language.Tag
@@ -91,7 +83,7 @@ func TestGetI18nPrinter(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(_ *testing.T) {
-			printer := GetI18nPrinter(tt.tag)
+			printer := cs.GetI18nPrinter(tt.tag)
 
 			if printer == nil {
 				t.Fatal("GetI18nPrinter returned nil")
@@ -106,16 +98,79 @@ func TestGetI18nPrinter(t *testing.T) {
 	}
 }
 
-func TestContextWithI18nPrinter(t *testing.T) {
-	resetI18nConfig()
+func TestGetI18nPrinter_CachedPerLanguage(t *testing.T) {
+	cs := New(&Config{FS: testFS})
+
+	en1 := cs.GetI18nPrinter(language.English)
+	en2 := cs.GetI18nPrinter(language.English)
+	fr := cs.GetI18nPrinter(language.French)
+
+	if en1 != en2 {
+		t.Error("Expected the same printer instance for the same language")
+	}
+
+	if en1 == fr {
+		t.Error("Expected different printer instances for different languages")
+	}
+
+	if err := cs.Reload(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cs.GetI18nPrinter(language.English) == en1 {
+		t.Error("Expected printer cache to be reset when catalogs are reloaded")
+	}
+}
+
+func TestCatalogs_Nil(t *testing.T) {
+	var cs *Catalogs
+
+	if _, ok := cs.Configuration(); ok {
+		t.Error("Expected nil catalogs to be unconfigured")
+	}
+
+	if got := cs.GetI18nPrinter(language.French).Sprintf("Hello"); got != "Hello" {
+		t.Errorf("Expected untranslated message for nil catalogs, got %q", got)
+	}
+}
+
+func TestGetI18nPrinter_Concurrent(t *testing.T) {
+	cs := New(&Config{FS: testFS})
 
+	tags := []language.Tag{language.English, language.French, language.Spanish}
+
+	var wg sync.WaitGroup
+	for i := range 1000 {
+		wg.Add(1)
+		go func(tag language.Tag) {
+			defer wg.Done()
+			p := cs.GetI18nPrinter(tag)
+			if s := p.Sprintf("Hello %s", "World"); s == "" {
+				t.Error("Expected non-empty translation")
+			}
+		}(tags[i%len(tags)])
+	}
+	wg.Wait()
+
+	count := 0
+	cs.printers.Range(func(_, _ any) bool {
+		count++
+		return true
+	})
+
+	if count != len(tags) {
+		t.Errorf("Expected %d cached printers, got %d", len(tags), count)
+	}
+}
+
+func TestContextWithI18nPrinter(t *testing.T) {
 	cfg := &Config{
 		FS: testFS,
 	}
 
-	Configure(cfg)
+	cs := New(cfg)
 
-	printer := GetI18nPrinter(language.English)
+	printer := cs.GetI18nPrinter(language.English)
 	ctx := context.Background()
 
 	newCtx := ContextWithI18nPrinter(ctx, printer)
@@ -136,13 +191,11 @@ func TestContextWithI18nPrinter(t *testing.T) {
 }
 
 func TestPrinterFromContext(t *testing.T) {
-	resetI18nConfig()
-
 	cfg := &Config{
 		FS: testFS,
 	}
 
-	Configure(cfg)
+	cs := New(cfg)
 
 	tests := []struct {
 		setupContext func() context.Context
@@ -152,7 +205,7 @@ func TestPrinterFromContext(t *testing.T) {
 		{
 			name: "with printer in context",
 			setupContext: func() context.Context {
-				printer := GetI18nPrinter(language.English)
+				printer := cs.GetI18nPrinter(language.English)
 				return ContextWithI18nPrinter(context.Background(), printer)
 			},
 			expectFound: true,
@@ -192,6 +245,18 @@ func TestPrinterFromContext(t *testing.T) {
 	}
 }
 
+func TestLanguageFromContext(t *testing.T) {
+	ctx := ContextWithLanguage(context.Background(), language.French)
+
+	if tag, found := LanguageFromContext(ctx); !found || tag != language.French {
+		t.Errorf("Expected (%v, true), got (%v, %v)", language.French, tag, found)
+	}
+
+	if tag, found := LanguageFromContext(context.Background()); found || tag != language.Und {
+		t.Errorf("Expected (und, false) without language in context, got (%v, %v)", tag, found)
+	}
+}
+
 func TestExtractLangTagFromFilename(t *testing.T) {
 	tests := []struct {
 		expected language.Tag
@@ -242,8 +307,6 @@ func TestExtractLangTagFromFilename(t *testing.T) {
 }
 
 func TestLoadJSONMessages(t *testing.T) {
-	resetI18nConfig()
-
 	builder := catalog.NewBuilder()
 
 	tests := []struct {
@@ -335,38 +398,34 @@ func TestLoadJSONMessages(t *testing.T) {
 }
 
 func TestLoadI18nCatalogs_NilConfig(_ *testing.T) {
-	resetI18nConfig()
+	cs := &Catalogs{}
 
 	// Should not panic with nil config
-	loadI18nCatalogs()
+	cs.loadI18nCatalogs()
 }
 
 func TestLoadI18nCatalogs_NilFS(_ *testing.T) {
-	resetI18nConfig()
-
-	config = &Config{
+	cs := &Catalogs{config: &Config{
 		FS: nil,
-	}
+	}}
 
 	// Should not panic with nil FS
-	loadI18nCatalogs()
+	cs.loadI18nCatalogs()
 }
 
 func TestLoadI18nCatalogs_WithTestData(t *testing.T) {
-	resetI18nConfig()
-
 	cfg := &Config{
 		FS: testFS,
 	}
 
-	Configure(cfg)
+	cs := New(cfg)
 
-	if msgCatalog == nil {
+	if cs.catalog == nil {
 		t.Error("Expected message catalog to be loaded")
 	}
 
 	// Test that we can create a printer and use it
-	printer := GetI18nPrinter(language.English)
+	printer := cs.GetI18nPrinter(language.English)
 	result := printer.Sprintf("Test message")
 
 	if result == "" {
@@ -491,13 +550,11 @@ func TestPlaceholderStruct(t *testing.T) {
 }
 
 func TestI18nPrinterTranslation(t *testing.T) {
-	resetI18nConfig()
-
 	cfg := &Config{
 		FS: testFS,
 	}
 
-	Configure(cfg)
+	cs := New(cfg)
 
 	tests := []struct {
 		lang     language.Tag
@@ -531,7 +588,7 @@ func TestI18nPrinterTranslation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(_ *testing.T) {
-			printer := GetI18nPrinter(tt.lang)
+			printer := cs.GetI18nPrinter(tt.lang)
 
 			var result string
 			if len(tt.args) > 0 {
@@ -557,30 +614,26 @@ func TestContextKey(t *testing.T) {
 }
 
 func BenchmarkGetI18nPrinter(b *testing.B) {
-	resetI18nConfig()
-
 	cfg := &Config{
 		FS: testFS,
 	}
 
-	Configure(cfg)
+	cs := New(cfg)
 
 	b.ResetTimer()
 	for b.Loop() {
-		GetI18nPrinter(language.English)
+		cs.GetI18nPrinter(language.English)
 	}
 }
 
 func BenchmarkContextWithI18nPrinter(b *testing.B) {
-	resetI18nConfig()
-
 	cfg := &Config{
 		FS: testFS,
 	}
 
-	Configure(cfg)
+	cs := New(cfg)
 
-	printer := GetI18nPrinter(language.English)
+	printer := cs.GetI18nPrinter(language.English)
 	ctx := context.Background()
 
 	b.ResetTimer()
@@ -590,15 +643,13 @@ func BenchmarkContextWithI18nPrinter(b *testing.B) {
 }
 
 func BenchmarkPrinterFromContext(b *testing.B) {
-	resetI18nConfig()
-
 	cfg := &Config{
 		FS: testFS,
 	}
 
-	Configure(cfg)
+	cs := New(cfg)
 
-	printer := GetI18nPrinter(language.English)
+	printer := cs.GetI18nPrinter(language.English)
 	ctx := ContextWithI18nPrinter(context.Background(), printer)
 
 	b.ResetTimer()
@@ -608,18 +659,146 @@ func BenchmarkPrinterFromContext(b *testing.B) {
 }
 
 func BenchmarkPrinterSprintf(b *testing.B) {
-	resetI18nConfig()
-
 	cfg := &Config{
 		FS: testFS,
 	}
 
-	Configure(cfg)
+	cs := New(cfg)
 
-	printer := GetI18nPrinter(language.English)
+	printer := cs.GetI18nPrinter(language.English)
 
 	b.ResetTimer()
 	for b.Loop() {
 		printer.Sprintf("Hello %s", "World")
 	}
 }
+
+func fallbackTestFS() fstest.MapFS {
+	file := func(lang string, messages map[string]string) *fstest.MapFile {
+		msgFile := MessageFile{Language: lang}
+		for id, translation := range messages {
+			msgFile.Messages = append(msgFile.Messages, MessageEntry{ID: id, Message: id, Translation: translation})
+		}
+		data, _ := json.Marshal(msgFile)
+		return &fstest.MapFile{Data: data}
+	}
+
+	return fstest.MapFS{
+		"messages.en.json": file("en", map[string]string{
+			"welcome": "Welcome",
+			"goodbye": "Goodbye",
+			"color":   "Color",
+		}),
+		"messages.fr.json": file("fr", map[string]string{
+			"welcome": "Bienvenue",
+			"color":   "Couleur",
+		}),
+		"messages.fr-CA.json": file("fr-CA", map[string]string{
+			"color": "Couleur (CA)",
+		}),
+	}
+}
+
+func TestLoadI18nCatalogs_FallbackChain(t *testing.T) {
+	cs := New(&Config{
+		FS:       fallbackTestFS(),
+		Fallback: []language.Tag{language.English},
+	})
+
+	tests := []struct {
+		lang     string
+		id       string
+		expected string
+	}{
+		{"fr-CA", "color", "Couleur (CA)"},
+		{"fr-CA", "welcome", "Bienvenue"},
+		{"fr-CA", "goodbye", "Goodbye"},
+		{"fr", "goodbye", "Goodbye"},
+		{"de", "welcome", "Welcome"},
+		{"fr-CA", "unknown", "unknown"},
+	}
+
+	for _, tt := range tests {
+		printer := cs.GetI18nPrinter(language.MustParse(tt.lang))
+		if got := printer.Sprintf(tt.id); got != tt.expected {
+			t.Errorf("%s %q: expected %q, got %q", tt.lang, tt.id, tt.expected, got)
+		}
+	}
+}
+
+func TestLoadI18nCatalogs_WithoutFallback(t *testing.T) {
+	cs := New(&Config{FS: fallbackTestFS()})
+
+	printer := cs.GetI18nPrinter(language.MustParse("fr-CA"))
+
+	if got := printer.Sprintf("welcome"); got != "Bienvenue" {
+		t.Errorf("Expected parent language translation 'Bienvenue', got %q", got)
+	}
+
+	if got := printer.Sprintf("goodbye"); got != "goodbye" {
+		t.Errorf("Expected message ID without fallback, got %q", got)
+	}
+}
+
+func TestReload(t *testing.T) {
+	fsys := fstest.MapFS{
+		"messages.fr.json": {Data: []byte(`{"language":"fr","messages":[{"id":"Hello","message":"Hello","translation":"Bonjour"}]}`)},
+	}
+	cs := New(&Config{FS: fsys})
+
+	if got := cs.GetI18nPrinter(language.French).Sprintf("Hello"); got != "Bonjour" {
+		t.Fatalf("Expected Bonjour, got %q", got)
+	}
+
+	fsys["messages.fr.json"] = &fstest.MapFile{
+		Data: []byte(`{"language":"fr","messages":[{"id":"Hello","message":"Hello","translation":"Salut"}]}`),
+	}
+	if err := cs.Reload(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := cs.GetI18nPrinter(language.French).Sprintf("Hello"); got != "Salut" {
+		t.Errorf("Expected the reloaded translation Salut, got %q", got)
+	}
+
+	fsys["messages.fr.json"] = &fstest.MapFile{Data: []byte(`{"language":`)}
+	if err := cs.Reload(); err == nil {
+		t.Fatal("Expected an error for a malformed message file")
+	}
+	if got := cs.GetI18nPrinter(language.French).Sprintf("Hello"); got != "Salut" {
+		t.Errorf("Expected a failed reload to keep the current translations, got %q", got)
+	}
+}
+
+func TestReload_NotConfigured(t *testing.T) {
+	var cs *Catalogs
+
+	if err := cs.Reload(); !errors.Is(err, ErrNotConfigured) {
+		t.Errorf("Expected ErrNotConfigured, got %v", err)
+	}
+
+	if err := New(&Config{}).Reload(); !errors.Is(err, ErrNotConfigured) {
+		t.Errorf("Expected ErrNotConfigured without a file system, got %v", err)
+	}
+}
+
+func TestReload_Concurrent(t *testing.T) {
+	cs := New(&Config{FS: testFS})
+
+	var wg sync.WaitGroup
+	for i := range 200 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if i%20 == 0 {
+				if err := cs.Reload(); err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if s := cs.GetI18nPrinter(language.French).Sprintf("Test message"); s == "" {
+				t.Error("Expected non-empty translation")
+			}
+		}()
+	}
+	wg.Wait()
+}
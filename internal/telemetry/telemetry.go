@@ -38,6 +38,32 @@ var (
 			Help: "Current number of active connections",
 		},
 	)
+
+	// CircuitBreakerState reports the state of each circuit breaker per route
+	// (0 = closed, 1 = half-open, 2 = open).
+	CircuitBreakerState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "circuit_breaker_state",
+			Help: "Circuit breaker state per route (0 = closed, 1 = half-open, 2 = open)",
+		},
+		[]string{"route"},
+	)
+
+	// ConcurrencyLimitQueued tracks the number of requests waiting for a ConcurrencyLimit slot.
+	ConcurrencyLimitQueued = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "concurrency_limit_queued",
+			Help: "Current number of requests waiting for a concurrency limit slot",
+		},
+	)
+
+	// ConcurrencyLimitRejectedTotal counts the requests rejected by ConcurrencyLimit after waiting for a slot.
+	ConcurrencyLimitRejectedTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "concurrency_limit_rejected_total",
+			Help: "Total number of requests rejected by the concurrency limit",
+		},
+	)
 )
 
 // ConfigureTelemetry initializes the telemetry registry and registers the provided collectors.
@@ -60,6 +86,9 @@ func ConfigureTelemetry(useDefaultRegistry bool, collectors ...prometheus.Collec
 			RequestsTotal,
 			RequestDurationSeconds,
 			ActiveConnections,
+			CircuitBreakerState,
+			ConcurrencyLimitQueued,
+			ConcurrencyLimitRejectedTotal,
 		)
 	}
 }
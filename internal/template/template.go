@@ -6,6 +6,7 @@ import (
 	"fmt"
 	htmlTemplate "html/template"
 	"io/fs"
+	"maps"
 	"path/filepath"
 	"regexp"
 	"slices"
@@ -21,64 +22,126 @@ type Config struct {
 	HTMLTemplateExtension string
 	TextTemplateExtension string
 	I18nFuncName          string
+	// Layouts are the base names of additional layouts that can be selected per render with
+	// LookupTemplateWithLayout, e.g. "admin" for admin.go.html files.
+	Layouts []string
+	// Funcs are additional functions available in HTML and text templates.
+	Funcs map[string]any
 }
 
-//nolint:gochecknoglobals // Package-level state for template configuration and caching
-var (
+// layoutFiles holds the file names of a layout and the cache the templates using it are stored in.
+type layoutFiles struct {
+	html  string
+	text  string
+	cache *sync.Map
+	// required skips the templates the layout does not apply to.
+	required bool
+}
+
+// Templates holds the templates parsed from a template configuration.
+type Templates struct {
 	config              *Config
 	htmlLayoutFileName  string
 	textLayoutFileName  string
 	templatesCache      sync.Map // map[string][string, *template.Template]
 	partialsCache       sync.Map // map[string]*htmlTemplate.Template - key: "folder|partialFilename"
-	layoutsCache        = make(map[string]any)
+	layoutsCache        map[string]any
+	namedLayoutsCache   map[string]*sync.Map // layout base name -> templates rendered with it
 	layoutPatternString string
 	layoutPattern       *regexp.Regexp
-	funcMap             = htmlTemplate.FuncMap{}
-)
+	funcMap             htmlTemplate.FuncMap
+}
 
-// Configure initializes the template system with the provided configuration.
+// New initializes the templates with the provided configuration.
 // It sets up the filesystem, template extensions, i18n function name, and layouts,
 // then caches all templates found in the filesystem.
 // Panics if any required configuration value is missing or invalid.
-func Configure(cfg *Config) {
-	config = cfg
+func New(cfg *Config) *Templates {
+	ts := &Templates{
+		config:            cfg,
+		layoutsCache:      make(map[string]any),
+		namedLayoutsCache: make(map[string]*sync.Map),
+		funcMap:           htmlTemplate.FuncMap{},
+	}
 
-	htmlLayoutFileName = config.LayoutBaseName + config.HTMLTemplateExtension
-	textLayoutFileName = config.LayoutBaseName + config.TextTemplateExtension
-	layoutPatternString = fmt.Sprintf("^_?(?:%s|%s)$", htmlLayoutFileName, textLayoutFileName)
-	layoutPattern = regexp.MustCompile(layoutPatternString)
+	ts.htmlLayoutFileName = ts.config.LayoutBaseName + ts.config.HTMLTemplateExtension
+	ts.textLayoutFileName = ts.config.LayoutBaseName + ts.config.TextTemplateExtension
 
-	funcMap[config.I18nFuncName] = fmt.Sprintf
+	layoutFileNames := []string{regexp.QuoteMeta(ts.htmlLayoutFileName), regexp.QuoteMeta(ts.textLayoutFileName)}
+	for _, name := range ts.config.Layouts {
+		layoutFileNames = append(layoutFileNames,
+			regexp.QuoteMeta(name+ts.config.HTMLTemplateExtension), regexp.QuoteMeta(name+ts.config.TextTemplateExtension))
+	}
+	ts.layoutPatternString = fmt.Sprintf("^_?(?:%s)$", strings.Join(layoutFileNames, "|"))
+	ts.layoutPattern = regexp.MustCompile(ts.layoutPatternString)
+
+	ts.funcMap[ts.config.I18nFuncName] = fmt.Sprintf
+	for name, fn := range ts.config.Funcs {
+		ts.funcMap[name] = fn
+	}
 
 	htmlLayouts := make([]string, 0)
 	textLayouts := make([]string, 0)
 
-	cacheTemplates(config.FS, ".", htmlLayouts, textLayouts)
+	defaultLayout := layoutFiles{html: ts.htmlLayoutFileName, text: ts.textLayoutFileName, cache: &ts.templatesCache}
+	ts.cacheTemplates(ts.config.FS, ".", defaultLayout, htmlLayouts, textLayouts)
+
+	// Templates are parsed again for every additional layout, so the layout is parsed before the
+	// template and the blocks defined by the template override those of the layout.
+	for _, name := range ts.config.Layouts {
+		layout := layoutFiles{
+			html:     name + ts.config.HTMLTemplateExtension,
+			text:     name + ts.config.TextTemplateExtension,
+			cache:    &sync.Map{},
+			required: true,
+		}
+		ts.cacheTemplates(ts.config.FS, ".", layout, nil, nil)
+		ts.namedLayoutsCache[name] = layout.cache
+	}
 	// Keep layoutsCache for dynamic template parsing
 	// layoutsCache = nil
+
+	return ts
 }
 
-// Configuration returns the current template configuration.
-// Returns the config and true if templates are configured, or an empty config and false if not configured.
-func Configuration() (Config, bool) {
-	if config == nil {
+// Configuration returns the template configuration.
+// Returns the config and true if templates are configured, or an empty config and false if ts is nil.
+func (ts *Templates) Configuration() (Config, bool) {
+	if ts == nil || ts.config == nil {
 		return Config{}, false
 	}
-	return *config, true
+	return *ts.config, true
 }
 
 // LookupTemplate retrieves a cached template by path.
 // If absolute is true, uses the path as-is. If false, prepends the configured base path.
 // Returns the template and true if found, or nil and false if not found.
-func LookupTemplate(path string, absolute bool) (*htmlTemplate.Template, bool) {
+func (ts *Templates) LookupTemplate(path string, absolute bool) (*htmlTemplate.Template, bool) {
 	if absolute {
-		return lookupAbsoluteTemplate(path)
+		return ts.lookupAbsoluteTemplate(path)
+	}
+	return ts.lookupRelativeTemplate(path)
+}
+
+// LookupTemplateWithLayout retrieves a cached template rendered with the named layout, which must be
+// one of the configured Layouts. The path is relative to the template directory, as with LookupTemplate.
+// If layout is empty, the template is looked up with the default layout.
+// Returns the template and true if found, or nil and false if the layout is unknown, the template does not
+// exist, or the named layout does not apply to the template's directory.
+func (ts *Templates) LookupTemplateWithLayout(path, layout string) (*htmlTemplate.Template, bool) {
+	if layout == "" {
+		return ts.lookupRelativeTemplate(path)
+	}
+
+	cache, ok := ts.namedLayoutsCache[layout]
+	if !ok {
+		return nil, false
 	}
-	return lookupRelativeTemplate(path)
+	return lookupTemplateInCache(cache, path)
 }
 
-func lookupAbsoluteTemplate(path string) (*htmlTemplate.Template, bool) {
-	nv, ok := templatesCache.Load(path)
+func (ts *Templates) lookupAbsoluteTemplate(path string) (*htmlTemplate.Template, bool) {
+	nv, ok := ts.templatesCache.Load(path)
 	if !ok {
 		return nil, false
 	}
@@ -93,11 +156,15 @@ func lookupAbsoluteTemplate(path string) (*htmlTemplate.Template, bool) {
 	return tmpl, true
 }
 
-func lookupRelativeTemplate(path string) (*htmlTemplate.Template, bool) {
+func (ts *Templates) lookupRelativeTemplate(path string) (*htmlTemplate.Template, bool) {
+	return lookupTemplateInCache(&ts.templatesCache, path)
+}
+
+func lookupTemplateInCache(cache *sync.Map, path string) (*htmlTemplate.Template, bool) {
 	// For relative paths, search for templates ending with the given path
 	var foundTemplate *htmlTemplate.Template
 	var found bool
-	templatesCache.Range(func(key, value any) bool {
+	cache.Range(func(key, value any) bool {
 		keyStr, ok := key.(string)
 		if !ok {
 			return true
@@ -133,22 +200,22 @@ func Must[T any](v T, err error) T {
 	return v
 }
 
-func cacheTemplates(dir fs.FS, dirPath string, htmlLayouts, textLayouts []string) {
-	htmlLayouts = updateLayoutsForHTML(dir, dirPath, htmlLayouts)
-	textLayouts = updateLayoutsForText(dir, dirPath, textLayouts)
+func (ts *Templates) cacheTemplates(dir fs.FS, dirPath string, layout layoutFiles, htmlLayouts, textLayouts []string) {
+	htmlLayouts = updateLayoutsForHTML(dir, dirPath, layout.html, htmlLayouts)
+	textLayouts = updateLayoutsForText(dir, dirPath, layout.text, textLayouts)
 
 	templates := Must(fs.ReadDir(dir, "."))
 
 	for _, entry := range templates {
 		if entry.IsDir() {
-			processSubdirectory(dir, dirPath, entry, htmlLayouts, textLayouts)
+			ts.processSubdirectory(dir, dirPath, entry, layout, htmlLayouts, textLayouts)
 			continue
 		}
-		processTemplateEntry(dirPath, entry, htmlLayouts, textLayouts)
+		ts.processTemplateEntry(dirPath, entry, layout, htmlLayouts, textLayouts)
 	}
 }
 
-func updateLayoutsForHTML(dir fs.FS, dirPath string, htmlLayouts []string) []string {
+func updateLayoutsForHTML(dir fs.FS, dirPath, htmlLayoutFileName string, htmlLayouts []string) []string {
 	if layoutFileName, ok := getLayout(dir, htmlLayoutFileName); ok {
 		layoutFilePath := dirPath + "/" + layoutFileName
 		layoutFilePath = strings.TrimPrefix(layoutFilePath, "./")
@@ -161,7 +228,7 @@ func updateLayoutsForHTML(dir fs.FS, dirPath string, htmlLayouts []string) []str
 	return htmlLayouts
 }
 
-func updateLayoutsForText(dir fs.FS, dirPath string, textLayouts []string) []string {
+func updateLayoutsForText(dir fs.FS, dirPath, textLayoutFileName string, textLayouts []string) []string {
 	if layoutFileName, ok := getLayout(dir, textLayoutFileName); ok {
 		layoutFilePath := dirPath + "/" + layoutFileName
 		layoutFilePath = strings.TrimPrefix(layoutFilePath, "./")
@@ -174,25 +241,27 @@ func updateLayoutsForText(dir fs.FS, dirPath string, textLayouts []string) []str
 	return textLayouts
 }
 
-func processSubdirectory(
+func (ts *Templates) processSubdirectory(
 	dir fs.FS,
 	dirPath string,
 	entry fs.DirEntry,
+	layout layoutFiles,
 	htmlLayouts, textLayouts []string,
 ) {
 	entryFS := Must(fs.Sub(dir, entry.Name()))
 	nestedDirPath := dirPath + "/" + entry.Name()
-	cacheTemplates(entryFS, nestedDirPath, htmlLayouts, textLayouts)
+	ts.cacheTemplates(entryFS, nestedDirPath, layout, htmlLayouts, textLayouts)
 }
 
-func processTemplateEntry(
+func (ts *Templates) processTemplateEntry(
 	dirPath string,
 	entry fs.DirEntry,
+	layout layoutFiles,
 	htmlLayouts, textLayouts []string,
 ) {
-	isLayoutFile := layoutPattern.MatchString(entry.Name())
-	isHTMLTemplateFile := strings.HasSuffix(entry.Name(), config.HTMLTemplateExtension)
-	isTextTemplateFile := strings.HasSuffix(entry.Name(), config.TextTemplateExtension)
+	isLayoutFile := ts.layoutPattern.MatchString(entry.Name())
+	isHTMLTemplateFile := strings.HasSuffix(entry.Name(), ts.config.HTMLTemplateExtension)
+	isTextTemplateFile := strings.HasSuffix(entry.Name(), ts.config.TextTemplateExtension)
 
 	if isLayoutFile || !isHTMLTemplateFile && !isTextTemplateFile {
 		return
@@ -209,14 +278,14 @@ func processTemplateEntry(
 	templatePath := dirPath + "/" + entry.Name()
 	templatePath = strings.TrimPrefix(templatePath, "./")
 
-	if isHTMLTemplateFile {
-		name, template := parseHTMLTemplate(templatePath, htmlLayoutsClone)
-		templatesCache.Store(templatePath, [2]any{name, template})
+	if isHTMLTemplateFile && (len(htmlLayoutsClone) > 0 || !layout.required) {
+		name, template := ts.parseHTMLTemplate(templatePath, htmlLayoutsClone)
+		layout.cache.Store(templatePath, [2]any{name, template})
 	}
 
-	if isTextTemplateFile {
-		name, template := parseTextTemplate(templatePath, textLayoutsClone)
-		templatesCache.Store(templatePath, [2]any{name, template})
+	if isTextTemplateFile && (len(textLayoutsClone) > 0 || !layout.required) {
+		name, template := ts.parseTextTemplate(templatePath, textLayoutsClone)
+		layout.cache.Store(templatePath, [2]any{name, template})
 	}
 }
 
@@ -254,12 +323,12 @@ func layoutExists(dir fs.FS, layoutName string) bool {
 	return true
 }
 
-func lookUpPartial(folder, partialFilename string) *htmlTemplate.Template {
+func (ts *Templates) lookUpPartial(folder, partialFilename string) *htmlTemplate.Template {
 	// Create cache key from starting folder and partial filename
 	cacheKey := folder + "|" + partialFilename
 
 	// Check if we've already resolved this partial from this folder
-	if cached, ok := partialsCache.Load(cacheKey); ok {
+	if cached, ok := ts.partialsCache.Load(cacheKey); ok {
 		if cached == nil {
 			return nil
 		}
@@ -278,16 +347,16 @@ func lookUpPartial(folder, partialFilename string) *htmlTemplate.Template {
 			partialPath = currentFolder + "/" + partialFilename
 		}
 
-		if tmpl, ok := LookupTemplate(partialPath, true); ok {
+		if tmpl, ok := ts.LookupTemplate(partialPath, true); ok {
 			// Cache the result for the original folder
-			partialsCache.Store(cacheKey, tmpl)
+			ts.partialsCache.Store(cacheKey, tmpl)
 			return tmpl
 		}
 
 		// Check if we've reached the root before calculating parent
 		if currentFolder == "" || currentFolder == "." || currentFolder == "/" {
 			// Not found, cache nil to avoid repeated searches
-			partialsCache.Store(cacheKey, nil)
+			ts.partialsCache.Store(cacheKey, nil)
 			return nil
 		}
 
@@ -295,7 +364,7 @@ func lookUpPartial(folder, partialFilename string) *htmlTemplate.Template {
 
 		// Check if parent is same as current (shouldn't happen, but safety check)
 		if parentFolder == currentFolder {
-			partialsCache.Store(cacheKey, nil)
+			ts.partialsCache.Store(cacheKey, nil)
 			return nil
 		}
 
@@ -303,23 +372,36 @@ func lookUpPartial(folder, partialFilename string) *htmlTemplate.Template {
 	}
 }
 
-func getPartialFunc(templatePath string) func(name string, data any) (htmlTemplate.HTML, error) {
-	return getPartialFuncWithI18n(templatePath, nil)
+func (ts *Templates) getPartialFunc(templatePath string) func(name string, data any) (htmlTemplate.HTML, error) {
+	return ts.getPartialFuncWithFuncs(templatePath, nil)
 }
 
 // GetPartialFuncWithI18n creates a partial template function with i18n support.
 // If i18nFunc is nil, uses the default funcMap i18n function.
 // This allows partials to use per-request i18n printers for proper translation.
-func GetPartialFuncWithI18n(
+func (ts *Templates) GetPartialFuncWithI18n(
 	templatePath string,
 	i18nFunc func(string, ...any) string,
 ) func(name string, data any) (htmlTemplate.HTML, error) {
-	return getPartialFuncWithI18n(templatePath, i18nFunc)
+	if i18nFunc == nil {
+		return ts.getPartialFuncWithFuncs(templatePath, nil)
+	}
+	return ts.getPartialFuncWithFuncs(templatePath, map[string]any{ts.config.I18nFuncName: i18nFunc})
 }
 
-func getPartialFuncWithI18n(
+// GetPartialFuncWithFuncs creates a partial template function executing partials with the given functions,
+// such as a per-request i18n function, in addition to the default funcMap. Nested partials get them as well.
+// If funcs is empty, uses the default funcMap functions.
+func (ts *Templates) GetPartialFuncWithFuncs(
 	templatePath string,
-	i18nFunc func(string, ...any) string,
+	funcs map[string]any,
+) func(name string, data any) (htmlTemplate.HTML, error) {
+	return ts.getPartialFuncWithFuncs(templatePath, funcs)
+}
+
+func (ts *Templates) getPartialFuncWithFuncs(
+	templatePath string,
+	requestFuncs map[string]any,
 ) func(name string, data any) (htmlTemplate.HTML, error) {
 	return func(name string, data any) (htmlTemplate.HTML, error) {
 		var templateDir string
@@ -327,14 +409,14 @@ func getPartialFuncWithI18n(
 			templateDir = strings.ReplaceAll(filepath.Dir(templatePath), "\\", "/")
 		}
 
-		partialFilename := "_" + name + config.HTMLTemplateExtension
+		partialFilename := "_" + name + ts.config.HTMLTemplateExtension
 
-		tmpl := lookUpPartial(templateDir, partialFilename)
+		tmpl := ts.lookUpPartial(templateDir, partialFilename)
 
 		//nolint:nestif // TODO: Refactor partial lookup logic to reduce nesting complexity
 		if tmpl != nil {
-			// If i18n function is provided, clone template and add it to funcMap
-			if i18nFunc != nil {
+			// If request functions are provided, clone template and add them to funcMap
+			if len(requestFuncs) > 0 {
 				// Get the partial's path for nested partial lookups
 				var partialPath string
 				if templateDir == "" || templateDir == "." {
@@ -343,10 +425,8 @@ func getPartialFuncWithI18n(
 					partialPath = templateDir + "/" + partialFilename
 				}
 
-				funcs := htmlTemplate.FuncMap{
-					config.I18nFuncName: i18nFunc,
-					"partial":           getPartialFuncWithI18n(partialPath, i18nFunc),
-				}
+				funcs := htmlTemplate.FuncMap{"partial": ts.getPartialFuncWithFuncs(partialPath, requestFuncs)}
+				maps.Copy(funcs, requestFuncs)
 				cloned, err := tmpl.Clone()
 				if err != nil {
 					return "", fmt.Errorf("failed to clone partial template: %w", err)
@@ -366,12 +446,12 @@ func getPartialFuncWithI18n(
 
 // Text template partial functions (similar to HTML but for text templates)
 
-func lookUpTextPartial(folder, partialFilename string) *textTemplate.Template {
+func (ts *Templates) lookUpTextPartial(folder, partialFilename string) *textTemplate.Template {
 	// Create cache key from starting folder and partial filename
 	cacheKey := "text|" + folder + "|" + partialFilename
 
 	// Check if we've already resolved this partial from this folder
-	if cached, ok := partialsCache.Load(cacheKey); ok {
+	if cached, ok := ts.partialsCache.Load(cacheKey); ok {
 		if cached == nil {
 			return nil
 		}
@@ -391,13 +471,13 @@ func lookUpTextPartial(folder, partialFilename string) *textTemplate.Template {
 		}
 
 		// Look up in cache directly - check if it's a text template
-		nv, ok := templatesCache.Load(partialPath)
+		nv, ok := ts.templatesCache.Load(partialPath)
 		if ok {
 			arr, arrOk := nv.([2]any)
 			if arrOk {
 				if tmpl, tmplOk := arr[1].(*textTemplate.Template); tmplOk {
 					// Cache the result for the original folder
-					partialsCache.Store(cacheKey, tmpl)
+					ts.partialsCache.Store(cacheKey, tmpl)
 					return tmpl
 				}
 			}
@@ -406,7 +486,7 @@ func lookUpTextPartial(folder, partialFilename string) *textTemplate.Template {
 		// Check if we've reached the root before calculating parent
 		if currentFolder == "" || currentFolder == "." || currentFolder == "/" {
 			// Not found, cache nil to avoid repeated searches
-			partialsCache.Store(cacheKey, nil)
+			ts.partialsCache.Store(cacheKey, nil)
 			return nil
 		}
 
@@ -414,7 +494,7 @@ func lookUpTextPartial(folder, partialFilename string) *textTemplate.Template {
 
 		// Check if parent is same as current (shouldn't happen, but safety check)
 		if parentFolder == currentFolder {
-			partialsCache.Store(cacheKey, nil)
+			ts.partialsCache.Store(cacheKey, nil)
 			return nil
 		}
 
@@ -422,23 +502,35 @@ func lookUpTextPartial(folder, partialFilename string) *textTemplate.Template {
 	}
 }
 
-func getTextPartialFunc(templatePath string) func(name string, data any) (string, error) {
-	return getTextPartialFuncWithI18n(templatePath, nil)
+func (ts *Templates) getTextPartialFunc(templatePath string) func(name string, data any) (string, error) {
+	return ts.getTextPartialFuncWithFuncs(templatePath, nil)
 }
 
 // GetTextPartialFuncWithI18n creates a text partial template function with i18n support.
 // If i18nFunc is nil, uses the default funcMap i18n function.
 // This allows text partials to use per-request i18n printers for proper translation.
-func GetTextPartialFuncWithI18n(
+func (ts *Templates) GetTextPartialFuncWithI18n(
 	templatePath string,
 	i18nFunc func(string, ...any) string,
 ) func(name string, data any) (string, error) {
-	return getTextPartialFuncWithI18n(templatePath, i18nFunc)
+	if i18nFunc == nil {
+		return ts.getTextPartialFuncWithFuncs(templatePath, nil)
+	}
+	return ts.getTextPartialFuncWithFuncs(templatePath, map[string]any{ts.config.I18nFuncName: i18nFunc})
 }
 
-func getTextPartialFuncWithI18n(
+// GetTextPartialFuncWithFuncs creates a text partial template function executing partials with the given
+// functions in addition to the default funcMap, like GetPartialFuncWithFuncs.
+func (ts *Templates) GetTextPartialFuncWithFuncs(
 	templatePath string,
-	i18nFunc func(string, ...any) string,
+	funcs map[string]any,
+) func(name string, data any) (string, error) {
+	return ts.getTextPartialFuncWithFuncs(templatePath, funcs)
+}
+
+func (ts *Templates) getTextPartialFuncWithFuncs(
+	templatePath string,
+	requestFuncs map[string]any,
 ) func(name string, data any) (string, error) {
 	return func(name string, data any) (string, error) {
 		var templateDir string
@@ -446,14 +538,14 @@ func getTextPartialFuncWithI18n(
 			templateDir = strings.ReplaceAll(filepath.Dir(templatePath), "\\", "/")
 		}
 
-		partialFilename := "_" + name + config.TextTemplateExtension
+		partialFilename := "_" + name + ts.config.TextTemplateExtension
 
-		tmpl := lookUpTextPartial(templateDir, partialFilename)
+		tmpl := ts.lookUpTextPartial(templateDir, partialFilename)
 
 		//nolint:nestif // TODO: Refactor text partial lookup logic to reduce nesting complexity
 		if tmpl != nil {
-			// If i18n function is provided, clone template and add it to funcMap
-			if i18nFunc != nil {
+			// If request functions are provided, clone template and add them to funcMap
+			if len(requestFuncs) > 0 {
 				// Get the partial's path for nested partial lookups
 				var partialPath string
 				if templateDir == "" || templateDir == "." {
@@ -462,10 +554,8 @@ func getTextPartialFuncWithI18n(
 					partialPath = templateDir + "/" + partialFilename
 				}
 
-				funcs := textTemplate.FuncMap{
-					config.I18nFuncName: i18nFunc,
-					"partial":           getTextPartialFuncWithI18n(partialPath, i18nFunc),
-				}
+				funcs := textTemplate.FuncMap{"partial": ts.getTextPartialFuncWithFuncs(partialPath, requestFuncs)}
+				maps.Copy(funcs, requestFuncs)
 				cloned, err := tmpl.Clone()
 				if err != nil {
 					return "", fmt.Errorf("failed to clone partial template: %w", err)
@@ -482,74 +572,74 @@ func getTextPartialFuncWithI18n(
 	}
 }
 
-func parseHTMLTemplate(templatePath string, layouts []string) (string, *htmlTemplate.Template) {
+func (ts *Templates) parseHTMLTemplate(templatePath string, layouts []string) (string, *htmlTemplate.Template) {
 	var tmpl *htmlTemplate.Template
 	var tmplName string
 
-	funcMap["partial"] = getPartialFunc(templatePath)
+	ts.funcMap["partial"] = ts.getPartialFunc(templatePath)
 
-	data := Must(fs.ReadFile(config.FS, templatePath))
+	data := Must(fs.ReadFile(ts.config.FS, templatePath))
 
 	if len(layouts) > 0 {
-		tmpl = getHTMLTemplateWithLayout(templatePath, layouts)
-		tmpl = htmlTemplate.Must(htmlTemplate.Must(tmpl.Clone()).Funcs(funcMap).Parse(string(data)))
+		tmpl = ts.getHTMLTemplateWithLayout(templatePath, layouts)
+		tmpl = htmlTemplate.Must(htmlTemplate.Must(tmpl.Clone()).Funcs(ts.funcMap).Parse(string(data)))
 		tmplName = tmpl.Name()
 	} else {
-		tmplName, _ = strings.CutSuffix(templatePath, config.HTMLTemplateExtension)
-		tmpl = htmlTemplate.Must(htmlTemplate.New(tmplName).Funcs(funcMap).Parse(string(data)))
+		tmplName, _ = strings.CutSuffix(templatePath, ts.config.HTMLTemplateExtension)
+		tmpl = htmlTemplate.Must(htmlTemplate.New(tmplName).Funcs(ts.funcMap).Parse(string(data)))
 	}
 
 	return tmplName, tmpl
 }
 
-func getHTMLTemplateWithLayout(templatePath string, layouts []string) *htmlTemplate.Template {
-	if v, ok := layoutsCache[templatePath]; ok {
+func (ts *Templates) getHTMLTemplateWithLayout(templatePath string, layouts []string) *htmlTemplate.Template {
+	if v, ok := ts.layoutsCache[templatePath]; ok {
 		if htmlTmpl, htmlOk := v.(*htmlTemplate.Template); htmlOk {
 			return htmlTmpl
 		}
 	}
-	return getOrCreateHTMLLayoutChain(layouts)
+	return ts.getOrCreateHTMLLayoutChain(layouts)
 }
 
-func getOrCreateHTMLLayoutChain(layouts []string) *htmlTemplate.Template {
+func (ts *Templates) getOrCreateHTMLLayoutChain(layouts []string) *htmlTemplate.Template {
 	var tmpl *htmlTemplate.Template
 
 	for i := range layouts {
-		if v, ok := layoutsCache[layouts[i]]; ok {
+		if v, ok := ts.layoutsCache[layouts[i]]; ok {
 			if htmlTmpl, htmlOk := v.(*htmlTemplate.Template); htmlOk {
 				tmpl = htmlTmpl
 			}
 		} else {
-			funcMap["partial"] = getPartialFunc("")
+			ts.funcMap["partial"] = ts.getPartialFunc("")
 			if tmpl == nil {
 				tmplName := filepath.Base(layouts[i])
-				tmpl = htmlTemplate.Must(htmlTemplate.New(tmplName).Funcs(funcMap).ParseFS(config.FS, layouts[i]))
+				tmpl = htmlTemplate.Must(htmlTemplate.New(tmplName).Funcs(ts.funcMap).ParseFS(ts.config.FS, layouts[i]))
 			} else {
-				data := Must(fs.ReadFile(config.FS, layouts[i]))
+				data := Must(fs.ReadFile(ts.config.FS, layouts[i]))
 
-				tmpl = Must(htmlTemplate.Must(tmpl.Clone()).Funcs(funcMap).Parse(string(data)))
+				tmpl = Must(htmlTemplate.Must(tmpl.Clone()).Funcs(ts.funcMap).Parse(string(data)))
 			}
-			layoutsCache[layouts[i]] = tmpl
+			ts.layoutsCache[layouts[i]] = tmpl
 		}
 	}
 	return tmpl
 }
 
-func parseTextTemplate(templatePath string, layouts []string) (string, *textTemplate.Template) {
+func (ts *Templates) parseTextTemplate(templatePath string, layouts []string) (string, *textTemplate.Template) {
 	var tmpl *textTemplate.Template
 	var tmplName string
 
 	// Create a text-specific funcMap with partial support
 	textFuncMap := make(textTemplate.FuncMap)
-	for k, v := range funcMap {
+	for k, v := range ts.funcMap {
 		textFuncMap[k] = v
 	}
-	textFuncMap["partial"] = getTextPartialFunc(templatePath)
+	textFuncMap["partial"] = ts.getTextPartialFunc(templatePath)
 
-	data := Must(fs.ReadFile(config.FS, templatePath))
+	data := Must(fs.ReadFile(ts.config.FS, templatePath))
 
 	if len(layouts) > 0 {
-		tmpl = getTextTemplateWithLayout(templatePath, layouts)
+		tmpl = ts.getTextTemplateWithLayout(templatePath, layouts)
 		tmpl = textTemplate.Must(textTemplate.Must(tmpl.Clone()).Funcs(textFuncMap).Parse(string(data)))
 		tmplName = tmpl.Name()
 	} else {
@@ -560,40 +650,40 @@ func parseTextTemplate(templatePath string, layouts []string) (string, *textTemp
 	return tmplName, tmpl
 }
 
-func getTextTemplateWithLayout(templatePath string, layouts []string) *textTemplate.Template {
-	if v, ok := layoutsCache[templatePath]; ok {
+func (ts *Templates) getTextTemplateWithLayout(templatePath string, layouts []string) *textTemplate.Template {
+	if v, ok := ts.layoutsCache[templatePath]; ok {
 		if textTmpl, textOk := v.(*textTemplate.Template); textOk {
 			return textTmpl
 		}
 	}
-	return getOrCreateTextLayoutChain(layouts)
+	return ts.getOrCreateTextLayoutChain(layouts)
 }
 
-func getOrCreateTextLayoutChain(layouts []string) *textTemplate.Template {
+func (ts *Templates) getOrCreateTextLayoutChain(layouts []string) *textTemplate.Template {
 	var tmpl *textTemplate.Template
 
 	// Create a text-specific funcMap with partial support
 	textFuncMap := make(textTemplate.FuncMap)
-	for k, v := range funcMap {
+	for k, v := range ts.funcMap {
 		textFuncMap[k] = v
 	}
-	textFuncMap["partial"] = getTextPartialFunc("")
+	textFuncMap["partial"] = ts.getTextPartialFunc("")
 
 	for i := range layouts {
-		if v, ok := layoutsCache[layouts[i]]; ok {
+		if v, ok := ts.layoutsCache[layouts[i]]; ok {
 			if textTmpl, textOk := v.(*textTemplate.Template); textOk {
 				tmpl = textTmpl
 			}
 		} else {
 			if tmpl == nil {
 				tmplName := filepath.Base(layouts[i])
-				tmpl = textTemplate.Must(textTemplate.New(tmplName).Funcs(textFuncMap).ParseFS(config.FS, layouts[i]))
+				tmpl = textTemplate.Must(textTemplate.New(tmplName).Funcs(textFuncMap).ParseFS(ts.config.FS, layouts[i]))
 			} else {
-				data := Must(fs.ReadFile(config.FS, layouts[i]))
+				data := Must(fs.ReadFile(ts.config.FS, layouts[i]))
 
 				tmpl = Must(textTemplate.Must(tmpl.Clone()).Funcs(textFuncMap).Parse(string(data)))
 			}
-			layoutsCache[layouts[i]] = tmpl
+			ts.layoutsCache[layouts[i]] = tmpl
 		}
 	}
 	return tmpl
@@ -7,25 +7,15 @@ import (
 	htmlTemplate "html/template"
 	"io/fs"
 	"strings"
-	"sync"
 	"testing"
+	"testing/fstest"
 )
 
 //go:embed all:testdata/**
 var testFS embed.FS
 
-func resetTemplateConfig() {
-	config = nil
-	templatesCache = sync.Map{}
-	partialsCache = sync.Map{}
-	layoutsCache = make(map[string]any)
-	layoutPattern = nil
-	funcMap = htmlTemplate.FuncMap{}
-}
-
-func setupTestTemplateConfig(t *testing.T) {
+func setupTestTemplateConfig(t *testing.T) *Templates {
 	t.Helper()
-	resetTemplateConfig()
 
 	cfg := &Config{
 		FS:                    testFS,
@@ -35,12 +25,10 @@ func setupTestTemplateConfig(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	return New(cfg)
 }
 
-func TestConfigure(t *testing.T) {
-	resetTemplateConfig()
-
+func TestNew(t *testing.T) {
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -49,32 +37,30 @@ func TestConfigure(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
-	if config == nil {
+	if ts.config == nil {
 		t.Fatal("Config was not set")
 	}
 
-	if htmlLayoutFileName != "layout.go.html" {
-		t.Errorf("Expected htmlLayoutFileName 'layout.go.html', got %q", htmlLayoutFileName)
+	if ts.htmlLayoutFileName != "layout.go.html" {
+		t.Errorf("Expected htmlLayoutFileName 'layout.go.html', got %q", ts.htmlLayoutFileName)
 	}
 
-	if textLayoutFileName != "layout.go.txt" {
-		t.Errorf("Expected textLayoutFileName 'layout.go.txt', got %q", textLayoutFileName)
+	if ts.textLayoutFileName != "layout.go.txt" {
+		t.Errorf("Expected textLayoutFileName 'layout.go.txt', got %q", ts.textLayoutFileName)
 	}
 
-	if layoutPattern == nil {
+	if ts.layoutPattern == nil {
 		t.Fatal("layoutPattern was not set")
 	}
 
-	if _, ok := funcMap[cfg.I18nFuncName]; !ok {
+	if _, ok := ts.funcMap[cfg.I18nFuncName]; !ok {
 		t.Errorf("I18n function %q was not added to funcMap", cfg.I18nFuncName)
 	}
 }
 
 func TestConfiguration(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -83,9 +69,9 @@ func TestConfiguration(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
-	result, ok := Configuration()
+	result, ok := ts.Configuration()
 
 	if !ok {
 		t.Fatal("Expected valid configuration")
@@ -102,6 +88,11 @@ func TestConfiguration(t *testing.T) {
 	if result.TextTemplateExtension != cfg.TextTemplateExtension {
 		t.Errorf("Expected TextTemplateExtension %q, got %q", cfg.TextTemplateExtension, result.TextTemplateExtension)
 	}
+
+	var unconfigured *Templates
+	if _, ok := unconfigured.Configuration(); ok {
+		t.Error("Expected no configuration for nil templates")
+	}
 }
 
 func TestMust_Success(t *testing.T) {
@@ -122,8 +113,6 @@ func TestMust_Panic(t *testing.T) {
 }
 
 func TestLookupTemplate_Absolute(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -132,13 +121,13 @@ func TestLookupTemplate_Absolute(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
 	// Store a test template
 	tmpl := htmlTemplate.Must(htmlTemplate.New("test").Parse("<h1>Test</h1>"))
-	templatesCache.Store("testdata/test.go.html", [2]any{"test", tmpl})
+	ts.templatesCache.Store("testdata/test.go.html", [2]any{"test", tmpl})
 
-	result, ok := LookupTemplate("testdata/test.go.html", true)
+	result, ok := ts.LookupTemplate("testdata/test.go.html", true)
 	if !ok {
 		t.Fatal("Template not found")
 	}
@@ -149,8 +138,6 @@ func TestLookupTemplate_Absolute(t *testing.T) {
 }
 
 func TestLookupTemplate_Relative(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -159,13 +146,13 @@ func TestLookupTemplate_Relative(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
 	// Store a test template
 	tmpl := htmlTemplate.Must(htmlTemplate.New("test").Parse("<h1>Test</h1>"))
-	templatesCache.Store("testdata/test.go.html", [2]any{"test", tmpl})
+	ts.templatesCache.Store("testdata/test.go.html", [2]any{"test", tmpl})
 
-	result, ok := LookupTemplate("test.go.html", false)
+	result, ok := ts.LookupTemplate("test.go.html", false)
 	if !ok {
 		t.Fatal("Template not found")
 	}
@@ -176,8 +163,6 @@ func TestLookupTemplate_Relative(t *testing.T) {
 }
 
 func TestLookupTemplate_NotFound(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -186,9 +171,9 @@ func TestLookupTemplate_NotFound(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
-	result, ok := LookupTemplate("nonexistent.go.html", false)
+	result, ok := ts.LookupTemplate("nonexistent.go.html", false)
 	if ok {
 		t.Error("Expected template not to be found")
 	}
@@ -300,12 +285,12 @@ func TestGetLayout_AmbiguousLayouts(t *testing.T) {
 }
 
 func TestParseHTMLTemplate_WithoutLayout(t *testing.T) {
-	setupTestTemplateConfig(t)
+	ts := setupTestTemplateConfig(t)
 
 	templatePath := "testdata/simple.go.html"
 	layouts := []string{}
 
-	name, tmpl := parseHTMLTemplate(templatePath, layouts)
+	name, tmpl := ts.parseHTMLTemplate(templatePath, layouts)
 
 	if name == "" {
 		t.Error("Expected non-empty template name")
@@ -324,8 +309,6 @@ func TestParseHTMLTemplate_WithoutLayout(t *testing.T) {
 }
 
 func TestParseHTMLTemplate_WithLayout(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -334,12 +317,12 @@ func TestParseHTMLTemplate_WithLayout(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
 	templatePath := "testdata/withLayout.go.html"
 	layouts := []string{"testdata/layout.go.html"}
 
-	name, tmpl := parseHTMLTemplate(templatePath, layouts)
+	name, tmpl := ts.parseHTMLTemplate(templatePath, layouts)
 
 	if name == "" {
 		t.Error("Expected non-empty template name")
@@ -351,12 +334,12 @@ func TestParseHTMLTemplate_WithLayout(t *testing.T) {
 }
 
 func TestParseTextTemplate_WithoutLayout(t *testing.T) {
-	setupTestTemplateConfig(t)
+	ts := setupTestTemplateConfig(t)
 
 	templatePath := "testdata/simple.go.txt"
 	layouts := []string{}
 
-	name, tmpl := parseTextTemplate(templatePath, layouts)
+	name, tmpl := ts.parseTextTemplate(templatePath, layouts)
 
 	if name == "" {
 		t.Error("Expected non-empty template name")
@@ -375,8 +358,6 @@ func TestParseTextTemplate_WithoutLayout(t *testing.T) {
 }
 
 func TestParseTextTemplate_WithLayout(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -385,12 +366,12 @@ func TestParseTextTemplate_WithLayout(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
 	templatePath := "testdata/withLayout.go.txt"
 	layouts := []string{"testdata/layout.go.txt"}
 
-	name, tmpl := parseTextTemplate(templatePath, layouts)
+	name, tmpl := ts.parseTextTemplate(templatePath, layouts)
 
 	if name == "" {
 		t.Error("Expected non-empty template name")
@@ -402,8 +383,6 @@ func TestParseTextTemplate_WithLayout(t *testing.T) {
 }
 
 func TestGetPartialFunc(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -412,13 +391,13 @@ func TestGetPartialFunc(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
 	// Store a partial template
 	partialTmpl := htmlTemplate.Must(htmlTemplate.New("_header").Parse("<header>{{.Title}}</header>"))
-	templatesCache.Store("testdata/_header.go.html", [2]any{"_header", partialTmpl})
+	ts.templatesCache.Store("testdata/_header.go.html", [2]any{"_header", partialTmpl})
 
-	partialFunc := getPartialFunc("testdata/page.go.html")
+	partialFunc := ts.getPartialFunc("testdata/page.go.html")
 
 	data := map[string]string{"Title": "Test Header"}
 	result, err := partialFunc("header", data)
@@ -434,8 +413,6 @@ func TestGetPartialFunc(t *testing.T) {
 }
 
 func TestGetPartialFunc_NotFound(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -444,9 +421,9 @@ func TestGetPartialFunc_NotFound(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
-	partialFunc := getPartialFunc("testdata/page.go.html")
+	partialFunc := ts.getPartialFunc("testdata/page.go.html")
 
 	data := map[string]string{"Title": "Test"}
 	_, err := partialFunc("nonexistent", data)
@@ -461,8 +438,6 @@ func TestGetPartialFunc_NotFound(t *testing.T) {
 }
 
 func TestLookUpPartial(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -471,14 +446,14 @@ func TestLookUpPartial(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
 	// Store a partial at the root
 	partialTmpl := htmlTemplate.Must(htmlTemplate.New("_partial").Parse("<div>Partial</div>"))
-	templatesCache.Store("testdata/_partial.go.html", [2]any{"_partial", partialTmpl})
+	ts.templatesCache.Store("testdata/_partial.go.html", [2]any{"_partial", partialTmpl})
 
 	// Look up from a nested folder
-	result := lookUpPartial("testdata/nested/deep", "_partial.go.html")
+	result := ts.lookUpPartial("testdata/nested/deep", "_partial.go.html")
 
 	if result == nil {
 		t.Error("Expected to find partial in parent directory")
@@ -486,8 +461,6 @@ func TestLookUpPartial(t *testing.T) {
 }
 
 func TestLookUpPartial_NotFound(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -496,9 +469,9 @@ func TestLookUpPartial_NotFound(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
-	result := lookUpPartial("testdata", "_nonexistent.go.html")
+	result := ts.lookUpPartial("testdata", "_nonexistent.go.html")
 
 	if result != nil {
 		t.Error("Expected nil for non-existent partial")
@@ -509,8 +482,6 @@ func TestLookUpPartial_NotFound(t *testing.T) {
 // when looking up from a subdirectory. This is a critical test for the bug fix
 // where the condition check was happening before the root lookup.
 func TestLookUpPartial_RootLevel(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -519,7 +490,7 @@ func TestLookUpPartial_RootLevel(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
 	tests := []struct {
 		name           string
@@ -579,7 +550,7 @@ func TestLookUpPartial_RootLevel(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := lookUpPartial(tt.startFolder, tt.partialFile)
+			result := ts.lookUpPartial(tt.startFolder, tt.partialFile)
 
 			if tt.shouldFind {
 				if result == nil {
@@ -607,8 +578,6 @@ func TestLookUpPartial_RootLevel(t *testing.T) {
 
 // TestLookUpPartial_Caching tests that the partial lookup results are properly cached.
 func TestLookUpPartial_Caching(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -617,16 +586,16 @@ func TestLookUpPartial_Caching(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
 	// First lookup - should cache the result
-	result1 := lookUpPartial("testdata/nested/deep", "_root_partial.go.html")
+	result1 := ts.lookUpPartial("testdata/nested/deep", "_root_partial.go.html")
 	if result1 == nil {
 		t.Fatal("Expected to find partial on first lookup")
 	}
 
 	// Second lookup - should return cached result
-	result2 := lookUpPartial("testdata/nested/deep", "_root_partial.go.html")
+	result2 := ts.lookUpPartial("testdata/nested/deep", "_root_partial.go.html")
 	if result2 == nil {
 		t.Fatal("Expected to find cached partial on second lookup")
 	}
@@ -636,12 +605,12 @@ func TestLookUpPartial_Caching(t *testing.T) {
 	}
 
 	// Test caching of not-found results
-	notFound1 := lookUpPartial("testdata", "_nonexistent.go.html")
+	notFound1 := ts.lookUpPartial("testdata", "_nonexistent.go.html")
 	if notFound1 != nil {
 		t.Error("Expected nil for non-existent partial")
 	}
 
-	notFound2 := lookUpPartial("testdata", "_nonexistent.go.html")
+	notFound2 := ts.lookUpPartial("testdata", "_nonexistent.go.html")
 	if notFound2 != nil {
 		t.Error("Expected cached nil for non-existent partial")
 	}
@@ -649,8 +618,6 @@ func TestLookUpPartial_Caching(t *testing.T) {
 
 // TestLookUpPartial_EmptyFolder tests edge cases with empty or root folder paths.
 func TestLookUpPartial_EmptyFolder(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -659,7 +626,7 @@ func TestLookUpPartial_EmptyFolder(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
 	tests := []struct {
 		name        string
@@ -697,9 +664,9 @@ func TestLookUpPartial_EmptyFolder(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Clear cache for this test
 			cacheKey := tt.folder + "|" + tt.partialFile
-			partialsCache.Delete(cacheKey)
+			ts.partialsCache.Delete(cacheKey)
 
-			result := lookUpPartial(tt.folder, tt.partialFile)
+			result := ts.lookUpPartial(tt.folder, tt.partialFile)
 
 			if tt.shouldFind && result == nil {
 				t.Errorf("Expected to find partial %s from folder %q, but got nil", tt.partialFile, tt.folder)
@@ -712,8 +679,6 @@ func TestLookUpPartial_EmptyFolder(t *testing.T) {
 
 // TestGetPartialFuncWithI18n tests the i18n-aware partial function creation.
 func TestGetPartialFuncWithI18n(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -722,7 +687,7 @@ func TestGetPartialFuncWithI18n(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
 	tests := []struct {
 		name           string
@@ -760,7 +725,7 @@ func TestGetPartialFuncWithI18n(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			partialFunc := GetPartialFuncWithI18n("testdata/page.go.html", tt.i18nFunc)
+			partialFunc := ts.GetPartialFuncWithI18n("testdata/page.go.html", tt.i18nFunc)
 
 			result, err := partialFunc(tt.partialName, tt.data)
 
@@ -785,8 +750,6 @@ func TestGetPartialFuncWithI18n(t *testing.T) {
 
 // TestGetPartialFuncWithI18n_NestedPartials tests that nested partials inherit i18n.
 func TestGetPartialFuncWithI18n_NestedPartials(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -795,14 +758,14 @@ func TestGetPartialFuncWithI18n_NestedPartials(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
 	// Create a custom i18n function that prefixes with language tag
 	spanishFunc := func(format string, _ ...any) string {
 		return "[ES] " + format
 	}
 
-	partialFunc := GetPartialFuncWithI18n("testdata/page.go.html", spanishFunc)
+	partialFunc := ts.GetPartialFuncWithI18n("testdata/page.go.html", spanishFunc)
 
 	result, err := partialFunc("nested_i18n", map[string]string{"Name": "Maria"})
 
@@ -824,8 +787,6 @@ func TestGetPartialFuncWithI18n_NestedPartials(t *testing.T) {
 
 // TestGetPartialFuncWithI18n_TemplateCloning tests that i18n injection doesn't affect cached templates.
 func TestGetPartialFuncWithI18n_TemplateCloning(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -834,7 +795,7 @@ func TestGetPartialFuncWithI18n_TemplateCloning(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
 	// Create two different i18n functions
 	englishFunc := func(format string, _ ...any) string {
@@ -846,8 +807,8 @@ func TestGetPartialFuncWithI18n_TemplateCloning(t *testing.T) {
 	}
 
 	// Create partial functions with different i18n
-	partialFuncEN := GetPartialFuncWithI18n("testdata/page.go.html", englishFunc)
-	partialFuncFR := GetPartialFuncWithI18n("testdata/page.go.html", frenchFunc)
+	partialFuncEN := ts.GetPartialFuncWithI18n("testdata/page.go.html", englishFunc)
+	partialFuncFR := ts.GetPartialFuncWithI18n("testdata/page.go.html", frenchFunc)
 
 	// Execute with English
 	resultEN, err := partialFuncEN("i18n_test", map[string]string{"Name": "John"})
@@ -878,8 +839,6 @@ func TestGetPartialFuncWithI18n_TemplateCloning(t *testing.T) {
 
 // TestGetPartialFunc_DefaultBehavior tests that getPartialFunc works without custom i18n injection.
 func TestGetPartialFunc_DefaultBehavior(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -888,10 +847,10 @@ func TestGetPartialFunc_DefaultBehavior(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
 	// Set up a default i18n in funcMap (simulates what the template system does)
-	funcMap[cfg.I18nFuncName] = func(format string, args ...any) string {
+	ts.funcMap[cfg.I18nFuncName] = func(format string, args ...any) string {
 		// This simulates the default fmt.Sprintf behavior
 		if len(args) > 0 {
 			return fmt.Sprintf(format, args...)
@@ -899,7 +858,7 @@ func TestGetPartialFunc_DefaultBehavior(t *testing.T) {
 		return format
 	}
 
-	partialFunc := getPartialFunc("testdata/page.go.html")
+	partialFunc := ts.getPartialFunc("testdata/page.go.html")
 
 	result, err := partialFunc("i18n_test", map[string]string{"Name": "Default"})
 
@@ -919,8 +878,6 @@ func TestGetPartialFunc_DefaultBehavior(t *testing.T) {
 }
 
 func TestGetOrCreateHTMLLayoutChain(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -929,18 +886,18 @@ func TestGetOrCreateHTMLLayoutChain(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
 	layouts := []string{"testdata/layout.go.html"}
 
-	tmpl := getOrCreateHTMLLayoutChain(layouts)
+	tmpl := ts.getOrCreateHTMLLayoutChain(layouts)
 
 	if tmpl == nil {
 		t.Fatal("Expected non-nil template")
 	}
 
 	// Call again to test caching
-	tmpl2 := getOrCreateHTMLLayoutChain(layouts)
+	tmpl2 := ts.getOrCreateHTMLLayoutChain(layouts)
 
 	if tmpl2 == nil {
 		t.Fatal("Expected non-nil cached template")
@@ -948,8 +905,6 @@ func TestGetOrCreateHTMLLayoutChain(t *testing.T) {
 }
 
 func TestGetOrCreateTextLayoutChain(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -958,18 +913,18 @@ func TestGetOrCreateTextLayoutChain(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
 	layouts := []string{"testdata/layout.go.txt"}
 
-	tmpl := getOrCreateTextLayoutChain(layouts)
+	tmpl := ts.getOrCreateTextLayoutChain(layouts)
 
 	if tmpl == nil {
 		t.Fatal("Expected non-nil template")
 	}
 
 	// Call again to test caching
-	tmpl2 := getOrCreateTextLayoutChain(layouts)
+	tmpl2 := ts.getOrCreateTextLayoutChain(layouts)
 
 	if tmpl2 == nil {
 		t.Fatal("Expected non-nil cached template")
@@ -977,8 +932,6 @@ func TestGetOrCreateTextLayoutChain(t *testing.T) {
 }
 
 func TestCacheTemplates(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -987,19 +940,17 @@ func TestCacheTemplates(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
 	// After Configure, templates should be cached
 	// Check that some templates exist
-	_, ok := LookupTemplate("simple.go.html", false)
+	_, ok := ts.LookupTemplate("simple.go.html", false)
 	if !ok {
 		t.Error("Expected simple.go.html to be cached")
 	}
 }
 
 func TestLayoutPattern(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -1008,7 +959,7 @@ func TestLayoutPattern(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
 	tests := []struct {
 		name     string
@@ -1025,7 +976,7 @@ func TestLayoutPattern(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := layoutPattern.MatchString(tt.filename)
+			result := ts.layoutPattern.MatchString(tt.filename)
 			if result != tt.expected {
 				t.Errorf("Expected match=%v for %q, got %v", tt.expected, tt.filename, result)
 			}
@@ -1034,8 +985,6 @@ func TestLayoutPattern(t *testing.T) {
 }
 
 func TestFuncMap_I18nFunction(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -1044,10 +993,10 @@ func TestFuncMap_I18nFunction(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
 	// Test that the I18n function works
-	i18nFunc, ok := funcMap["T"].(func(string, ...any) string)
+	i18nFunc, ok := ts.funcMap["T"].(func(string, ...any) string)
 	if !ok {
 		t.Fatal("I18n function not found in funcMap")
 	}
@@ -1059,8 +1008,6 @@ func TestFuncMap_I18nFunction(t *testing.T) {
 }
 
 func BenchmarkLookupTemplate(b *testing.B) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -1069,21 +1016,19 @@ func BenchmarkLookupTemplate(b *testing.B) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
 	// Store a test template
 	tmpl := htmlTemplate.Must(htmlTemplate.New("test").Parse("<h1>Test</h1>"))
-	templatesCache.Store("testdata/test.go.html", [2]any{"test", tmpl})
+	ts.templatesCache.Store("testdata/test.go.html", [2]any{"test", tmpl})
 
 	b.ResetTimer()
 	for b.Loop() {
-		LookupTemplate("test.go.html", false)
+		ts.LookupTemplate("test.go.html", false)
 	}
 }
 
 func BenchmarkParseHTMLTemplate(b *testing.B) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -1092,22 +1037,20 @@ func BenchmarkParseHTMLTemplate(b *testing.B) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
 	templatePath := "testdata/simple.go.html"
 	layouts := []string{}
 
 	b.ResetTimer()
 	for b.Loop() {
-		parseHTMLTemplate(templatePath, layouts)
+		ts.parseHTMLTemplate(templatePath, layouts)
 	}
 }
 
 // Text template partial tests
 
 func TestLookUpTextPartial_RootLevel(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -1116,7 +1059,7 @@ func TestLookUpTextPartial_RootLevel(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
 	tests := []struct {
 		name           string
@@ -1156,7 +1099,7 @@ func TestLookUpTextPartial_RootLevel(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := lookUpTextPartial(tt.startFolder, tt.partialFile)
+			result := ts.lookUpTextPartial(tt.startFolder, tt.partialFile)
 
 			if tt.shouldFind {
 				if result == nil {
@@ -1183,8 +1126,6 @@ func TestLookUpTextPartial_RootLevel(t *testing.T) {
 }
 
 func TestGetTextPartialFuncWithI18n(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -1193,7 +1134,7 @@ func TestGetTextPartialFuncWithI18n(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
 	tests := []struct {
 		name           string
@@ -1230,7 +1171,7 @@ func TestGetTextPartialFuncWithI18n(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			partialFunc := GetTextPartialFuncWithI18n("testdata/page.go.txt", tt.i18nFunc)
+			partialFunc := ts.GetTextPartialFuncWithI18n("testdata/page.go.txt", tt.i18nFunc)
 
 			result, err := partialFunc(tt.partialName, tt.data)
 
@@ -1253,8 +1194,6 @@ func TestGetTextPartialFuncWithI18n(t *testing.T) {
 }
 
 func TestGetTextPartialFuncWithI18n_NestedPartials(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -1263,13 +1202,13 @@ func TestGetTextPartialFuncWithI18n_NestedPartials(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
 	spanishFunc := func(format string, _ ...any) string {
 		return "[ES] " + format
 	}
 
-	partialFunc := GetTextPartialFuncWithI18n("testdata/page.go.txt", spanishFunc)
+	partialFunc := ts.GetTextPartialFuncWithI18n("testdata/page.go.txt", spanishFunc)
 
 	result, err := partialFunc("nested_i18n", map[string]string{"Name": "Maria"})
 
@@ -1288,8 +1227,6 @@ func TestGetTextPartialFuncWithI18n_NestedPartials(t *testing.T) {
 }
 
 func TestGetTextPartialFuncWithI18n_TemplateCloning(t *testing.T) {
-	resetTemplateConfig()
-
 	cfg := &Config{
 		FS:                    testFS,
 		LayoutBaseName:        "layout",
@@ -1298,7 +1235,7 @@ func TestGetTextPartialFuncWithI18n_TemplateCloning(t *testing.T) {
 		I18nFuncName:          "T",
 	}
 
-	Configure(cfg)
+	ts := New(cfg)
 
 	englishFunc := func(format string, _ ...any) string {
 		return "[EN] " + format
@@ -1308,8 +1245,8 @@ func TestGetTextPartialFuncWithI18n_TemplateCloning(t *testing.T) {
 		return "[FR] " + format
 	}
 
-	partialFuncEN := GetTextPartialFuncWithI18n("testdata/page.go.txt", englishFunc)
-	partialFuncFR := GetTextPartialFuncWithI18n("testdata/page.go.txt", frenchFunc)
+	partialFuncEN := ts.GetTextPartialFuncWithI18n("testdata/page.go.txt", englishFunc)
+	partialFuncFR := ts.GetTextPartialFuncWithI18n("testdata/page.go.txt", frenchFunc)
 
 	resultEN, err := partialFuncEN("i18n_test", map[string]string{"Name": "John"})
 	if err != nil {
@@ -1333,3 +1270,64 @@ func TestGetTextPartialFuncWithI18n_TemplateCloning(t *testing.T) {
 		t.Error("Expected different results for different i18n functions")
 	}
 }
+
+func TestLookupTemplateWithLayout(t *testing.T) {
+	ts := New(&Config{
+		FS: fstest.MapFS{
+			"layout.go.html":            {Data: []byte(`<public>{{template "content" .}}</public>`)},
+			"admin.go.html":             {Data: []byte(`<admin>{{block "content" .}}empty{{end}}</admin>`)},
+			"page.go.html":              {Data: []byte(`{{define "content"}}page{{end}}`)},
+			"admin/users.go.html":       {Data: []byte(`{{define "content"}}users{{end}}`)},
+			"emails/_admin.go.html":     {Data: []byte(`<mail>{{template "content" .}}</mail>`)},
+			"emails/welcome.go.html":    {Data: []byte(`{{define "content"}}welcome{{end}}`)},
+			"standalone/_plain.go.html": {Data: []byte(`standalone`)},
+		},
+		LayoutBaseName:        "layout",
+		HTMLTemplateExtension: ".go.html",
+		TextTemplateExtension: ".go.txt",
+		I18nFuncName:          "T",
+		Layouts:               []string{"admin"},
+	})
+
+	render := func(tmpl *htmlTemplate.Template) string {
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, nil); err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+		return sb.String()
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		layout   string
+		expected string
+	}{
+		{"default layout", "page.go.html", "", "<public>page</public>"},
+		{"named layout", "page.go.html", "admin", "<admin>page</admin>"},
+		{"named layout in subdirectory", "admin/users.go.html", "admin", "<admin>users</admin>"},
+		{"non-inherited named layout", "emails/welcome.go.html", "admin", "<mail>welcome</mail>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, ok := ts.LookupTemplateWithLayout(tt.path, tt.layout)
+			if !ok {
+				t.Fatalf("Expected template %q with layout %q to be found", tt.path, tt.layout)
+			}
+			if got := render(tmpl); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+
+	if _, ok := ts.LookupTemplateWithLayout("page.go.html", "unknown"); ok {
+		t.Error("Expected unknown layout not to be found")
+	}
+	if _, ok := ts.LookupTemplateWithLayout("standalone/_plain.go.html", "admin"); ok {
+		t.Error("Expected templates without layout not to be available with a named layout")
+	}
+	if _, ok := ts.LookupTemplate("admin.go.html", false); ok {
+		t.Error("Expected named layout files not to be cached as templates")
+	}
+}
@@ -0,0 +1,79 @@
+package webfram
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/bondowe/webfram/internal/bind"
+)
+
+// JSONArrayError is returned by BindJSONArray when an element of the JSON array is malformed or invalid.
+type JSONArrayError struct {
+	// Index is the zero-based index of the element in the array.
+	Index int
+	// Errors are the validation errors of the element, if it is invalid.
+	Errors []ValidationError
+	// Err is the decoding error, if the element is malformed. Syntax errors, type mismatches
+	// and unknown fields are reported as a *DecodeError with a localized message.
+	Err error
+}
+
+// Error returns a description of the error including the element index.
+func (e *JSONArrayError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("json array element %d: %v", e.Index, e.Err)
+	}
+
+	msgs := make([]string, 0, len(e.Errors))
+	for _, ve := range e.Errors {
+		msgs = append(msgs, ve.Field+" "+ve.Error)
+	}
+
+	return fmt.Sprintf("json array element %d: %s", e.Index, strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the underlying decoding error.
+func (e *JSONArrayError) Unwrap() error {
+	return e.Err
+}
+
+// BindJSONArray parses a JSON array from the request body and calls fn with every element bound to the provided
+// type T and its zero-based index. The body is read as a stream and elements are decoded one at a time, so large
+// arrays are not buffered. The request body is limited to the configured MaxUploadSize.
+// Every element is validated according to struct tags (validate, errmsg), and unknown fields are rejected.
+// Reading stops at the first malformed or invalid element, which is reported as a *JSONArrayError with its index,
+// or at the first error returned by fn, which is returned as is; elements before it have already been passed to fn.
+// Returns an error wrapping ErrNotJSONArray if the body is not an array, ErrUploadTooLarge if the limit is exceeded,
+// and io.EOF if the body is empty. If the request context is canceled while the body is being read, the error wraps
+// the context error.
+func BindJSONArray[T any](r *Request, fn func(item T, index int) error) error {
+	r.Body = http.MaxBytesReader(nil, r.Body, appFromContext(r.Context()).maxUploadSize)
+	if err := decompressBody(r); err != nil {
+		return err
+	}
+
+	err := bind.JSONArray(r.Context(), r.Body, func(item T, index int, valErrors []bind.ValidationError) error {
+		if len(valErrors) > 0 {
+			arrErr := &JSONArrayError{Index: index}
+			for _, ve := range valErrors {
+				arrErr.Errors = append(arrErr.Errors, validationError(r, &ve))
+			}
+			reportValidationErrors(r, &ValidationErrors{Errors: arrErr.Errors})
+			return arrErr
+		}
+
+		return fn(item, index)
+	})
+
+	var elemErr *bind.ArrayElementError
+	if errors.As(err, &elemErr) {
+		if err := uploadError(elemErr.Err); errors.Is(err, ErrUploadTooLarge) {
+			return err
+		}
+		return &JSONArrayError{Index: elemErr.Index, Err: localizeDecodeError(r, elemErr.Err)}
+	}
+
+	return uploadError(err)
+}
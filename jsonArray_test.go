@@ -0,0 +1,122 @@
+package webfram
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type jsonArrayItem struct {
+	Email string `json:"email" validate:"required,format=email"`
+	Age   int    `json:"age"   validate:"min=18"`
+}
+
+func newJSONArrayRequest(body string) *Request {
+	r := NewTestRequest(http.MethodPost, "/import", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	return r
+}
+
+func TestBindJSONArray(t *testing.T) {
+	r := newJSONArrayRequest(`[{"email":"ann@example.com","age":30}, {"email":"bob@example.com","age":42}]`)
+
+	var items []jsonArrayItem
+	err := BindJSONArray(r, func(item jsonArrayItem, index int) error {
+		if index != len(items) {
+			t.Errorf("Expected index %d, got %d", len(items), index)
+		}
+		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("BindJSONArray failed: %v", err)
+	}
+
+	if len(items) != 2 || items[1].Email != "bob@example.com" || items[1].Age != 42 {
+		t.Errorf("Unexpected items: %+v", items)
+	}
+}
+
+func TestBindJSONArray_ElementErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		index     int
+		decodeErr bool
+	}{
+		{"validation", `[{"email":"ann@example.com","age":30},{"email":"nope","age":12}]`, 1, false},
+		{"type mismatch", `[{"email":"ann@example.com","age":30},{"email":"bob@example.com","age":"x"}]`, 1, true},
+		{"unknown field", `[{"email":"ann@example.com","age":30},{"name":"bob"}]`, 1, true},
+		{"truncated", `[{"email":"ann@example.com","age":30}`, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count := 0
+			err := BindJSONArray(newJSONArrayRequest(tt.body), func(_ jsonArrayItem, _ int) error {
+				count++
+				return nil
+			})
+
+			var arrErr *JSONArrayError
+			if !errors.As(err, &arrErr) {
+				t.Fatalf("Expected *JSONArrayError, got %v", err)
+			}
+			if arrErr.Index != tt.index {
+				t.Errorf("Expected index %d, got %d", tt.index, arrErr.Index)
+			}
+			if (arrErr.Err != nil) != tt.decodeErr {
+				t.Errorf("Unexpected decode error: %v", arrErr.Err)
+			}
+			if !tt.decodeErr && len(arrErr.Errors) != 2 {
+				t.Errorf("Expected 2 validation errors, got %v", arrErr.Errors)
+			}
+			if count != 1 {
+				t.Errorf("Expected the valid element before the error to be passed to fn, got %d elements", count)
+			}
+		})
+	}
+}
+
+func TestBindJSONArray_Errors(t *testing.T) {
+	stop := errors.New("stop")
+
+	tests := []struct {
+		name string
+		body string
+		fn   func(jsonArrayItem, int) error
+		want error
+	}{
+		{"not an array", `{"email":"ann@example.com"}`, nil, ErrNotJSONArray},
+		{"empty body", ``, nil, io.EOF},
+		{"callback error", `[{"email":"ann@example.com","age":30}]`, func(jsonArrayItem, int) error { return stop }, stop},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn := tt.fn
+			if fn == nil {
+				fn = func(jsonArrayItem, int) error { return nil }
+			}
+
+			if err := BindJSONArray(newJSONArrayRequest(tt.body), fn); !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestBindJSONArray_TooLarge(t *testing.T) {
+	previous := defaultApp.maxUploadSize
+	defaultApp.maxUploadSize = 64
+	defer func() { defaultApp.maxUploadSize = previous }()
+
+	body := "[" + strings.Repeat(`{"email":"ann@example.com","age":30},`, 10) + `{"email":"ann@example.com","age":30}]`
+
+	err := BindJSONArray(newJSONArrayRequest(body), func(jsonArrayItem, int) error { return nil })
+	if !errors.Is(err, ErrUploadTooLarge) {
+		t.Errorf("Expected ErrUploadTooLarge, got %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package webfram
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	jsonpatch "github.com/evanphx/json-patch"
+)
+
+// JSONPatchError is returned by PatchJSON when an operation of the patch cannot be applied.
+// The data passed to PatchJSON is left unchanged.
+type JSONPatchError struct {
+	// Index is the zero-based index of the operation in the patch.
+	Index int
+	// Op is the kind of the operation, e.g. "test" or "replace".
+	Op string
+	// Path is the JSON pointer the operation applies to.
+	Path string
+	// Err is the error of the operation. It wraps ErrPatchTestFailed if a test operation failed.
+	Err error
+}
+
+// Error returns a description of the error including the failed operation.
+func (e *JSONPatchError) Error() string {
+	return fmt.Sprintf("json patch operation %d (%s %s): %v", e.Index, e.Op, e.Path, e.Err)
+}
+
+// Unwrap returns the error of the operation.
+func (e *JSONPatchError) Unwrap() error {
+	return e.Err
+}
+
+func newJSONPatchError(index int, op jsonpatch.Operation, err error) *JSONPatchError {
+	path, _ := op.Path()
+	patchErr := &JSONPatchError{Index: index, Op: op.Kind(), Path: path, Err: err}
+
+	// A test operation fails when the value differs or the path does not exist.
+	if patchErr.Op == "test" {
+		patchErr.Err = fmt.Errorf("%w: %w", ErrPatchTestFailed, err)
+	}
+
+	return patchErr
+}
+
+// patchTarget returns the value to decode the patched document of t into: a copy of t whose JSON fields are
+// cleared if t is a struct, or the zero value otherwise.
+func patchTarget[T any](t *T) T {
+	patched := *t
+
+	if v := reflect.ValueOf(&patched).Elem(); v.Kind() == reflect.Struct {
+		clearJSONFields(v)
+		return patched
+	}
+
+	var zero T
+	return zero
+}
+
+// clearJSONFields zeroes the fields of the struct v that are encoded in JSON, recursively for nested
+// structs, so that decoding the patched document into v drops the fields removed by the patch while keeping
+// the fields the document cannot hold, such as unexported fields and fields tagged json:"-".
+func clearJSONFields(v reflect.Value) {
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		fv := v.Field(i)
+
+		if field.Tag.Get("json") == "-" {
+			continue
+		}
+
+		// The fields of embedded structs are promoted, even if the struct type is unexported.
+		// Structs decoding themselves are replaced as a whole.
+		if fv.Kind() == reflect.Struct && (field.IsExported() || field.Anonymous) &&
+			!reflect.PointerTo(field.Type).Implements(reflect.TypeFor[json.Unmarshaler]()) {
+			clearJSONFields(fv)
+			continue
+		}
+
+		if fv.CanSet() {
+			fv.SetZero()
+		}
+	}
+}
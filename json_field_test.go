@@ -0,0 +1,79 @@
+package webfram
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func bindJSONFieldRequest(t *testing.T, body string) (testUser, *ValidationErrors, error) {
+	t.Helper()
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	return BindJSONField[testUser](&Request{Request: req}, "data", true)
+}
+
+func TestBindJSONField_Success(t *testing.T) {
+	user, valErrs, err := bindJSONFieldRequest(t,
+		`{"data":{"name":"John","email":"john@example.com","age":30},"meta":{"version":2}}`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if valErrs.Any() {
+		t.Errorf("Unexpected validation errors: %+v", valErrs)
+	}
+	if user.Name != "John" || user.Email != "john@example.com" || user.Age != 30 {
+		t.Errorf("Unexpected bound value: %+v", user)
+	}
+}
+
+func TestBindJSONField_MissingKey(t *testing.T) {
+	for _, body := range []string{`{"user":{"name":"John"}}`, `{"data":null}`} {
+		_, _, err := bindJSONFieldRequest(t, body)
+		if !errors.Is(err, ErrMissingJSONField) {
+			t.Errorf("Expected ErrMissingJSONField for %s, got %v", body, err)
+		}
+	}
+}
+
+func TestBindJSONField_ValidationErrorsArePrefixed(t *testing.T) {
+	_, valErrs, err := bindJSONFieldRequest(t, `{"data":{"name":"J","email":"john@example.com"}}`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(valErrs.Errors) != 1 || valErrs.Errors[0].Field != "data.name" {
+		t.Errorf("Expected a validation error for data.name, got %+v", valErrs.Errors)
+	}
+}
+
+func TestBindJSONField_DecodeErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{"type mismatch", `{"data":{"name":"John","age":"old"}}`, "data.age must be a number"},
+		{"unknown field", `{"data":{"name":"John","nickname":"JD"}}`, "unknown field nickname"},
+		{"not an object", `[]`, "body must be an object"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := bindJSONFieldRequest(t, tt.body)
+
+			var decodeErr *DecodeError
+			if !errors.As(err, &decodeErr) {
+				t.Fatalf("Expected *DecodeError, got %v", err)
+			}
+			if decodeErr.Message != tt.expected {
+				t.Errorf("Expected message %q, got %q", tt.expected, decodeErr.Message)
+			}
+		})
+	}
+}
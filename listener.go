@@ -1,13 +1,16 @@
 package webfram
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	_ "embed"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"strings"
@@ -15,6 +18,7 @@ import (
 	"time"
 
 	"github.com/bondowe/webfram/internal/telemetry"
+	"github.com/bondowe/webfram/openapi"
 )
 
 //go:embed openapi.go.html
@@ -46,29 +50,62 @@ const (
 	maxHeaderBytes    = http.DefaultMaxHeaderBytes
 )
 
-// setupOpenAPIEndpoints configures the OpenAPI endpoints if enabled.
+// setupOpenAPIEndpoints configures the OpenAPI endpoints of the application document, if enabled,
+// and of the named documents of the mux.
 func setupOpenAPIEndpoints(mux *ServeMux) {
-	if openAPIConfig == nil || !openAPIConfig.Enabled {
-		return
-	}
+	app := mux.getApp()
 
-	openAPIConfig.internalConfig.Self = openAPIConfig.URLPath
+	if openAPIConfig := app.openAPIConfig; openAPIConfig != nil && openAPIConfig.Enabled {
+		for _, hc := range app.handlerConfigs {
+			if hc.mux == mux && hc.operation != nil {
+				app.configureOpenAPIOperation(hc.pathPattern, hc.openAPIOperation())
+			}
+		}
+		serveOpenAPIDocument(mux, openAPIConfig, "")
+	}
 
-	for _, hc := range handlerConfigs {
-		if hc.mux == mux && hc.operation != nil {
-			configureOpenAPIOperation(hc.pathPattern, hc.operation)
+	for _, document := range mux.openAPIDocuments {
+		for _, hc := range app.handlerConfigs {
+			if document.includes(hc) {
+				addOpenAPIOperation(document.openAPI.internalConfig, hc.pathPattern, hc.openAPIOperation())
+			}
 		}
+		serveOpenAPIDocument(mux, document.openAPI, document.name)
 	}
+}
+
+// serveOpenAPIDocument serves the OpenAPI document at its URL path and the OpenAPI UI next to it.
+// The document is serialized once and served with an ETag and a Last-Modified date,
+// so that conditional requests are answered with 304 Not Modified.
+func serveOpenAPIDocument(mux *ServeMux, openAPIConfig *OpenAPI, name string) {
+	openAPIConfig.internalConfig.Self = openAPIConfig.URLPath
 
 	doc, err := openAPIConfig.internalConfig.MarshalJSON()
 
 	if err != nil {
 		panic(err)
 	}
-	mux.HandleFunc(openAPIConfig.URLPath, func(w ResponseWriter, _ *Request) {
-		if jsonErr := w.Bytes(doc, "application/json"); jsonErr != nil {
-			w.Error(http.StatusInternalServerError, jsonErr.Error())
+	docETag := contentETag(doc)
+	modTime := time.Now()
+	serverFromRequest := openAPIConfig.ServerFromRequest && len(openAPIConfig.internalConfig.Servers) == 0
+
+	mux.HandleFunc(openAPIConfig.URLPath, func(w ResponseWriter, r *Request) {
+		body, etag := doc, docETag
+		if serverFromRequest {
+			var docErr error
+			if body, docErr = openAPIDocumentForRequest(openAPIConfig, r); docErr != nil {
+				w.Error(http.StatusInternalServerError, docErr.Error())
+				return
+			}
+			etag = contentETag(body)
+			w.Header().Add("Vary", "X-Forwarded-Proto, X-Forwarded-Host")
 		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("ETag", etag)
+
+		http.ServeContent(w.ResponseWriter, r.Request, "", modTime, bytes.NewReader(body))
 	})
 
 	openAPIDocumentPath := strings.TrimPrefix(openAPIConfig.URLPath, "GET ")
@@ -90,13 +127,56 @@ func setupOpenAPIEndpoints(mux *ServeMux) {
 	})
 
 	if os.Getenv("WEBFRAM_SILENT") == "" {
-		slog.Info("OpenAPI docs: " + openAPIConfig.URLPath) //nolint:sloglint // Startup logging is acceptable
-		slog.Info("OpenAPI UI: " + pageURL)                 //nolint:sloglint // Startup logging is acceptable
+		label := "OpenAPI"
+		if name != "" {
+			label += " (" + name + ")"
+		}
+		slog.Info(label + " docs: " + openAPIConfig.URLPath) //nolint:sloglint // Startup logging is acceptable
+		slog.Info(label + " UI: " + pageURL)                 //nolint:sloglint // Startup logging is acceptable
+	}
+}
+
+// openAPIDocumentForRequest marshals the OpenAPI document with the URL the request was sent to as its server.
+func openAPIDocumentForRequest(openAPIConfig *OpenAPI, r *Request) ([]byte, error) {
+	cfg := *openAPIConfig.internalConfig
+	cfg.Servers = []openapi.Server{{URL: requestBaseURL(r)}}
+
+	return cfg.MarshalJSON()
+}
+
+// requestBaseURL returns the scheme and host of the request (e.g., "https://api.example.com"),
+// preferring the X-Forwarded-Proto and X-Forwarded-Host headers if the request comes from a trusted proxy.
+func requestBaseURL(r *Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
 	}
+	host := r.Host
+
+	if r.fromTrustedProxy() {
+		if proto := forwardedHeaderValue(r, "X-Forwarded-Proto"); proto == "http" || proto == "https" {
+			scheme = proto
+		}
+		if forwardedHost := forwardedHeaderValue(r, "X-Forwarded-Host"); forwardedHost != "" {
+			host = forwardedHost
+		}
+	}
+
+	return (&url.URL{Scheme: scheme, Host: host}).String()
+}
+
+// forwardedHeaderValue returns the first value of a comma-separated forwarding header,
+// which is the one set by the proxy closest to the client.
+func forwardedHeaderValue(r *Request, name string) string {
+	value, _, _ := strings.Cut(r.Header.Get(name), ",")
+	return strings.ToLower(strings.TrimSpace(value))
 }
 
 // setupTelemetry configures telemetry endpoints and returns a telemetry server if configured separately.
+// When the telemetry address is empty or the same as addr, the metrics endpoint is registered on mux,
+// unless mux already has a handler for it. It must be called before the handlers of mux are registered.
 func setupTelemetry(addr string, mux *ServeMux) (*http.Server, bool) {
+	telemetryConfig := mux.getApp().telemetryConfig
 	if telemetryConfig == nil || !telemetryConfig.Enabled {
 		return nil, false
 	}
@@ -104,10 +184,10 @@ func setupTelemetry(addr string, mux *ServeMux) (*http.Server, bool) {
 	handler := telemetry.GetHTTPHandler(telemetryConfig.HandlerOpts)
 
 	// Check if telemetry should run on a separate server
-	if telemetryConfig.Addr != "" && telemetryConfig.Addr != addr {
-		// Create separate telemetry server
-		telemetryMux := NewServeMux()
-		telemetryMux.Handle(telemetryConfig.URLPath, adaptHTTPHandler(handler))
+	if telemetryConfig.Addr != "" && !sameListenAddr(telemetryConfig.Addr, addr) {
+		// The separate server only serves metrics, without the application middlewares.
+		telemetryMux := http.NewServeMux()
+		telemetryMux.Handle(telemetryConfig.URLPath, handler)
 
 		telemetryServer := &http.Server{
 			Addr:              telemetryConfig.Addr,
@@ -122,10 +202,46 @@ func setupTelemetry(addr string, mux *ServeMux) (*http.Server, bool) {
 	}
 
 	// Run telemetry on the main server
-	mux.Handle(telemetryConfig.URLPath, adaptHTTPHandler(handler))
+	if !mux.hasPattern(telemetryConfig.URLPath) {
+		mux.Handle(telemetryConfig.URLPath, adaptHTTPHandler(handler))
+	}
 	return nil, false
 }
 
+// hasPattern reports whether a handler has been registered on m for the given pattern.
+func (m *ServeMux) hasPattern(pattern string) bool {
+	for _, hc := range m.getApp().handlerConfigs {
+		if hc.mux == m && hc.pathPattern == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// sameListenAddr reports whether two listen addresses refer to the same port, treating an empty
+// or unspecified host (e.g., ":8080" or "0.0.0.0:8080") as matching any host.
+func sameListenAddr(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	hostA, portA, errA := net.SplitHostPort(a)
+	hostB, portB, errB := net.SplitHostPort(b)
+	if errA != nil || errB != nil || portA != portB {
+		return false
+	}
+
+	return hostA == hostB || isUnspecifiedHost(hostA) || isUnspecifiedHost(hostB)
+}
+
+func isUnspecifiedHost(host string) bool {
+	if host == "" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsUnspecified()
+}
+
 // createHTTPServer creates and configures an HTTP server with the provided settings.
 func createHTTPServer(addr string, handler http.Handler, cfg *ServerConfig) *http.Server {
 	server := &http.Server{
@@ -157,14 +273,27 @@ func createHTTPServer(addr string, handler http.Handler, cfg *ServerConfig) *htt
 	return server
 }
 
-// startServer starts an HTTP server in a goroutine and reports errors to the provided channel.
-func startServer(server *http.Server, serverType string, errorChan chan<- error) {
+// startServer listens on the server address and serves in a goroutine, reporting serve errors to errorChan.
+// Listening happens before returning, so that errors such as an address already in use are returned immediately.
+func startServer(server *http.Server, serverType string, errorChan chan<- error) error {
+	addr := server.Addr
+	if addr == "" {
+		addr = ":http"
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("%s server: %w", serverType, err)
+	}
+
 	go func() {
 		slog.Info("Starting server", "type", serverType, "addr", server.Addr)
-		if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
-			errorChan <- err
+		if serveErr := server.Serve(listener); !errors.Is(serveErr, http.ErrServerClosed) {
+			errorChan <- serveErr
 		}
 	}()
+
+	return nil
 }
 
 // waitForShutdownSignal waits for either a server error or a shutdown signal.
@@ -205,31 +334,56 @@ func shutdownServers(mainServer *http.Server, telemetryServer *http.Server, hasS
 }
 
 func registerHandlers(mux *ServeMux) {
-	for _, hc := range handlerConfigs {
+	for _, hc := range mux.getApp().handlerConfigs {
 		if hc.mux != mux {
 			continue
 		}
 		registerHandlerFunc(hc)
 	}
+
+	if mux.redirectTrailingSlash {
+		registerTrailingSlashRedirects(mux)
+	}
 }
 
-// ListenAndServe starts an HTTP server on the specified address with the given multiplexer.
+// ListenAndServe starts an HTTP server on the specified address with the given multiplexer,
+// using the configuration of the App the multiplexer was created by (the default App for NewServeMux).
 // It automatically sets up OpenAPI endpoint if configured, applies server configuration,
 // and handles graceful shutdown on SIGINT or SIGTERM signals.
 // If telemetry is configured with a separate address, starts an additional server for metrics.
 // Blocks until the server is shut down. Panics if server startup or shutdown fails.
 func ListenAndServe(addr string, mux *ServeMux, cfg *ServerConfig) {
+	mux.getApp().ListenAndServe(addr, mux, cfg)
+}
+
+// ListenAndServe starts an HTTP server for this App on the specified address with the given multiplexer.
+// See the package-level ListenAndServe. Panics if mux was not created by this App.
+func (a *App) ListenAndServe(addr string, mux *ServeMux, cfg *ServerConfig) {
+	if mux.getApp() != a {
+		panic("ServeMux was created by a different App")
+	}
+
+	a.activeServers.Add(1)
+	defer a.activeServers.Add(-1)
+
 	setupOpenAPIEndpoints(mux)
-	registerHandlers(mux)
 	telemetryServer, hasSeparateTelemetry := setupTelemetry(addr, mux)
+	setupDebugConfigEndpoint(mux)
+	setupI18nReloadEndpoint(mux)
+	registerHandlers(mux)
 	mainServer := createHTTPServer(addr, mux, cfg)
 
 	//nolint:mnd // buffer size for main and telemetry servers
 	serverError := make(chan error, 2)
-	startServer(mainServer, "main", serverError)
+	if err := startServer(mainServer, "main", serverError); err != nil {
+		panic(err)
+	}
 
 	if hasSeparateTelemetry {
-		startServer(telemetryServer, "telemetry", serverError)
+		if err := startServer(telemetryServer, "telemetry", serverError); err != nil {
+			_ = mainServer.Close()
+			panic(err)
+		}
 	}
 
 	waitForShutdownSignal(serverError)
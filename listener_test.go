@@ -271,7 +271,7 @@ func TestListenAndServe_WithOpenAPIEndpoint(t *testing.T) {
 	}
 
 	// Reset app configuration
-	appConfigured = false
+	defaultApp.configured = false
 
 	Configure(&Config{
 		OpenAPI: &OpenAPI{
@@ -596,11 +596,11 @@ func BenchmarkGetValueOrDefault(b *testing.B) {
 
 func TestSetupOpenAPIEndpoint_Enabled(t *testing.T) {
 	// Save and restore original config
-	originalConfig := openAPIConfig
-	defer func() { openAPIConfig = originalConfig }()
+	originalConfig := defaultApp.openAPIConfig
+	defer func() { defaultApp.openAPIConfig = originalConfig }()
 
 	// Use Configure to properly initialize the OpenAPI config
-	appConfigured = false
+	defaultApp.configured = false
 	Configure(&Config{
 		OpenAPI: &OpenAPI{
 			Enabled: true,
@@ -626,10 +626,10 @@ func TestSetupOpenAPIEndpoint_Enabled(t *testing.T) {
 }
 
 func TestSetupOpenAPIEndpoint_Disabled(_ *testing.T) {
-	originalConfig := openAPIConfig
-	defer func() { openAPIConfig = originalConfig }()
+	originalConfig := defaultApp.openAPIConfig
+	defer func() { defaultApp.openAPIConfig = originalConfig }()
 
-	openAPIConfig = nil
+	defaultApp.openAPIConfig = nil
 
 	mux := NewServeMux()
 	setupOpenAPIEndpoints(mux)
@@ -638,11 +638,11 @@ func TestSetupOpenAPIEndpoint_Disabled(_ *testing.T) {
 }
 
 func TestSetupOpenAPIEndpoint_InvalidConfig(t *testing.T) {
-	originalConfig := openAPIConfig
-	defer func() { openAPIConfig = originalConfig }()
+	originalConfig := defaultApp.openAPIConfig
+	defer func() { defaultApp.openAPIConfig = originalConfig }()
 
 	// Invalid config that will fail to marshal
-	openAPIConfig = &OpenAPI{
+	defaultApp.openAPIConfig = &OpenAPI{
 		Enabled: true,
 		URLPath: "GET /api-docs",
 		Config:  nil, // This will cause panic
@@ -664,17 +664,17 @@ func TestSetupOpenAPIEndpoint_RenderingFailure(t *testing.T) {
 	// The current implementation panics when MarshalJSON fails during setup.
 
 	// Save and restore original config
-	originalConfig := openAPIConfig
-	defer func() { openAPIConfig = originalConfig }()
+	originalConfig := defaultApp.openAPIConfig
+	defer func() { defaultApp.openAPIConfig = originalConfig }()
 
 	// Configure OpenAPI with nil info, which will fail validation and cause panic
-	appConfigured = false
-	openAPIConfig = &OpenAPI{
+	defaultApp.configured = false
+	defaultApp.openAPIConfig = &OpenAPI{
 		Enabled:        true,
 		URLPath:        "GET /openapi.json",
 		internalConfig: &openapi.Config{}, // Empty config with nil Info will fail validation
 	}
-	openAPIConfig.internalConfig.Paths = make(openapi.Paths)
+	defaultApp.openAPIConfig.internalConfig.Paths = make(openapi.Paths)
 
 	mux := NewServeMux()
 
@@ -689,11 +689,11 @@ func TestSetupOpenAPIEndpoint_RenderingFailure(t *testing.T) {
 
 func TestSetupOpenAPIEndpoint_HTMLUIGenerated(t *testing.T) {
 	// Save and restore original config
-	originalConfig := openAPIConfig
-	defer func() { openAPIConfig = originalConfig }()
+	originalConfig := defaultApp.openAPIConfig
+	defer func() { defaultApp.openAPIConfig = originalConfig }()
 
 	// Configure OpenAPI
-	appConfigured = false
+	defaultApp.configured = false
 	Configure(&Config{
 		OpenAPI: &OpenAPI{
 			Enabled: true,
@@ -709,6 +709,7 @@ func TestSetupOpenAPIEndpoint_HTMLUIGenerated(t *testing.T) {
 
 	mux := NewServeMux()
 	setupOpenAPIEndpoints(mux)
+	registerHandlers(mux)
 
 	// Test the HTML UI endpoint
 	req := httptest.NewRequest(http.MethodGet, "/openapi.html", nil)
@@ -740,11 +741,11 @@ func TestSetupOpenAPIEndpoint_HTMLUIGenerated(t *testing.T) {
 
 func TestSetupOpenAPIEndpoint_CustomURLPath(t *testing.T) {
 	// Save and restore original config
-	originalConfig := openAPIConfig
-	defer func() { openAPIConfig = originalConfig }()
+	originalConfig := defaultApp.openAPIConfig
+	defer func() { defaultApp.openAPIConfig = originalConfig }()
 
 	// Configure OpenAPI with custom path
-	appConfigured = false
+	defaultApp.configured = false
 	Configure(&Config{
 		OpenAPI: &OpenAPI{
 			Enabled: true,
@@ -760,6 +761,7 @@ func TestSetupOpenAPIEndpoint_CustomURLPath(t *testing.T) {
 
 	mux := NewServeMux()
 	setupOpenAPIEndpoints(mux)
+	registerHandlers(mux)
 
 	// Test the JSON endpoint
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/docs.json", nil)
@@ -791,11 +793,11 @@ func TestSetupOpenAPIEndpoint_CustomURLPath(t *testing.T) {
 
 func TestSetupOpenAPIEndpoint_JSONResponse(t *testing.T) {
 	// Save and restore original config
-	originalConfig := openAPIConfig
-	defer func() { openAPIConfig = originalConfig }()
+	originalConfig := defaultApp.openAPIConfig
+	defer func() { defaultApp.openAPIConfig = originalConfig }()
 
 	// Configure OpenAPI
-	appConfigured = false
+	defaultApp.configured = false
 	Configure(&Config{
 		OpenAPI: &OpenAPI{
 			Enabled: true,
@@ -811,6 +813,7 @@ func TestSetupOpenAPIEndpoint_JSONResponse(t *testing.T) {
 
 	mux := NewServeMux()
 	setupOpenAPIEndpoints(mux)
+	registerHandlers(mux)
 
 	// Test that JSON endpoint works
 	req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
@@ -833,10 +836,10 @@ func TestSetupOpenAPIEndpoint_JSONResponse(t *testing.T) {
 }
 
 func TestSetupTelemetry_Disabled(t *testing.T) {
-	originalConfig := telemetryConfig
-	defer func() { telemetryConfig = originalConfig }()
+	originalConfig := defaultApp.telemetryConfig
+	defer func() { defaultApp.telemetryConfig = originalConfig }()
 
-	telemetryConfig = nil
+	defaultApp.telemetryConfig = nil
 
 	mux := NewServeMux()
 	server, separate := setupTelemetry(":8080", mux)
@@ -850,11 +853,11 @@ func TestSetupTelemetry_Disabled(t *testing.T) {
 }
 
 func TestSetupTelemetry_SameServer(t *testing.T) {
-	originalConfig := telemetryConfig
-	defer func() { telemetryConfig = originalConfig }()
+	originalConfig := defaultApp.telemetryConfig
+	defer func() { defaultApp.telemetryConfig = originalConfig }()
 
 	// Reset app configuration
-	appConfigured = false
+	defaultApp.configured = false
 	Configure(&Config{
 		Telemetry: &Telemetry{
 			Enabled: true,
@@ -875,11 +878,11 @@ func TestSetupTelemetry_SameServer(t *testing.T) {
 }
 
 func TestSetupTelemetry_SameServerMatchingAddr(t *testing.T) {
-	originalConfig := telemetryConfig
-	defer func() { telemetryConfig = originalConfig }()
+	originalConfig := defaultApp.telemetryConfig
+	defer func() { defaultApp.telemetryConfig = originalConfig }()
 
 	// Reset app configuration
-	appConfigured = false
+	defaultApp.configured = false
 	Configure(&Config{
 		Telemetry: &Telemetry{
 			Enabled: true,
@@ -900,11 +903,11 @@ func TestSetupTelemetry_SameServerMatchingAddr(t *testing.T) {
 }
 
 func TestSetupTelemetry_SeparateServer(t *testing.T) {
-	originalConfig := telemetryConfig
-	defer func() { telemetryConfig = originalConfig }()
+	originalConfig := defaultApp.telemetryConfig
+	defer func() { defaultApp.telemetryConfig = originalConfig }()
 
 	// Reset app configuration
-	appConfigured = false
+	defaultApp.configured = false
 	Configure(&Config{
 		Telemetry: &Telemetry{
 			Enabled: true,
@@ -1072,6 +1075,8 @@ func TestStartServer(t *testing.T) {
 	addr := listener.Addr().String()
 	listener.Close()
 
+	registerHandlers(mux)
+
 	server := createHTTPServer(addr, mux, nil)
 	errorChan := make(chan error, 1)
 
@@ -1164,7 +1169,7 @@ func TestTelemetryIntegration_SeparateServer(t *testing.T) {
 	}
 
 	// Reset app configuration
-	appConfigured = false
+	defaultApp.configured = false
 
 	// Find free ports
 	listener1, _ := net.Listen("tcp", "127.0.0.1:0")
@@ -1194,6 +1199,8 @@ func TestTelemetryIntegration_SeparateServer(t *testing.T) {
 		t.Fatal("Expected separate telemetry server")
 	}
 
+	registerHandlers(mux)
+
 	mainServer := createHTTPServer(mainAddr, mux, nil)
 
 	errorChan := make(chan error, 2)
@@ -1233,7 +1240,7 @@ func TestTelemetryIntegration_SameServer(t *testing.T) {
 	}
 
 	// Reset app configuration
-	appConfigured = false
+	defaultApp.configured = false
 
 	listener, _ := net.Listen("tcp", "127.0.0.1:0")
 	addr := listener.Addr().String()
@@ -1260,6 +1267,8 @@ func TestTelemetryIntegration_SameServer(t *testing.T) {
 		t.Fatal("Expected nil telemetry server")
 	}
 
+	registerHandlers(mux)
+
 	mainServer := createHTTPServer(addr, mux, nil)
 
 	errorChan := make(chan error, 1)
@@ -1293,7 +1302,7 @@ func TestTelemetryIntegration_SameServer(t *testing.T) {
 
 func TestTelemetryConfig_DefaultURLPath(t *testing.T) {
 	// Reset app configuration
-	appConfigured = false
+	defaultApp.configured = false
 
 	Configure(&Config{
 		Telemetry: &Telemetry{
@@ -1302,14 +1311,14 @@ func TestTelemetryConfig_DefaultURLPath(t *testing.T) {
 		},
 	})
 
-	if telemetryConfig.URLPath != "GET /metrics" {
-		t.Errorf("Expected default URLPath 'GET /metrics', got %q", telemetryConfig.URLPath)
+	if defaultApp.telemetryConfig.URLPath != "GET /metrics" {
+		t.Errorf("Expected default URLPath 'GET /metrics', got %q", defaultApp.telemetryConfig.URLPath)
 	}
 }
 
 func TestTelemetryConfig_CustomURLPath(t *testing.T) {
 	// Reset app configuration
-	appConfigured = false
+	defaultApp.configured = false
 
 	Configure(&Config{
 		Telemetry: &Telemetry{
@@ -1318,14 +1327,14 @@ func TestTelemetryConfig_CustomURLPath(t *testing.T) {
 		},
 	})
 
-	if telemetryConfig.URLPath != "GET /custom-metrics" {
-		t.Errorf("Expected URLPath 'GET /custom-metrics', got %q", telemetryConfig.URLPath)
+	if defaultApp.telemetryConfig.URLPath != "GET /custom-metrics" {
+		t.Errorf("Expected URLPath 'GET /custom-metrics', got %q", defaultApp.telemetryConfig.URLPath)
 	}
 }
 
 func TestTelemetryConfig_WithHandlerOpts(t *testing.T) {
 	// Reset app configuration
-	appConfigured = false
+	defaultApp.configured = false
 
 	handlerOpts := promhttp.HandlerOpts{
 		EnableOpenMetrics: true,
@@ -1339,7 +1348,7 @@ func TestTelemetryConfig_WithHandlerOpts(t *testing.T) {
 		},
 	})
 
-	if !telemetryConfig.HandlerOpts.EnableOpenMetrics {
+	if !defaultApp.telemetryConfig.HandlerOpts.EnableOpenMetrics {
 		t.Error("Expected EnableOpenMetrics to be true")
 	}
 }
@@ -0,0 +1,71 @@
+package webfram
+
+import (
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	maintenanceTemplatePath = "maintenance"
+	maintenanceMessage      = "Service is temporarily unavailable for maintenance"
+	maintenanceHTML         = "<!DOCTYPE html><html><head><title>Maintenance</title></head>" +
+		"<body><h1>Under maintenance</h1><p>" + maintenanceMessage + ".</p></body></html>"
+)
+
+// Maintenance creates middleware that responds with 503 Service Unavailable while flag is set,
+// so the app can be taken offline during deploys without stopping the server. The flag is read on
+// every request and can be toggled at runtime, e.g. from an admin endpoint.
+// Requests to allowPaths are always served; a path ending in "/" allows the whole subtree
+// (e.g., "/admin/"), otherwise the path must match exactly (e.g., "/health").
+// Clients preferring JSON get an error JSON body; others get the "maintenance" HTML template if it
+// exists in the template directory, or a built-in HTML page.
+// Panics if flag is nil.
+func Maintenance(flag *atomic.Bool, allowPaths ...string) AppMiddleware {
+	if flag == nil {
+		panic("Maintenance flag must not be nil")
+	}
+
+	return func(next Handler) Handler {
+		return HandlerFunc(func(w ResponseWriter, r *Request) {
+			if !flag.Load() || isMaintenanceAllowedPath(r.URL.Path, allowPaths) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Cache-Control", "no-store")
+			w.Vary("Accept")
+
+			if r.NegotiateContentType("text/html", "application/json") == "application/json" {
+				w.ErrorJSON(http.StatusServiceUnavailable, maintenanceMessage)
+				return
+			}
+
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			if !hasMaintenanceTemplate(appFromContext(r.Context())) || w.HTML(r.Context(), maintenanceTemplatePath, nil) != nil {
+				_, _ = w.Write([]byte(maintenanceHTML))
+			}
+		})
+	}
+}
+
+func isMaintenanceAllowedPath(path string, allowPaths []string) bool {
+	for _, allowed := range allowPaths {
+		if path == allowed || (strings.HasSuffix(allowed, "/") && strings.HasPrefix(path, allowed)) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasMaintenanceTemplate(a *App) bool {
+	tmplConfig, ok := a.templates.Configuration()
+	if !ok {
+		return false
+	}
+
+	_, found := a.templates.LookupTemplate(maintenanceTemplatePath+tmplConfig.HTMLTemplateExtension, false)
+	return found
+}
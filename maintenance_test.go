@@ -0,0 +1,127 @@
+package webfram
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func serveMaintenance(handler Handler, path, accept string) (int, http.Header, string) {
+	r := NewTestRequest(http.MethodGet, path, nil)
+	if accept != "" {
+		r.Header.Set("Accept", accept)
+	}
+	w, rec := NewTestResponseWriter()
+	handler.ServeHTTP(w, r)
+	return rec.Code, rec.Header(), rec.Body.String()
+}
+
+func TestMaintenance_PanicsOnNilFlag(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for nil flag")
+		}
+	}()
+
+	Maintenance(nil)
+}
+
+func TestMaintenance_Toggle(t *testing.T) {
+	var flag atomic.Bool
+	handler := Maintenance(&flag)(HandlerFunc(func(w ResponseWriter, _ *Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+
+	if code, _, body := serveMaintenance(handler, "/users", ""); code != http.StatusOK || body != "ok" {
+		t.Errorf("Expected request to be served when flag is unset, got %d %q", code, body)
+	}
+
+	flag.Store(true)
+
+	code, header, _ := serveMaintenance(handler, "/users", "")
+	if code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503 when flag is set, got %d", code)
+	}
+	if header.Get("Content-Type") != "text/html" {
+		t.Errorf("Expected text/html response, got %q", header.Get("Content-Type"))
+	}
+	if header.Get("Cache-Control") != "no-store" {
+		t.Errorf("Expected Cache-Control 'no-store', got %q", header.Get("Cache-Control"))
+	}
+
+	flag.Store(false)
+
+	if code, _, _ := serveMaintenance(handler, "/users", ""); code != http.StatusOK {
+		t.Errorf("Expected request to be served after flag is cleared, got %d", code)
+	}
+}
+
+func TestMaintenance_AllowPaths(t *testing.T) {
+	var flag atomic.Bool
+	flag.Store(true)
+
+	handler := Maintenance(&flag, "/health", "/admin/")(HandlerFunc(func(w ResponseWriter, _ *Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		path     string
+		expected int
+	}{
+		{"/health", http.StatusOK},
+		{"/health/deep", http.StatusServiceUnavailable},
+		{"/admin/", http.StatusOK},
+		{"/admin/maintenance", http.StatusOK},
+		{"/administrator", http.StatusServiceUnavailable},
+		{"/", http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		if code, _, _ := serveMaintenance(handler, tt.path, ""); code != tt.expected {
+			t.Errorf("Path %s: expected status %d, got %d", tt.path, tt.expected, code)
+		}
+	}
+}
+
+func TestMaintenance_JSON(t *testing.T) {
+	var flag atomic.Bool
+	flag.Store(true)
+
+	handler := Maintenance(&flag)(HandlerFunc(func(_ ResponseWriter, _ *Request) {
+		t.Error("Handler should not be called during maintenance")
+	}))
+
+	code, header, body := serveMaintenance(handler, "/api/users", "application/json")
+
+	if code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", code)
+	}
+	if header.Get("Content-Type") != "application/json" {
+		t.Errorf("Expected application/json response, got %q", header.Get("Content-Type"))
+	}
+
+	var resp map[string]string
+	if err := json.Unmarshal([]byte(body), &resp); err != nil || resp["error"] == "" {
+		t.Errorf("Expected JSON error body, got %q (err: %v)", body, err)
+	}
+}
+
+func TestMaintenance_HTMLTemplate(t *testing.T) {
+	setupResponseWriterTests()
+
+	var flag atomic.Bool
+	flag.Store(true)
+
+	handler := Maintenance(&flag)(HandlerFunc(func(_ ResponseWriter, _ *Request) {}))
+
+	code, _, body := serveMaintenance(handler, "/", "text/html,application/xhtml+xml,*/*;q=0.8")
+
+	if code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", code)
+	}
+	if !strings.Contains(body, "Back soon") {
+		t.Errorf("Expected maintenance template to be rendered, got %q", body)
+	}
+}
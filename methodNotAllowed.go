@@ -0,0 +1,90 @@
+package webfram
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// SetMethodNotAllowedHandler sets the handler for requests whose path matches a registered pattern
+// but whose method does not. The Allow header is already set when the handler is called, and the
+// allowed methods are available with AllowedMethodsFromContext, e.g. to build a JSON error body.
+// The handler should respond with 405 Method Not Allowed. App and mux middlewares are applied to it.
+// Without a handler, http.ServeMux responds with a plain text 405.
+func (m *ServeMux) SetMethodNotAllowedHandler(handler Handler) {
+	m.methodNotAllowedHandler = handler
+}
+
+// AllowedMethodsFromContext returns the methods allowed for the requested path, as passed to the handler
+// set with SetMethodNotAllowedHandler. Returns false for other requests.
+func AllowedMethodsFromContext(ctx context.Context) ([]string, bool) {
+	methods, ok := ctx.Value(allowedMethodsKey).([]string)
+	return methods, ok
+}
+
+// allowedMethods returns the methods allowed for the request path if the path matches a registered
+// pattern but the method does not. http.ServeMux reports this case with a 405 handler setting the
+// Allow header, which is run against a header recorder.
+func (m *ServeMux) allowedMethods(r *http.Request) ([]string, bool) {
+	handler, pattern := m.ServeMux.Handler(r)
+	if pattern != "" {
+		return nil, false
+	}
+
+	rec := &headerRecorder{header: make(http.Header)}
+	handler.ServeHTTP(rec, r)
+	if rec.statusCode != http.StatusMethodNotAllowed {
+		return nil, false
+	}
+
+	var methods []string
+	for method := range strings.SplitSeq(rec.header.Get("Allow"), ",") {
+		if method = strings.TrimSpace(method); method != "" {
+			methods = append(methods, method)
+		}
+	}
+
+	return methods, true
+}
+
+// serveMethodNotAllowed calls the method not allowed handler with the app and mux middlewares.
+func (m *ServeMux) serveMethodNotAllowed(w http.ResponseWriter, r *http.Request, allowed []string) {
+	app := m.getApp()
+
+	handler := wrapMiddlewares(m.methodNotAllowedHandler, m.middlewares)
+	handler = wrapMiddlewares(handler, app.middlewares)
+
+	ctx := context.WithValue(r.Context(), allowedMethodsKey, allowed)
+	if app != defaultApp {
+		ctx = context.WithValue(ctx, appKey, app)
+	}
+
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+
+	statusCode := 0
+	req := &Request{r.WithContext(ctx)}
+	handler.ServeHTTP(ResponseWriter{ResponseWriter: w, statusCode: &statusCode, request: req}, req)
+}
+
+// headerRecorder records the headers and status code written by a handler and discards the body.
+type headerRecorder struct {
+	header     http.Header
+	statusCode int
+}
+
+func (h *headerRecorder) Header() http.Header {
+	return h.header
+}
+
+func (h *headerRecorder) Write(b []byte) (int, error) {
+	if h.statusCode == 0 {
+		h.statusCode = http.StatusOK
+	}
+	return len(b), nil
+}
+
+func (h *headerRecorder) WriteHeader(statusCode int) {
+	if h.statusCode == 0 {
+		h.statusCode = statusCode
+	}
+}
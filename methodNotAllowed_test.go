@@ -0,0 +1,97 @@
+package webfram
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSetMethodNotAllowedHandler(t *testing.T) {
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+
+	mux := NewServeMux()
+	mux.Use(func(next Handler) Handler {
+		return HandlerFunc(func(w ResponseWriter, r *Request) {
+			w.Header().Set("X-Middleware", "applied")
+			next.ServeHTTP(w, r)
+		})
+	})
+	mux.HandleFunc("GET /users", func(w ResponseWriter, _ *Request) {
+		_, _ = w.Write([]byte("users"))
+	})
+	mux.HandleFunc("DELETE /users", func(w ResponseWriter, _ *Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.SetMethodNotAllowedHandler(HandlerFunc(func(w ResponseWriter, r *Request) {
+		allowed, ok := AllowedMethodsFromContext(r.Context())
+		if !ok {
+			t.Error("Expected allowed methods in context")
+		}
+		w.ErrorJSON(http.StatusMethodNotAllowed, "use one of "+strings.Join(allowed, ", "))
+	}))
+	registerHandlers(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users", http.NoBody))
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("Expected status 405, got %d", rec.Code)
+	}
+	if allow := rec.Header().Get("Allow"); allow != "DELETE, GET, HEAD" {
+		t.Errorf("Expected Allow 'DELETE, GET, HEAD', got %q", allow)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Errorf("Expected JSON body, got Content-Type %q", ct)
+	}
+	if !strings.Contains(rec.Body.String(), "use one of DELETE, GET, HEAD") {
+		t.Errorf("Unexpected body: %q", rec.Body.String())
+	}
+	if rec.Header().Get("X-Middleware") != "applied" {
+		t.Error("Expected mux middlewares to be applied")
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		expected int
+	}{
+		{"matched route", http.MethodGet, "/users", http.StatusOK},
+		{"unknown path", http.MethodPost, "/orders", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, http.NoBody))
+
+			if rec.Code != tt.expected {
+				t.Errorf("Expected status %d, got %d", tt.expected, rec.Code)
+			}
+		})
+	}
+}
+
+func TestMethodNotAllowed_DefaultHandler(t *testing.T) {
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+
+	mux := NewServeMux()
+	mux.HandleFunc("GET /users", func(_ ResponseWriter, _ *Request) {})
+	registerHandlers(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users", http.NoBody))
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405, got %d", rec.Code)
+	}
+	if rec.Header().Get("Allow") == "" {
+		t.Error("Expected Allow header")
+	}
+	if _, ok := AllowedMethodsFromContext(t.Context()); ok {
+		t.Error("Expected no allowed methods outside the handler")
+	}
+}
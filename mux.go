@@ -1,14 +1,22 @@
 package webfram
 
 import (
+	"bytes"
 	"cmp"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io/fs"
+	"maps"
+	"mime"
 	"net/http"
+	"net/url"
 	"slices"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/bondowe/webfram/internal/bind"
 	"github.com/bondowe/webfram/internal/i18n"
@@ -22,13 +30,14 @@ import (
 const (
 	mediaTypeTextEventStream = "text/event-stream"
 	mediaTypeJSONSeq         = "application/json-seq"
-)
+	methodOverrideHeader     = "X-HTTP-Method-Override"
+	methodOverrideFormField  = "_method"
 
-var (
-	mediaTypesXML  = []string{"application/xml", "text/xml"} //nolint:gochecknoglobals
-	handlerConfigs []*HandlerConfig                          //nolint:gochecknoglobals
+	staticContentCacheControl = "public, max-age=86400"
 )
 
+var mediaTypesXML = []string{"application/xml", "text/xml"} //nolint:gochecknoglobals
+
 type (
 	// Request wraps http.Request with additional framework functionality.
 	Request struct {
@@ -38,8 +47,12 @@ type (
 	ServeMux struct {
 		http.ServeMux
 
-		securityConfig *security.Config
-		middlewares    []AppMiddleware
+		app                     *App
+		securityConfig          *security.Config
+		middlewares             []AppMiddleware
+		methodNotAllowedHandler Handler
+		redirectTrailingSlash   bool
+		openAPIDocuments        []*openAPIDocument
 	}
 	// Handler responds to HTTP requests.
 	Handler interface {
@@ -50,16 +63,21 @@ type (
 
 	// OperationConfig configures OpenAPI documentation for a route.
 	OperationConfig struct {
-		Method      string
-		Summary     string
-		Description string
-		OperationID string
-		Tags        []string
-		Parameters  []Parameter
-		Security    []map[string][]string
-		RequestBody *RequestBody
-		Responses   map[string]Response
-		Servers     []Server
+		Method       string
+		Summary      string
+		Description  string
+		OperationID  string
+		Tags         []string
+		Parameters   []Parameter
+		Security     []map[string][]string
+		RequestBody  *RequestBody
+		Responses    map[string]Response
+		Servers      []Server
+		ExternalDocs *ExternalDocs
+		// Extensions are specification extensions inlined in the operation, e.g. "x-internal": true.
+		// Keys must start with "x-".
+		Extensions map[string]any
+		Deprecated bool
 	}
 	// PathInfo contains path-level OpenAPI documentation.
 	PathInfo struct {
@@ -176,44 +194,220 @@ type (
 		handler     Handler
 		operation   *OperationConfig
 		security    *security.Config
+		group       *Group
 		middlewares []interface{}
+		accepts     []string
+		sunset      time.Time
+		meta        map[string]string
 	}
 )
 
 // registerHandlerFunc registers the handler with all applicable middlewares and telemetry.
 func registerHandlerFunc(hc *HandlerConfig) {
-	wrappedHandler := wrapMiddlewares(hc.handler, getHandlerMiddlewares(hc.middlewares))
+	app := hc.mux.getApp()
+
+	wrappedHandler := hc.handler
+	if app.validateResponses && hc.operation != nil {
+		wrappedHandler = responseValidationMiddleware(hc.pathPattern, hc.operation)(wrappedHandler)
+	}
+	if len(hc.accepts) > 0 {
+		wrappedHandler = acceptsMiddleware(hc.accepts)(wrappedHandler)
+	}
+
+	wrappedHandler = wrapMiddlewares(wrappedHandler, getHandlerMiddlewares(hc.middlewares))
+	if hc.group != nil {
+		wrappedHandler = wrapMiddlewares(wrappedHandler, getHandlerMiddlewares(hc.group.middlewares))
+	}
 	wrappedHandler = wrapMiddlewares(wrappedHandler, hc.mux.middlewares)
-	wrappedHandler = wrapMiddlewares(wrappedHandler, appMiddlewares)
+	wrappedHandler = wrapMiddlewares(wrappedHandler, app.middlewares)
 
-	securityMiddlewares := getSecurityMiddlewares(hc.mux.securityConfig, hc.security)
+	securityMiddlewares := app.getSecurityMiddlewares(hc.mux.securityConfig, hc.security)
+	if hc.security == nil {
+		// The security requirements of the operation take precedence over the mux and app configurations
+		if mdwr := app.securityRequirementsMiddleware(hc.operation, hc.pathPattern); mdwr != nil {
+			securityMiddlewares = []AppMiddleware{mdwr}
+		}
+	}
 
 	if len(securityMiddlewares) > 0 {
 		// Apply security middlewares after app and mux middlewares, but before handler-specific middlewares
 		wrappedHandler = wrapMiddlewares(wrappedHandler, securityMiddlewares)
 	}
 
-	wrappedHandler = telemetryMiddleware(wrappedHandler)
+	if app.deprecationWarningHeader && hc.operation != nil && hc.operation.Deprecated {
+		wrappedHandler = deprecationMiddleware(hc.operation)(wrappedHandler)
+	}
+	if !hc.sunset.IsZero() {
+		wrappedHandler = sunsetMiddleware(hc.sunset)(wrappedHandler)
+	}
+
+	wrappedHandler = telemetryMiddleware(app.statusClassifier())(wrappedHandler)
 
-	if i18nConfig, ok := i18n.Configuration(); ok && i18nConfig.FS != nil {
+	if i18nConfig, ok := app.i18nCatalogs.Configuration(); ok && i18nConfig.FS != nil {
 		i18nMdwr := I18nMiddleware(i18nConfig.FS)
 		wrappedHandler = i18nMdwr(wrappedHandler)
 	}
 
+	meta := maps.Clone(hc.meta)
+
 	hc.mux.ServeMux.Handle(hc.pathPattern, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The default App is used when the request context has none, so the request is only copied
+		// for other apps and routes with metadata.
+		if app != defaultApp || meta != nil {
+			ctx := r.Context()
+			if app != defaultApp {
+				ctx = context.WithValue(ctx, appKey, app)
+			}
+			if meta != nil {
+				ctx = context.WithValue(ctx, routeMetaKey, meta)
+			}
+			r = r.WithContext(ctx)
+		}
+
 		statusCode := 0
-		wrappedHandler.ServeHTTP(ResponseWriter{w, &statusCode}, &Request{r})
+		req := &Request{r}
+		wrappedHandler.ServeHTTP(ResponseWriter{ResponseWriter: w, statusCode: &statusCode, request: req}, req)
 	}))
 }
 
+// registerTrailingSlashRedirects registers redirect handlers for the trailing slash counterpart of every
+// pattern registered on the mux. It must run after the handlers are registered, so that counterparts
+// already matched by another pattern, e.g. a subtree pattern or one with a differently named wildcard,
+// are skipped instead of overriding it or conflicting with it.
+func registerTrailingSlashRedirects(mux *ServeMux) {
+	var candidates []string
+
+	for _, hc := range mux.getApp().handlerConfigs {
+		if hc.mux != mux {
+			continue
+		}
+		candidates = append(candidates, hc.pathPattern)
+	}
+
+	for _, pattern := range candidates {
+		alternate, ok := trailingSlashAlternate(pattern)
+		if !ok {
+			continue
+		}
+
+		probe, ok := patternProbeRequest(alternate)
+		if !ok {
+			continue
+		}
+		if _, matched := mux.ServeMux.Handler(probe); matched != "" && !isSlashRedirect(probe.URL.Path, matched) {
+			continue
+		}
+
+		registerHandlerFunc(mux.HandleFunc(alternate, func(w ResponseWriter, r *Request) {
+			target := url.URL{RawQuery: r.URL.RawQuery}
+			if strings.HasSuffix(r.URL.Path, "/") {
+				target.Path = strings.TrimSuffix(r.URL.Path, "/")
+			} else {
+				target.Path = r.URL.Path + "/"
+			}
+			w.Redirect(r, target.String(), http.StatusMovedPermanently)
+		}))
+	}
+}
+
+// patternProbeRequest returns a request matched by the given pattern, with its wildcards replaced by a
+// segment no literal pattern segment can match. Patterns without a method are probed with GET.
+func patternProbeRequest(pattern string) (*http.Request, bool) {
+	method, rest, hasMethod := strings.Cut(pattern, " ")
+	if hasMethod {
+		rest = strings.TrimLeft(rest, " \t")
+	} else {
+		method, rest = http.MethodGet, pattern
+	}
+
+	slash := strings.Index(rest, "/")
+	if slash < 0 {
+		return nil, false
+	}
+	host, path := rest[:slash], strings.TrimSuffix(rest[slash:], "{$}")
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") {
+			segments[i] = "{}"
+			continue
+		}
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			return nil, false
+		}
+		segments[i] = unescaped
+	}
+
+	return &http.Request{
+		Method: method,
+		Host:   host,
+		URL:    &url.URL{Path: strings.Join(segments, "/")},
+	}, true
+}
+
+// isSlashRedirect reports whether matched, the pattern returned by http.ServeMux.Handler for path, only
+// matches path with a trailing slash added, which the mux answers with a redirect rather than the handler.
+func isSlashRedirect(path, matched string) bool {
+	if strings.HasSuffix(path, "/") {
+		return false
+	}
+
+	if _, rest, hasMethod := strings.Cut(matched, " "); hasMethod {
+		matched = rest
+	}
+	if slash := strings.Index(matched, "/"); slash >= 0 {
+		matched = matched[slash:]
+	}
+
+	return strings.Count(strings.TrimSuffix(matched, "{$}"), "/") > strings.Count(path, "/")
+}
+
+// trailingSlashAlternate returns the pattern matching the same path with the trailing slash toggled.
+// Subtree patterns ("/users/") and remainder wildcards are skipped since they already match both forms.
+func trailingSlashAlternate(pattern string) (string, bool) {
+	method, path, hasMethod := strings.Cut(pattern, " ")
+	if hasMethod {
+		path = strings.TrimLeft(path, " \t")
+	} else {
+		method, path = "", pattern
+	}
+
+	var alternate string
+
+	switch {
+	case strings.HasSuffix(path, "/{$}"):
+		alternate = strings.TrimSuffix(path, "/{$}")
+		if alternate == "" || strings.HasSuffix(alternate, "/") {
+			return "", false
+		}
+	case strings.HasSuffix(path, "/"), strings.HasSuffix(path, "...}"):
+		return "", false
+	default:
+		alternate = path + "/{$}"
+	}
+
+	if hasMethod {
+		return method + " " + alternate, true
+	}
+	return alternate, true
+}
+
 // configureOpenAPIOperation attaches OpenAPI configuration to a handler.
 // This generates OpenAPI documentation for the endpoint with request/response schemas, parameters, etc.
 // Only works if OpenAPI endpoint is enabled in configuration.
-func configureOpenAPIOperation(pathPattern string, cfg *OperationConfig) {
-	if openAPIConfig == nil || !openAPIConfig.Enabled {
+func (a *App) configureOpenAPIOperation(pathPattern string, cfg *OperationConfig) {
+	if a.openAPIConfig == nil || !a.openAPIConfig.Enabled {
 		return
 	}
 
+	addOpenAPIOperation(a.openAPIConfig.internalConfig, pathPattern, cfg)
+}
+
+// addOpenAPIOperation adds the operation of a handler to the given OpenAPI document.
+func addOpenAPIOperation(doc *openapi.Config, pathPattern string, cfg *OperationConfig) {
+	components := doc.Components
+
 	var requestBody *openapi.RequestBodyOrRef
 
 	if cfg.RequestBody != nil {
@@ -221,7 +415,7 @@ func configureOpenAPIOperation(pathPattern string, cfg *OperationConfig) {
 			RequestBody: &openapi.RequestBody{
 				Description: cfg.RequestBody.Description,
 				Required:    cfg.RequestBody.Required,
-				Content:     mapContent(cfg.RequestBody.Content),
+				Content:     mapContent(cfg.RequestBody.Content, components),
 			},
 		}
 	}
@@ -235,15 +429,15 @@ func configureOpenAPIOperation(pathPattern string, cfg *OperationConfig) {
 				Response: &openapi.Response{
 					Summary:     resp.Summary,
 					Description: resp.Description,
-					Headers:     mapHeaders(resp.Headers),
-					Content:     mapContent(resp.Content),
+					Headers:     mapHeaders(resp.Headers, components),
+					Content:     mapContent(resp.Content, components),
 					Links:       mapLinks(resp.Links),
 				},
 			}
 		}
 	}
 
-	parameters := mapParameters(cfg.Parameters)
+	parameters := mapParameters(cfg.Parameters, components)
 
 	parts := strings.Fields(pathPattern)
 
@@ -252,22 +446,66 @@ func configureOpenAPIOperation(pathPattern string, cfg *OperationConfig) {
 	}
 
 	method := strings.ToLower(parts[0])
-	path := parts[1]
-
-	openAPIConfig.internalConfig.Paths.AddOperation(path, method, openapi.Operation{
-		Summary:     cfg.Summary,
-		Description: cfg.Description,
-		OperationID: cfg.OperationID,
-		Tags:        cfg.Tags,
-		Security:    cfg.Security,
-		RequestBody: requestBody,
-		Parameters:  parameters,
-		Servers:     mapServers(cfg.Servers),
-		Responses:   responses,
+	// OpenAPI path templates have no wildcard syntax: {path...} is documented as {path}.
+	path := strings.ReplaceAll(parts[1], "...}", "}")
+
+	doc.Paths.AddOperation(path, method, openapi.Operation{
+		Summary:      cfg.Summary,
+		Description:  cfg.Description,
+		OperationID:  cfg.OperationID,
+		Tags:         cfg.Tags,
+		Security:     cfg.Security,
+		RequestBody:  requestBody,
+		Parameters:   parameters,
+		Servers:      mapServers(cfg.Servers),
+		Responses:    responses,
+		ExternalDocs: mapExternalDocs(cfg.ExternalDocs),
+		Extensions:   cfg.Extensions,
+		Deprecated:   cfg.Deprecated,
 	})
 
 }
 
+func mapExternalDocs(docs *ExternalDocs) *openapi.ExternalDocs {
+	if docs == nil {
+		return nil
+	}
+
+	return &openapi.ExternalDocs{
+		Description: docs.Description,
+		URL:         docs.URL,
+	}
+}
+
+// sunsetMiddleware adds the Deprecation and RFC 8594 Sunset headers to responses of a handler due to be removed.
+func sunsetMiddleware(sunset time.Time) AppMiddleware {
+	sunsetHeader := sunset.UTC().Format(http.TimeFormat)
+
+	return func(next Handler) Handler {
+		return HandlerFunc(func(w ResponseWriter, r *Request) {
+			w.Header().Set("Deprecation", "true")
+			w.Header().Set("Sunset", sunsetHeader)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// deprecationMiddleware adds the RFC 8594 deprecation headers to responses of a deprecated operation.
+// The Link header is only added when the operation references external documentation.
+func deprecationMiddleware(op *OperationConfig) AppMiddleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(w ResponseWriter, r *Request) {
+			w.Header().Set("Deprecation", "true")
+			if op.ExternalDocs != nil && op.ExternalDocs.URL != "" {
+				w.Header().Add("Link", "<"+op.ExternalDocs.URL+`>; rel="deprecation"`)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func mapLinks(links map[string]Link) map[string]openapi.LinkOrRef {
 	if links == nil {
 		return nil
@@ -288,7 +526,7 @@ func mapLinks(links map[string]Link) map[string]openapi.LinkOrRef {
 	return output
 }
 
-func mapContent(typeInfos map[string]TypeInfo) map[string]openapi.MediaType {
+func mapContent(typeInfos map[string]TypeInfo, components *openapi.Components) map[string]openapi.MediaType {
 	if typeInfos == nil {
 		return nil
 	}
@@ -306,10 +544,10 @@ func mapContent(typeInfos map[string]TypeInfo) map[string]openapi.MediaType {
 				schemaOrRef = bind.GenerateXMLSchema(
 					info.TypeHint,
 					info.XMLRootName,
-					openAPIConfig.internalConfig.Components,
+					components,
 				)
 			} else {
-				schemaOrRef = bind.GenerateJSONSchema(info.TypeHint, openAPIConfig.internalConfig.Components)
+				schemaOrRef = bind.GenerateJSONSchema(info.TypeHint, components)
 			}
 
 			mediaType := openapi.MediaType{
@@ -329,7 +567,7 @@ func mapContent(typeInfos map[string]TypeInfo) map[string]openapi.MediaType {
 	return content
 }
 
-func mapHeaders(header map[string]Header) map[string]openapi.HeaderOrRef {
+func mapHeaders(header map[string]Header, components *openapi.Components) map[string]openapi.HeaderOrRef {
 	if header == nil {
 		return nil
 	}
@@ -344,7 +582,7 @@ func mapHeaders(header map[string]Header) map[string]openapi.HeaderOrRef {
 			content = make(map[string]openapi.MediaTypeOrRef)
 			for mediaType, model := range v.Content {
 				for _, mt := range strings.Split(mediaType, ",") {
-					schema := bind.GenerateJSONSchema(model, openAPIConfig.internalConfig.Components)
+					schema := bind.GenerateJSONSchema(model, components)
 					content[mt] = openapi.MediaTypeOrRef{
 						MediaType: &openapi.MediaType{
 							Schema: schema,
@@ -356,7 +594,7 @@ func mapHeaders(header map[string]Header) map[string]openapi.HeaderOrRef {
 			if v.TypeHint == nil {
 				v.TypeHint = ""
 			}
-			schemaOrRef = bind.GenerateJSONSchema(v.TypeHint, openAPIConfig.internalConfig.Components)
+			schemaOrRef = bind.GenerateJSONSchema(v.TypeHint, components)
 
 			if schemaOrRef.Ref == "" && schemaOrRef.Schema != nil {
 				schema := schemaOrRef.Schema
@@ -383,11 +621,12 @@ func mapHeaders(header map[string]Header) map[string]openapi.HeaderOrRef {
 	return output
 }
 
-func mapParameters(params []Parameter) []openapi.ParameterOrRef {
+func mapParameters(params []Parameter, components *openapi.Components) []openapi.ParameterOrRef {
 	var parameters []openapi.ParameterOrRef
 	for i := range params {
 		param := &params[i]
-		schemaOrRef, content := processParameterSchema(param)
+		schemaOrRef, content := processParameterSchema(param, components)
+		style, explode := parameterSerialization(param, schemaOrRef)
 		parameters = append(parameters, openapi.ParameterOrRef{
 			Parameter: &openapi.Parameter{
 				Name:          param.Name,
@@ -398,8 +637,8 @@ func mapParameters(params []Parameter) []openapi.ParameterOrRef {
 				AllowReserved: param.AllowReserved,
 				Schema:        schemaOrRef,
 				Content:       content,
-				Style:         param.Style,
-				Explode:       param.Explode,
+				Style:         style,
+				Explode:       explode,
 			},
 		})
 	}
@@ -407,18 +646,33 @@ func mapParameters(params []Parameter) []openapi.ParameterOrRef {
 	return parameters
 }
 
-func processParameterSchema(param *Parameter) (*openapi.SchemaOrRef, map[string]openapi.MediaType) {
+// parameterSerialization returns the style and explode of a parameter. Unless set explicitly, array query
+// parameters are declared as repeated (form style, exploded, e.g. tags=go&tags=web), which is how BindQuery reads them.
+func parameterSerialization(param *Parameter, schemaOrRef *openapi.SchemaOrRef) (string, *bool) {
+	if param.In != "query" || param.Style != "" || param.Explode != nil ||
+		schemaOrRef == nil || schemaOrRef.Schema == nil || schemaOrRef.Schema.Type != "array" {
+		return param.Style, param.Explode
+	}
+
+	explode := true
+	return "form", &explode
+}
+
+func processParameterSchema(
+	param *Parameter,
+	components *openapi.Components,
+) (*openapi.SchemaOrRef, map[string]openapi.MediaType) {
 	if param.Content != nil {
-		return nil, buildParameterContent(param.Content)
+		return nil, buildParameterContent(param.Content, components)
 	}
-	return buildParameterSchema(param), nil
+	return buildParameterSchema(param, components), nil
 }
 
-func buildParameterContent(content map[string]any) map[string]openapi.MediaType {
+func buildParameterContent(content map[string]any, components *openapi.Components) map[string]openapi.MediaType {
 	result := make(map[string]openapi.MediaType)
 	for mediaType, model := range content {
 		for _, mt := range strings.Split(mediaType, ",") {
-			schema := bind.GenerateJSONSchema(model, openAPIConfig.internalConfig.Components)
+			schema := bind.GenerateJSONSchema(model, components)
 			result[mt] = openapi.MediaType{
 				Schema: schema,
 			}
@@ -427,11 +681,11 @@ func buildParameterContent(content map[string]any) map[string]openapi.MediaType
 	return result
 }
 
-func buildParameterSchema(param *Parameter) *openapi.SchemaOrRef {
+func buildParameterSchema(param *Parameter, components *openapi.Components) *openapi.SchemaOrRef {
 	if param.TypeHint == nil {
 		param.TypeHint = ""
 	}
-	schemaOrRef := bind.GenerateJSONSchema(param.TypeHint, openAPIConfig.internalConfig.Components)
+	schemaOrRef := bind.GenerateJSONSchema(param.TypeHint, components)
 
 	if schemaOrRef.Ref == "" && schemaOrRef.Schema != nil {
 		applySchemaConstraints(schemaOrRef.Schema, param)
@@ -605,46 +859,73 @@ func getHandlerMiddlewares(middlewares []interface{}) []AppMiddleware {
 	return mdwrs
 }
 
-// / TelemetryMiddleware creates middleware that collects HTTP request metrics using Prometheus.
-// / It tracks total requests, request duration, and active connections per endpoint.
-// / It uses the telemetry package's predefined Prometheus metrics.
-func telemetryMiddleware(next Handler) Handler {
-	return HandlerFunc(func(w ResponseWriter, r *Request) {
-		path := r.URL.Path
-		method := r.Method
-
-		// Track active connections
-		telemetry.ActiveConnections.Inc()
-		defer telemetry.ActiveConnections.Dec()
-
-		// Start timer and defer recording metrics
-		timer := prometheus.NewTimer(prometheus.ObserverFunc(func(v float64) {
-			// Get status code from ResponseWriter's context
-			statusCode, ok := w.StatusCode()
-			if !ok {
-				statusCode = http.StatusOK // Default to 200 if not set
+// telemetryMiddleware creates middleware that collects HTTP request metrics using Prometheus.
+// It tracks total requests, request duration, and active connections per endpoint, labeling
+// the status of the response with classify.
+// It uses the telemetry package's predefined Prometheus metrics.
+func telemetryMiddleware(classify func(statusCode int) string) AppMiddleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(w ResponseWriter, r *Request) {
+			path := r.URL.Path
+			method := r.Method
+
+			// Handlers call Request.SkipTelemetry to set the flag, which is checked once they return.
+			skip := new(atomic.Bool)
+			r = &Request{r.WithContext(context.WithValue(r.Context(), skipTelemetryKey, skip))}
+
+			// Track active connections
+			telemetry.ActiveConnections.Inc()
+			defer telemetry.ActiveConnections.Dec()
+
+			// Start timer and defer recording metrics
+			timer := prometheus.NewTimer(prometheus.ObserverFunc(func(v float64) {
+				if skip.Load() {
+					return
+				}
+				status := classify(responseStatusCode(w))
+				telemetry.RequestDurationSeconds.WithLabelValues(method, path, status).Observe(v)
+			}))
+			defer timer.ObserveDuration()
+
+			next.ServeHTTP(w, r)
+
+			if skip.Load() {
+				return
 			}
-			//nolint:mnd // divide by 100 to get status class
-			statusClass := fmt.Sprintf("%dxx", statusCode/100)
-			telemetry.RequestDurationSeconds.WithLabelValues(method, path, statusClass).Observe(v)
-		}))
-		defer timer.ObserveDuration()
 
-		next.ServeHTTP(w, r)
+			// Record total requests
+			status := classify(responseStatusCode(w))
+			telemetry.RequestsTotal.WithLabelValues(method, path, status).Inc()
+		})
+	}
+}
 
-		// Record total requests
-		statusCode, ok := w.StatusCode()
-		if !ok {
-			statusCode = http.StatusOK // Default to 200 if not set
-		}
-		//nolint:mnd // divide by 100 to get status class
-		statusClass := fmt.Sprintf("%dxx", statusCode/100)
-		telemetry.RequestsTotal.WithLabelValues(method, path, statusClass).Inc()
-	})
+// responseStatusCode returns the status code written to w, defaulting to 200 if none was set.
+func responseStatusCode(w ResponseWriter) int {
+	statusCode, ok := w.StatusCode()
+	if !ok {
+		return http.StatusOK
+	}
+	return statusCode
+}
+
+// statusClassifier returns the function labeling the status of the request metrics:
+// Telemetry.StatusClassifier if configured, statusClass otherwise.
+func (a *App) statusClassifier() func(statusCode int) string {
+	if a.telemetryConfig != nil && a.telemetryConfig.StatusClassifier != nil {
+		return a.telemetryConfig.StatusClassifier
+	}
+	return statusClass
+}
+
+// statusClass returns the class of a status code, e.g. "4xx" for 404.
+func statusClass(statusCode int) string {
+	//nolint:mnd // divide by 100 to get status class
+	return fmt.Sprintf("%dxx", statusCode/100)
 }
 
-func getSecurityMiddlewares(msc *security.Config, sc *security.Config) []AppMiddleware {
-	cfg := cmp.Or(sc, msc, securityConfig)
+func (a *App) getSecurityMiddlewares(msc *security.Config, sc *security.Config) []AppMiddleware {
+	cfg := cmp.Or(sc, msc, a.securityConfig)
 
 	if cfg == nil || cfg.AllowAnonymousAuth {
 		return nil
@@ -652,80 +933,114 @@ func getSecurityMiddlewares(msc *security.Config, sc *security.Config) []AppMidd
 
 	var mdwrs []AppMiddleware
 
+	for _, mdwr := range securityConfigMiddlewares(cfg) {
+		mdwrs = append(mdwrs, adaptHTTPMiddleware(mdwr))
+	}
+
+	return mdwrs
+}
+
+// securityConfigMiddlewares returns the middlewares of the authentication schemes configured in cfg,
+// all of which must succeed. Returns nil with AllowAnonymousAuth.
+func securityConfigMiddlewares(cfg *security.Config) []StandardMiddleware {
+	if cfg.AllowAnonymousAuth {
+		return nil
+	}
+
+	var mdwrs []StandardMiddleware
+
 	if cfg.APIKeyAuth != nil {
 		mdwr := security.APIKeyAuth(*cfg.APIKeyAuth)
-		mdwrs = append(mdwrs, adaptHTTPMiddleware(mdwr))
+		mdwrs = append(mdwrs, authSchemeMiddleware(AuthSchemeAPIKey, mdwr))
 	}
 
 	if cfg.BasicAuth != nil {
 		mdwr := security.BasicAuth(*cfg.BasicAuth)
-		mdwrs = append(mdwrs, adaptHTTPMiddleware(mdwr))
+		mdwrs = append(mdwrs, authSchemeMiddleware(AuthSchemeBasic, mdwr))
 	}
 
 	if cfg.DigestAuth != nil {
 		mdwr := security.DigestAuth(*cfg.DigestAuth)
-		mdwrs = append(mdwrs, adaptHTTPMiddleware(mdwr))
+		mdwrs = append(mdwrs, authSchemeMiddleware(AuthSchemeDigest, mdwr))
 	}
 
 	if cfg.BearerAuth != nil {
 		mdwr := security.BearerAuth(*cfg.BearerAuth)
-		mdwrs = append(mdwrs, adaptHTTPMiddleware(mdwr))
+		mdwrs = append(mdwrs, authSchemeMiddleware(AuthSchemeBearer, mdwr))
 	}
 
 	if cfg.MutualTLSAuth != nil {
 		mdwr := security.MutualTLSAuth(*cfg.MutualTLSAuth)
-		mdwrs = append(mdwrs, adaptHTTPMiddleware(mdwr))
+		mdwrs = append(mdwrs, authSchemeMiddleware(AuthSchemeMutualTLS, mdwr))
 	}
 
 	if cfg.OAuth2AuthorizationCode != nil {
 		mdwr := security.OAuth2AuthorizationCodeAuth(*cfg.OAuth2AuthorizationCode)
-		mdwrs = append(mdwrs, adaptHTTPMiddleware(mdwr))
+		mdwrs = append(mdwrs, authSchemeMiddleware(AuthSchemeOAuth2, mdwr))
 	}
 
 	if cfg.OAuth2ClientCredentials != nil {
 		mdwr := security.OAuth2ClientCredentialsAuth(*cfg.OAuth2ClientCredentials)
-		mdwrs = append(mdwrs, adaptHTTPMiddleware(mdwr))
+		mdwrs = append(mdwrs, authSchemeMiddleware(AuthSchemeOAuth2, mdwr))
 	}
 
 	if cfg.OAuth2Device != nil {
 		mdwr := security.OAuth2DeviceAuth(*cfg.OAuth2Device)
-		mdwrs = append(mdwrs, adaptHTTPMiddleware(mdwr))
+		mdwrs = append(mdwrs, authSchemeMiddleware(AuthSchemeOAuth2, mdwr))
 	}
 
 	if cfg.OAuth2Implicit != nil {
 		mdwr := security.OAuth2ImplicitAuth(*cfg.OAuth2Implicit)
-		mdwrs = append(mdwrs, adaptHTTPMiddleware(mdwr))
+		mdwrs = append(mdwrs, authSchemeMiddleware(AuthSchemeOAuth2, mdwr))
 	}
 
 	if cfg.OpenIDConnectAuth != nil {
 		mdwr := security.OpenIDConnectAuth(*cfg.OpenIDConnectAuth)
-		mdwrs = append(mdwrs, adaptHTTPMiddleware(mdwr))
+		mdwrs = append(mdwrs, authSchemeMiddleware(AuthSchemeOpenIDConnect, mdwr))
 	}
 
 	return mdwrs
 }
 
-func parseAcceptLanguage(acceptLang string) language.Tag {
+// authSchemeMiddleware wraps a security middleware and records scheme as the authentication scheme of
+// the requests it lets through, unless an outer security middleware already did.
+func authSchemeMiddleware(scheme string, mw StandardMiddleware) StandardMiddleware {
+	return func(next http.Handler) http.Handler {
+		return mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := r.Context().Value(authSchemeKey).(string); !ok {
+				r = r.WithContext(context.WithValue(r.Context(), authSchemeKey, scheme))
+			}
+			next.ServeHTTP(w, r)
+		}))
+	}
+}
+
+func (a *App) parseAcceptLanguage(acceptLang string) language.Tag {
 	// Parse Accept-Language header (e.g., "en-US,en;q=0.9,fr;q=0.8")
 	tags, _, err := language.ParseAcceptLanguage(acceptLang)
 	if err != nil || len(tags) == 0 {
 		return language.Und
 	}
 
-	i18nConfig, ok := i18n.Configuration()
+	i18nConfig, ok := a.i18nCatalogs.Configuration()
 
 	if !ok {
 		return language.Und
 	}
 
-	supportedLanguages := i18nConfig.SupportedLanguages
+	// Use the application provided matcher if any
+	matcher := i18nConfig.Matcher
 
-	if len(supportedLanguages) == 0 {
-		return language.Und
-	}
+	if matcher == nil {
+		supportedLanguages := i18nConfig.SupportedLanguages
+
+		if len(supportedLanguages) == 0 {
+			return language.Und
+		}
 
-	// Create a matcher for supported languages
-	matcher := language.NewMatcher(supportedLanguages)
+		// Create a matcher for supported languages
+		matcher = language.NewMatcher(supportedLanguages)
+	}
 
 	// Find the best match
 	tag, _, _ := matcher.Match(tags...)
@@ -736,26 +1051,39 @@ func parseAcceptLanguage(acceptLang string) language.Tag {
 // This should be called before registering handlers to set common parameters and servers for a path.
 // Only works if OpenAPI endpoint is enabled in configuration.
 func SetOpenAPIPathInfo(path string, info *PathInfo) {
-	if openAPIConfig == nil || !openAPIConfig.Enabled {
+	defaultApp.SetOpenAPIPathInfo(path, info)
+}
+
+// SetOpenAPIPathInfo adds or updates path-level information in the OpenAPI documentation of this App.
+// This should be called before registering handlers to set common parameters and servers for a path.
+// Only works if OpenAPI endpoint is enabled in configuration.
+func (a *App) SetOpenAPIPathInfo(path string, info *PathInfo) {
+	if a.openAPIConfig == nil || !a.openAPIConfig.Enabled {
 		return
 	}
 
-	if !appConfigured {
-		Configure(nil)
+	if !a.configured {
+		a.configure(nil)
 	}
 
-	parameters := mapParameters(info.Parameters)
+	components := a.openAPIConfig.internalConfig.Components
+	parameters := mapParameters(info.Parameters, components)
 	servers := mapServers(info.Servers)
 
-	openAPIConfig.internalConfig.Paths.SetPathInfo(path, info.Summary, info.Description, parameters, servers)
+	a.openAPIConfig.internalConfig.Paths.SetPathInfo(path, info.Summary, info.Description, parameters, servers)
 }
 
 // I18nMiddleware creates middleware that adds internationalization support to handlers.
 // It parses the Accept-Language header and language cookie to determine the user's preferred language,
-// then injects an i18n printer into the request context for message translation.
+// then injects an i18n printer of the App serving the request into the request context for message translation.
+// Responses are marked with "Vary: Accept-Language" since their content may depend on it.
 func I18nMiddleware(_ fs.FS) func(Handler) Handler {
 	return func(next Handler) Handler {
 		return HandlerFunc(func(w ResponseWriter, r *Request) {
+			w.Vary("Accept-Language")
+
+			app := appFromContext(r.Context())
+
 			var langTag language.Tag
 			// Try to get language from cookie first
 			cookie, err := r.Cookie("lang")
@@ -769,21 +1097,18 @@ func I18nMiddleware(_ fs.FS) func(Handler) Handler {
 			if langTag == language.Und {
 				acceptLang := r.Header.Get("Accept-Language")
 				if acceptLang != "" {
-					langTag = parseAcceptLanguage(acceptLang)
+					langTag = app.parseAcceptLanguage(acceptLang)
 				}
 			}
 
 			// Default to first supported language if no language could be determined
 			if langTag == language.Und {
-				if i18nConfig, ok := i18n.Configuration(); ok && len(i18nConfig.SupportedLanguages) > 0 {
-					langTag = i18nConfig.SupportedLanguages[0]
-				} else {
-					langTag = language.English
-				}
+				langTag = app.fallbackLanguage()
 			}
 
-			msgPrinter := i18n.GetI18nPrinter(langTag)
-			ctx := i18n.ContextWithI18nPrinter(context.Background(), msgPrinter)
+			msgPrinter := app.GetI18nPrinter(langTag)
+			ctx := i18n.ContextWithI18nPrinter(r.Context(), msgPrinter)
+			ctx = i18n.ContextWithLanguage(ctx, langTag)
 
 			req := Request{r.WithContext(ctx)}
 
@@ -792,41 +1117,81 @@ func I18nMiddleware(_ fs.FS) func(Handler) Handler {
 	}
 }
 
+// fallbackLanguage returns the first supported language, or English if i18n is not configured.
+func (a *App) fallbackLanguage() language.Tag {
+	if i18nConfig, ok := a.i18nCatalogs.Configuration(); ok && len(i18nConfig.SupportedLanguages) > 0 {
+		return i18nConfig.SupportedLanguages[0]
+	}
+
+	return defaultLanguage
+}
+
 // SetLanguageCookie sets a language preference cookie for the user.
 // The maxAge parameter controls cookie lifetime in seconds (0 = delete cookie, -1 = session cookie).
+// The cookie is marked Secure when the request was received over TLS.
+// Use LanguageCookie to set other attributes, such as Domain.
 func SetLanguageCookie(w ResponseWriter, lang string, maxAge int) {
-	cookie := &http.Cookie{
+	cookie := LanguageCookie(lang, maxAge)
+	cookie.Secure = w.request != nil && w.request.TLS != nil
+
+	http.SetCookie(w.ResponseWriter, cookie)
+}
+
+// LanguageCookie returns the language preference cookie set by SetLanguageCookie, so its attributes
+// can be customized before it is written with http.SetCookie, e.g.:
+//
+//	cookie := app.LanguageCookie("fr", 86400)
+//	cookie.Domain = "example.com"
+//	cookie.Secure = true
+//	http.SetCookie(&w, cookie)
+func LanguageCookie(lang string, maxAge int) *http.Cookie {
+	return &http.Cookie{
 		Name:     "lang",
 		Value:    lang,
 		Path:     "/",
 		MaxAge:   maxAge, // seconds (e.g., 86400 for 24 hours, 0 to delete)
 		HttpOnly: false,  // Allow JavaScript access for language switchers
-		Secure:   false,  // Set to true in production with HTTPS
 		SameSite: http.SameSiteLaxMode,
 	}
-
-	http.SetCookie(w.ResponseWriter, cookie)
 }
 
-// NewServeMux creates a new HTTP request multiplexer with webfram enhancements.
+// NewServeMux creates a new HTTP request multiplexer with webfram enhancements for the default App.
 // Automatically calls Configure(nil) if the application hasn't been configured yet.
 // Returns a ServeMux that supports middleware, custom handlers, and OpenAPI documentation.
 func NewServeMux() *ServeMux {
-	if !appConfigured {
-		Configure(nil)
+	return defaultApp.NewServeMux()
+}
+
+// NewServeMux creates a new HTTP request multiplexer whose handlers use the configuration
+// and middlewares of this App.
+func (a *App) NewServeMux() *ServeMux {
+	if !a.configured {
+		a.configure(nil)
 	}
 
 	return &ServeMux{
+		app:         a,
 		middlewares: nil,
 		ServeMux:    http.ServeMux{},
 	}
 }
 
+// getApp returns the App the ServeMux belongs to, or the default App for a ServeMux
+// not created with NewServeMux.
+func (m *ServeMux) getApp() *App {
+	if m.app == nil {
+		return defaultApp
+	}
+
+	return m.app
+}
+
 // UseSecurity sets the security configuration for the ServeMux.
 // This configuration will be applied to all handlers registered on this ServeMux.
 // This overrides any global security configuration set via `Configure(*Config)`.
 func (m *ServeMux) UseSecurity(cfg security.Config) {
-	securityConfigs = append(securityConfigs, cfg)
+	app := m.getApp()
+	app.securityConfigs = append(app.securityConfigs, cfg)
 
 	m.securityConfig = &cfg
 }
@@ -850,6 +1215,63 @@ func (m *ServeMux) Use(mw interface{}) {
 	}
 }
 
+// RedirectTrailingSlash enables or disables automatic trailing slash redirects for this ServeMux.
+// When enabled, a request for "/users/" is answered with a 301 redirect to "/users" when only
+// "/users" is registered, and vice versa. Redirect routes are generated when the handlers are
+// registered and never override an explicitly registered pattern: no redirect is added for a path
+// another pattern already matches, including subtree patterns such as "/users/" or "/".
+func (m *ServeMux) RedirectTrailingSlash(enabled bool) {
+	m.redirectTrailingSlash = enabled
+}
+
+// RedirectPermanent registers a 301 Moved Permanently redirect from the given pattern to the target URL.
+// The query string of the incoming request is preserved unless the target URL defines its own.
+// Returns a HandlerConfig that can be used to document the redirect via OpenAPIOperation.
+func (m *ServeMux) RedirectPermanent(from, to string) *HandlerConfig {
+	return m.HandleFunc(from, func(w ResponseWriter, r *Request) {
+		target := to
+		if r.URL.RawQuery != "" && !strings.Contains(to, "?") {
+			target += "?" + r.URL.RawQuery
+		}
+		w.Redirect(r, target, http.StatusMovedPermanently)
+	})
+}
+
+// Favicon registers a "GET /favicon.ico" handler serving the given icon from memory, so that browser
+// requests for it don't end up as 404 responses. The content type is detected from the data
+// (e.g., "image/x-icon" or "image/png"). Responses can be cached for a day and are revalidated with an ETag.
+// Returns a HandlerConfig that can be used to further configure the handler.
+func (m *ServeMux) Favicon(data []byte) *HandlerConfig {
+	return m.HandleFunc("GET /favicon.ico", staticContentHandler(data, http.DetectContentType(data)))
+}
+
+// Robots registers a "GET /robots.txt" handler serving the given content as text/plain.
+// Responses can be cached for a day and are revalidated with an ETag.
+// Returns a HandlerConfig that can be used to further configure the handler.
+func (m *ServeMux) Robots(content string) *HandlerConfig {
+	return m.HandleFunc("GET /robots.txt", staticContentHandler([]byte(content), "text/plain; charset=utf-8"))
+}
+
+// staticContentHandler returns a handler serving data with the given content type and cache headers.
+// Conditional requests are answered with 304 Not Modified.
+func staticContentHandler(data []byte, contentType string) HandlerFunc {
+	etag := contentETag(data)
+
+	return func(w ResponseWriter, r *Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Cache-Control", staticContentCacheControl)
+		w.Header().Set("ETag", etag)
+
+		http.ServeContent(w.ResponseWriter, r.Request, "", time.Time{}, bytes.NewReader(data))
+	}
+}
+
+// contentETag returns a strong ETag derived from the SHA-256 hash of data.
+func contentETag(data []byte) string {
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
 // Handle registers a handler for the given pattern.
 // The pattern can include HTTP method prefix (e.g., "GET /users").
 // Optional per-handler middlewares can be provided and will be applied only to this handler.
@@ -861,7 +1283,8 @@ func (m *ServeMux) Handle(pattern string, handler Handler) *HandlerConfig {
 		pathPattern: pattern,
 		handler:     handler,
 	}
-	handlerConfigs = append(handlerConfigs, hc)
+	app := m.getApp()
+	app.handlerConfigs = append(app.handlerConfigs, hc)
 
 	return hc
 }
@@ -875,7 +1298,8 @@ func (m *ServeMux) HandleFunc(pattern string, handler HandlerFunc) *HandlerConfi
 		pathPattern: pattern,
 		handler:     handler,
 	}
-	handlerConfigs = append(handlerConfigs, hc)
+	app := m.getApp()
+	app.handlerConfigs = append(app.handlerConfigs, hc)
 
 	return hc
 }
@@ -883,9 +1307,51 @@ func (m *ServeMux) HandleFunc(pattern string, handler HandlerFunc) *HandlerConfi
 // ServeHTTP implements the http.Handler interface.
 // It wraps the request, applies middlewares, and handles JSONP callbacks if configured.
 func (m *ServeMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if app := m.getApp(); app.allowMethodOverride {
+		applyMethodOverride(r, app.maxUploadSize)
+	}
+
+	if m.methodNotAllowedHandler != nil {
+		if allowed, ok := m.allowedMethods(r); ok {
+			m.serveMethodNotAllowed(w, r, allowed)
+			return
+		}
+	}
+
 	m.ServeMux.ServeHTTP(w, r)
 }
 
+// applyMethodOverride rewrites the method of a POST request to PUT, PATCH or DELETE
+// when requested via the X-HTTP-Method-Override header or the "_method" form field.
+// The header takes precedence; the form field is only read from URL-encoded bodies, limited to
+// maxBodySize. Multipart bodies are not parsed, so handlers parse them with the upload limits.
+func applyMethodOverride(r *http.Request, maxBodySize int64) {
+	if r.Method != http.MethodPost {
+		return
+	}
+
+	override := r.Header.Get(methodOverrideHeader)
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); override == "" &&
+		mediaType == "application/x-www-form-urlencoded" {
+		r.Body = http.MaxBytesReader(nil, r.Body, maxBodySize)
+		override = r.PostFormValue(methodOverrideFormField)
+	}
+
+	switch method := strings.ToUpper(override); method {
+	case http.MethodPut, http.MethodPatch, http.MethodDelete:
+		r.Method = method
+	}
+}
+
+func isFormContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/x-www-form-urlencoded" || mediaType == "multipart/form-data"
+}
+
 // UseSecurity sets the security configuration for this specific handler.
 // This configuration overrides both the ServeMux-level and global security configurations.
 func (h *HandlerConfig) UseSecurity(cfg security.Config) *HandlerConfig {
@@ -909,28 +1375,53 @@ func (h *HandlerConfig) OpenAPIOperation(cfg OperationConfig) *HandlerConfig {
 	return h
 }
 
-// ServeHTTP implements the Handler interface, allowing HandlerFunc to be used as a Handler.
-func (hf HandlerFunc) ServeHTTP(w ResponseWriter, r *Request) {
-	ctx := context.Background()
+// Deprecated marks this handler's operation as deprecated in the OpenAPI documentation
+// and records the reason under the "x-deprecation-reason" extension.
+// Call it after OpenAPIOperation, which replaces the operation configuration.
+// When Config.DeprecationWarningHeader is enabled, responses also carry a Deprecation header.
+func (h *HandlerConfig) Deprecated(reason string) *HandlerConfig {
+	if h.operation == nil {
+		h.operation = &OperationConfig{}
+	}
 
-	if i18nPrinter, ok := i18n.PrinterFromContext(r.Context()); ok {
-		ctx = i18n.ContextWithI18nPrinter(ctx, i18nPrinter)
+	h.operation.Deprecated = true
+
+	if reason != "" {
+		// Clone the extensions, which may be shared with the OperationConfig of other routes.
+		h.operation.Extensions = maps.Clone(h.operation.Extensions)
+		if h.operation.Extensions == nil {
+			h.operation.Extensions = make(map[string]any)
+		}
+		h.operation.Extensions["x-deprecation-reason"] = reason
 	}
 
-	if jsonpCallbackMethodName := r.URL.Query().Get(jsonpCallbackParamName); jsonpCallbackMethodName != "" {
-		matched := jsonpCallbackNamePattern.MatchString(jsonpCallbackMethodName)
-		if !matched {
+	return h
+}
+
+// Sunset marks this handler as deprecated and due to be removed at the sunset date: its responses carry
+// the "Deprecation: true" and RFC 8594 Sunset headers, so clients can warn their users, regardless of
+// Config.DeprecationWarningHeader. Its OpenAPI operation, if any, is documented as deprecated with the date
+// under the "x-sunset" extension.
+func (h *HandlerConfig) Sunset(sunset time.Time) *HandlerConfig {
+	h.sunset = sunset
+	return h
+}
+
+// ServeHTTP implements the Handler interface, allowing HandlerFunc to be used as a Handler.
+func (hf HandlerFunc) ServeHTTP(w ResponseWriter, r *Request) {
+	ctx := r.Context()
+
+	paramName := appFromContext(ctx).jsonpCallbackParamName
+	if jsonpCallbackMethodName := r.URL.Query().Get(paramName); jsonpCallbackMethodName != "" {
+		if err := appFromContext(ctx).validateJSONPCallback(jsonpCallbackMethodName); err != nil {
 			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte(fmt.Errorf(
-				"invalid JSONP callback method name: %q. "+
-					"Must start with a letter or underscore and only contain alphanumeric characters and underscores",
-				jsonpCallbackMethodName).Error()))
+			_, _ = w.Write([]byte(err.Error()))
 			return
 		}
 		ctx = context.WithValue(ctx, jsonpCallbackMethodNameKey, jsonpCallbackMethodName)
 	}
 
-	// Update request context if modified (for i18n or JSONP)
+	// Update request context if modified (for JSONP)
 	if ctx != r.Context() {
 		r.Request = r.WithContext(ctx)
 	}
@@ -0,0 +1,62 @@
+package webfram
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bondowe/webfram/security"
+)
+
+func TestRequest_AuthScheme(t *testing.T) {
+	resetAppConfig()
+	Configure(&Config{
+		Security: &security.Config{
+			BasicAuth: &security.BasicAuthConfig{
+				Authenticator: func(username, password string) bool {
+					return username == "admin" && password == "secret"
+				},
+			},
+		},
+	})
+
+	var scheme string
+	var authenticated bool
+
+	mux := NewServeMux()
+	mux.Use(func(next Handler) Handler {
+		return HandlerFunc(func(w ResponseWriter, r *Request) {
+			scheme, authenticated = r.AuthScheme()
+			next.ServeHTTP(w, r)
+		})
+	})
+	mux.HandleFunc("GET /private", func(w ResponseWriter, _ *Request) {
+		w.NoContent()
+	})
+	mux.HandleFunc("GET /public", func(w ResponseWriter, _ *Request) {
+		w.NoContent()
+	}).UseSecurity(security.Config{AllowAnonymousAuth: true})
+	registerHandlers(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/private", http.NoBody)
+	req.SetBasicAuth("admin", "secret")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d", rec.Code)
+	}
+	if !authenticated || scheme != AuthSchemeBasic {
+		t.Errorf("Expected AuthScheme (%q, true), got (%q, %v)", AuthSchemeBasic, scheme, authenticated)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/public", http.NoBody))
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d", rec.Code)
+	}
+	if authenticated || scheme != "" {
+		t.Errorf("Expected AuthScheme (\"\", false) for anonymous route, got (%q, %v)", scheme, authenticated)
+	}
+}
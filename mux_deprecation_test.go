@@ -0,0 +1,188 @@
+package webfram
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func setupDeprecationMux(t *testing.T, warningHeader bool) *ServeMux {
+	t.Helper()
+	resetAppConfig()
+	Configure(&Config{
+		DeprecationWarningHeader: warningHeader,
+		OpenAPI: &OpenAPI{
+			Enabled: true,
+			URLPath: "GET /openapi.json",
+			Config: &OpenAPIConfig{
+				Info: &Info{Title: "Test API", Version: "1.0.0"},
+			},
+		},
+	})
+
+	mux := NewServeMux()
+	mux.HandleFunc("GET /v1/users", func(w ResponseWriter, _ *Request) {
+		w.WriteHeader(http.StatusOK)
+	}).OpenAPIOperation(OperationConfig{
+		Summary:      "List users",
+		ExternalDocs: &ExternalDocs{URL: "https://example.com/deprecation"},
+		Responses:    map[string]Response{"200": {Description: "OK"}},
+	}).Deprecated("Use /v2/users")
+
+	mux.HandleFunc("GET /v2/users", func(w ResponseWriter, _ *Request) {
+		w.WriteHeader(http.StatusOK)
+	}).OpenAPIOperation(OperationConfig{
+		Summary:   "List users",
+		Responses: map[string]Response{"200": {Description: "OK"}},
+	})
+
+	setupOpenAPIEndpoints(mux)
+	registerHandlers(mux)
+
+	return mux
+}
+
+func TestHandlerConfig_Deprecated_Headers(t *testing.T) {
+	mux := setupDeprecationMux(t, true)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/users", http.NoBody))
+
+	if got := rec.Header().Get("Deprecation"); got != "true" {
+		t.Errorf("Expected Deprecation header 'true', got %q", got)
+	}
+	if got := rec.Header().Get("Link"); got != `<https://example.com/deprecation>; rel="deprecation"` {
+		t.Errorf("Unexpected Link header: %q", got)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v2/users", http.NoBody))
+
+	if got := rec.Header().Get("Deprecation"); got != "" {
+		t.Errorf("Expected no Deprecation header on active route, got %q", got)
+	}
+}
+
+func TestHandlerConfig_Deprecated_HeaderDisabled(t *testing.T) {
+	mux := setupDeprecationMux(t, false)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/users", http.NoBody))
+
+	if got := rec.Header().Get("Deprecation"); got != "" {
+		t.Errorf("Expected no Deprecation header when disabled, got %q", got)
+	}
+}
+
+func TestHandlerConfig_Deprecated_OpenAPI(t *testing.T) {
+	mux := setupDeprecationMux(t, false)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", http.NoBody))
+
+	var doc struct {
+		Paths map[string]map[string]struct {
+			Deprecated        bool           `json:"deprecated"`
+			DeprecationReason string         `json:"x-deprecation-reason"`
+			Extensions        map[string]any `json:"extensions"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("Failed to parse OpenAPI document: %v", err)
+	}
+
+	v1 := doc.Paths["/v1/users"]["get"]
+	if !v1.Deprecated {
+		t.Error("Expected /v1/users to be deprecated")
+	}
+	if v1.DeprecationReason != "Use /v2/users" || v1.Extensions != nil {
+		t.Errorf("Expected an inline x-deprecation-reason extension, got %+v", v1)
+	}
+
+	if doc.Paths["/v2/users"]["get"].Deprecated {
+		t.Error("Expected /v2/users not to be deprecated")
+	}
+}
+
+func TestHandlerConfig_Sunset(t *testing.T) {
+	resetAppConfig()
+	Configure(&Config{
+		OpenAPI: &OpenAPI{
+			Enabled: true,
+			URLPath: "GET /openapi.json",
+			Config: &OpenAPIConfig{
+				Info: &Info{Title: "Test API", Version: "1.0.0"},
+			},
+		},
+	})
+
+	sunset := time.Date(2027, time.March, 31, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+
+	mux := NewServeMux()
+	mux.HandleFunc("GET /v1/orders", func(w ResponseWriter, _ *Request) {
+		w.WriteHeader(http.StatusOK)
+	}).OpenAPIOperation(OperationConfig{
+		Summary:   "List orders",
+		Responses: map[string]Response{"200": {Description: "OK"}},
+	}).Sunset(sunset)
+	mux.HandleFunc("GET /v2/orders", func(w ResponseWriter, _ *Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	setupOpenAPIEndpoints(mux)
+	registerHandlers(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/orders", http.NoBody))
+
+	if got := rec.Header().Get("Deprecation"); got != "true" {
+		t.Errorf("Expected Deprecation header 'true', got %q", got)
+	}
+	if got := rec.Header().Get("Sunset"); got != "Wed, 31 Mar 2027 11:00:00 GMT" {
+		t.Errorf("Expected Sunset header as an HTTP date, got %q", got)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v2/orders", http.NoBody))
+
+	if rec.Header().Get("Deprecation") != "" || rec.Header().Get("Sunset") != "" {
+		t.Errorf("Expected no deprecation headers on active route, got %v", rec.Header())
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", http.NoBody))
+
+	var doc struct {
+		Paths map[string]map[string]struct {
+			Deprecated bool           `json:"deprecated"`
+			Sunset     string         `json:"x-sunset"`
+			Extensions map[string]any `json:"extensions"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("Failed to parse OpenAPI document: %v", err)
+	}
+
+	v1 := doc.Paths["/v1/orders"]["get"]
+	if !v1.Deprecated || v1.Sunset != "2027-03-31T11:00:00Z" || v1.Extensions != nil {
+		t.Errorf("Expected deprecated operation with x-sunset extension, got %+v", v1)
+	}
+}
+
+func TestHandlerConfig_Deprecated_KeepsSharedExtensions(t *testing.T) {
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+	Configure(nil)
+
+	shared := map[string]any{"x-internal": true}
+	mux := NewServeMux()
+	mux.HandleFunc("GET /v1/items", func(ResponseWriter, *Request) {}).
+		OpenAPIOperation(OperationConfig{Extensions: shared}).
+		Deprecated("Use /v2/items")
+
+	if _, ok := shared["x-deprecation-reason"]; ok {
+		t.Errorf("Expected Deprecated not to modify the OperationConfig extensions, got %v", shared)
+	}
+}
@@ -0,0 +1,65 @@
+package webfram
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetLanguageCookie_SecureOverTLS(t *testing.T) {
+	tests := []struct {
+		name   string
+		tls    bool
+		secure bool
+	}{
+		{"plain http", false, false},
+		{"https", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := NewTestRequest(http.MethodPost, "/language", nil)
+			if tt.tls {
+				req.TLS = &tls.ConnectionState{}
+			}
+
+			rec := httptest.NewRecorder()
+			SetLanguageCookie(ResponseWriter{ResponseWriter: rec, request: req}, "fr", 86400)
+
+			cookies := rec.Result().Cookies()
+			if len(cookies) != 1 {
+				t.Fatalf("Expected 1 cookie, got %d", len(cookies))
+			}
+			if cookies[0].Secure != tt.secure {
+				t.Errorf("Expected Secure %v, got %v", tt.secure, cookies[0].Secure)
+			}
+			if cookies[0].SameSite != http.SameSiteLaxMode {
+				t.Errorf("Expected SameSite=Lax, got %v", cookies[0].SameSite)
+			}
+		})
+	}
+}
+
+func TestLanguageCookie_Customized(t *testing.T) {
+	cookie := LanguageCookie("de", -1)
+	cookie.Domain = "example.com"
+	cookie.SameSite = http.SameSiteStrictMode
+
+	rec := httptest.NewRecorder()
+	w := ResponseWriter{ResponseWriter: rec}
+	http.SetCookie(&w, cookie)
+
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("Expected 1 cookie, got %d", len(cookies))
+	}
+
+	got := cookies[0]
+	if got.Name != "lang" || got.Value != "de" || got.Path != "/" {
+		t.Errorf("Expected lang=de cookie on path '/', got %s=%s on %q", got.Name, got.Value, got.Path)
+	}
+	if got.Domain != "example.com" || got.SameSite != http.SameSiteStrictMode {
+		t.Errorf("Expected customized Domain and SameSite, got %q and %v", got.Domain, got.SameSite)
+	}
+}
@@ -6,6 +6,12 @@ import (
 	"github.com/bondowe/webfram/openapi"
 )
 
+// testOpenAPIComponents returns the components of the default App's OpenAPI document, in which
+// the mappers register the schemas of named types.
+func testOpenAPIComponents() *openapi.Components {
+	return defaultApp.openAPIConfig.internalConfig.Components
+}
+
 // =============================================================================
 // mapLinks Tests
 // =============================================================================
@@ -117,7 +123,7 @@ func TestMapContent_WithSingleMediaType(t *testing.T) {
 		},
 	}
 
-	result := mapContent(content)
+	result := mapContent(content, testOpenAPIComponents())
 
 	if result == nil {
 		t.Fatal("Expected non-nil result")
@@ -154,7 +160,7 @@ func TestMapContent_WithMultipleMediaTypes(t *testing.T) {
 		},
 	}
 
-	result := mapContent(content)
+	result := mapContent(content, testOpenAPIComponents())
 
 	if result == nil {
 		t.Fatal("Expected non-nil result")
@@ -212,7 +218,7 @@ func TestMapContent_WithExamples(t *testing.T) {
 		},
 	}
 
-	result := mapContent(content)
+	result := mapContent(content, testOpenAPIComponents())
 
 	if result == nil {
 		t.Fatal("Expected non-nil result")
@@ -242,7 +248,7 @@ func TestMapContent_WithExamples(t *testing.T) {
 }
 
 func TestMapContent_NilInput(t *testing.T) {
-	result := mapContent(nil)
+	result := mapContent(nil, testOpenAPIComponents())
 
 	if result != nil {
 		t.Error("Expected nil result for nil input")
@@ -259,7 +265,7 @@ func TestMapContent_TextEventStream_AutoSSEPayload(t *testing.T) {
 		},
 	}
 
-	result := mapContent(content)
+	result := mapContent(content, testOpenAPIComponents())
 
 	if result == nil {
 		t.Fatal("Expected non-nil result")
@@ -298,7 +304,7 @@ func TestMapContent_TextEventStream_IgnoresCustomTypeHint(t *testing.T) {
 		},
 	}
 
-	result := mapContent(content)
+	result := mapContent(content, testOpenAPIComponents())
 
 	if result == nil {
 		t.Fatal("Expected non-nil result")
@@ -335,7 +341,7 @@ func TestMapContent_ApplicationJSONSeq_UsesItemSchema(t *testing.T) {
 		},
 	}
 
-	result := mapContent(content)
+	result := mapContent(content, testOpenAPIComponents())
 
 	if result == nil {
 		t.Fatal("Expected non-nil result")
@@ -381,7 +387,7 @@ func TestMapContent_MixedStreamingAndRegular(t *testing.T) {
 		},
 	}
 
-	result := mapContent(content)
+	result := mapContent(content, testOpenAPIComponents())
 
 	if result == nil {
 		t.Fatal("Expected non-nil result")
@@ -448,7 +454,7 @@ func TestMapContent_CommaSeparated_WithStreamingTypes(t *testing.T) {
 		},
 	}
 
-	result := mapContent(content)
+	result := mapContent(content, testOpenAPIComponents())
 
 	if result == nil {
 		t.Fatal("Expected non-nil result")
@@ -530,7 +536,7 @@ func TestMapContent_WithExamplesAndStreamingTypes(t *testing.T) {
 		},
 	}
 
-	result := mapContent(content)
+	result := mapContent(content, testOpenAPIComponents())
 
 	if result == nil {
 		t.Fatal("Expected non-nil result")
@@ -585,7 +591,7 @@ func TestMapHeaders_WithBasicHeaders(t *testing.T) {
 		},
 	}
 
-	result := mapHeaders(headers)
+	result := mapHeaders(headers, testOpenAPIComponents())
 
 	if result == nil {
 		t.Fatal("Expected non-nil result")
@@ -636,7 +642,7 @@ func TestMapHeaders_WithContent(t *testing.T) {
 		},
 	}
 
-	result := mapHeaders(headers)
+	result := mapHeaders(headers, testOpenAPIComponents())
 
 	if result == nil {
 		t.Fatal("Expected non-nil result")
@@ -682,7 +688,7 @@ func TestMapHeaders_WithExamples(t *testing.T) {
 		},
 	}
 
-	result := mapHeaders(headers)
+	result := mapHeaders(headers, testOpenAPIComponents())
 
 	if result == nil {
 		t.Fatal("Expected non-nil result")
@@ -720,7 +726,7 @@ func TestMapHeaders_WithExplodeAndStyle(t *testing.T) {
 		},
 	}
 
-	result := mapHeaders(headers)
+	result := mapHeaders(headers, testOpenAPIComponents())
 
 	if result == nil {
 		t.Fatal("Expected non-nil result")
@@ -745,7 +751,7 @@ func TestMapHeaders_WithExplodeAndStyle(t *testing.T) {
 }
 
 func TestMapHeaders_NilInput(t *testing.T) {
-	result := mapHeaders(nil)
+	result := mapHeaders(nil, testOpenAPIComponents())
 
 	if result != nil {
 		t.Error("Expected nil result for nil input")
@@ -771,7 +777,7 @@ func TestProcessParameterSchema_WithContent(t *testing.T) {
 		},
 	}
 
-	schema, content := processParameterSchema(param)
+	schema, content := processParameterSchema(param, testOpenAPIComponents())
 
 	// When Content is provided, Schema should be nil
 	if schema != nil {
@@ -801,7 +807,7 @@ func TestProcessParameterSchema_WithoutContent(t *testing.T) {
 		TypeHint: 0,
 	}
 
-	schema, content := processParameterSchema(param)
+	schema, content := processParameterSchema(param, testOpenAPIComponents())
 
 	// When Content is not provided, Schema should be non-nil
 	if schema == nil {
@@ -829,7 +835,7 @@ func TestBuildParameterContent_SingleMediaType(t *testing.T) {
 		"application/json": Filter{},
 	}
 
-	result := buildParameterContent(content)
+	result := buildParameterContent(content, testOpenAPIComponents())
 
 	if result == nil {
 		t.Fatal("Expected non-nil result")
@@ -860,7 +866,7 @@ func TestBuildParameterContent_MultipleMediaTypes(t *testing.T) {
 		"application/json,application/xml": Data{},
 	}
 
-	result := buildParameterContent(content)
+	result := buildParameterContent(content, testOpenAPIComponents())
 
 	if result == nil {
 		t.Fatal("Expected non-nil result")
@@ -895,7 +901,7 @@ func TestBuildParameterSchema_DefaultTypeHint(t *testing.T) {
 		TypeHint: nil, // Should default to ""
 	}
 
-	result := buildParameterSchema(param)
+	result := buildParameterSchema(param, testOpenAPIComponents())
 
 	if result == nil {
 		t.Fatal("Expected non-nil result")
@@ -920,7 +926,7 @@ func TestBuildParameterSchema_WithConstraints(t *testing.T) {
 		Default:   "guest",
 	}
 
-	result := buildParameterSchema(param)
+	result := buildParameterSchema(param, testOpenAPIComponents())
 
 	if result == nil {
 		t.Fatal("Expected non-nil result")
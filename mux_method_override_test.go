@@ -0,0 +1,149 @@
+package webfram
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func setupMethodOverrideMux(t *testing.T, allow bool) *ServeMux {
+	t.Helper()
+	resetAppConfig()
+	Configure(&Config{AllowMethodOverride: allow})
+
+	mux := NewServeMux()
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		mux.HandleFunc(method+" /users/{id}", func(w ResponseWriter, r *Request) {
+			_, _ = w.Write([]byte(r.Method + " " + r.FormValue("name")))
+		})
+	}
+	registerHandlers(mux)
+
+	return mux
+}
+
+func TestServeMux_MethodOverride_FormField(t *testing.T) {
+	mux := setupMethodOverrideMux(t, true)
+
+	req := httptest.NewRequest(http.MethodPost, "/users/1", strings.NewReader("_method=patch&name=John"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if body := rec.Body.String(); body != "PATCH John" {
+		t.Errorf("Expected 'PATCH John', got %q", body)
+	}
+}
+
+func TestServeMux_MethodOverride_Header(t *testing.T) {
+	mux := setupMethodOverrideMux(t, true)
+
+	req := httptest.NewRequest(http.MethodPost, "/users/1", http.NoBody)
+	req.Header.Set("X-HTTP-Method-Override", "DELETE")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if body := rec.Body.String(); body != "DELETE " {
+		t.Errorf("Expected 'DELETE ', got %q", body)
+	}
+}
+
+func TestServeMux_MethodOverride_IgnoresUnsupportedMethods(t *testing.T) {
+	mux := setupMethodOverrideMux(t, true)
+
+	req := httptest.NewRequest(http.MethodPost, "/users/1", http.NoBody)
+	req.Header.Set("X-HTTP-Method-Override", "GET")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if body := rec.Body.String(); body != "POST " {
+		t.Errorf("Expected 'POST ', got %q", body)
+	}
+}
+
+func TestServeMux_MethodOverride_OnlyAppliesToPost(t *testing.T) {
+	mux := setupMethodOverrideMux(t, true)
+
+	req := httptest.NewRequest(http.MethodPut, "/users/1", http.NoBody)
+	req.Header.Set("X-HTTP-Method-Override", "DELETE")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if body := rec.Body.String(); body != "PUT " {
+		t.Errorf("Expected 'PUT ', got %q", body)
+	}
+}
+
+func TestServeMux_MethodOverride_Disabled(t *testing.T) {
+	mux := setupMethodOverrideMux(t, false)
+
+	req := httptest.NewRequest(http.MethodPost, "/users/1", strings.NewReader("_method=PUT&name=John"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if body := rec.Body.String(); body != "POST John" {
+		t.Errorf("Expected 'POST John', got %q", body)
+	}
+}
+
+func TestServeMux_MethodOverride_IgnoresMultipartForms(t *testing.T) {
+	resetAppConfig()
+	Configure(&Config{AllowMethodOverride: true, MaxUploadSize: 512})
+
+	mux := NewServeMux()
+	mux.HandleFunc("POST /upload", func(w ResponseWriter, r *Request) {
+		if r.MultipartForm != nil {
+			t.Error("Expected the multipart form not to be parsed before the handler")
+		}
+		if _, _, err := r.FormFile("document"); !errors.Is(err, ErrUploadTooLarge) {
+			t.Errorf("Expected ErrUploadTooLarge, got %v", err)
+		}
+		_, _ = w.Write([]byte(r.Method))
+	})
+	mux.HandleFunc("PUT /upload", func(w ResponseWriter, r *Request) {
+		_, _ = w.Write([]byte(r.Method))
+	})
+	registerHandlers(mux)
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	_ = mw.WriteField("_method", "PUT")
+	fw, _ := mw.CreateFormFile("document", "report.txt")
+	_, _ = fw.Write(bytes.Repeat([]byte("x"), 4096))
+	_ = mw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if body := rec.Body.String(); body != http.MethodPost {
+		t.Errorf("Expected the multipart _method field to be ignored, got %q", body)
+	}
+}
+
+func TestServeMux_MethodOverride_LimitsFormBody(t *testing.T) {
+	resetAppConfig()
+	Configure(&Config{AllowMethodOverride: true, MaxUploadSize: 64})
+
+	mux := NewServeMux()
+	mux.HandleFunc("POST /users/{id}", func(w ResponseWriter, r *Request) {
+		_, _ = w.Write([]byte(r.Method))
+	})
+	registerHandlers(mux)
+
+	form := "name=" + strings.Repeat("x", 128) + "&_method=DELETE"
+	req := httptest.NewRequest(http.MethodPost, "/users/1", strings.NewReader(form))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if body := rec.Body.String(); body != http.MethodPost {
+		t.Errorf("Expected a form over MaxUploadSize not to be read, got %q", body)
+	}
+}
@@ -0,0 +1,185 @@
+package webfram
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServeMux_RedirectTrailingSlash_AddsSlashRedirect(t *testing.T) {
+	resetAppConfig()
+
+	mux := NewServeMux()
+	mux.RedirectTrailingSlash(true)
+	mux.HandleFunc("GET /users", func(w ResponseWriter, _ *Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	registerHandlers(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/?page=2&sort=name", http.NoBody)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusMovedPermanently {
+		t.Fatalf("Expected status 301, got %d", rec.Code)
+	}
+
+	if location := rec.Header().Get("Location"); location != "/users?page=2&sort=name" {
+		t.Errorf("Expected Location '/users?page=2&sort=name', got %q", location)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/users", http.NoBody)
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status 200 for canonical URL, got %d", rec.Code)
+	}
+}
+
+func TestServeMux_RedirectTrailingSlash_RemovesSlashRedirect(t *testing.T) {
+	resetAppConfig()
+
+	mux := NewServeMux()
+	mux.RedirectTrailingSlash(true)
+	mux.HandleFunc("GET /items/{id}/{$}", func(w ResponseWriter, _ *Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	registerHandlers(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/items/42", http.NoBody)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusMovedPermanently {
+		t.Fatalf("Expected status 301, got %d", rec.Code)
+	}
+
+	if location := rec.Header().Get("Location"); location != "/items/42/" {
+		t.Errorf("Expected Location '/items/42/', got %q", location)
+	}
+}
+
+func TestServeMux_RedirectTrailingSlash_KeepsExplicitRoutes(t *testing.T) {
+	resetAppConfig()
+
+	mux := NewServeMux()
+	mux.RedirectTrailingSlash(true)
+	mux.HandleFunc("GET /users", func(w ResponseWriter, _ *Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("GET /users/{$}", func(w ResponseWriter, _ *Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+	registerHandlers(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/", http.NoBody)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("Expected explicit handler status 202, got %d", rec.Code)
+	}
+}
+
+func TestServeMux_RedirectTrailingSlash_Disabled(t *testing.T) {
+	resetAppConfig()
+
+	mux := NewServeMux()
+	mux.HandleFunc("GET /users", func(w ResponseWriter, _ *Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	registerHandlers(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/", http.NoBody)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", rec.Code)
+	}
+}
+
+func TestServeMux_RedirectPermanent(t *testing.T) {
+	resetAppConfig()
+
+	mux := NewServeMux()
+	hc := mux.RedirectPermanent("GET /old-users", "/users")
+	if hc == nil {
+		t.Fatal("Expected RedirectPermanent to return a HandlerConfig")
+	}
+	hc.OpenAPIOperation(OperationConfig{
+		Summary:   "Moved to /users",
+		Responses: map[string]Response{"301": {Description: "Moved Permanently"}},
+	})
+	registerHandlers(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/old-users?page=3", http.NoBody)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusMovedPermanently {
+		t.Fatalf("Expected status 301, got %d", rec.Code)
+	}
+
+	if location := rec.Header().Get("Location"); location != "/users?page=3" {
+		t.Errorf("Expected Location '/users?page=3', got %q", location)
+	}
+
+	if hc.operation == nil || hc.operation.Summary != "Moved to /users" {
+		t.Error("Expected OpenAPI operation to be attached to redirect handler")
+	}
+}
+
+func TestServeMux_RedirectTrailingSlash_SkipsPathsMatchedByOtherWildcards(t *testing.T) {
+	resetAppConfig()
+
+	mux := NewServeMux()
+	mux.RedirectTrailingSlash(true)
+	mux.HandleFunc("GET /a/{name}/{$}", func(w ResponseWriter, _ *Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("GET /a/{id}", func(w ResponseWriter, _ *Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	registerHandlers(mux)
+
+	tests := []struct {
+		path string
+		code int
+	}{
+		{"/a/42/", http.StatusAccepted},
+		{"/a/42", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+
+		if rec.Code != tt.code {
+			t.Errorf("%s: expected status %d, got %d", tt.path, tt.code, rec.Code)
+		}
+	}
+}
+
+func TestServeMux_RedirectTrailingSlash_KeepsSubtreeRoutes(t *testing.T) {
+	resetAppConfig()
+
+	mux := NewServeMux()
+	mux.RedirectTrailingSlash(true)
+	mux.HandleFunc("GET /users", func(w ResponseWriter, _ *Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("GET /users/", func(w ResponseWriter, _ *Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+	registerHandlers(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/", http.NoBody)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("Expected subtree handler status 202, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,68 @@
+package webfram
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServeMux_Favicon(t *testing.T) {
+	resetAppConfig()
+
+	icon := []byte{0x00, 0x00, 0x01, 0x00, 0x01, 0x00, 0x10, 0x10}
+
+	mux := NewServeMux()
+	mux.Favicon(icon)
+	registerHandlers(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/favicon.ico", http.NoBody))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	if rec.Body.String() != string(icon) {
+		t.Error("Expected icon data in response body")
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "image/x-icon" {
+		t.Errorf("Expected Content-Type 'image/x-icon', got %q", ct)
+	}
+	if cc := rec.Header().Get("Cache-Control"); cc != staticContentCacheControl {
+		t.Errorf("Expected Cache-Control %q, got %q", staticContentCacheControl, cc)
+	}
+}
+
+func TestServeMux_Robots(t *testing.T) {
+	resetAppConfig()
+
+	mux := NewServeMux()
+	mux.Robots("User-agent: *\nDisallow: /admin/\n")
+	registerHandlers(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/robots.txt", http.NoBody))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	if rec.Body.String() != "User-agent: *\nDisallow: /admin/\n" {
+		t.Errorf("Unexpected body: %q", rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/plain; charset=utf-8" {
+		t.Errorf("Expected Content-Type 'text/plain; charset=utf-8', got %q", ct)
+	}
+
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("Expected ETag header")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/robots.txt", http.NoBody)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("Expected status 304 for matching ETag, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,86 @@
+package webfram
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bondowe/webfram/internal/telemetry"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestTelemetryMiddleware_SkipTelemetry(t *testing.T) {
+	resetAppConfig()
+	telemetry.RequestsTotal.Reset()
+	telemetry.RequestDurationSeconds.Reset()
+
+	mux := NewServeMux()
+	mux.HandleFunc("GET /health", func(w ResponseWriter, r *Request) {
+		if r.Header.Get("X-Internal-Probe") != "" {
+			r.SkipTelemetry()
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	registerHandlers(mux)
+
+	probe := httptest.NewRequest(http.MethodGet, "/health", http.NoBody)
+	probe.Header.Set("X-Internal-Probe", "1")
+	mux.ServeHTTP(httptest.NewRecorder(), probe)
+
+	if count := testutil.CollectAndCount(telemetry.RequestsTotal); count != 0 {
+		t.Errorf("Expected skipped request not to be counted, got %d series", count)
+	}
+	if count := testutil.CollectAndCount(telemetry.RequestDurationSeconds); count != 0 {
+		t.Errorf("Expected skipped request duration not to be observed, got %d series", count)
+	}
+
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", http.NoBody))
+
+	if count := testutil.ToFloat64(telemetry.RequestsTotal.WithLabelValues("GET", "/health", "2xx")); count != 1 {
+		t.Errorf("Expected 1 counted request, got %v", count)
+	}
+}
+
+func TestRequest_SkipTelemetry_OutsideMux(_ *testing.T) {
+	// Must not panic when the request is not served through the telemetry middleware.
+	NewTestRequest(http.MethodGet, "/", nil).SkipTelemetry()
+}
+
+func TestTelemetryMiddleware_StatusClassifier(t *testing.T) {
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+	telemetry.RequestsTotal.Reset()
+	telemetry.RequestDurationSeconds.Reset()
+
+	Configure(&Config{
+		Telemetry: &Telemetry{
+			Enabled: true,
+			StatusClassifier: func(statusCode int) string {
+				if statusCode == http.StatusNotFound {
+					return "404"
+				}
+				return statusClass(statusCode)
+			},
+		},
+	})
+
+	mux := NewServeMux()
+	mux.HandleFunc("GET /items/{id}", func(w ResponseWriter, r *Request) {
+		if r.PathValue("id") == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	registerHandlers(mux)
+
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items/missing", http.NoBody))
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items/bad", http.NoBody))
+
+	if count := testutil.ToFloat64(telemetry.RequestsTotal.WithLabelValues("GET", "/items/missing", "404")); count != 1 {
+		t.Errorf("Expected 1 request labeled '404', got %v", count)
+	}
+	if count := testutil.ToFloat64(telemetry.RequestsTotal.WithLabelValues("GET", "/items/bad", "4xx")); count != 1 {
+		t.Errorf("Expected 1 request labeled '4xx', got %v", count)
+	}
+}
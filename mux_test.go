@@ -22,10 +22,10 @@ var testMuxI18nFS embed.FS
 
 // Helper function to reset and setup app for mux tests.
 func setupMuxTest() {
-	appConfigured = false
-	appMiddlewares = nil
-	openAPIConfig = nil
-	jsonpCallbackParamName = ""
+	defaultApp.configured = false
+	defaultApp.middlewares = nil
+	defaultApp.openAPIConfig = nil
+	defaultApp.jsonpCallbackParamName = ""
 
 	Configure(&Config{
 		Assets: &Assets{
@@ -39,10 +39,10 @@ func setupMuxTest() {
 
 // Helper function to setup app with OpenAPI enabled.
 func setupMuxTestWithOpenAPI() {
-	appConfigured = false
-	appMiddlewares = nil
-	openAPIConfig = &OpenAPI{Enabled: true}
-	jsonpCallbackParamName = ""
+	defaultApp.configured = false
+	defaultApp.middlewares = nil
+	defaultApp.openAPIConfig = &OpenAPI{Enabled: true}
+	defaultApp.jsonpCallbackParamName = ""
 
 	Configure(&Config{
 		OpenAPI: &OpenAPI{
@@ -83,8 +83,8 @@ func TestNewServeMux_Success(t *testing.T) {
 
 func TestNewServeMux_ConfiguresAppIfNeeded(t *testing.T) {
 	// Don't call setupMuxTest to test auto-configuration
-	appConfigured = false
-	appMiddlewares = nil
+	defaultApp.configured = false
+	defaultApp.middlewares = nil
 
 	mux := NewServeMux()
 
@@ -92,7 +92,7 @@ func TestNewServeMux_ConfiguresAppIfNeeded(t *testing.T) {
 		t.Fatal("NewServeMux returned nil")
 	}
 
-	if !appConfigured {
+	if !defaultApp.configured {
 		t.Error("Expected app to be configured automatically")
 	}
 }
@@ -115,6 +115,8 @@ func TestServeMux_HandleFunc_BasicHandler(t *testing.T) {
 
 	mux.HandleFunc("GET /test", handler)
 
+	registerHandlers(mux)
+
 	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
 	rec := httptest.NewRecorder()
 
@@ -146,6 +148,8 @@ func TestServeMux_HandleFunc_WithPathParameters(t *testing.T) {
 
 	mux.HandleFunc("GET /users/{id}", handler)
 
+	registerHandlers(mux)
+
 	req := httptest.NewRequest(http.MethodGet, "/users/123", http.NoBody)
 	rec := httptest.NewRecorder()
 
@@ -175,6 +179,8 @@ func TestServeMux_HandleFunc_MultipleRoutes(t *testing.T) {
 	})
 
 	// Test route1
+	registerHandlers(mux)
+
 	req1 := httptest.NewRequest(http.MethodGet, "/route1", http.NoBody)
 	rec1 := httptest.NewRecorder()
 	mux.ServeHTTP(rec1, req1)
@@ -240,6 +246,8 @@ func TestServeMux_Handle_WithHandlerInterface(t *testing.T) {
 
 	mux.Handle("POST /resource", handler)
 
+	registerHandlers(mux)
+
 	req := httptest.NewRequest(http.MethodPost, "/resource", http.NoBody)
 	rec := httptest.NewRecorder()
 
@@ -308,6 +316,8 @@ func TestServeMux_Use_WithAppMiddleware(t *testing.T) {
 
 	mux.HandleFunc("GET /test", handler)
 
+	registerHandlers(mux)
+
 	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
 	rec := httptest.NewRecorder()
 
@@ -348,6 +358,8 @@ func TestServeMux_Use_WithStandardMiddleware(t *testing.T) {
 
 	mux.HandleFunc("GET /test", handler)
 
+	registerHandlers(mux)
+
 	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
 	rec := httptest.NewRecorder()
 
@@ -422,6 +434,8 @@ func TestServeMux_Use_MultipleMiddlewares(t *testing.T) {
 
 	mux.HandleFunc("GET /test", handler)
 
+	registerHandlers(mux)
+
 	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
 	rec := httptest.NewRecorder()
 
@@ -466,7 +480,9 @@ func TestServeMux_HandleFunc_WithHandlerMiddleware(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 	}
 
-	mux.HandleFunc("GET /test", handler, handlerMw)
+	mux.HandleFunc("GET /test", handler).Use(handlerMw)
+
+	registerHandlers(mux)
 
 	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
 	rec := httptest.NewRecorder()
@@ -508,7 +524,9 @@ func TestServeMux_HandleFunc_WithMultipleHandlerMiddlewares(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 	}
 
-	mux.HandleFunc("GET /test", handler, mw1, mw2)
+	mux.HandleFunc("GET /test", handler).Use(mw1, mw2)
+
+	registerHandlers(mux)
 
 	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
 	rec := httptest.NewRecorder()
@@ -571,7 +589,9 @@ func TestServeMux_MixedMuxAndHandlerMiddlewares(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 	}
 
-	mux.HandleFunc("GET /test", handler, handlerMw)
+	mux.HandleFunc("GET /test", handler).Use(handlerMw)
+
+	registerHandlers(mux)
 
 	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
 	rec := httptest.NewRecorder()
@@ -644,8 +664,8 @@ func TestHandlerFunc_ServeHTTP_WithJSONPCallback_Valid(t *testing.T) {
 	setupMuxTest()
 
 	// Reset and configure with JSONP
-	appConfigured = false
-	jsonpCallbackParamName = ""
+	defaultApp.configured = false
+	defaultApp.jsonpCallbackParamName = ""
 	Configure(&Config{
 		JSONPCallbackParamName: "callback",
 		Assets: &Assets{
@@ -681,8 +701,8 @@ func TestHandlerFunc_ServeHTTP_WithJSONPCallback_Invalid(t *testing.T) {
 	setupMuxTest()
 
 	// Reset and configure with JSONP
-	appConfigured = false
-	jsonpCallbackParamName = ""
+	defaultApp.configured = false
+	defaultApp.jsonpCallbackParamName = ""
 	Configure(&Config{
 		JSONPCallbackParamName: "callback",
 		Assets: &Assets{
@@ -749,6 +769,8 @@ func TestI18nMiddleware_WithAcceptLanguageHeader(t *testing.T) {
 
 	mux.HandleFunc("GET /test", handler)
 
+	registerHandlers(mux)
+
 	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
 	req.Header.Set("Accept-Language", "fr-FR,fr;q=0.9,en;q=0.8")
 	rec := httptest.NewRecorder()
@@ -771,6 +793,8 @@ func TestI18nMiddleware_WithLanguageCookie(t *testing.T) {
 
 	mux.HandleFunc("GET /test", handler)
 
+	registerHandlers(mux)
+
 	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
 	req.AddCookie(&http.Cookie{
 		Name:  "lang",
@@ -786,10 +810,10 @@ func TestI18nMiddleware_WithLanguageCookie(t *testing.T) {
 }
 
 func TestI18nMiddleware_DefaultsToFirstSupportedLanguage(t *testing.T) {
-	appConfigured = false
-	appMiddlewares = nil
-	openAPIConfig = nil
-	jsonpCallbackParamName = ""
+	defaultApp.configured = false
+	defaultApp.middlewares = nil
+	defaultApp.openAPIConfig = nil
+	defaultApp.jsonpCallbackParamName = ""
 
 	// Configure with French as first supported language (not English)
 	Configure(&Config{
@@ -821,6 +845,8 @@ func TestI18nMiddleware_DefaultsToFirstSupportedLanguage(t *testing.T) {
 	mux.HandleFunc("GET /test", handler)
 
 	// No Accept-Language header or cookie - should default to first supported (fr)
+	registerHandlers(mux)
+
 	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
 	rec := httptest.NewRecorder()
 
@@ -833,10 +859,10 @@ func TestI18nMiddleware_DefaultsToFirstSupportedLanguage(t *testing.T) {
 
 func TestParseAcceptLanguage_ValidLanguages(t *testing.T) {
 	// Configure i18n with multiple supported languages
-	appConfigured = false
-	appMiddlewares = nil
-	openAPIConfig = nil
-	jsonpCallbackParamName = ""
+	defaultApp.configured = false
+	defaultApp.middlewares = nil
+	defaultApp.openAPIConfig = nil
+	defaultApp.jsonpCallbackParamName = ""
 
 	Configure(&Config{
 		Assets: &Assets{
@@ -877,7 +903,7 @@ func TestParseAcceptLanguage_ValidLanguages(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tag := parseAcceptLanguage(tt.acceptLang)
+			tag := defaultApp.parseAcceptLanguage(tt.acceptLang)
 			base, _ := tag.Base()
 			if base.String() != tt.wantBase {
 				t.Errorf(
@@ -892,7 +918,7 @@ func TestParseAcceptLanguage_ValidLanguages(t *testing.T) {
 }
 
 func TestParseAcceptLanguage_Empty(t *testing.T) {
-	tag := parseAcceptLanguage("")
+	tag := defaultApp.parseAcceptLanguage("")
 
 	if tag != language.Und {
 		t.Errorf("Expected undefined language for empty string, got %v", tag)
@@ -900,7 +926,7 @@ func TestParseAcceptLanguage_Empty(t *testing.T) {
 }
 
 func TestParseAcceptLanguage_Invalid(t *testing.T) {
-	tag := parseAcceptLanguage("invalid-language-string!!!!")
+	tag := defaultApp.parseAcceptLanguage("invalid-language-string!!!!")
 
 	if tag != language.Und {
 		t.Errorf("Expected undefined language for invalid string, got %v", tag)
@@ -956,10 +982,10 @@ func TestSetLanguageCookie_DeleteCookie(t *testing.T) {
 // =============================================================================
 
 func TestLanguageMatching_QualityValues(t *testing.T) {
-	appConfigured = false
-	appMiddlewares = nil
-	openAPIConfig = nil
-	jsonpCallbackParamName = ""
+	defaultApp.configured = false
+	defaultApp.middlewares = nil
+	defaultApp.openAPIConfig = nil
+	defaultApp.jsonpCallbackParamName = ""
 
 	Configure(&Config{
 		Assets: &Assets{
@@ -1000,7 +1026,7 @@ func TestLanguageMatching_QualityValues(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tag := parseAcceptLanguage(tt.acceptLang)
+			tag := defaultApp.parseAcceptLanguage(tt.acceptLang)
 			base, _ := tag.Base()
 			if base.String() != tt.wantBase {
 				t.Errorf(
@@ -1015,10 +1041,10 @@ func TestLanguageMatching_QualityValues(t *testing.T) {
 }
 
 func TestLanguageMatching_Fallback(t *testing.T) {
-	appConfigured = false
-	appMiddlewares = nil
-	openAPIConfig = nil
-	jsonpCallbackParamName = ""
+	defaultApp.configured = false
+	defaultApp.middlewares = nil
+	defaultApp.openAPIConfig = nil
+	defaultApp.jsonpCallbackParamName = ""
 
 	Configure(&Config{
 		Assets: &Assets{
@@ -1054,7 +1080,7 @@ func TestLanguageMatching_Fallback(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tag := parseAcceptLanguage(tt.acceptLang)
+			tag := defaultApp.parseAcceptLanguage(tt.acceptLang)
 			base, _ := tag.Base()
 			if base.String() != tt.wantBase {
 				t.Errorf(
@@ -1069,10 +1095,10 @@ func TestLanguageMatching_Fallback(t *testing.T) {
 }
 
 func TestLanguageCookie_PreferenceOverAcceptLanguage(t *testing.T) {
-	appConfigured = false
-	appMiddlewares = nil
-	openAPIConfig = nil
-	jsonpCallbackParamName = ""
+	defaultApp.configured = false
+	defaultApp.middlewares = nil
+	defaultApp.openAPIConfig = nil
+	defaultApp.jsonpCallbackParamName = ""
 
 	Configure(&Config{
 		Assets: &Assets{
@@ -1097,6 +1123,8 @@ func TestLanguageCookie_PreferenceOverAcceptLanguage(t *testing.T) {
 
 	// Request with both cookie (fr) and Accept-Language (es)
 	// Cookie should take precedence
+	registerHandlers(mux)
+
 	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
 	req.Header.Set("Accept-Language", "es-ES,es;q=0.9")
 	req.AddCookie(&http.Cookie{Name: "lang", Value: "fr"})
@@ -1110,10 +1138,10 @@ func TestLanguageCookie_PreferenceOverAcceptLanguage(t *testing.T) {
 }
 
 func TestLanguageContext_PersistsThroughHandlers(t *testing.T) {
-	appConfigured = false
-	appMiddlewares = nil
-	openAPIConfig = nil
-	jsonpCallbackParamName = ""
+	defaultApp.configured = false
+	defaultApp.middlewares = nil
+	defaultApp.openAPIConfig = nil
+	defaultApp.jsonpCallbackParamName = ""
 
 	Configure(&Config{
 		Assets: &Assets{
@@ -1147,6 +1175,8 @@ func TestLanguageContext_PersistsThroughHandlers(t *testing.T) {
 
 	mux.HandleFunc("GET /test", handler)
 
+	registerHandlers(mux)
+
 	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
 	req.Header.Set("Accept-Language", "fr-FR")
 	rec := httptest.NewRecorder()
@@ -1162,10 +1192,10 @@ func TestLanguageContext_PersistsThroughHandlers(t *testing.T) {
 }
 
 func TestParseAcceptLanguage_UnsupportedLanguage(t *testing.T) {
-	appConfigured = false
-	appMiddlewares = nil
-	openAPIConfig = nil
-	jsonpCallbackParamName = ""
+	defaultApp.configured = false
+	defaultApp.middlewares = nil
+	defaultApp.openAPIConfig = nil
+	defaultApp.jsonpCallbackParamName = ""
 
 	Configure(&Config{
 		Assets: &Assets{
@@ -1178,7 +1208,7 @@ func TestParseAcceptLanguage_UnsupportedLanguage(t *testing.T) {
 	})
 
 	// Request language not in supported list should fall back to English
-	tag := parseAcceptLanguage("fr-FR,fr;q=0.9")
+	tag := defaultApp.parseAcceptLanguage("fr-FR,fr;q=0.9")
 	base, _ := tag.Base()
 
 	if base.String() != "en" {
@@ -1187,10 +1217,10 @@ func TestParseAcceptLanguage_UnsupportedLanguage(t *testing.T) {
 }
 
 func TestLanguageMatching_EdgeCases(t *testing.T) {
-	appConfigured = false
-	appMiddlewares = nil
-	openAPIConfig = nil
-	jsonpCallbackParamName = ""
+	defaultApp.configured = false
+	defaultApp.middlewares = nil
+	defaultApp.openAPIConfig = nil
+	defaultApp.jsonpCallbackParamName = ""
 
 	Configure(&Config{
 		Assets: &Assets{
@@ -1232,7 +1262,7 @@ func TestLanguageMatching_EdgeCases(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tag := parseAcceptLanguage(tt.acceptLang)
+			tag := defaultApp.parseAcceptLanguage(tt.acceptLang)
 
 			if tt.wantUnd {
 				if tag != language.Und {
@@ -1257,10 +1287,10 @@ func TestLanguageMatching_EdgeCases(t *testing.T) {
 func TestI18n_NoConfiguration(t *testing.T) {
 	// Reset app state
 	// This tests auto-detection when no explicit supported languages are provided
-	appConfigured = false
-	appMiddlewares = nil
-	openAPIConfig = nil
-	jsonpCallbackParamName = ""
+	defaultApp.configured = false
+	defaultApp.middlewares = nil
+	defaultApp.openAPIConfig = nil
+	defaultApp.jsonpCallbackParamName = ""
 
 	Configure(&Config{
 		Assets: &Assets{
@@ -1273,7 +1303,7 @@ func TestI18n_NoConfiguration(t *testing.T) {
 	})
 
 	// With auto-detection, French should be found and matched
-	tag := parseAcceptLanguage("fr-FR")
+	tag := defaultApp.parseAcceptLanguage("fr-FR")
 
 	base, _ := tag.Base()
 	if base.String() != "fr" {
@@ -1537,7 +1567,7 @@ func TestGetSecurityMiddlewares_AllowAnonymousAuth(t *testing.T) {
 
 	mux.UseSecurity(config)
 
-	middlewares := getSecurityMiddlewares(mux)
+	middlewares := defaultApp.getSecurityMiddlewares(mux.securityConfig, nil)
 
 	if len(middlewares) != 0 {
 		t.Errorf("Expected no security middlewares when AllowAnonymousAuth is true, got %d", len(middlewares))
@@ -1549,7 +1579,7 @@ func TestGetSecurityMiddlewares_NoSecurityConfig(t *testing.T) {
 
 	mux := NewServeMux()
 
-	middlewares := getSecurityMiddlewares(mux)
+	middlewares := defaultApp.getSecurityMiddlewares(mux.securityConfig, nil)
 
 	if len(middlewares) != 0 {
 		t.Errorf("Expected no security middlewares when no config is set, got %d", len(middlewares))
@@ -1563,7 +1593,7 @@ func TestGetSecurityMiddlewares_GlobalSecurityConfig(t *testing.T) {
 
 	// Test that mux-level security config takes precedence over global config
 	// When no security is configured on the mux, it should return empty middlewares
-	middlewares := getSecurityMiddlewares(mux)
+	middlewares := defaultApp.getSecurityMiddlewares(mux.securityConfig, nil)
 
 	if len(middlewares) != 0 {
 		t.Errorf("Expected 0 security middlewares when no mux security is configured, got %d", len(middlewares))
@@ -1585,7 +1615,7 @@ func TestGetSecurityMiddlewares_APIKeyAuth(t *testing.T) {
 
 	mux.UseSecurity(config)
 
-	middlewares := getSecurityMiddlewares(mux)
+	middlewares := defaultApp.getSecurityMiddlewares(mux.securityConfig, nil)
 
 	if len(middlewares) != 1 {
 		t.Errorf("Expected 1 security middleware, got %d", len(middlewares))
@@ -1606,7 +1636,7 @@ func TestGetSecurityMiddlewares_BasicAuth(t *testing.T) {
 
 	mux.UseSecurity(config)
 
-	middlewares := getSecurityMiddlewares(mux)
+	middlewares := defaultApp.getSecurityMiddlewares(mux.securityConfig, nil)
 
 	if len(middlewares) != 1 {
 		t.Errorf("Expected 1 security middleware, got %d", len(middlewares))
@@ -1626,7 +1656,7 @@ func TestGetSecurityMiddlewares_DigestAuth(t *testing.T) {
 
 	mux.UseSecurity(config)
 
-	middlewares := getSecurityMiddlewares(mux)
+	middlewares := defaultApp.getSecurityMiddlewares(mux.securityConfig, nil)
 
 	if len(middlewares) != 1 {
 		t.Errorf("Expected 1 security middleware, got %d", len(middlewares))
@@ -1646,7 +1676,7 @@ func TestGetSecurityMiddlewares_BearerAuth(t *testing.T) {
 
 	mux.UseSecurity(config)
 
-	middlewares := getSecurityMiddlewares(mux)
+	middlewares := defaultApp.getSecurityMiddlewares(mux.securityConfig, nil)
 
 	if len(middlewares) != 1 {
 		t.Errorf("Expected 1 security middleware, got %d", len(middlewares))
@@ -1666,7 +1696,7 @@ func TestGetSecurityMiddlewares_MutualTLSAuth(t *testing.T) {
 
 	mux.UseSecurity(config)
 
-	middlewares := getSecurityMiddlewares(mux)
+	middlewares := defaultApp.getSecurityMiddlewares(mux.securityConfig, nil)
 
 	if len(middlewares) != 1 {
 		t.Errorf("Expected 1 security middleware, got %d", len(middlewares))
@@ -1691,7 +1721,7 @@ func TestGetSecurityMiddlewares_OAuth2AuthorizationCode(t *testing.T) {
 
 	mux.UseSecurity(config)
 
-	middlewares := getSecurityMiddlewares(mux)
+	middlewares := defaultApp.getSecurityMiddlewares(mux.securityConfig, nil)
 
 	if len(middlewares) != 1 {
 		t.Errorf("Expected 1 security middleware, got %d", len(middlewares))
@@ -1716,7 +1746,7 @@ func TestGetSecurityMiddlewares_OAuth2ClientCredentials(t *testing.T) {
 
 	mux.UseSecurity(config)
 
-	middlewares := getSecurityMiddlewares(mux)
+	middlewares := defaultApp.getSecurityMiddlewares(mux.securityConfig, nil)
 
 	if len(middlewares) != 1 {
 		t.Errorf("Expected 1 security middleware, got %d", len(middlewares))
@@ -1740,7 +1770,7 @@ func TestGetSecurityMiddlewares_OAuth2Device(t *testing.T) {
 
 	mux.UseSecurity(config)
 
-	middlewares := getSecurityMiddlewares(mux)
+	middlewares := defaultApp.getSecurityMiddlewares(mux.securityConfig, nil)
 
 	if len(middlewares) != 1 {
 		t.Errorf("Expected 1 security middleware, got %d", len(middlewares))
@@ -1766,7 +1796,7 @@ func TestGetSecurityMiddlewares_OAuth2Implicit(t *testing.T) {
 
 	mux.UseSecurity(config)
 
-	middlewares := getSecurityMiddlewares(mux)
+	middlewares := defaultApp.getSecurityMiddlewares(mux.securityConfig, nil)
 
 	if len(middlewares) != 1 {
 		t.Errorf("Expected 1 security middleware, got %d", len(middlewares))
@@ -1787,7 +1817,7 @@ func TestGetSecurityMiddlewares_OpenIDConnectAuth(t *testing.T) {
 
 	mux.UseSecurity(config)
 
-	middlewares := getSecurityMiddlewares(mux)
+	middlewares := defaultApp.getSecurityMiddlewares(mux.securityConfig, nil)
 
 	if len(middlewares) != 1 {
 		t.Errorf("Expected 1 security middleware, got %d", len(middlewares))
@@ -1816,7 +1846,7 @@ func TestGetSecurityMiddlewares_MultipleAuthMethods(t *testing.T) {
 
 	mux.UseSecurity(config)
 
-	middlewares := getSecurityMiddlewares(mux)
+	middlewares := defaultApp.getSecurityMiddlewares(mux.securityConfig, nil)
 
 	if len(middlewares) != 3 {
 		t.Errorf("Expected 3 security middlewares, got %d", len(middlewares))
@@ -1849,6 +1879,8 @@ func TestServeMux_SecurityMiddlewareIntegration_APIKeyAuth(t *testing.T) {
 	mux.HandleFunc("GET /secure", handler)
 
 	// Test without API key - should fail
+	registerHandlers(mux)
+
 	req1 := httptest.NewRequest(http.MethodGet, "/secure", http.NoBody)
 	rec1 := httptest.NewRecorder()
 	mux.ServeHTTP(rec1, req1)
@@ -1903,6 +1935,8 @@ func TestServeMux_SecurityMiddlewareIntegration_BearerAuth(t *testing.T) {
 	mux.HandleFunc("GET /secure", handler)
 
 	// Test without bearer token - should fail
+	registerHandlers(mux)
+
 	req1 := httptest.NewRequest(http.MethodGet, "/secure", http.NoBody)
 	rec1 := httptest.NewRecorder()
 	mux.ServeHTTP(rec1, req1)
@@ -1975,6 +2009,8 @@ func TestServeMux_SecurityMiddlewareIntegration_BasicAuth(t *testing.T) {
 	mux.HandleFunc("GET /secure", handler)
 
 	// Test without basic auth - should fail
+	registerHandlers(mux)
+
 	req1 := httptest.NewRequest(http.MethodGet, "/secure", http.NoBody)
 	rec1 := httptest.NewRecorder()
 	mux.ServeHTTP(rec1, req1)
@@ -2055,6 +2091,8 @@ func TestServeMux_SecurityMiddlewareOrder(t *testing.T) {
 
 	mux.HandleFunc("GET /secure", handler)
 
+	registerHandlers(mux)
+
 	req := httptest.NewRequest(http.MethodGet, "/secure", http.NoBody)
 	req.Header.Set("X-Api-Key", "valid-key")
 	rec := httptest.NewRecorder()
@@ -2081,7 +2119,7 @@ func TestServeMux_SecurityMiddlewareOrder(t *testing.T) {
 // OpenAPI Integration Tests
 // =============================================================================
 
-func TestHandlerConfig_WithOperationConfig_Success(t *testing.T) {
+func TestHandlerConfig_OpenAPIOperation_Success(t *testing.T) {
 	setupMuxTestWithOpenAPI()
 
 	mux := NewServeMux()
@@ -2099,18 +2137,18 @@ func TestHandlerConfig_WithOperationConfig_Success(t *testing.T) {
 		Tags:        []string{"users"},
 	}
 
-	config.WithOperationConfig(apiConfig)
+	config.OpenAPIOperation(*apiConfig)
 
-	if config.OperationConfig == nil {
-		t.Error("OperationConfig was not set")
+	if config.operation == nil {
+		t.Error("operation was not set")
 	}
 
-	if config.OperationConfig.OperationID != "getUsers" {
-		t.Errorf("Expected OperationID 'getUsers', got %q", config.OperationConfig.OperationID)
+	if config.operation.OperationID != "getUsers" {
+		t.Errorf("Expected OperationID 'getUsers', got %q", config.operation.OperationID)
 	}
 }
 
-func TestHandlerConfig_WithOperationConfig_NilConfig(t *testing.T) {
+func TestHandlerConfig_OpenAPIOperation_NotSet(t *testing.T) {
 	setupMuxTestWithOpenAPI()
 
 	mux := NewServeMux()
@@ -2121,15 +2159,13 @@ func TestHandlerConfig_WithOperationConfig_NilConfig(t *testing.T) {
 
 	config := mux.HandleFunc("GET /api/test", handler)
 
-	// Should not panic with nil config
-	config.WithOperationConfig(nil)
-
-	if config.OperationConfig != nil {
-		t.Error("OperationConfig should remain nil")
+	// Handlers without an operation are left out of the OpenAPI document
+	if config.operation != nil {
+		t.Error("operation should remain nil")
 	}
 }
 
-func TestHandlerConfig_WithOperationConfig_OpenAPIDisabled(_ *testing.T) {
+func TestHandlerConfig_OpenAPIOperation_OpenAPIDisabled(_ *testing.T) {
 	setupMuxTest() // Sets up without OpenAPI
 
 	mux := NewServeMux()
@@ -2146,28 +2182,25 @@ func TestHandlerConfig_WithOperationConfig_OpenAPIDisabled(_ *testing.T) {
 	}
 
 	// Should not panic even if OpenAPI is disabled
-	config.WithOperationConfig(apiConfig)
+	config.OpenAPIOperation(*apiConfig)
 }
 
-func TestHandlerConfig_WithOperationConfig_InvalidPathPattern(t *testing.T) {
+func TestHandlerConfig_OpenAPIOperation_InvalidPathPattern(t *testing.T) {
 	setupMuxTestWithOpenAPI()
 
-	config := &HandlerConfig{
-		pathPattern: "invalid-pattern", // Missing method
-	}
-
 	defer func() {
 		if r := recover(); r == nil {
 			t.Error("Expected panic for invalid path pattern")
 		}
 	}()
 
-	config.WithOperationConfig(&OperationConfig{
+	// Missing method
+	defaultApp.configureOpenAPIOperation("invalid-pattern", &OperationConfig{
 		OperationID: "testOp",
 	})
 }
 
-func TestHandlerConfig_WithOperationConfig_WithRequestBody(t *testing.T) {
+func TestHandlerConfig_OpenAPIOperation_WithRequestBody(t *testing.T) {
 	setupMuxTestWithOpenAPI()
 
 	mux := NewServeMux()
@@ -2197,14 +2230,14 @@ func TestHandlerConfig_WithOperationConfig_WithRequestBody(t *testing.T) {
 		},
 	}
 
-	config.WithOperationConfig(apiConfig)
+	config.OpenAPIOperation(*apiConfig)
 
-	if config.OperationConfig.RequestBody == nil {
+	if config.operation.RequestBody == nil {
 		t.Error("RequestBody was not set")
 	}
 }
 
-func TestHandlerConfig_WithOperationConfig_WithResponses(t *testing.T) {
+func TestHandlerConfig_OpenAPIOperation_WithResponses(t *testing.T) {
 	setupMuxTestWithOpenAPI()
 
 	mux := NewServeMux()
@@ -2238,18 +2271,18 @@ func TestHandlerConfig_WithOperationConfig_WithResponses(t *testing.T) {
 		},
 	}
 
-	config.WithOperationConfig(apiConfig)
+	config.OpenAPIOperation(*apiConfig)
 
-	if config.OperationConfig.Responses == nil {
+	if config.operation.Responses == nil {
 		t.Error("Responses were not set")
 	}
 
-	if len(config.OperationConfig.Responses) != 2 {
-		t.Errorf("Expected 2 responses, got %d", len(config.OperationConfig.Responses))
+	if len(config.operation.Responses) != 2 {
+		t.Errorf("Expected 2 responses, got %d", len(config.operation.Responses))
 	}
 }
 
-func TestHandlerConfig_WithOperationConfig_WithSecurity(t *testing.T) {
+func TestHandlerConfig_OpenAPIOperation_WithSecurity(t *testing.T) {
 	setupMuxTestWithOpenAPI()
 
 	mux := NewServeMux()
@@ -2269,30 +2302,30 @@ func TestHandlerConfig_WithOperationConfig_WithSecurity(t *testing.T) {
 		},
 	}
 
-	config.WithOperationConfig(operationConfig)
+	config.OpenAPIOperation(*operationConfig)
 
-	if config.OperationConfig.Security == nil {
+	if config.operation.Security == nil {
 		t.Fatal("Expected Security to be set")
 	}
 
-	if len(config.OperationConfig.Security) != 2 {
-		t.Errorf("Expected 2 security requirements, got %d", len(config.OperationConfig.Security))
+	if len(config.operation.Security) != 2 {
+		t.Errorf("Expected 2 security requirements, got %d", len(config.operation.Security))
 	}
 
 	// Verify BearerAuth requirement
-	if _, ok := config.OperationConfig.Security[0]["BearerAuth"]; !ok {
+	if _, ok := config.operation.Security[0]["BearerAuth"]; !ok {
 		t.Error("Expected BearerAuth security requirement")
 	}
 
 	// Verify ApiKeyAuth requirement with scopes
-	if scopes, ok := config.OperationConfig.Security[1]["ApiKeyAuth"]; !ok {
+	if scopes, ok := config.operation.Security[1]["ApiKeyAuth"]; !ok {
 		t.Error("Expected ApiKeyAuth security requirement")
 	} else if len(scopes) != 2 {
 		t.Errorf("Expected 2 scopes for ApiKeyAuth, got %d", len(scopes))
 	}
 }
 
-func TestHandlerConfig_WithOperationConfig_WithEmptySecurity(t *testing.T) {
+func TestHandlerConfig_OpenAPIOperation_WithEmptySecurity(t *testing.T) {
 	setupMuxTestWithOpenAPI()
 
 	mux := NewServeMux()
@@ -2309,19 +2342,19 @@ func TestHandlerConfig_WithOperationConfig_WithEmptySecurity(t *testing.T) {
 		Security:    []map[string][]string{},
 	}
 
-	config.WithOperationConfig(operationConfig)
+	config.OpenAPIOperation(*operationConfig)
 
 	// Empty security array means no authentication required
-	if config.OperationConfig.Security == nil {
+	if config.operation.Security == nil {
 		t.Error("Expected Security to be initialized even when empty")
 	}
 
-	if len(config.OperationConfig.Security) != 0 {
-		t.Errorf("Expected 0 security requirements, got %d", len(config.OperationConfig.Security))
+	if len(config.operation.Security) != 0 {
+		t.Errorf("Expected 0 security requirements, got %d", len(config.operation.Security))
 	}
 }
 
-func TestHandlerConfig_WithOperationConfig_WithNilSecurity(t *testing.T) {
+func TestHandlerConfig_OpenAPIOperation_WithNilSecurity(t *testing.T) {
 	setupMuxTestWithOpenAPI()
 
 	mux := NewServeMux()
@@ -2338,10 +2371,10 @@ func TestHandlerConfig_WithOperationConfig_WithNilSecurity(t *testing.T) {
 		Security:    nil,
 	}
 
-	config.WithOperationConfig(operationConfig)
+	config.OpenAPIOperation(*operationConfig)
 
 	// Nil security means use global security requirements
-	if config.OperationConfig.Security != nil {
+	if config.operation.Security != nil {
 		t.Error("Expected Security to remain nil when not specified")
 	}
 }
@@ -2515,7 +2548,7 @@ func TestMapParameters_Basic(t *testing.T) {
 	}
 
 	setupMuxTestWithOpenAPI()
-	result := mapParameters(params)
+	result := mapParameters(params, testOpenAPIComponents())
 
 	if len(result) != 2 {
 		t.Errorf("Expected 2 parameters, got %d", len(result))
@@ -2600,6 +2633,8 @@ func BenchmarkServeMux_ServeHTTP(b *testing.B) {
 
 	mux.HandleFunc("GET /test", handler)
 
+	registerHandlers(mux)
+
 	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
 
 	b.ResetTimer()
@@ -2639,7 +2674,7 @@ func BenchmarkParseAcceptLanguage(b *testing.B) {
 
 	b.ResetTimer()
 	for b.Loop() {
-		_ = parseAcceptLanguage(acceptLang)
+		_ = defaultApp.parseAcceptLanguage(acceptLang)
 	}
 }
 
@@ -2676,6 +2711,8 @@ func TestTelemetryMiddleware_RequestsTotal(t *testing.T) {
 	mux.HandleFunc("GET /notfound", handler)
 	mux.HandleFunc("GET /error", handler)
 
+	registerHandlers(mux)
+
 	// Make requests
 	testCases := []struct {
 		method       string
@@ -2746,6 +2783,8 @@ func TestTelemetryMiddleware_ActiveConnections(t *testing.T) {
 
 	mux.HandleFunc("GET /test", handler)
 
+	registerHandlers(mux)
+
 	// Start request in goroutine
 	go func() {
 		req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
@@ -2791,6 +2830,8 @@ func TestTelemetryMiddleware_RequestDuration(t *testing.T) {
 
 	mux.HandleFunc("GET /timed", handler)
 
+	registerHandlers(mux)
+
 	req := httptest.NewRequest(http.MethodGet, "/timed", http.NoBody)
 	rec := httptest.NewRecorder()
 	mux.ServeHTTP(rec, req)
@@ -2941,6 +2982,8 @@ func TestTelemetryMiddleware_StatusClasses(t *testing.T) {
 		})
 	}
 
+	registerHandlers(mux)
+
 	// Make requests and verify metrics
 	for _, tc := range testCases {
 		req := httptest.NewRequest(http.MethodGet, tc.path, http.NoBody)
@@ -2978,6 +3021,8 @@ func TestTelemetryMiddleware_DifferentHTTPMethods(t *testing.T) {
 	mux.HandleFunc("DELETE /resource", handler)
 	mux.HandleFunc("PATCH /resource", handler)
 
+	registerHandlers(mux)
+
 	methods := []string{"GET", "POST", "PUT", "DELETE", "PATCH"}
 
 	for _, method := range methods {
@@ -3013,6 +3058,8 @@ func TestTelemetryMiddleware_ConcurrentRequests(t *testing.T) {
 
 	mux.HandleFunc("GET /concurrent", handler)
 
+	registerHandlers(mux)
+
 	// Make concurrent requests
 	var wg sync.WaitGroup
 	numRequests := 10
@@ -0,0 +1,139 @@
+package webfram
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// mediaRange is a single media range of an Accept header, such as "application/*;q=0.8".
+type mediaRange struct {
+	mediaType string
+	subtype   string
+	quality   float64
+}
+
+// respondFormats lists the media types Respond can produce, in order of server preference.
+//
+//nolint:gochecknoglobals // Read-only lookup table
+var respondFormats = []string{
+	"application/json",
+	"application/xml",
+	"text/x-yaml",
+}
+
+// parseAccept parses an Accept header (e.g., "application/xml;q=0.9, application/json").
+// Media ranges without a q parameter have a quality of 1; malformed ranges and
+// ranges with an invalid quality are ignored.
+func parseAccept(accept string) []mediaRange {
+	var ranges []mediaRange
+
+	for _, part := range strings.Split(accept, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		mediaType, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			continue
+		}
+
+		typ, subtype, ok := strings.Cut(mediaType, "/")
+		if !ok || typ == "" || subtype == "" || (typ == "*" && subtype != "*") {
+			continue
+		}
+
+		quality := 1.0
+		if q, hasQ := params["q"]; hasQ {
+			quality, err = strconv.ParseFloat(q, 64)
+			if err != nil || quality < 0 || quality > 1 {
+				continue
+			}
+		}
+
+		ranges = append(ranges, mediaRange{mediaType: typ, subtype: subtype, quality: quality})
+	}
+
+	return ranges
+}
+
+// specificity returns how closely the media range matches the offered type and subtype,
+// or -1 if it does not match at all. Exact matches win over "type/*", which wins over "*/*".
+func (mr mediaRange) specificity(typ, subtype string) int {
+	switch {
+	case mr.mediaType == typ && mr.subtype == subtype:
+		return 2
+	case mr.mediaType == typ && mr.subtype == "*":
+		return 1
+	case mr.mediaType == "*":
+		return 0
+	default:
+		return -1
+	}
+}
+
+// negotiateContentType returns the offer preferred by the given Accept header.
+// The quality of each offer is taken from the most specific matching media range, the offer with
+// the highest quality wins, and ties are broken by the order of offers.
+// Returns the first offer if the header is empty, and "" if no offer is acceptable.
+func negotiateContentType(accept string, offers ...string) string {
+	if len(offers) == 0 {
+		return ""
+	}
+
+	if strings.TrimSpace(accept) == "" {
+		return offers[0]
+	}
+
+	ranges := parseAccept(accept)
+
+	best := ""
+	bestQuality := 0.0
+
+	for _, offer := range offers {
+		typ, subtype, _ := strings.Cut(strings.ToLower(offer), "/")
+
+		quality, matched := 0.0, -1
+		for _, mr := range ranges {
+			if s := mr.specificity(typ, subtype); s > matched {
+				quality, matched = mr.quality, s
+			}
+		}
+
+		if quality > bestQuality {
+			best, bestQuality = offer, quality
+		}
+	}
+
+	return best
+}
+
+// NegotiateContentType returns the offered media type that best matches the request's Accept header,
+// honoring q-values (e.g., "application/xml;q=0.9, application/json;q=1.0" prefers JSON).
+// Ties are broken by the order of offers, so list them in order of preference.
+// Returns the first offer if no Accept header is present, and "" if none of the offers is acceptable.
+func (r *Request) NegotiateContentType(offers ...string) string {
+	return negotiateContentType(r.Header.Get("Accept"), offers...)
+}
+
+// Respond writes v as JSON, XML or YAML, depending on the request's Accept header.
+// JSON is used when the client has no preference. Responses are marked with "Vary: Accept".
+// If the client accepts none of these formats, a 406 Not Acceptable error is written instead.
+// Returns an error if marshaling or writing fails.
+func (w *ResponseWriter) Respond(r *Request, v any) error {
+	w.Vary("Accept")
+
+	switch r.NegotiateContentType(respondFormats...) {
+	case "application/json":
+		return w.JSON(r.Context(), v)
+	case "application/xml":
+		return w.XML(v)
+	case "text/x-yaml":
+		return w.YAML(v)
+	default:
+		w.Error(http.StatusNotAcceptable, http.StatusText(http.StatusNotAcceptable))
+		return nil
+	}
+}
@@ -0,0 +1,127 @@
+package webfram
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestParseAccept(t *testing.T) {
+	ranges := parseAccept("application/xml;q=0.9, application/json, text/*;q=0.5, */*;q=0.1, bad, image/png;q=2")
+
+	expected := []mediaRange{
+		{mediaType: "application", subtype: "xml", quality: 0.9},
+		{mediaType: "application", subtype: "json", quality: 1},
+		{mediaType: "text", subtype: "*", quality: 0.5},
+		{mediaType: "*", subtype: "*", quality: 0.1},
+	}
+
+	if len(ranges) != len(expected) {
+		t.Fatalf("Expected %d media ranges, got %d: %+v", len(expected), len(ranges), ranges)
+	}
+
+	for i, mr := range ranges {
+		if mr != expected[i] {
+			t.Errorf("Range %d: expected %+v, got %+v", i, expected[i], mr)
+		}
+	}
+}
+
+func TestNegotiateContentType(t *testing.T) {
+	offers := []string{"application/json", "application/xml", "text/x-yaml"}
+
+	tests := []struct {
+		name     string
+		accept   string
+		expected string
+	}{
+		{"no header", "", "application/json"},
+		{"single type", "application/xml", "application/xml"},
+		{"competing q-values", "application/xml;q=0.9, application/json;q=1.0", "application/json"},
+		{"higher q listed last", "application/json;q=0.5, application/xml;q=0.8", "application/xml"},
+		{"equal q uses offer order", "application/xml, application/json", "application/json"},
+		{"wildcard", "*/*", "application/json"},
+		{"subtype wildcard", "text/*", "text/x-yaml"},
+		{"specific range overrides wildcard", "application/*;q=0.9, application/json;q=0.2", "application/xml"},
+		{"q=0 excludes type", "application/json;q=0, */*;q=0.1", "application/xml"},
+		{"case insensitive", "Application/XML", "application/xml"},
+		{"no acceptable offer", "image/png", ""},
+		{"all excluded", "*/*;q=0", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := negotiateContentType(tt.accept, offers...); got != tt.expected {
+				t.Errorf("negotiateContentType(%q) = %q, want %q", tt.accept, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRequest_NegotiateContentType(t *testing.T) {
+	r := NewTestRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "text/html;q=0.8, application/json;q=0.9")
+
+	if got := r.NegotiateContentType("text/html", "application/json"); got != "application/json" {
+		t.Errorf("Expected application/json, got %q", got)
+	}
+
+	if got := r.NegotiateContentType(); got != "" {
+		t.Errorf("Expected empty result without offers, got %q", got)
+	}
+}
+
+func TestResponseWriter_Respond(t *testing.T) {
+	type item struct {
+		Name string `json:"name" xml:"name" yaml:"name"`
+	}
+
+	tests := []struct {
+		accept      string
+		contentType string
+		body        string
+	}{
+		{"", "application/json", `{"name":"widget"}`},
+		{"application/xml;q=0.9, application/json;q=1.0", "application/json", `{"name":"widget"}`},
+		{"application/xml;q=1.0, application/json;q=0.9", "application/xml", "<item><name>widget</name></item>"},
+		{"text/x-yaml", "text/x-yaml", "name: widget"},
+	}
+
+	for _, tt := range tests {
+		r := NewTestRequest(http.MethodGet, "/", nil)
+		if tt.accept != "" {
+			r.Header.Set("Accept", tt.accept)
+		}
+		w, rec := NewTestResponseWriter()
+
+		if err := w.Respond(r, item{Name: "widget"}); err != nil {
+			t.Fatalf("Respond failed: %v", err)
+		}
+
+		if got := rec.Header().Get("Content-Type"); got != tt.contentType {
+			t.Errorf("Accept %q: expected Content-Type %q, got %q", tt.accept, tt.contentType, got)
+		}
+
+		if got := strings.TrimSpace(rec.Body.String()); got != tt.body {
+			t.Errorf("Accept %q: expected body %q, got %q", tt.accept, tt.body, got)
+		}
+
+		if got := rec.Header().Get("Vary"); got != "Accept" {
+			t.Errorf("Expected Vary 'Accept', got %q", got)
+		}
+	}
+}
+
+func TestResponseWriter_Respond_NotAcceptable(t *testing.T) {
+	r := NewTestRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "image/png")
+	w, rec := NewTestResponseWriter()
+
+	if err := w.Respond(r, map[string]string{"name": "widget"}); err != nil {
+		t.Fatalf("Respond failed: %v", err)
+	}
+
+	if rec.Code != http.StatusNotAcceptable {
+		t.Errorf("Expected status 406, got %d", rec.Code)
+	}
+}
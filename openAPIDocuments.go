@@ -0,0 +1,58 @@
+package webfram
+
+import "fmt"
+
+// openAPIDocument is a named OpenAPI document served by a ServeMux, in addition to the
+// application document configured with Config.OpenAPI.
+type openAPIDocument struct {
+	name    string
+	openAPI *OpenAPI
+	mux     *ServeMux
+	group   *Group
+}
+
+// OpenAPI serves a named OpenAPI document at urlPath (e.g., "/admin/openapi.json"), documenting the
+// operations of the handlers registered on this mux, including those of its groups.
+// The OpenAPI UI is served next to it, with the .html extension.
+// It is independent of Config.OpenAPI, so several documents can be served by one application.
+// Panics if a document with the same name is already registered on this mux.
+func (m *ServeMux) OpenAPI(name, urlPath string, cfg *OpenAPIConfig) *ServeMux {
+	m.addOpenAPIDocument(name, urlPath, cfg, nil)
+	return m
+}
+
+// OpenAPI serves a named OpenAPI document at urlPath (e.g., "/billing/openapi.json"), documenting
+// only the operations of the handlers registered on this group.
+// The document is served by the mux of the group, like ServeMux.OpenAPI.
+func (g *Group) OpenAPI(name, urlPath string, cfg *OpenAPIConfig) *Group {
+	g.mux.addOpenAPIDocument(name, urlPath, cfg, g)
+	return g
+}
+
+func (m *ServeMux) addOpenAPIDocument(name, urlPath string, cfg *OpenAPIConfig, group *Group) {
+	for _, document := range m.openAPIDocuments {
+		if document.name == name {
+			panic(fmt.Errorf("OpenAPI document %q is already registered", name))
+		}
+	}
+
+	m.openAPIDocuments = append(m.openAPIDocuments, &openAPIDocument{
+		name: name,
+		openAPI: &OpenAPI{
+			internalConfig: newOpenAPIDocument(cfg),
+			Config:         cfg,
+			URLPath:        getURLPathPattern(urlPath, defaultOpenAPIURLPath),
+			Enabled:        true,
+		},
+		mux:   m,
+		group: group,
+	})
+}
+
+// includes reports whether the operation of the handler belongs to the document.
+func (d *openAPIDocument) includes(hc *HandlerConfig) bool {
+	if hc.mux != d.mux || hc.operation == nil {
+		return false
+	}
+	return d.group == nil || hc.group == d.group
+}
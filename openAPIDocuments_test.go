@@ -0,0 +1,113 @@
+package webfram
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func setupOpenAPIDocumentsMux(t *testing.T) *ServeMux {
+	t.Helper()
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+
+	Configure(&Config{
+		OpenAPI: &OpenAPI{
+			Enabled: true,
+			Config:  &OpenAPIConfig{Info: &Info{Title: "Main API", Version: "1.0.0"}},
+		},
+	})
+
+	handler := func(w ResponseWriter, _ *Request) { w.NoContent() }
+
+	mux := NewServeMux()
+	mux.OpenAPI("all", "/all/openapi.json", &OpenAPIConfig{Info: &Info{Title: "All", Version: "2.0.0"}})
+	mux.HandleFunc("GET /health", handler).OpenAPIOperation(OperationConfig{Summary: "Health"})
+
+	billing := mux.Group("/billing").
+		OpenAPI("billing", "/billing/openapi.json", &OpenAPIConfig{Info: &Info{Title: "Billing", Version: "3.0.0"}})
+	billing.HandleFunc("GET /invoices", handler).OpenAPIOperation(OperationConfig{Summary: "Invoices"})
+
+	users := mux.Group("/users")
+	users.HandleFunc("GET /{id}", handler).OpenAPIOperation(OperationConfig{Summary: "User"})
+
+	setupOpenAPIEndpoints(mux)
+	registerHandlers(mux)
+
+	return mux
+}
+
+func TestServeMuxOpenAPI_ServesNamedDocuments(t *testing.T) {
+	mux := setupOpenAPIDocumentsMux(t)
+
+	tests := []struct {
+		path  string
+		title string
+		paths []string
+	}{
+		{"/openapi.json", "Main API", []string{"/billing/invoices", "/health", "/users/{id}"}},
+		{"/all/openapi.json", "All", []string{"/billing/invoices", "/health", "/users/{id}"}},
+		{"/billing/openapi.json", "Billing", []string{"/billing/invoices"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d", rec.Code)
+			}
+
+			var doc struct {
+				Info  struct{ Title string } `json:"info"`
+				Paths map[string]any         `json:"paths"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+				t.Fatalf("Failed to decode OpenAPI document: %v", err)
+			}
+
+			if doc.Info.Title != tt.title {
+				t.Errorf("Expected title %q, got %q", tt.title, doc.Info.Title)
+			}
+
+			var paths []string
+			for path := range doc.Paths {
+				paths = append(paths, path)
+			}
+			slices.Sort(paths)
+			if !slices.Equal(paths, tt.paths) {
+				t.Errorf("Expected paths %v, got %v", tt.paths, paths)
+			}
+		})
+	}
+}
+
+func TestServeMuxOpenAPI_ServesUI(t *testing.T) {
+	mux := setupOpenAPIDocumentsMux(t)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/billing/openapi.html", http.NoBody))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+}
+
+func TestServeMuxOpenAPI_PanicsOnDuplicateName(t *testing.T) {
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+
+	mux := NewServeMux()
+	mux.OpenAPI("public", "/public/openapi.json", nil)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for a duplicate document name")
+		}
+	}()
+
+	mux.Group("/v2").OpenAPI("public", "/v2/openapi.json", nil)
+}
@@ -1,8 +1,11 @@
 package openapi
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 
 	yaml "sigs.k8s.io/yaml/goyaml.v2"
 )
@@ -80,6 +83,9 @@ type (
 		Callbacks       map[string]CallbackOrRef       `json:"callbacks,omitempty" yaml:"callbacks,omitempty"`
 		PathItems       map[string]PathItem            `json:"pathItems,omitempty" yaml:"pathItems,omitempty"`
 		MediaTypes      map[string]MediaTypeOrRef      `json:"mediaTypes,omitempty" yaml:"mediaTypes,omitempty"`
+
+		// names records the names of the components generated for Go types, see ComponentNames.
+		names map[any]string
 	}
 	Schema struct {
 		Schema        string         `json:"$schema,omitempty" yaml:"$schema,omitempty"`
@@ -156,8 +162,13 @@ type (
 		Responses    map[string]ResponseOrRef `json:"responses" yaml:"responses"`
 		Callbacks    map[string]CallbackOrRef `json:"callbacks,omitempty" yaml:"callbacks,omitempty"`
 		Deprecated   bool                     `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
-		Security     []map[string][]string    `json:"security,omitempty" yaml:"security,omitempty"`
-		Servers      []Server                 `json:"servers,omitempty" yaml:"servers,omitempty"`
+		// Security is omitted when nil so the document-level security applies; an empty,
+		// non-nil list is kept to mark the operation as public.
+		Security []map[string][]string `json:"security,omitzero" yaml:"security,omitempty"`
+		Servers  []Server              `json:"servers,omitempty" yaml:"servers,omitempty"`
+		// Extensions are the specification extensions of the operation, such as "x-sunset", inlined in the
+		// operation object when it is encoded. Keys not starting with "x-" are not encoded.
+		Extensions map[string]any `json:"-" yaml:"-"`
 	}
 	PathItem struct {
 		Summary              string                `json:"summary,omitempty" yaml:"summary,omitempty"`
@@ -341,6 +352,17 @@ func (c *Config) SetDefaults() {
 	}
 }
 
+// ComponentNames returns the registry of the names of the components generated from Go types, keyed by
+// the generator, creating it on first use. It lives as long as the components and is not serialized.
+// The registry is not safe for concurrent use.
+func (c *Components) ComponentNames() map[any]string {
+	if c.names == nil {
+		c.names = make(map[any]string)
+	}
+
+	return c.names
+}
+
 // SetPathInfo sets or updates path-level information in the OpenAPI specification.
 // Allows setting common parameters, servers, and descriptions that apply to all operations on a path.
 // Creates a new PathItem if the path doesn't exist.
@@ -458,6 +480,77 @@ func (s *Schema) ToYAML() (string, error) {
 	return string(bytes), nil
 }
 
+// MarshalJSON encodes the operation according to its json tags, with its Extensions inlined.
+func (o Operation) MarshalJSON() ([]byte, error) {
+	// The alias has no MarshalJSON method, which avoids an infinite recursion.
+	type operationAlias Operation
+	data, err := json.Marshal(operationAlias(o))
+	if err != nil {
+		return nil, err
+	}
+
+	// Append the extensions to the object, keeping the order of the fields.
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1])
+
+	for _, key := range extensionKeys(o.Extensions) {
+		value, err := json.Marshal(o.Extensions[key])
+		if err != nil {
+			return nil, fmt.Errorf("extension %q: %w", key, err)
+		}
+
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// MarshalYAML encodes the operation according to its yaml tags, with its Extensions inlined. An empty,
+// non-nil Security list, which marks the operation as public, is kept although the omitempty option drops it.
+func (o Operation) MarshalYAML() (any, error) {
+	// The alias has no MarshalYAML method, which avoids an infinite recursion.
+	type operationAlias Operation
+	data, err := yaml.Marshal(operationAlias(o))
+	if err != nil {
+		return nil, err
+	}
+
+	var fields yaml.MapSlice //nolint:staticcheck // MapSlice keeps the order of the fields
+	if err := yaml.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	if o.Security != nil && len(o.Security) == 0 {
+		fields = append(fields, yaml.MapItem{Key: "security", Value: []any{}}) //nolint:staticcheck // See above
+	}
+
+	for _, key := range extensionKeys(o.Extensions) {
+		fields = append(fields, yaml.MapItem{Key: key, Value: o.Extensions[key]}) //nolint:staticcheck // See above
+	}
+
+	return fields, nil
+}
+
+// extensionKeys returns the sorted keys of the extensions that are valid specification extensions.
+func extensionKeys(extensions map[string]any) []string {
+	keys := make([]string, 0, len(extensions))
+	for key := range extensions {
+		if strings.HasPrefix(key, "x-") {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+	return keys
+}
+
 // MarshalYaml converts the entire OpenAPI configuration to YAML format.
 // Returns the YAML bytes or an error if marshaling fails.
 func (c *Config) MarshalYaml() ([]byte, error) {
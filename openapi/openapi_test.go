@@ -445,6 +445,97 @@ func TestPaths_AddOperation_MultipleOperations(t *testing.T) {
 	}
 }
 
+func TestOperation_YAMLSecurity(t *testing.T) {
+	responses := map[string]ResponseOrRef{"200": {Response: &Response{Description: "OK"}}}
+
+	var paths Paths
+	paths.AddOperation("/default", "get", Operation{OperationID: "default", Responses: responses})
+	paths.AddOperation("/public", "get", Operation{
+		OperationID: "public",
+		Responses:   responses,
+		Security:    []map[string][]string{},
+	})
+	paths.AddOperation("/private", "get", Operation{
+		OperationID: "private",
+		Responses:   responses,
+		Security:    []map[string][]string{{"BearerAuth": {}}},
+	})
+
+	data, err := yaml.Marshal(paths)
+	if err != nil {
+		t.Fatalf("Failed to marshal paths: %v", err)
+	}
+
+	var result map[string]map[string]map[string]any
+	if err := yaml.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	if _, ok := result["/default"]["get"]["security"]; ok {
+		t.Errorf("Expected no security for the default operation, got:\n%s", data)
+	}
+	if security, ok := result["/public"]["get"]["security"].([]any); !ok || len(security) != 0 {
+		t.Errorf("Expected an empty security list for the public operation, got:\n%s", data)
+	}
+	if security, ok := result["/private"]["get"]["security"].([]any); !ok || len(security) != 1 {
+		t.Errorf("Expected the security requirement of the private operation, got:\n%s", data)
+	}
+	if result["/public"]["get"]["operationId"] != "public" {
+		t.Errorf("Expected the other fields to be kept, got:\n%s", data)
+	}
+}
+
+func TestOperation_InlinesExtensions(t *testing.T) {
+	operation := Operation{
+		OperationID: "listOrders",
+		Responses:   map[string]ResponseOrRef{"200": {Response: &Response{Description: "OK"}}},
+		Extensions: map[string]any{
+			"x-sunset":  "2027-03-31T11:00:00Z",
+			"x-limits":  map[string]any{"rate": 10},
+			"not-an-ex": true,
+		},
+	}
+
+	jsonData, err := json.Marshal(operation)
+	if err != nil {
+		t.Fatalf("Failed to marshal JSON: %v", err)
+	}
+	yamlData, err := yaml.Marshal(operation)
+	if err != nil {
+		t.Fatalf("Failed to marshal YAML: %v", err)
+	}
+
+	var fromJSON, fromYAML map[string]any
+	if err := json.Unmarshal(jsonData, &fromJSON); err != nil {
+		t.Fatalf("Failed to unmarshal JSON %s: %v", jsonData, err)
+	}
+	if err := yaml.Unmarshal(yamlData, &fromYAML); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	for format, result := range map[string]map[string]any{"JSON": fromJSON, "YAML": fromYAML} {
+		if result["x-sunset"] != "2027-03-31T11:00:00Z" || result["x-limits"] == nil {
+			t.Errorf("%s: expected inline extensions, got %v", format, result)
+		}
+		if _, ok := result["extensions"]; ok {
+			t.Errorf("%s: expected no extensions object, got %v", format, result)
+		}
+		if _, ok := result["not-an-ex"]; ok {
+			t.Errorf("%s: expected keys without the x- prefix to be dropped, got %v", format, result)
+		}
+		if result["operationId"] != "listOrders" {
+			t.Errorf("%s: expected the other fields to be kept, got %v", format, result)
+		}
+	}
+
+	if !strings.HasPrefix(string(jsonData), `{"operationId":"listOrders",`) {
+		t.Errorf("Expected the fields to keep their order, got %s", jsonData)
+	}
+	if data, _ := json.Marshal(Operation{}); string(data) != `{"responses":null}` {
+		t.Errorf("Expected an operation without extensions to be unchanged, got %s", data)
+	}
+}
+
 // ============================================================================
 // Schema Tests
 // ============================================================================
@@ -0,0 +1,81 @@
+package webfram
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOpenAPIEndpoint_ConditionalRequests(t *testing.T) {
+	mux := setupOpenAPIServerMux(t, false, nil)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", http.NoBody))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected Content-Type 'application/json', got %q", ct)
+	}
+	if cc := rec.Header().Get("Cache-Control"); cc != "no-cache" {
+		t.Errorf("Expected Cache-Control 'no-cache', got %q", cc)
+	}
+
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("Expected ETag header")
+	}
+	lastModified := rec.Header().Get("Last-Modified")
+	if lastModified == "" {
+		t.Fatal("Expected Last-Modified header")
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", http.NoBody))
+	if got := rec.Header().Get("ETag"); got != etag {
+		t.Errorf("Expected stable ETag %s, got %s", etag, got)
+	}
+
+	tests := []struct {
+		name     string
+		header   string
+		value    string
+		expected int
+	}{
+		{"matching ETag", "If-None-Match", etag, http.StatusNotModified},
+		{"other ETag", "If-None-Match", `"other"`, http.StatusOK},
+		{"not modified since", "If-Modified-Since", lastModified, http.StatusNotModified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/openapi.json", http.NoBody)
+			req.Header.Set(tt.header, tt.value)
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Errorf("Expected status %d, got %d", tt.expected, rec.Code)
+			}
+		})
+	}
+}
+
+func TestOpenAPIEndpoint_ServerFromRequestETag(t *testing.T) {
+	mux := setupOpenAPIServerMux(t, true, nil)
+
+	fetchETag := func(target string) string {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, http.NoBody))
+		if rec.Header().Get("Vary") == "" {
+			t.Error("Expected Vary header for documents built from the request")
+		}
+		return rec.Header().Get("ETag")
+	}
+
+	if fetchETag("http://a.example.com/openapi.json") == fetchETag("http://b.example.com/openapi.json") {
+		t.Error("Expected different ETags for documents with different servers")
+	}
+}
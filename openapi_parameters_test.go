@@ -0,0 +1,39 @@
+package webfram
+
+import (
+	"testing"
+
+	"github.com/bondowe/webfram/openapi"
+)
+
+func TestMapParameters_ArraySerialization(t *testing.T) {
+	explode, exploded := false, true
+	params := []Parameter{
+		{Name: "tags", In: "query", TypeHint: []string{}},
+		{Name: "ids", In: "query", TypeHint: []int{}, Style: "pipeDelimited", Explode: &explode},
+		{Name: "page", In: "query", TypeHint: 0},
+		{Name: "X-Tags", In: "header", TypeHint: []string{}},
+	}
+
+	mapped := mapParameters(params, &openapi.Components{})
+
+	tests := []struct {
+		style   string
+		explode *bool
+	}{
+		{"form", &exploded},
+		{"pipeDelimited", &explode},
+		{"", nil},
+		{"", nil},
+	}
+
+	for i, tt := range tests {
+		param := mapped[i].Parameter
+		if param.Style != tt.style {
+			t.Errorf("%s: expected style %q, got %q", param.Name, tt.style, param.Style)
+		}
+		if (param.Explode == nil) != (tt.explode == nil) || (param.Explode != nil && *param.Explode != *tt.explode) {
+			t.Errorf("%s: expected explode %v, got %v", param.Name, tt.explode, param.Explode)
+		}
+	}
+}
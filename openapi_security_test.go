@@ -1,6 +1,9 @@
 package webfram
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -377,7 +380,7 @@ func TestMapOAuthFlows_NilList(t *testing.T) {
 
 func TestConfigureOpenAPI_WithSecuritySchemes(t *testing.T) {
 	resetAppConfig()
-	openAPIConfig = &OpenAPI{Enabled: true}
+	defaultApp.openAPIConfig = &OpenAPI{Enabled: true}
 
 	cfg := &Config{
 		OpenAPI: &OpenAPI{
@@ -398,17 +401,17 @@ func TestConfigureOpenAPI_WithSecuritySchemes(t *testing.T) {
 		},
 	}
 
-	configureOpenAPI(cfg)
+	defaultApp.configureOpenAPI(cfg)
 
-	if openAPIConfig.internalConfig.Components.SecuritySchemes == nil {
+	if defaultApp.openAPIConfig.internalConfig.Components.SecuritySchemes == nil {
 		t.Fatal("Expected SecuritySchemes to be initialized")
 	}
 
-	if len(openAPIConfig.internalConfig.Components.SecuritySchemes) != 2 {
-		t.Errorf("Expected 2 security schemes, got %d", len(openAPIConfig.internalConfig.Components.SecuritySchemes))
+	if len(defaultApp.openAPIConfig.internalConfig.Components.SecuritySchemes) != 2 {
+		t.Errorf("Expected 2 security schemes, got %d", len(defaultApp.openAPIConfig.internalConfig.Components.SecuritySchemes))
 	}
 
-	bearerScheme := openAPIConfig.internalConfig.Components.SecuritySchemes["BearerAuth"]
+	bearerScheme := defaultApp.openAPIConfig.internalConfig.Components.SecuritySchemes["BearerAuth"]
 	if bearerScheme.SecurityScheme == nil {
 		t.Error("Expected BearerAuth scheme to be set")
 	}
@@ -416,7 +419,7 @@ func TestConfigureOpenAPI_WithSecuritySchemes(t *testing.T) {
 		t.Errorf("Expected BearerAuth type 'http', got %q", bearerScheme.SecurityScheme.Type)
 	}
 
-	apiKeyScheme := openAPIConfig.internalConfig.Components.SecuritySchemes["ApiKeyAuth"]
+	apiKeyScheme := defaultApp.openAPIConfig.internalConfig.Components.SecuritySchemes["ApiKeyAuth"]
 	if apiKeyScheme.SecurityScheme == nil {
 		t.Error("Expected ApiKeyAuth scheme to be set")
 	}
@@ -427,7 +430,7 @@ func TestConfigureOpenAPI_WithSecuritySchemes(t *testing.T) {
 
 func TestConfigureOpenAPI_WithoutSecuritySchemes(t *testing.T) {
 	resetAppConfig()
-	openAPIConfig = &OpenAPI{Enabled: true}
+	defaultApp.openAPIConfig = &OpenAPI{Enabled: true}
 
 	cfg := &Config{
 		OpenAPI: &OpenAPI{
@@ -440,17 +443,17 @@ func TestConfigureOpenAPI_WithoutSecuritySchemes(t *testing.T) {
 		},
 	}
 
-	configureOpenAPI(cfg)
+	defaultApp.configureOpenAPI(cfg)
 
 	// Empty map should not create SecuritySchemes in internal config
-	if openAPIConfig.internalConfig.Components.SecuritySchemes != nil {
+	if defaultApp.openAPIConfig.internalConfig.Components.SecuritySchemes != nil {
 		t.Error("Expected SecuritySchemes to not be initialized for empty map")
 	}
 }
 
 func TestConfigureOpenAPI_NilComponents(t *testing.T) {
 	resetAppConfig()
-	openAPIConfig = &OpenAPI{Enabled: true}
+	defaultApp.openAPIConfig = &OpenAPI{Enabled: true}
 
 	cfg := &Config{
 		OpenAPI: &OpenAPI{
@@ -461,10 +464,10 @@ func TestConfigureOpenAPI_NilComponents(t *testing.T) {
 		},
 	}
 
-	configureOpenAPI(cfg)
+	defaultApp.configureOpenAPI(cfg)
 
 	// Should not panic with nil Components
-	if openAPIConfig.internalConfig.Components.SecuritySchemes != nil {
+	if defaultApp.openAPIConfig.internalConfig.Components.SecuritySchemes != nil {
 		t.Error("Expected SecuritySchemes to be nil when Components is nil")
 	}
 }
@@ -519,15 +522,15 @@ func TestSecuritySchemes_EndToEnd(t *testing.T) {
 	})
 
 	// Verify configuration
-	if openAPIConfig == nil {
+	if defaultApp.openAPIConfig == nil {
 		t.Fatal("Expected openAPIConfig to be set")
 	}
 
-	if openAPIConfig.internalConfig.Components.SecuritySchemes == nil {
+	if defaultApp.openAPIConfig.internalConfig.Components.SecuritySchemes == nil {
 		t.Fatal("Expected SecuritySchemes to be initialized")
 	}
 
-	schemes := openAPIConfig.internalConfig.Components.SecuritySchemes
+	schemes := defaultApp.openAPIConfig.internalConfig.Components.SecuritySchemes
 	if len(schemes) != 4 {
 		t.Errorf("Expected 4 security schemes, got %d", len(schemes))
 	}
@@ -602,7 +605,7 @@ func TestSecuritySchemes_WithAllOAuthFlows(t *testing.T) {
 		},
 	})
 
-	scheme := openAPIConfig.internalConfig.Components.SecuritySchemes["OAuth2Auth"]
+	scheme := defaultApp.openAPIConfig.internalConfig.Components.SecuritySchemes["OAuth2Auth"]
 	if scheme.SecurityScheme == nil {
 		t.Fatal("Expected OAuth2Auth scheme to be set")
 	}
@@ -626,3 +629,75 @@ func TestSecuritySchemes_WithAllOAuthFlows(t *testing.T) {
 		t.Error("Expected DeviceAuthorization flow to be set")
 	}
 }
+
+func TestOperationSecurity_GlobalDefault(t *testing.T) {
+	resetAppConfig()
+	Configure(&Config{
+		OpenAPI: &OpenAPI{
+			Enabled: true,
+			Config: &OpenAPIConfig{
+				Info:     &Info{Title: "Test API", Version: "1.0.0"},
+				Security: []map[string][]string{{"BearerAuth": {}}},
+				Components: &Components{
+					SecuritySchemes: map[string]SecurityScheme{
+						"BearerAuth": NewHTTPBearerSecurityScheme(&HTTPBearerSecuritySchemeOptions{}),
+						"ApiKeyAuth": NewAPIKeySecurityScheme(&APIKeySecuritySchemeOptions{
+							Name: "X-API-Key",
+							In:   "header",
+						}),
+					},
+				},
+			},
+		},
+	})
+
+	mux := NewServeMux()
+	handler := func(_ ResponseWriter, _ *Request) {}
+	responses := map[string]Response{"200": {Description: "OK"}}
+
+	mux.HandleFunc("GET /global", handler).OpenAPIOperation(OperationConfig{
+		Responses: responses,
+	})
+	mux.HandleFunc("GET /public", handler).OpenAPIOperation(OperationConfig{
+		Security:  []map[string][]string{},
+		Responses: responses,
+	})
+	mux.HandleFunc("GET /override", handler).OpenAPIOperation(OperationConfig{
+		Security:  []map[string][]string{{"ApiKeyAuth": {}}},
+		Responses: responses,
+	})
+
+	setupOpenAPIEndpoints(mux)
+	registerHandlers(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", http.NoBody))
+
+	var doc struct {
+		Security []map[string][]string                            `json:"security"`
+		Paths    map[string]map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("Failed to parse OpenAPI document: %v", err)
+	}
+
+	if len(doc.Security) != 1 || doc.Security[0]["BearerAuth"] == nil {
+		t.Errorf("Expected global BearerAuth security, got %v", doc.Security)
+	}
+
+	if security, ok := doc.Paths["/global"]["get"]["security"]; ok {
+		t.Errorf("Expected no operation security so the global one applies, got %s", security)
+	}
+
+	if security := string(doc.Paths["/public"]["get"]["security"]); security != "[]" {
+		t.Errorf("Expected empty security for public operation, got %q", security)
+	}
+
+	var override []map[string][]string
+	if err := json.Unmarshal(doc.Paths["/override"]["get"]["security"], &override); err != nil {
+		t.Fatalf("Failed to parse override security: %v", err)
+	}
+	if len(override) != 1 || override[0]["ApiKeyAuth"] == nil {
+		t.Errorf("Expected ApiKeyAuth override, got %v", override)
+	}
+}
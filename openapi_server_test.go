@@ -0,0 +1,116 @@
+package webfram
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func setupOpenAPIServerMux(t *testing.T, serverFromRequest bool, servers []Server) *ServeMux {
+	t.Helper()
+	resetAppConfig()
+	Configure(&Config{
+		// The network of the RemoteAddr of httptest requests.
+		TrustedProxies: []string{"192.0.2.0/24"},
+		OpenAPI: &OpenAPI{
+			Enabled:           true,
+			ServerFromRequest: serverFromRequest,
+			Config: &OpenAPIConfig{
+				Info:    &Info{Title: "Test API", Version: "1.0.0"},
+				Servers: servers,
+			},
+		},
+	})
+
+	mux := NewServeMux()
+	setupOpenAPIEndpoints(mux)
+	registerHandlers(mux)
+
+	return mux
+}
+
+func fetchOpenAPIServers(t *testing.T, mux *ServeMux, req *http.Request) []string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var doc struct {
+		Servers []struct {
+			URL string `json:"url"`
+		} `json:"servers"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("Failed to parse OpenAPI document: %v", err)
+	}
+
+	urls := make([]string, 0, len(doc.Servers))
+	for _, server := range doc.Servers {
+		urls = append(urls, server.URL)
+	}
+
+	return urls
+}
+
+func TestOpenAPI_ServerFromRequest(t *testing.T) {
+	mux := setupOpenAPIServerMux(t, true, nil)
+
+	tests := []struct {
+		name     string
+		setup    func(r *http.Request)
+		expected string
+	}{
+		{"plain HTTP", func(_ *http.Request) {}, "http://api.internal:8080"},
+		{"TLS", func(r *http.Request) { r.TLS = &tls.ConnectionState{} }, "https://api.internal:8080"},
+		{"forwarded", func(r *http.Request) {
+			r.Header.Set("X-Forwarded-Proto", "https")
+			r.Header.Set("X-Forwarded-Host", "api.example.com, proxy.internal")
+		}, "https://api.example.com"},
+		{"invalid forwarded proto", func(r *http.Request) {
+			r.Header.Set("X-Forwarded-Proto", "javascript")
+		}, "http://api.internal:8080"},
+		{"forwarded by untrusted peer", func(r *http.Request) {
+			r.RemoteAddr = "203.0.113.7:1234"
+			r.Header.Set("X-Forwarded-Proto", "https")
+			r.Header.Set("X-Forwarded-Host", "evil.example.com")
+		}, "http://api.internal:8080"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "http://api.internal:8080/openapi.json", http.NoBody)
+			tt.setup(req)
+
+			servers := fetchOpenAPIServers(t, mux, req)
+			if len(servers) != 1 || servers[0] != tt.expected {
+				t.Errorf("Expected servers [%s], got %v", tt.expected, servers)
+			}
+		})
+	}
+}
+
+func TestOpenAPI_ServerFromRequest_ExplicitServersTakePrecedence(t *testing.T) {
+	mux := setupOpenAPIServerMux(t, true, []Server{{URL: "https://api.example.com"}})
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/openapi.json", http.NoBody)
+	servers := fetchOpenAPIServers(t, mux, req)
+
+	if len(servers) != 1 || servers[0] != "https://api.example.com" {
+		t.Errorf("Expected configured servers, got %v", servers)
+	}
+}
+
+func TestOpenAPI_ServerFromRequest_Disabled(t *testing.T) {
+	mux := setupOpenAPIServerMux(t, false, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/openapi.json", http.NoBody)
+	if servers := fetchOpenAPIServers(t, mux, req); len(servers) != 0 {
+		t.Errorf("Expected no servers, got %v", servers)
+	}
+}
@@ -0,0 +1,64 @@
+package webfram
+
+// PageResult is a page of a list, returned by Paginate. It is also the JSON envelope of paginated responses
+// written by JSONPage.
+type PageResult[T any] struct {
+	// Items are the items of the page; empty, not nil, past the last page.
+	Items []T `json:"items"`
+	// Page is the 1-based page number.
+	Page int `json:"page"`
+	// PageSize is the maximum number of items of a page.
+	PageSize int `json:"pageSize"`
+	// Total is the number of items of the whole list.
+	Total int `json:"total"`
+	// TotalPages is the number of pages of the list, at least 1.
+	TotalPages int `json:"totalPages"`
+	// HasNext reports whether there is a page after Page.
+	HasNext bool `json:"hasNext"`
+	// HasPrev reports whether there is a page before Page.
+	HasPrev bool `json:"hasPrev"`
+}
+
+// Paginate returns the page of items with the given 1-based page number and page size, e.g. with the page
+// and pageSize query parameters of the request bound by BindQuery. A page lower than 1 is the first page,
+// and a page size lower than 1 puts all the items in a single page. Items of the result are a copy.
+func Paginate[T any](items []T, page, pageSize int) PageResult[T] {
+	total := len(items)
+	page = max(page, 1)
+	if pageSize < 1 {
+		pageSize = max(total, 1)
+	}
+
+	totalPages := max((total+pageSize-1)/pageSize, 1)
+	start := total
+	// Compared before multiplying, so that large page numbers from the query string cannot overflow.
+	if page-1 <= total/pageSize {
+		start = min((page-1)*pageSize, total)
+	}
+	end := min(start+pageSize, total)
+
+	pageItems := make([]T, end-start)
+	copy(pageItems, items[start:end])
+
+	return PageResult[T]{
+		Items:      pageItems,
+		Page:       page,
+		PageSize:   pageSize,
+		Total:      total,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}
+}
+
+// JSONPage writes result as a JSON response, with the Link header of its first, prev, next and last pages
+// added by LinkPagination.
+//
+// Example:
+//
+//	users := store.AllUsers()
+//	_ = app.JSONPage(w, r, app.Paginate(users, params.Page, params.PageSize))
+func JSONPage[T any](w ResponseWriter, r *Request, result PageResult[T]) error {
+	w.LinkPagination(r, result.Page, result.PageSize, result.Total)
+	return w.JSON(r.Context(), result)
+}
@@ -0,0 +1,84 @@
+package webfram
+
+import (
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+
+	tests := []struct {
+		name         string
+		items        []int
+		page         int
+		pageSize     int
+		expected     []int
+		expectedPage int
+		expectedSize int
+		totalPages   int
+		hasNext      bool
+		hasPrev      bool
+	}{
+		{"first page", items, 1, 3, []int{1, 2, 3}, 1, 3, 3, true, false},
+		{"middle page", items, 2, 3, []int{4, 5, 6}, 2, 3, 3, true, true},
+		{"last partial page", items, 3, 3, []int{7}, 3, 3, 3, false, true},
+		{"past the last page", items, 4, 3, []int{}, 4, 3, 3, false, true},
+		{"huge page", items, math.MaxInt, 3, []int{}, math.MaxInt, 3, 3, false, true},
+		{"page below 1", items, 0, 3, []int{1, 2, 3}, 1, 3, 3, true, false},
+		{"no page size", items, 1, 0, items, 1, 7, 1, false, false},
+		{"empty list", nil, 1, 3, []int{}, 1, 3, 1, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Paginate(tt.items, tt.page, tt.pageSize)
+
+			if result.Items == nil || !slices.Equal(result.Items, tt.expected) {
+				t.Errorf("Expected items %v, got %#v", tt.expected, result.Items)
+			}
+			if result.Page != tt.expectedPage || result.PageSize != tt.expectedSize || result.Total != len(tt.items) {
+				t.Errorf("Expected page %d, size %d, total %d, got %+v", tt.expectedPage, tt.expectedSize, len(tt.items), result)
+			}
+			if result.TotalPages != tt.totalPages || result.HasNext != tt.hasNext || result.HasPrev != tt.hasPrev {
+				t.Errorf("Expected %d pages, next %v, prev %v, got %+v", tt.totalPages, tt.hasNext, tt.hasPrev, result)
+			}
+		})
+	}
+}
+
+func TestPaginate_CopiesItems(t *testing.T) {
+	items := []string{"a", "b", "c"}
+	result := Paginate(items, 1, 2)
+
+	result.Items[0] = "changed"
+	if items[0] != "a" {
+		t.Errorf("Expected the page items to be a copy, got %v", items)
+	}
+}
+
+func TestJSONPage(t *testing.T) {
+	resetAppConfig()
+	Configure(nil)
+
+	r := NewTestRequest(http.MethodGet, "/users?page=2&pageSize=2&sort=name", http.NoBody)
+	w, rec := NewTestResponseWriter()
+
+	if err := JSONPage(w, r, Paginate([]string{"a", "b", "c", "d", "e"}, 2, 2)); err != nil {
+		t.Fatalf("JSONPage failed: %v", err)
+	}
+
+	link := rec.Header().Get("Link")
+	if !strings.Contains(link, `</users?page=1&pageSize=2&sort=name>; rel="prev"`) ||
+		!strings.Contains(link, `</users?page=3&pageSize=2&sort=name>; rel="last"`) {
+		t.Errorf("Unexpected Link header %q", link)
+	}
+
+	expected := `{"items":["c","d"],"page":2,"pageSize":2,"total":5,"totalPages":3,"hasNext":true,"hasPrev":true}`
+	if got := strings.TrimSpace(rec.Body.String()); got != expected {
+		t.Errorf("Expected body %s, got %s", expected, got)
+	}
+}
@@ -0,0 +1,99 @@
+package webfram
+
+import (
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// precompressedEncodings are the content encodings of pre-compressed files, in order of preference,
+// with the extension of their file.
+//
+//nolint:gochecknoglobals // read-only lookup table
+var precompressedEncodings = []struct {
+	encoding string
+	ext      string
+}{
+	{"br", ".br"},
+	{"gzip", ".gz"},
+}
+
+// servePrecompressedFS serves the pre-compressed sibling of the file name in fsys, such as "app.js.br" or
+// "app.js.gz" for "app.js", with its Content-Encoding if the request accepts it. Brotli is preferred over gzip.
+// The Content-Type is the one of name, from its extension. Responses of files with pre-compressed siblings
+// vary on Accept-Encoding. Returns false if no sibling is acceptable, in which case nothing is written
+// and the file itself should be served.
+func servePrecompressedFS(w http.ResponseWriter, r *http.Request, fsys fs.FS, name string) bool {
+	contentType := mime.TypeByExtension(path.Ext(name))
+	if contentType == "" || (r.Method != http.MethodGet && r.Method != http.MethodHead) {
+		return false
+	}
+
+	acceptEncoding := r.Header.Get("Accept-Encoding")
+	hasVariant := false
+
+	for _, pc := range precompressedEncodings {
+		f, err := fsys.Open(name + pc.ext)
+		if err != nil {
+			continue
+		}
+
+		stat, statErr := f.Stat()
+		content, seekable := f.(io.ReadSeeker)
+		if statErr != nil || stat.IsDir() || !seekable {
+			_ = f.Close()
+			continue
+		}
+
+		hasVariant = true
+		if !acceptsEncoding(acceptEncoding, pc.encoding) {
+			_ = f.Close()
+			continue
+		}
+
+		w.Header().Add("Vary", "Accept-Encoding")
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Encoding", pc.encoding)
+		http.ServeContent(w, r, name, stat.ModTime(), content)
+		_ = f.Close()
+		return true
+	}
+
+	// The uncompressed file is served, but other clients get a pre-compressed one.
+	if hasVariant {
+		w.Header().Add("Vary", "Accept-Encoding")
+	}
+
+	return false
+}
+
+// acceptsEncoding reports whether an Accept-Encoding header accepts the content coding with a non-zero
+// quality, either by name or through "*".
+func acceptsEncoding(acceptEncoding, encoding string) bool {
+	wildcard := false
+
+	for part := range strings.SplitSeq(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.TrimSpace(coding)
+
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = v
+			}
+		}
+
+		switch {
+		case strings.EqualFold(coding, encoding):
+			return quality > 0
+		case coding == "*":
+			wildcard = quality > 0
+		}
+	}
+
+	return wildcard
+}
@@ -0,0 +1,68 @@
+package webfram
+
+import (
+	"net/http"
+	"testing"
+	"testing/fstest"
+)
+
+func TestServeFileFS_Precompressed(t *testing.T) {
+	fsys := fstest.MapFS{
+		"app.js":       {Data: []byte("console.log('raw')")},
+		"app.js.br":    {Data: []byte("brotli")},
+		"app.js.gz":    {Data: []byte("gzip")},
+		"style.css":    {Data: []byte("body{}")},
+		"style.css.gz": {Data: []byte("gzip-css")},
+	}
+
+	tests := []struct {
+		name           string
+		file           string
+		acceptEncoding string
+		body           string
+		encoding       string
+		vary           bool
+	}{
+		{"brotli preferred", "app.js", "gzip, deflate, br", "brotli", "br", true},
+		{"gzip", "app.js", "gzip", "gzip", "gzip", true},
+		{"brotli refused", "app.js", "br;q=0, *", "gzip", "gzip", true},
+		{"gzip only variant", "style.css", "br, gzip", "gzip-css", "gzip", true},
+		{"not accepted", "app.js", "", "console.log('raw')", "", true},
+		{"identity only", "app.js", "identity", "console.log('raw')", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewTestRequest(http.MethodGet, "/"+tt.file, nil)
+			r.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			w, rec := NewTestResponseWriter()
+
+			w.ServeFileFS(r, fsys, tt.file, &ServeFileOptions{Inline: true})
+
+			if rec.Code != http.StatusOK || rec.Body.String() != tt.body {
+				t.Fatalf("Expected 200 %q, got %d %q", tt.body, rec.Code, rec.Body.String())
+			}
+			if got := rec.Header().Get("Content-Encoding"); got != tt.encoding {
+				t.Errorf("Expected Content-Encoding %q, got %q", tt.encoding, got)
+			}
+			if got := rec.Header().Get("Content-Type"); got != "text/javascript; charset=utf-8" && got != "text/css; charset=utf-8" {
+				t.Errorf("Expected the content type of the original file, got %q", got)
+			}
+			if (rec.Header().Get("Vary") == "Accept-Encoding") != tt.vary {
+				t.Errorf("Unexpected Vary header %q", rec.Header().Get("Vary"))
+			}
+		})
+	}
+}
+
+func TestServeFileFS_WithoutPrecompressedVariant(t *testing.T) {
+	r := NewTestRequest(http.MethodGet, "/app.js", nil)
+	r.Header.Set("Accept-Encoding", "br, gzip")
+	w, rec := NewTestResponseWriter()
+
+	w.ServeFileFS(r, fstest.MapFS{"app.js": {Data: []byte("raw")}}, "app.js", nil)
+
+	if rec.Body.String() != "raw" || rec.Header().Get("Content-Encoding") != "" || rec.Header().Get("Vary") != "" {
+		t.Errorf("Expected the raw file without Vary, got %q %v", rec.Body.String(), rec.Header())
+	}
+}
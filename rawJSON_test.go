@@ -0,0 +1,71 @@
+package webfram
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestBindRawJSON_DiscriminatedUnion(t *testing.T) {
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+	Configure(nil)
+
+	type circle struct {
+		Radius float64 `json:"radius" validate:"min=1"`
+	}
+	type rectangle struct {
+		Width  float64 `json:"width"  validate:"min=1"`
+		Height float64 `json:"height" validate:"min=1"`
+	}
+	type shapeRequest struct {
+		Type  string          `json:"type"  validate:"required,enum=circle|rectangle"`
+		Shape json.RawMessage `json:"shape" validate:"required"`
+	}
+
+	area := func(body string) (float64, *ValidationErrors, error) {
+		r := NewTestRequest(http.MethodPost, "/shapes", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+
+		req, valErrors, err := BindJSON[shapeRequest](r, true)
+		if err != nil || valErrors.Any() {
+			return 0, valErrors, err
+		}
+
+		switch req.Type {
+		case "circle":
+			c, valErrors, err := BindRawJSON[circle](r, req.Shape, "shape", true)
+			return 3 * c.Radius * c.Radius, valErrors, err
+		default:
+			rect, valErrors, err := BindRawJSON[rectangle](r, req.Shape, "shape", true)
+			return rect.Width * rect.Height, valErrors, err
+		}
+	}
+
+	got, valErrors, err := area(`{"type":"circle","shape":{"radius":2}}`)
+	if err != nil || valErrors.Any() || got != 12 {
+		t.Errorf("Expected circle area 12, got %v, %v, %v", got, valErrors, err)
+	}
+
+	got, valErrors, err = area(`{"type":"rectangle","shape":{"width":2,"height":3}}`)
+	if err != nil || valErrors.Any() || got != 6 {
+		t.Errorf("Expected rectangle area 6, got %v, %v, %v", got, valErrors, err)
+	}
+
+	_, valErrors, err = area(`{"type":"rectangle","shape":{"width":0,"height":3}}`)
+	if err != nil || len(valErrors.Errors) != 1 || valErrors.Errors[0].Field != "shape.width" {
+		t.Errorf("Expected a validation error on shape.width, got %v, %v", valErrors, err)
+	}
+
+	_, valErrors, err = area(`{"type":"circle"}`)
+	if err != nil || len(valErrors.Errors) != 1 || valErrors.Errors[0].Field != "shape" {
+		t.Errorf("Expected a required error on shape, got %v, %v", valErrors, err)
+	}
+
+	_, _, err = area(`{"type":"circle","shape":{"width":2}}`)
+	if !errors.Is(err, ErrMalformedBody) {
+		t.Errorf("Expected ErrMalformedBody for a variant with unknown fields, got %v", err)
+	}
+}
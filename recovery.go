@@ -0,0 +1,50 @@
+package webfram
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrPanic is wrapped by the errors passed to Config.OnError by the Recovery middleware.
+var ErrPanic = errors.New("panic recovered")
+
+// Recovery creates middleware that recovers from panics in the handlers it wraps. The panic is
+// reported to Config.OnError with status 500 as an error wrapping ErrPanic (and the panic value
+// itself if it is an error), then a 500 Internal Server Error response is sent unless the handler
+// already wrote a status code. http.ErrAbortHandler panics are not recovered, so the server can
+// abort the response as usual.
+func Recovery() AppMiddleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(w ResponseWriter, r *Request) {
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+				if err, ok := rec.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+					panic(rec)
+				}
+
+				if onError := appFromContext(r.Context()).onError; onError != nil {
+					onError(r, http.StatusInternalServerError, panicError(rec))
+				}
+
+				if _, written := w.StatusCode(); !written {
+					http.Error(w.ResponseWriter, http.StatusText(http.StatusInternalServerError),
+						http.StatusInternalServerError)
+				}
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// panicError converts a recovered panic value to an error wrapping ErrPanic.
+func panicError(rec any) error {
+	if err, ok := rec.(error); ok {
+		return fmt.Errorf("%w: %w", ErrPanic, err)
+	}
+	return fmt.Errorf("%w: %v", ErrPanic, rec)
+}
@@ -0,0 +1,115 @@
+package webfram
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type reportedError struct {
+	request    *Request
+	statusCode int
+	err        error
+}
+
+func setupOnErrorMux(t *testing.T, reported *[]reportedError, handler HandlerFunc) *ServeMux {
+	t.Helper()
+	resetAppConfig()
+	Configure(&Config{
+		OnError: func(r *Request, statusCode int, err error) {
+			*reported = append(*reported, reportedError{r, statusCode, err})
+		},
+	})
+
+	mux := NewServeMux()
+	mux.Use(Recovery())
+	mux.HandleFunc("GET /test", handler)
+	registerHandlers(mux)
+
+	return mux
+}
+
+func TestRecovery_ReportsPanic(t *testing.T) {
+	var reported []reportedError
+	mux := setupOnErrorMux(t, &reported, func(_ ResponseWriter, _ *Request) {
+		panic("boom")
+	})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", http.NoBody))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", rec.Code)
+	}
+	if len(reported) != 1 {
+		t.Fatalf("Expected 1 reported error, got %d", len(reported))
+	}
+	if reported[0].statusCode != http.StatusInternalServerError {
+		t.Errorf("Expected reported status 500, got %d", reported[0].statusCode)
+	}
+	if !errors.Is(reported[0].err, ErrPanic) || reported[0].err.Error() != "panic recovered: boom" {
+		t.Errorf("Unexpected reported error: %v", reported[0].err)
+	}
+	if reported[0].request == nil || reported[0].request.URL.Path != "/test" {
+		t.Error("Expected the request to be passed to OnError")
+	}
+}
+
+func TestRecovery_WrapsPanicError(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	var reported []reportedError
+	mux := setupOnErrorMux(t, &reported, func(w ResponseWriter, _ *Request) {
+		w.WriteHeader(http.StatusAccepted)
+		panic(errBoom)
+	})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", http.NoBody))
+
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("Expected the written status to be kept, got %d", rec.Code)
+	}
+	if len(reported) != 1 || !errors.Is(reported[0].err, errBoom) {
+		t.Errorf("Expected the panic error to be reported, got %v", reported)
+	}
+}
+
+func TestRecovery_AbortHandler(t *testing.T) {
+	handler := Recovery()(HandlerFunc(func(_ ResponseWriter, _ *Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	defer func() {
+		if rec := recover(); rec != http.ErrAbortHandler {
+			t.Errorf("Expected http.ErrAbortHandler to be re-panicked, got %v", rec)
+		}
+	}()
+
+	w, _ := NewTestResponseWriter()
+	handler.ServeHTTP(w, NewTestRequest(http.MethodGet, "/", nil))
+}
+
+func TestResponseWriter_Error_ReportsToOnError(t *testing.T) {
+	var reported []reportedError
+	mux := setupOnErrorMux(t, &reported, func(w ResponseWriter, _ *Request) {
+		w.Error(http.StatusBadGateway, "upstream unavailable")
+	})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", http.NoBody))
+
+	if rec.Code != http.StatusBadGateway {
+		t.Errorf("Expected status 502, got %d", rec.Code)
+	}
+	if len(reported) != 1 {
+		t.Fatalf("Expected 1 reported error, got %d", len(reported))
+	}
+	if reported[0].statusCode != http.StatusBadGateway || reported[0].err.Error() != "upstream unavailable" {
+		t.Errorf("Unexpected reported error: %d %v", reported[0].statusCode, reported[0].err)
+	}
+	if reported[0].request == nil {
+		t.Error("Expected the request to be passed to OnError")
+	}
+}
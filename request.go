@@ -0,0 +1,235 @@
+package webfram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+	"golang.org/x/text/language"
+
+	"github.com/bondowe/webfram/internal/bind"
+	"github.com/bondowe/webfram/internal/i18n"
+)
+
+const (
+	requestValuesKey  contextKey = "requestValues"
+	skipTelemetryKey  contextKey = "skipTelemetry"
+	authSchemeKey     contextKey = "authScheme"
+	cspNonceKey       contextKey = "cspNonce"
+	allowedMethodsKey contextKey = "allowedMethods"
+	routeMetaKey      contextKey = "routeMeta"
+)
+
+// Authentication schemes returned by Request.AuthScheme.
+const (
+	AuthSchemeAPIKey        = "apiKey"
+	AuthSchemeBasic         = "basic"
+	AuthSchemeDigest        = "digest"
+	AuthSchemeBearer        = "bearer"
+	AuthSchemeMutualTLS     = "mutualTLS"
+	AuthSchemeOAuth2        = "oauth2"
+	AuthSchemeOpenIDConnect = "openIdConnect"
+)
+
+// requestValues is the value bag shared by all handlers and middlewares serving a request.
+type requestValues struct {
+	mu     sync.RWMutex
+	values map[string]any
+}
+
+// ErrPathValueMissing is returned by the typed path value helpers when the path parameter is absent or empty.
+var ErrPathValueMissing = errors.New("path value missing")
+
+// PathInt returns the named path parameter parsed as an int.
+// Returns ErrPathValueMissing if the parameter is empty, or a descriptive error if it is not a valid integer.
+func (r *Request) PathInt(name string) (int, error) {
+	return parsePathValue(r, name, "integer", strconv.Atoi)
+}
+
+// PathInt64 returns the named path parameter parsed as an int64.
+// Returns ErrPathValueMissing if the parameter is empty, or a descriptive error if it is not a valid integer.
+func (r *Request) PathInt64(name string) (int64, error) {
+	return parsePathValue(r, name, "integer", parseInt64)
+}
+
+// PathUint returns the named path parameter parsed as a uint.
+// Returns ErrPathValueMissing if the parameter is empty, or a descriptive error if it is not a valid unsigned integer.
+func (r *Request) PathUint(name string) (uint, error) {
+	return parsePathValue(r, name, "unsigned integer", func(s string) (uint, error) {
+		v, err := strconv.ParseUint(s, 10, 0)
+		return uint(v), err
+	})
+}
+
+// PathFloat64 returns the named path parameter parsed as a float64.
+// Returns ErrPathValueMissing if the parameter is empty, or a descriptive error if it is not a valid float.
+func (r *Request) PathFloat64(name string) (float64, error) {
+	return parsePathValue(r, name, "float", parseFloat64)
+}
+
+// PathUUID returns the named path parameter parsed as a UUID.
+// Returns ErrPathValueMissing if the parameter is empty, or a descriptive error if it is not a valid UUID.
+func (r *Request) PathUUID(name string) (uuid.UUID, error) {
+	return parsePathValue(r, name, "UUID", uuid.Parse)
+}
+
+// PathRemainder returns the remainder of the path captured by a trailing wildcard of the matched route,
+// such as "docs/guide.md" for "/files/docs/guide.md" matched by "GET /files/{path...}". The value is unescaped.
+// Returns an empty string if the route has no trailing wildcard.
+func (r *Request) PathRemainder() string {
+	segment := r.Pattern[strings.LastIndex(r.Pattern, "/")+1:]
+	if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "...}") {
+		return ""
+	}
+
+	return r.PathValue(strings.TrimSuffix(segment[1:], "...}"))
+}
+
+func parsePathValue[T any](r *Request, name, typeName string, parse func(string) (T, error)) (T, error) {
+	var zero T
+
+	value := r.PathValue(name)
+	if value == "" {
+		return zero, fmt.Errorf("%w: %q", ErrPathValueMissing, name)
+	}
+
+	v, err := parse(value)
+	if err != nil {
+		return zero, fmt.Errorf("invalid path value %q for %q: expected %s", value, name, typeName)
+	}
+
+	return v, nil
+}
+
+// QueryInt returns the named query parameter parsed as an int, or def if it is absent or invalid.
+func (r *Request) QueryInt(name string, def int) int {
+	return parseValueOrDefault(r.URL.Query().Get(name), def, strconv.Atoi)
+}
+
+// QueryInt64 returns the named query parameter parsed as an int64, or def if it is absent or invalid.
+func (r *Request) QueryInt64(name string, def int64) int64 {
+	return parseValueOrDefault(r.URL.Query().Get(name), def, parseInt64)
+}
+
+// QueryFloat64 returns the named query parameter parsed as a float64, or def if it is absent or invalid.
+func (r *Request) QueryFloat64(name string, def float64) float64 {
+	return parseValueOrDefault(r.URL.Query().Get(name), def, parseFloat64)
+}
+
+// QueryBool returns the named query parameter as a bool, or def if it is absent.
+// "true", "1" and "yes" are true; any other value is false, as with the binders.
+func (r *Request) QueryBool(name string, def bool) bool {
+	return parseValueOrDefault(r.URL.Query().Get(name), def, parseBool)
+}
+
+// HeaderInt returns the named header parsed as an int, or def if it is absent or invalid.
+func (r *Request) HeaderInt(name string, def int) int {
+	return parseValueOrDefault(r.Header.Get(name), def, strconv.Atoi)
+}
+
+// HeaderInt64 returns the named header parsed as an int64, or def if it is absent or invalid.
+func (r *Request) HeaderInt64(name string, def int64) int64 {
+	return parseValueOrDefault(r.Header.Get(name), def, parseInt64)
+}
+
+// HeaderFloat64 returns the named header parsed as a float64, or def if it is absent or invalid.
+func (r *Request) HeaderFloat64(name string, def float64) float64 {
+	return parseValueOrDefault(r.Header.Get(name), def, parseFloat64)
+}
+
+// HeaderBool returns the named header as a bool, or def if it is absent.
+// "true", "1" and "yes" are true; any other value is false, as with the binders.
+func (r *Request) HeaderBool(name string, def bool) bool {
+	return parseValueOrDefault(r.Header.Get(name), def, parseBool)
+}
+
+func parseValueOrDefault[T any](value string, def T, parse func(string) (T, error)) T {
+	if value == "" {
+		return def
+	}
+
+	v, err := parse(value)
+	if err != nil {
+		return def
+	}
+
+	return v
+}
+
+func parseInt64(s string) (int64, error) {
+	return strconv.ParseInt(s, 10, 64)
+}
+
+func parseFloat64(s string) (float64, error) {
+	return strconv.ParseFloat(s, 64)
+}
+
+func parseBool(s string) (bool, error) {
+	return bind.ParseBool(s), nil
+}
+
+// Set stores a value under key for the rest of the request, so middlewares can pass values such as
+// the authenticated user or feature flags down to handlers without defining context keys.
+// Values are visible to the handlers called after Set, including through StandardMiddleware.
+// Safe for concurrent use.
+func (r *Request) Set(key string, v any) {
+	bag, ok := r.Context().Value(requestValuesKey).(*requestValues)
+	if !ok {
+		bag = &requestValues{values: make(map[string]any)}
+		r.Request = r.WithContext(context.WithValue(r.Context(), requestValuesKey, bag))
+	}
+
+	bag.mu.Lock()
+	defer bag.mu.Unlock()
+
+	bag.values[key] = v
+}
+
+// Get returns the value stored under key by Set, and whether it was found.
+func (r *Request) Get(key string) (any, bool) {
+	bag, ok := r.Context().Value(requestValuesKey).(*requestValues)
+	if !ok {
+		return nil, false
+	}
+
+	bag.mu.RLock()
+	defer bag.mu.RUnlock()
+
+	v, found := bag.values[key]
+	return v, found
+}
+
+// SkipTelemetry excludes the request from the request count and duration metrics, e.g. for internal
+// health probes identified by a header. It can be called by handlers and middlewares at any time
+// before the response is complete. Does nothing if the request is not served by a ServeMux.
+func (r *Request) SkipTelemetry() {
+	if skip, ok := r.Context().Value(skipTelemetryKey).(*atomic.Bool); ok {
+		skip.Store(true)
+	}
+}
+
+// AuthScheme returns the scheme (one of the AuthScheme constants) of the security middleware that
+// authenticated the request, e.g. for audit logging. If several schemes are configured, all of them
+// must succeed and the first one is returned. Security middlewares run before the middlewares
+// registered with Use, so those can call AuthScheme too.
+// Returns ("", false) if the route is not secured, including with AllowAnonymousAuth.
+func (r *Request) AuthScheme() (string, bool) {
+	scheme, ok := r.Context().Value(authSchemeKey).(string)
+	return scheme, ok
+}
+
+// Language returns the language resolved for the request by the i18n middleware, from the "lang" cookie
+// or the Accept-Language header, e.g. to format numbers and dates or to log which locale served a response.
+// Falls back to the first supported language, or English if i18n is not configured.
+func (r *Request) Language() language.Tag {
+	if tag, ok := i18n.LanguageFromContext(r.Context()); ok {
+		return tag
+	}
+
+	return appFromContext(r.Context()).fallbackLanguage()
+}
@@ -0,0 +1,53 @@
+package webfram
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestRequest_Language_ResolvedByI18nMiddleware(t *testing.T) {
+	setupTestConfig(t)
+	t.Cleanup(resetAppConfig)
+
+	var got language.Tag
+	handler := I18nMiddleware(nil)(HandlerFunc(func(_ ResponseWriter, r *Request) {
+		got = r.Language()
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set("Accept-Language", "fr-CA,fr;q=0.9")
+	handler.ServeHTTP(ResponseWriter{ResponseWriter: httptest.NewRecorder()}, &Request{req})
+
+	if base, _ := got.Base(); base.String() != "fr" {
+		t.Errorf("Expected a French language, got %v", got)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.AddCookie(&http.Cookie{Name: "lang", Value: "de"})
+	handler.ServeHTTP(ResponseWriter{ResponseWriter: httptest.NewRecorder()}, &Request{req})
+
+	if got != language.German {
+		t.Errorf("Expected the cookie language %v, got %v", language.German, got)
+	}
+}
+
+func TestRequest_Language_FallsBackToDefault(t *testing.T) {
+	setupTestConfig(t)
+	t.Cleanup(resetAppConfig)
+
+	cfg, _ := defaultApp.i18nCatalogs.Configuration()
+	r := &Request{httptest.NewRequest(http.MethodGet, "/", http.NoBody)}
+
+	if got := r.Language(); got != cfg.SupportedLanguages[0] {
+		t.Errorf("Expected the first supported language %v, got %v", cfg.SupportedLanguages[0], got)
+	}
+
+	resetAppConfig()
+
+	if got := r.Language(); got != language.English {
+		t.Errorf("Expected English without i18n, got %v", got)
+	}
+}
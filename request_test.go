@@ -0,0 +1,270 @@
+package webfram
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func newPathRequest(values map[string]string) *Request {
+	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
+	for k, v := range values {
+		req.SetPathValue(k, v)
+	}
+	return &Request{Request: req}
+}
+
+func TestRequest_PathInt(t *testing.T) {
+	r := newPathRequest(map[string]string{"id": "42", "bad": "abc"})
+
+	v, err := r.PathInt("id")
+	if err != nil || v != 42 {
+		t.Errorf("Expected 42, got %d (err: %v)", v, err)
+	}
+
+	if _, err = r.PathInt("bad"); err == nil {
+		t.Error("Expected error for non-integer value")
+	}
+
+	if _, err = r.PathInt("missing"); !errors.Is(err, ErrPathValueMissing) {
+		t.Errorf("Expected ErrPathValueMissing, got %v", err)
+	}
+}
+
+func TestRequest_PathInt64(t *testing.T) {
+	r := newPathRequest(map[string]string{"id": "9223372036854775807"})
+
+	v, err := r.PathInt64("id")
+	if err != nil || v != 9223372036854775807 {
+		t.Errorf("Expected max int64, got %d (err: %v)", v, err)
+	}
+}
+
+func TestRequest_PathUint(t *testing.T) {
+	r := newPathRequest(map[string]string{"id": "7", "neg": "-7"})
+
+	v, err := r.PathUint("id")
+	if err != nil || v != 7 {
+		t.Errorf("Expected 7, got %d (err: %v)", v, err)
+	}
+
+	if _, err = r.PathUint("neg"); err == nil {
+		t.Error("Expected error for negative value")
+	}
+}
+
+func TestRequest_PathFloat64(t *testing.T) {
+	r := newPathRequest(map[string]string{"price": "19.99", "bad": "1,5"})
+
+	v, err := r.PathFloat64("price")
+	if err != nil || v != 19.99 {
+		t.Errorf("Expected 19.99, got %f (err: %v)", v, err)
+	}
+
+	if _, err = r.PathFloat64("bad"); err == nil {
+		t.Error("Expected error for invalid float")
+	}
+}
+
+func TestRequest_PathUUID(t *testing.T) {
+	id := uuid.New()
+	r := newPathRequest(map[string]string{"id": id.String(), "bad": "not-a-uuid"})
+
+	v, err := r.PathUUID("id")
+	if err != nil || v != id {
+		t.Errorf("Expected %s, got %s (err: %v)", id, v, err)
+	}
+
+	_, err = r.PathUUID("bad")
+	if err == nil {
+		t.Fatal("Expected error for invalid UUID")
+	}
+
+	if err.Error() != `invalid path value "not-a-uuid" for "bad": expected UUID` {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+}
+
+func TestRequest_PathRemainder(t *testing.T) {
+	resetAppConfig()
+
+	type fileParams struct {
+		Path string `form:"path"`
+	}
+
+	var remainder string
+	var params fileParams
+
+	mux := NewServeMux()
+	mux.HandleFunc("GET /files/{path...}", func(w ResponseWriter, r *Request) {
+		remainder = r.PathRemainder()
+		params, _ = BindPath[fileParams](r)
+		w.WriteHeader(http.StatusOK)
+	})
+	registerHandlers(mux)
+
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/files/docs/my%20guide.md", http.NoBody))
+
+	if remainder != "docs/my guide.md" {
+		t.Errorf("Expected remainder 'docs/my guide.md', got %q", remainder)
+	}
+	if params.Path != remainder {
+		t.Errorf("Expected BindPath to bind the remainder, got %q", params.Path)
+	}
+
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"GET /files/{name}", ""},
+		{"/files/{path...}", "a/b"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		req := newPathRequest(map[string]string{"name": "a/b", "path": "a/b"})
+		req.Pattern = tt.pattern
+		if got := req.PathRemainder(); got != tt.want {
+			t.Errorf("PathRemainder() with pattern %q = %q, want %q", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestAddOpenAPIOperation_WildcardPath(t *testing.T) {
+	doc := newOpenAPIDocument(nil)
+	addOpenAPIOperation(doc, "GET /files/{path...}", &OperationConfig{})
+
+	if _, ok := doc.Paths["/files/{path}"]; !ok {
+		t.Errorf("Expected the wildcard to be documented as /files/{path}, got %v", doc.Paths)
+	}
+}
+
+func TestRequest_QueryGetters(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/test?page=3&size=abc&big=9000000000&ratio=0.5&debug=yes&flag=no", http.NoBody)
+	r := &Request{Request: req}
+
+	if got := r.QueryInt("page", 1); got != 3 {
+		t.Errorf("QueryInt(page) = %d, want 3", got)
+	}
+	if got := r.QueryInt("size", 10); got != 10 {
+		t.Errorf("QueryInt(size) = %d, want default 10 for invalid value", got)
+	}
+	if got := r.QueryInt("missing", 5); got != 5 {
+		t.Errorf("QueryInt(missing) = %d, want default 5", got)
+	}
+	if got := r.QueryInt64("big", 0); got != 9000000000 {
+		t.Errorf("QueryInt64(big) = %d, want 9000000000", got)
+	}
+	if got := r.QueryFloat64("ratio", 1); got != 0.5 {
+		t.Errorf("QueryFloat64(ratio) = %f, want 0.5", got)
+	}
+}
+
+func TestRequest_QueryBool(t *testing.T) {
+	tests := []struct {
+		query    string
+		def      bool
+		expected bool
+	}{
+		{"v=true", false, true},
+		{"v=1", false, true},
+		{"v=yes", false, true},
+		{"v=false", true, false},
+		{"v=no", true, false},
+		{"v=0", true, false},
+		{"v=other", true, false},
+		{"", true, true},
+		{"", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/test?"+tt.query, http.NoBody)
+			r := &Request{Request: req}
+
+			if got := r.QueryBool("v", tt.def); got != tt.expected {
+				t.Errorf("QueryBool(%q, %v) = %v, want %v", tt.query, tt.def, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRequest_HeaderGetters(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
+	req.Header.Set("X-Retry-Count", "2")
+	req.Header.Set("X-Request-Size", "4294967296")
+	req.Header.Set("X-Weight", "1.25")
+	req.Header.Set("X-Debug", "1")
+	req.Header.Set("X-Invalid", "abc")
+	r := &Request{Request: req}
+
+	if got := r.HeaderInt("X-Retry-Count", 0); got != 2 {
+		t.Errorf("HeaderInt = %d, want 2", got)
+	}
+	if got := r.HeaderInt("X-Invalid", 7); got != 7 {
+		t.Errorf("HeaderInt(invalid) = %d, want default 7", got)
+	}
+	if got := r.HeaderInt64("X-Request-Size", 0); got != 4294967296 {
+		t.Errorf("HeaderInt64 = %d, want 4294967296", got)
+	}
+	if got := r.HeaderFloat64("X-Weight", 0); got != 1.25 {
+		t.Errorf("HeaderFloat64 = %f, want 1.25", got)
+	}
+	if got := r.HeaderBool("X-Debug", false); !got {
+		t.Error("HeaderBool(X-Debug) = false, want true")
+	}
+	if got := r.HeaderBool("X-Missing", true); !got {
+		t.Error("HeaderBool(X-Missing) = false, want default true")
+	}
+}
+
+func TestRequest_SetGet(t *testing.T) {
+	r := &Request{Request: httptest.NewRequest(http.MethodGet, "/test", http.NoBody)}
+
+	if _, ok := r.Get("user"); ok {
+		t.Error("Expected missing value before Set")
+	}
+
+	r.Set("user", "alice")
+	r.Set("flags", []string{"beta"})
+	r.Set("user", "bob")
+
+	if v, ok := r.Get("user"); !ok || v != "bob" {
+		t.Errorf("Get(user) = %v, %v, want bob, true", v, ok)
+	}
+	if v, ok := r.Get("flags"); !ok || len(v.([]string)) != 1 {
+		t.Errorf("Get(flags) = %v, %v", v, ok)
+	}
+}
+
+func TestRequest_SetGet_ThroughMiddlewares(t *testing.T) {
+	resetAppConfig()
+
+	mux := NewServeMux()
+	mux.Use(func(next Handler) Handler {
+		return HandlerFunc(func(w ResponseWriter, r *Request) {
+			r.Set("requestID", "req-42")
+			next.ServeHTTP(w, r)
+		})
+	})
+	mux.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(r.Context()))
+		})
+	})
+	mux.HandleFunc("GET /values", func(w ResponseWriter, r *Request) {
+		v, _ := r.Get("requestID")
+		_, _ = w.Write([]byte(v.(string)))
+	})
+	registerHandlers(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/values", http.NoBody))
+
+	if rec.Body.String() != "req-42" {
+		t.Errorf("Expected value set by middleware, got %q", rec.Body.String())
+	}
+}
@@ -0,0 +1,88 @@
+package webfram
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Resource registers the handlers of the methods of a RESTful resource on a single path, such as
+// "/users/{id}", sharing middlewares, default OpenAPI tags and OpenAPI path information.
+type Resource struct {
+	group *Group
+	path  string
+}
+
+// Resource creates a resource whose handlers are registered on path (e.g., "/users/{id}"), one per method:
+//
+//	user := mux.Resource("/users/{id}").Use(loadUser)
+//	user.Get(getUser)
+//	user.Put(updateUser).Use(audit)
+//	user.Delete(deleteUser).Use(audit)
+//
+// Middlewares of some methods only, such as mutating ones, are registered on the HandlerConfig returned
+// for the method. Panics if path does not start with "/".
+func (m *ServeMux) Resource(path string) *Resource {
+	if !strings.HasPrefix(path, "/") {
+		panic(`resource path must start with "/"`)
+	}
+
+	return &Resource{
+		group: &Group{mux: m, prefix: path},
+		path:  path,
+	}
+}
+
+// Use registers middlewares to be applied to the handlers of all the methods of this resource.
+// They run after the ServeMux middlewares and before handler-specific middlewares.
+// Accepts either AppMiddleware (func(Handler) Handler) or StandardMiddleware (func(http.Handler) http.Handler).
+// Unsupported middleware type would cause a panic.
+func (res *Resource) Use(mdwrs ...interface{}) *Resource {
+	res.group.Use(mdwrs...)
+	return res
+}
+
+// OpenAPITags sets the OpenAPI tags applied to the operations of this resource.
+// Operations that define their own Tags keep them.
+func (res *Resource) OpenAPITags(tags ...string) *Resource {
+	res.group.OpenAPITags(tags...)
+	return res
+}
+
+// OpenAPIPathInfo sets the path-level information of the resource in the OpenAPI documentation, such as its
+// summary and the parameters common to all its methods, as SetOpenAPIPathInfo does.
+func (res *Resource) OpenAPIPathInfo(info *PathInfo) *Resource {
+	// OpenAPI path templates have no wildcard syntax: {path...} is documented as {path}.
+	res.group.mux.getApp().SetOpenAPIPathInfo(strings.ReplaceAll(res.path, "...}", "}"), info)
+	return res
+}
+
+// Method registers the handler of the given HTTP method of the resource.
+// Returns a HandlerConfig that can be used to further configure the handler.
+func (res *Resource) Method(method string, handler HandlerFunc) *HandlerConfig {
+	return res.group.Handle(method+" ", handler)
+}
+
+// Get registers the GET handler of the resource. Unless a HEAD handler is registered, it also serves HEAD.
+func (res *Resource) Get(handler HandlerFunc) *HandlerConfig {
+	return res.Method(http.MethodGet, handler)
+}
+
+// Post registers the POST handler of the resource.
+func (res *Resource) Post(handler HandlerFunc) *HandlerConfig {
+	return res.Method(http.MethodPost, handler)
+}
+
+// Put registers the PUT handler of the resource.
+func (res *Resource) Put(handler HandlerFunc) *HandlerConfig {
+	return res.Method(http.MethodPut, handler)
+}
+
+// Patch registers the PATCH handler of the resource.
+func (res *Resource) Patch(handler HandlerFunc) *HandlerConfig {
+	return res.Method(http.MethodPatch, handler)
+}
+
+// Delete registers the DELETE handler of the resource.
+func (res *Resource) Delete(handler HandlerFunc) *HandlerConfig {
+	return res.Method(http.MethodDelete, handler)
+}
@@ -0,0 +1,132 @@
+package webfram
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestResource_MethodsAndMiddleware(t *testing.T) {
+	resetAppConfig()
+
+	orderMiddleware := func(name string) AppMiddleware {
+		return func(next Handler) Handler {
+			return HandlerFunc(func(w ResponseWriter, r *Request) {
+				w.Header().Add("X-Order", name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	mux := NewServeMux()
+	user := mux.Resource("/users/{id}").Use(orderMiddleware("resource"))
+	user.Get(func(w ResponseWriter, r *Request) {
+		_, _ = w.Write([]byte("get " + r.PathValue("id")))
+	})
+	user.Put(func(w ResponseWriter, r *Request) {
+		_, _ = w.Write([]byte("put " + r.PathValue("id")))
+	}).Use(orderMiddleware("mutating"))
+	user.Delete(func(w ResponseWriter, _ *Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}).Use(orderMiddleware("mutating"))
+	registerHandlers(mux)
+
+	tests := []struct {
+		method         string
+		expectedStatus int
+		expectedBody   string
+		expectedOrder  []string
+	}{
+		{http.MethodGet, http.StatusOK, "get 42", []string{"resource"}},
+		{http.MethodPut, http.StatusOK, "put 42", []string{"resource", "mutating"}},
+		{http.MethodDelete, http.StatusNoContent, "", []string{"resource", "mutating"}},
+		{http.MethodPost, http.StatusMethodNotAllowed, "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(tt.method, "/users/42", http.NoBody))
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+			if tt.expectedStatus != http.StatusMethodNotAllowed && rec.Body.String() != tt.expectedBody {
+				t.Errorf("Expected body %q, got %q", tt.expectedBody, rec.Body.String())
+			}
+			if got := rec.Header().Values("X-Order"); !slices.Equal(got, tt.expectedOrder) {
+				t.Errorf("Expected middlewares %v, got %v", tt.expectedOrder, got)
+			}
+		})
+	}
+}
+
+func TestResource_InvalidPath(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for path without leading slash")
+		}
+	}()
+
+	(&ServeMux{}).Resource("users/{id}")
+}
+
+func TestResource_OpenAPI(t *testing.T) {
+	resetAppConfig()
+	Configure(&Config{
+		OpenAPI: &OpenAPI{
+			Enabled: true,
+			URLPath: "GET /openapi.json",
+			Config: &OpenAPIConfig{
+				Info: &Info{Title: "Test API", Version: "1.0.0"},
+			},
+		},
+	})
+
+	mux := NewServeMux()
+	user := mux.Resource("/users/{id}").
+		OpenAPITags("Users").
+		OpenAPIPathInfo(&PathInfo{Summary: "A user"})
+	user.Get(func(_ ResponseWriter, _ *Request) {}).OpenAPIOperation(OperationConfig{
+		Summary:   "Get user",
+		Responses: map[string]Response{"200": {Description: "OK"}},
+	})
+	user.Delete(func(_ ResponseWriter, _ *Request) {}).OpenAPIOperation(OperationConfig{
+		Summary:   "Delete user",
+		Responses: map[string]Response{"204": {Description: "No Content"}},
+	})
+
+	setupOpenAPIEndpoints(mux)
+	registerHandlers(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", http.NoBody))
+
+	var doc struct {
+		Paths map[string]struct {
+			Summary string `json:"summary"`
+			Get     struct {
+				Tags []string `json:"tags"`
+			} `json:"get"`
+			Delete struct {
+				Tags []string `json:"tags"`
+			} `json:"delete"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("Failed to parse OpenAPI document: %v", err)
+	}
+
+	path, ok := doc.Paths["/users/{id}"]
+	if !ok {
+		t.Fatalf("Expected the resource path to be documented, got %v", doc.Paths)
+	}
+	if path.Summary != "A user" {
+		t.Errorf("Expected the path summary, got %q", path.Summary)
+	}
+	if !slices.Equal(path.Get.Tags, []string{"Users"}) || !slices.Equal(path.Delete.Tags, []string{"Users"}) {
+		t.Errorf("Expected the resource tags on every operation, got %v and %v", path.Get.Tags, path.Delete.Tags)
+	}
+}
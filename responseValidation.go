@@ -0,0 +1,225 @@
+package webfram
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"math"
+	"mime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bondowe/webfram/openapi"
+)
+
+// responseValidationMaxBody is the largest response body validated by ValidateResponses.
+const responseValidationMaxBody = 10 << 20
+
+// responseSchemas holds the declared responses of an operation, by status code and media type.
+type responseSchemas struct {
+	responses  map[string]map[string]openapi.MediaType
+	components *openapi.Components
+}
+
+// responseValidationMiddleware validates the JSON responses of a documented handler against the
+// schemas declared in its OperationConfig.Responses, and logs the mismatches as warnings.
+// Responses are written to the client unchanged; streamed responses are not validated.
+func responseValidationMiddleware(pathPattern string, op *OperationConfig) AppMiddleware {
+	schemas := newResponseSchemas(op)
+
+	return func(next Handler) Handler {
+		return HandlerFunc(func(w ResponseWriter, r *Request) {
+			rec := &teeRecorder{ResponseWriter: w.ResponseWriter, maxBody: responseValidationMaxBody}
+
+			next.ServeHTTP(ResponseWriter{ResponseWriter: rec, statusCode: w.statusCode, request: w.request}, r)
+
+			if rec.streamed {
+				return
+			}
+
+			status, header := rec.recorded()
+			if errs := schemas.validate(status, header.Get("Content-Type"), rec.body.Bytes()); len(errs) > 0 {
+				slog.WarnContext(r.Context(), "response does not match the OpenAPI schema",
+					"pattern", pathPattern, "status", status, "errors", errs)
+			}
+		})
+	}
+}
+
+func newResponseSchemas(op *OperationConfig) *responseSchemas {
+	schemas := &responseSchemas{
+		responses:  make(map[string]map[string]openapi.MediaType, len(op.Responses)),
+		components: &openapi.Components{},
+	}
+
+	for statusCode, resp := range op.Responses {
+		schemas.responses[strings.ToUpper(statusCode)] = mapContent(resp.Content, schemas.components)
+	}
+
+	return schemas
+}
+
+// validate returns the mismatches between a JSON response and its declared schema.
+// Responses that are not JSON, and operations without declared responses, are not validated.
+func (s *responseSchemas) validate(status int, contentType string, body []byte) []string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if len(s.responses) == 0 || (mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json")) {
+		return nil
+	}
+
+	content, ok := s.response(status)
+	if !ok {
+		return []string{fmt.Sprintf("status %d is not documented", status)}
+	}
+
+	declared, ok := content[mediaType]
+	if !ok {
+		if len(content) == 0 {
+			return nil
+		}
+		return []string{fmt.Sprintf("content type %q is not documented for status %d", mediaType, status)}
+	}
+
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []string{"invalid JSON: " + err.Error()}
+	}
+
+	var errs []string
+	s.validateValue(value, declared.Schema, "$", &errs)
+	return errs
+}
+
+// response returns the declared content of a status code, falling back to its range (e.g., "4XX") and "default".
+func (s *responseSchemas) response(status int) (map[string]openapi.MediaType, bool) {
+	for _, key := range []string{strconv.Itoa(status), strconv.Itoa(status/100) + "XX", "DEFAULT"} { //nolint:mnd // status class
+		if content, ok := s.responses[key]; ok {
+			return content, true
+		}
+	}
+	return nil, false
+}
+
+// validateValue checks the type, required and undeclared properties, items and enum values of a JSON value.
+// Formats and value constraints such as minimum or pattern are not checked.
+func (s *responseSchemas) validateValue(value any, schemaOrRef *openapi.SchemaOrRef, path string, errs *[]string) {
+	schema := s.resolve(schemaOrRef)
+	if schema == nil {
+		return
+	}
+
+	for i := range schema.AllOf {
+		s.validateValue(value, &schema.AllOf[i], path, errs)
+	}
+	if alternatives := slices.Concat(schema.OneOf, schema.AnyOf); len(alternatives) > 0 &&
+		!slices.ContainsFunc(alternatives, func(alt openapi.SchemaOrRef) bool { return s.matches(value, &alt, path) }) {
+		*errs = append(*errs, path+": does not match any of the alternative schemas")
+	}
+
+	if value == nil {
+		if schema.Type != "" && !schema.Nullable {
+			*errs = append(*errs, fmt.Sprintf("%s: must be %s, got null", path, schema.Type))
+		}
+		return
+	}
+
+	if !jsonTypeMatches(value, schema.Type) {
+		*errs = append(*errs, fmt.Sprintf("%s: must be %s, got %s", path, schema.Type, jsonTypeName(value)))
+		return
+	}
+
+	if len(schema.Enum) > 0 && !slices.ContainsFunc(schema.Enum, func(e any) bool { return fmt.Sprint(e) == fmt.Sprint(value) }) {
+		*errs = append(*errs, fmt.Sprintf("%s: %v is not one of %v", path, value, schema.Enum))
+	}
+
+	switch v := value.(type) {
+	case []any:
+		for i, item := range v {
+			s.validateValue(item, schema.Items, fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+	case map[string]any:
+		s.validateObject(v, schema, path, errs)
+	}
+}
+
+func (s *responseSchemas) validateObject(object map[string]any, schema *openapi.Schema, path string, errs *[]string) {
+	for _, name := range schema.Required {
+		if _, ok := object[name]; !ok {
+			*errs = append(*errs, fmt.Sprintf("%s.%s: is required", path, name))
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(object)) {
+		value := object[name]
+
+		property, declared := schema.Properties[name]
+		switch {
+		case declared && value == nil && !slices.Contains(schema.Required, name):
+			// Optional properties, such as pointer fields, may be null.
+		case declared:
+			s.validateValue(value, &property, path+"."+name, errs)
+		case schema.Properties == nil && schema.AdditionalProperties == nil:
+			// Free-form object, e.g. a map.
+		case schema.AdditionalProperties == nil || schema.AdditionalProperties == false:
+			*errs = append(*errs, fmt.Sprintf("%s.%s: is not declared in the schema", path, name))
+		default:
+			if additional, ok := schema.AdditionalProperties.(*openapi.SchemaOrRef); ok {
+				s.validateValue(value, additional, path+"."+name, errs)
+			}
+		}
+	}
+}
+
+// matches reports whether a value matches a schema, without recording the mismatches.
+func (s *responseSchemas) matches(value any, schemaOrRef *openapi.SchemaOrRef, path string) bool {
+	var errs []string
+	s.validateValue(value, schemaOrRef, path, &errs)
+	return len(errs) == 0
+}
+
+// resolve returns the schema, following references to the component schemas.
+func (s *responseSchemas) resolve(schemaOrRef *openapi.SchemaOrRef) *openapi.Schema {
+	if schemaOrRef == nil {
+		return nil
+	}
+	if schemaOrRef.Ref == "" {
+		return schemaOrRef.Schema
+	}
+
+	schema, ok := s.components.Schemas[strings.TrimPrefix(schemaOrRef.Ref, "#/components/schemas/")]
+	if !ok {
+		return nil
+	}
+	return &schema
+}
+
+func jsonTypeMatches(value any, schemaType string) bool {
+	switch schemaType {
+	case "":
+		return true
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	default:
+		return jsonTypeName(value) == schemaType
+	}
+}
+
+func jsonTypeName(value any) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return "null"
+	}
+}
@@ -0,0 +1,100 @@
+package webfram
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type validatedUser struct {
+	ID      int              `json:"id"      validate:"required"`
+	Name    string           `json:"name"    validate:"required"`
+	Address *validatedStreet `json:"address"`
+	Tags    []string         `json:"tags"`
+}
+
+type validatedStreet struct {
+	Street string `json:"street"`
+}
+
+func TestResponseSchemas_Validate(t *testing.T) {
+	schemas := newResponseSchemas(&OperationConfig{
+		Responses: map[string]Response{
+			"200": {Content: map[string]TypeInfo{"application/json": {TypeHint: &validatedUser{}}}},
+			"4XX": {Content: map[string]TypeInfo{"application/json": {TypeHint: &ProblemDetails{}}}},
+		},
+	})
+
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		expected []string
+	}{
+		{"valid", 200, `{"id":1,"name":"Ada","address":{"street":"Main"},"tags":["a"]}`, nil},
+		{"optional null", 200, `{"id":1,"name":"Ada","address":null}`, nil},
+		{"undeclared property", 200, `{"id":1,"name":"Ada","email":"ada@example.com"}`, []string{"$.email: is not declared"}},
+		{"missing required", 200, `{"id":1}`, []string{"$.name: is required"}},
+		{"wrong type", 200, `{"id":1.5,"name":"Ada"}`, []string{"$.id: must be integer, got number"}},
+		{"nested", 200, `{"id":1,"name":"Ada","address":{"zip":"1"},"tags":[1]}`, []string{
+			"$.address.zip: is not declared", "$.tags[0]: must be string, got number",
+		}},
+		{"status range", 404, `{"title":"Not Found","status":404}`, nil},
+		{"undocumented status", 500, `{}`, []string{"status 500 is not documented"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := schemas.validate(tt.status, "application/json; charset=utf-8", []byte(tt.body))
+
+			if len(errs) != len(tt.expected) {
+				t.Fatalf("Expected %d errors, got %v", len(tt.expected), errs)
+			}
+			for i, expected := range tt.expected {
+				if !strings.Contains(errs[i], expected) {
+					t.Errorf("Expected error %q to contain %q", errs[i], expected)
+				}
+			}
+		})
+	}
+
+	if errs := schemas.validate(500, "text/plain", []byte("boom")); errs != nil {
+		t.Errorf("Expected non-JSON responses not to be validated, got %v", errs)
+	}
+}
+
+func TestConfig_ValidateResponses_LogsMismatches(t *testing.T) {
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+
+	var logs bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+	Configure(&Config{ValidateResponses: true})
+
+	mux := NewServeMux()
+	mux.HandleFunc("GET /users/{id}", func(w ResponseWriter, r *Request) {
+		_ = w.JSON(r.Context(), map[string]any{"id": 1, "name": "Ada", "password": "secret"})
+	}).OpenAPIOperation(OperationConfig{
+		Responses: map[string]Response{
+			"200": {Content: map[string]TypeInfo{"application/json": {TypeHint: &validatedUser{}}}},
+		},
+	})
+	registerHandlers(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/1", http.NoBody))
+
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"password"`) {
+		t.Errorf("Expected the response to be sent unchanged, got %d %q", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(logs.String(), "response does not match the OpenAPI schema") ||
+		!strings.Contains(logs.String(), "$.password: is not declared") {
+		t.Errorf("Expected the undeclared property to be logged, got %q", logs.String())
+	}
+}
@@ -2,6 +2,7 @@ package webfram
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"encoding/xml"
@@ -10,11 +11,18 @@ import (
 	htmlTemplate "html/template"
 	"io"
 	"io/fs"
+	"iter"
+	"maps"
+	"math"
 	"net"
 	"net/http"
+	"net/url"
 	"path/filepath"
 	"reflect"
+	"strconv"
+	"strings"
 	textTemplate "text/template"
+	"time"
 
 	"github.com/bondowe/webfram/internal/i18n"
 	"github.com/bondowe/webfram/internal/template"
@@ -27,7 +35,15 @@ type (
 	ResponseWriter struct {
 		http.ResponseWriter
 
-		statusCode *int // Pointer to allow mutation across value copies
+		statusCode *int     // Pointer to allow mutation across value copies
+		request    *Request // Request being served, passed to Config.OnError
+	}
+
+	// JSONSeqOptions configures how JSONSeqWithOptions flushes the response.
+	JSONSeqOptions struct {
+		// FlushEvery is the number of records written between flushes (default: 1, every record).
+		// Larger values trade latency for throughput. A negative value flushes only after the last record.
+		FlushEvery int
 	}
 
 	// ServeFileOptions configures how files are served to clients.
@@ -35,10 +51,20 @@ type (
 		Inline   bool   // If true, serves the file inline; otherwise as an attachment
 		Filename string // Optional filename for Content-Disposition header
 	}
+
+	// ProblemDetails is an RFC 9457 (formerly RFC 7807) problem details object.
+	ProblemDetails struct {
+		Type     string `json:"type,omitempty"`     // URI reference identifying the problem type
+		Title    string `json:"title,omitempty"`    // Short, human-readable summary of the problem type
+		Status   int    `json:"status,omitempty"`   // HTTP status code
+		Detail   string `json:"detail,omitempty"`   // Human-readable explanation specific to this occurrence
+		Instance string `json:"instance,omitempty"` // URI reference identifying this occurrence
+	}
 )
 
 const (
 	jsonSeqRecordSeparator = '\x1E'
+	streamBufferSize       = 32 << 10
 )
 
 func i18nPrinterFunc(messagePrinter *message.Printer) func(str string, args ...any) string {
@@ -49,15 +75,204 @@ func i18nPrinterFunc(messagePrinter *message.Printer) func(str string, args ...a
 
 // Error sends an error response with the specified HTTP status code and message.
 // Uses http.Error to format the error message as plain text.
+// The error is reported to Config.OnError, if set, before the response is written.
 func (w *ResponseWriter) Error(statusCode int, message string) {
+	w.reportError(statusCode, errors.New(message))
 	http.Error(w.ResponseWriter, message, statusCode)
 }
 
+// reportError passes an error to the OnError hook of the App serving the request, if one is configured.
+func (w *ResponseWriter) reportError(statusCode int, err error) {
+	ctx := context.Background()
+	if w.request != nil {
+		ctx = w.request.Context()
+	}
+
+	if onError := appFromContext(ctx).onError; onError != nil {
+		onError(w.request, statusCode, err)
+	}
+}
+
+// ErrorJSON sends an error response with the specified HTTP status code and message
+// as a JSON object of the form {"error": "message"}.
+// Sets Content-Type header to "application/json".
+func (w *ResponseWriter) ErrorJSON(statusCode int, message string) {
+	h := w.Header()
+	h.Del("Content-Length")
+	h.Set("Content-Type", "application/json")
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(statusCode)
+
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+// BindError sends a JSON error response for an error returned by the binders and request helpers
+// (BindJSON, BindXML, BindForm, PatchJSON, FormFile, ...), choosing the status code from the kind of error:
+// 413 for bodies exceeding a size limit, 415 for unsupported media types, 405 for disallowed methods,
+// 408 if the request deadline expired while reading the body, and 400 for empty or malformed bodies.
+// The body has the same {"error": "message"} form as ErrorJSON. Does nothing if err is nil.
+func (w *ResponseWriter) BindError(err error) {
+	if err == nil {
+		return
+	}
+
+	statusCode, message := bindErrorResponse(err)
+	w.ErrorJSON(statusCode, message)
+}
+
+// bindErrorResponse maps a binding error to an HTTP status code and error message.
+func bindErrorResponse(err error) (int, string) {
+	var maxBytesErr *http.MaxBytesError
+
+	switch {
+	case errors.Is(err, ErrBodyTooLarge), errors.As(err, &maxBytesErr), errors.Is(err, ErrUploadTooLarge):
+		return http.StatusRequestEntityTooLarge, ErrBodyTooLarge.Error()
+	case errors.Is(err, ErrUnsupportedMediaType):
+		return http.StatusUnsupportedMediaType, err.Error()
+	case errors.Is(err, ErrMethodNotAllowed):
+		return http.StatusMethodNotAllowed, err.Error()
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusRequestTimeout, "request body read timed out"
+	case errors.Is(err, ErrEmptyBody), errors.Is(err, io.EOF):
+		return http.StatusBadRequest, ErrEmptyBody.Error()
+	default:
+		return http.StatusBadRequest, ErrMalformedBody.Error() + ": " + err.Error()
+	}
+}
+
+// Problem sends an RFC 9457 problem details response.
+// The HTTP status code is taken from p.Status, defaulting to 500 if unset,
+// and the title defaults to the standard status text.
+// Sets Content-Type header to "application/problem+json".
+func (w *ResponseWriter) Problem(p ProblemDetails) {
+	if p.Status == 0 {
+		p.Status = http.StatusInternalServerError
+	}
+	if p.Title == "" {
+		p.Title = http.StatusText(p.Status)
+	}
+
+	h := w.Header()
+	h.Del("Content-Length")
+	h.Set("Content-Type", "application/problem+json")
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+
+	_ = json.NewEncoder(w).Encode(p)
+}
+
 // Header returns the response header map for inspection and modification.
 func (w *ResponseWriter) Header() http.Header {
 	return w.ResponseWriter.Header()
 }
 
+// Vary adds the given request header names to the Vary response header.
+// Names already present (case-insensitively) are not duplicated, and nothing is added if Vary is "*".
+// Use it when the response depends on request headers such as Accept or Accept-Language,
+// so that caches store a separate response for each variant.
+func (w *ResponseWriter) Vary(headers ...string) {
+	h := w.Header()
+
+	var values []string
+	seen := make(map[string]bool)
+
+	for _, line := range h.Values("Vary") {
+		for _, v := range strings.Split(line, ",") {
+			v = strings.TrimSpace(v)
+			if v == "" {
+				continue
+			}
+			if v == "*" {
+				return
+			}
+			if key := http.CanonicalHeaderKey(v); !seen[key] {
+				seen[key] = true
+				values = append(values, v)
+			}
+		}
+	}
+
+	added := false
+	for _, v := range headers {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if key := http.CanonicalHeaderKey(v); !seen[key] {
+			seen[key] = true
+			values = append(values, v)
+			added = true
+		}
+	}
+
+	if added {
+		h.Set("Vary", strings.Join(values, ", "))
+	}
+}
+
+// RetryAfter sets the Retry-After response header to the given delay, rounded up to whole seconds.
+// Use it with 503 Service Unavailable or 429 Too Many Requests responses.
+// Delays under one second are reported as one second.
+func (w *ResponseWriter) RetryAfter(d time.Duration) {
+	seconds := int64(math.Ceil(d.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	w.Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+}
+
+// NoStore sets the Cache-Control: no-store, no-cache and Pragma: no-cache response headers, so that neither
+// browsers nor intermediaries store the response. Use it for responses containing tokens or personal data,
+// such as those of authentication endpoints. Responses with no-store are never stored by the Cache middleware.
+func (w *ResponseWriter) NoStore() {
+	h := w.Header()
+	h.Set("Cache-Control", "no-store, no-cache")
+	h.Set("Pragma", "no-cache")
+}
+
+// LinkPagination adds an RFC 8288 Link header with the first, prev, next and last pages of a paginated
+// list, given the current 1-based page, the page size and the total number of items. The links are
+// relative URLs built from the request path and query, with the "page" and "pageSize" query parameters
+// replaced. The prev link is omitted on the first page and the next link on the last one.
+// Nothing is added if pageSize is not positive.
+//
+// Example:
+//
+//	// GET /users?page=2&pageSize=20 with 95 users
+//	w.LinkPagination(r, 2, 20, 95)
+//	// Link: </users?page=1&pageSize=20>; rel="first", </users?page=1&pageSize=20>; rel="prev",
+//	//       </users?page=3&pageSize=20>; rel="next", </users?page=5&pageSize=20>; rel="last"
+func (w *ResponseWriter) LinkPagination(r *Request, page, pageSize, total int) {
+	if pageSize <= 0 {
+		return
+	}
+
+	last := max((total+pageSize-1)/pageSize, 1)
+	page = max(page, 1)
+
+	links := []string{paginationLink(r, 1, pageSize, "first")}
+	if page > 1 {
+		links = append(links, paginationLink(r, min(page-1, last), pageSize, "prev"))
+	}
+	if page < last {
+		links = append(links, paginationLink(r, page+1, pageSize, "next"))
+	}
+	links = append(links, paginationLink(r, last, pageSize, "last"))
+
+	w.Header().Add("Link", strings.Join(links, ", "))
+}
+
+func paginationLink(r *Request, page, pageSize int, rel string) string {
+	query := r.URL.Query()
+	query.Set("page", strconv.Itoa(page))
+	query.Set("pageSize", strconv.Itoa(pageSize))
+
+	target := url.URL{Path: r.URL.Path, RawPath: r.URL.RawPath, RawQuery: query.Encode()}
+
+	return "<" + target.String() + `>; rel="` + rel + `"`
+}
+
 // Write writes the data to the connection as part of an HTTP reply.
 // Implements the io.Writer interface.
 func (w *ResponseWriter) Write(b []byte) (int, error) {
@@ -78,7 +293,7 @@ func (w *ResponseWriter) WriteHeader(statusCode int) {
 
 // Flush sends any buffered data to the client.
 // If the underlying writer does not support flushing, this is a no-op.
-func (w *ResponseWriter) Flush() {
+func (w ResponseWriter) Flush() {
 	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
 		flusher.Flush()
 	}
@@ -87,17 +302,20 @@ func (w *ResponseWriter) Flush() {
 // Hijack takes over the connection from the HTTP server.
 // Returns the connection, buffered reader/writer, and any error.
 // After hijacking, the HTTP server will not do anything else with the connection.
-func (w *ResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+// Returns net.ErrClosed if the underlying writer does not support hijacking.
+// Flush, Hijack and Push have value receivers, so the ResponseWriter passed to handlers implements
+// http.Flusher, http.Hijacker and http.Pusher, as does &w, which libraries such as WebSocket upgraders accept.
+func (w ResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 	if hj, ok := w.ResponseWriter.(http.Hijacker); ok {
 		return hj.Hijack()
 	}
 
-	return nil, nil, http.ErrNotSupported
+	return nil, nil, net.ErrClosed
 }
 
 // Push initiates an HTTP/2 server push for the specified target.
 // Returns an error if the underlying connection does not support HTTP/2 push.
-func (w *ResponseWriter) Push(target string, opts *http.PushOptions) error {
+func (w ResponseWriter) Push(target string, opts *http.PushOptions) error {
 	if pusher, ok := w.ResponseWriter.(http.Pusher); ok {
 		return pusher.Push(target, opts)
 	}
@@ -137,8 +355,15 @@ func (w *ResponseWriter) StatusCode() (int, bool) {
 func (w *ResponseWriter) JSON(ctx context.Context, v any) error {
 	jsonpCallback, ok := ctx.Value(jsonpCallbackMethodNameKey).(string)
 	if ok && jsonpCallback != "" {
-		w.Header().Set("Content-Type", "application/javascript")
-		if _, writeErr := w.Write([]byte(jsonpCallback + "(")); writeErr != nil {
+		app := appFromContext(ctx)
+		w.Header().Set("Content-Type", app.jsonpContentType)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+
+		prefix := jsonpCallback + "("
+		if app.jsonpSafeCallback {
+			prefix = "typeof " + jsonpCallback + " === 'function' && " + prefix
+		}
+		if _, writeErr := w.Write([]byte(prefix)); writeErr != nil {
 			return writeErr
 		}
 		bs, err := json.Marshal(v)
@@ -162,8 +387,16 @@ func (w *ResponseWriter) JSON(ctx context.Context, v any) error {
 // JSONSeq streams a sequence of JSON objects as per RFC 7464.
 // Each JSON object is prefixed with the ASCII Record Separator character.
 // Sets Content-Type header to "application/json-seq".
+// The response is flushed after every record; use JSONSeqWithOptions to batch flushes.
+// Returns an error if items is not a slice, marshaling fails, or writing fails.
+func (w *ResponseWriter) JSONSeq(ctx context.Context, items any) error {
+	return w.JSONSeqWithOptions(ctx, items, JSONSeqOptions{})
+}
+
+// JSONSeqWithOptions streams a sequence of JSON objects like JSONSeq, flushing the response as configured
+// by opts. Records still waiting for a flush are flushed after the last record.
 // Returns an error if items is not a slice, marshaling fails, or writing fails.
-func (w *ResponseWriter) JSONSeq(_ context.Context, items any) error {
+func (w *ResponseWriter) JSONSeqWithOptions(_ context.Context, items any, opts JSONSeqOptions) error {
 	v := reflect.ValueOf(items)
 	if v.Kind() != reflect.Slice {
 		return errors.New("items must be a slice")
@@ -174,9 +407,12 @@ func (w *ResponseWriter) JSONSeq(_ context.Context, items any) error {
 		return errors.New("response writer does not support flushing")
 	}
 
+	flushEvery := getValueOrDefault(opts.FlushEvery, 1)
+
 	w.Header().Set("Content-Type", "application/json-seq")
 
 	encoder := json.NewEncoder(w)
+	pending := 0
 
 	for i := range v.Len() {
 		item := v.Index(i).Interface()
@@ -190,6 +426,14 @@ func (w *ResponseWriter) JSONSeq(_ context.Context, items any) error {
 			return err
 		}
 
+		pending++
+		if flushEvery > 0 && pending >= flushEvery {
+			flusher.Flush()
+			pending = 0
+		}
+	}
+
+	if pending > 0 {
 		flusher.Flush()
 	}
 
@@ -218,7 +462,33 @@ func (w *ResponseWriter) HTMLString(s string, data any) error {
 // The ctx parameter is used for i18n support; pass request context or context.Background().
 // Returns an error if templates are not configured, template is not found, or execution fails.
 func (w *ResponseWriter) HTML(ctx context.Context, path string, data any) error {
-	return w.renderTemplate(ctx, path, data, "text/html", true)
+	return w.renderTemplate(ctx, w.ResponseWriter, "", path, data, "text/html", true)
+}
+
+// HTMLWithETag renders a cached HTML template like HTML, using the request context, and sends it with an
+// ETag computed from the rendered page. Requests whose If-None-Match header matches it get 304 Not Modified
+// without a body. The page is rendered into a buffer first, so nothing is written if rendering fails.
+// Only useful for pages that render identically for the same data,
+// e.g. not with a CSP nonce, which changes on every request.
+// Returns an error if templates are not configured, template is not found, or execution fails.
+func (w *ResponseWriter) HTMLWithETag(r *Request, path string, data any) error {
+	var buf bytes.Buffer
+	if err := w.renderTemplate(r.Context(), &buf, "", path, data, "text/html", true); err != nil {
+		return err
+	}
+
+	w.Header().Set("ETag", contentETag(buf.Bytes()))
+	http.ServeContent(w.ResponseWriter, r.Request, "", time.Time{}, bytes.NewReader(buf.Bytes()))
+
+	return nil
+}
+
+// HTMLWithLayout renders a cached HTML template like HTML, using the named layout instead of the default one.
+// The layout must be one of the Layouts configured in Templates; an empty layout selects the default layout.
+// Returns an error if templates are not configured, the layout is unknown or does not apply to the template's
+// directory, the template is not found, or execution fails.
+func (w *ResponseWriter) HTMLWithLayout(ctx context.Context, layout, path string, data any) error {
+	return w.renderTemplate(ctx, w.ResponseWriter, layout, path, data, "text/html", true)
 }
 
 // TextString parses a plain text template string and executes it with the provided data.
@@ -241,18 +511,41 @@ func (w *ResponseWriter) TextString(s string, data any) error {
 // The ctx parameter is used for i18n support; pass request context or context.Background().
 // Returns an error if templates are not configured, template is not found, or execution fails.
 func (w *ResponseWriter) Text(ctx context.Context, path string, data any) error {
-	return w.renderTemplate(ctx, path, data, "text/plain", false)
+	return w.renderTemplate(ctx, w.ResponseWriter, "", path, data, "text/plain", false)
+}
+
+// requestTemplateFuncs returns the template functions bound to the request: the i18n function using
+// the request's message printer, sortStrings using the request's language, and cspNonce returning
+// the request's CSP nonce, if present in ctx.
+func requestTemplateFuncs(ctx context.Context, i18nFuncName string) map[string]any {
+	funcs := make(map[string]any)
+
+	if msgPrinter, ok := i18n.PrinterFromContext(ctx); ok {
+		funcs[i18nFuncName] = i18nPrinterFunc(msgPrinter)
+	}
+	if _, ok := i18n.LanguageFromContext(ctx); ok {
+		funcs[sortStringsFuncName] = sortStringsFunc(ctx)
+	}
+	if nonce, ok := cspNonceFromContext(ctx); ok {
+		funcs["cspNonce"] = func() string { return nonce }
+	}
+
+	return funcs
 }
 
 // renderTemplate is a helper function that handles template rendering for both HTML and text templates.
+// The output is written to out, usually the underlying http.ResponseWriter.
 func (w *ResponseWriter) renderTemplate(
 	ctx context.Context,
+	out io.Writer,
+	layout string,
 	path string,
 	data any,
 	contentType string,
 	isHTML bool,
 ) error {
-	tmplConfig, ok := template.Configuration()
+	templates := appFromContext(ctx).templates
+	tmplConfig, ok := templates.Configuration()
 	if !ok {
 		return errors.New("templates not configured")
 	}
@@ -266,26 +559,25 @@ func (w *ResponseWriter) renderTemplate(
 		extension = tmplConfig.TextTemplateExtension
 	}
 
-	if tmpl, tmplFound := template.LookupTemplate(path+extension, false); tmplFound {
-		if msgPrinter, printerOk := i18n.PrinterFromContext(ctx); printerOk {
-			if isHTML {
-				i18nFunc := i18nPrinterFunc(msgPrinter)
-				funcs := htmlTemplate.FuncMap{
-					tmplConfig.I18nFuncName: i18nFunc,
-					"partial":               template.GetPartialFuncWithI18n(path+extension, i18nFunc),
-				}
-				return template.Must(tmpl.Clone()).Funcs(funcs).Execute(w.ResponseWriter, data)
-			}
-			i18nFunc := i18nPrinterFunc(msgPrinter)
-			funcs := textTemplate.FuncMap{
-				tmplConfig.I18nFuncName: i18nFunc,
-				"partial":               template.GetTextPartialFuncWithI18n(path+extension, i18nFunc),
-			}
-			return template.Must(tmpl.Clone()).Funcs(funcs).Execute(w.ResponseWriter, data)
+	if tmpl, tmplFound := templates.LookupTemplateWithLayout(path+extension, layout); tmplFound {
+		requestFuncs := requestTemplateFuncs(ctx, tmplConfig.I18nFuncName)
+		if len(requestFuncs) == 0 {
+			return tmpl.Execute(out, data)
 		}
-		return tmpl.Execute(w.ResponseWriter, data)
+
+		if isHTML {
+			funcs := htmlTemplate.FuncMap{"partial": templates.GetPartialFuncWithFuncs(path+extension, requestFuncs)}
+			maps.Copy(funcs, requestFuncs)
+			return template.Must(tmpl.Clone()).Funcs(funcs).Execute(out, data)
+		}
+		funcs := textTemplate.FuncMap{"partial": templates.GetTextPartialFuncWithFuncs(path+extension, requestFuncs)}
+		maps.Copy(funcs, requestFuncs)
+		return template.Must(tmpl.Clone()).Funcs(funcs).Execute(out, data)
 	}
 
+	if layout != "" {
+		return fmt.Errorf("template not found in cache: %s (layout %q)", path, layout)
+	}
 	return fmt.Errorf("template not found in cache: %s", path)
 }
 
@@ -352,6 +644,45 @@ func (w *ResponseWriter) XMLArray(items any, rootName string) error {
 	return err
 }
 
+// XMLStream writes the items of a sequence as XML elements wrapped in a root element, without buffering
+// the whole document. Each item is encoded and flushed to the client as soon as the sequence yields it,
+// which suits large exports produced from a database cursor. The root parameter specifies the name of the
+// wrapping element; each item uses its struct's XMLName or type name for its element, as with XMLArray.
+// Sets Content-Type header to "application/xml".
+// Returns an error if encoding or writing fails. Iteration stops at the first error; since the response has
+// already started, the client receives a truncated document.
+//
+// Example:
+//
+//	w.XMLStream("users", func(yield func(any) bool) {
+//	    for rows.Next() {
+//	        var u User
+//	        _ = rows.Scan(&u.Name)
+//	        if !yield(u) {
+//	            return
+//	        }
+//	    }
+//	})
+func (w *ResponseWriter) XMLStream(root string, items iter.Seq[any]) error {
+	w.Header().Set("Content-Type", "application/xml")
+
+	if _, err := w.Write([]byte(xml.Header + "<" + root + ">")); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+
+	for item := range items {
+		if err := encoder.Encode(item); err != nil {
+			return err
+		}
+		w.Flush()
+	}
+
+	_, err := w.Write([]byte("</" + root + ">"))
+	return err
+}
+
 // YAML marshals the provided data as YAML and writes it to the response.
 // Sets Content-Type header to "text/x-yaml".
 // Returns an error if marshaling or writing fails.
@@ -381,6 +712,49 @@ func (w *ResponseWriter) Bytes(bs []byte, contentType string) error {
 	return err
 }
 
+// Blob writes pre-serialized data (e.g., a cached JSON document) to the response as is, with the
+// specified Content-Type and a Content-Length header. Unlike JSON, the data is never wrapped in a JSONP callback.
+// If contentType is empty, automatically detects the content type using http.DetectContentType.
+// Returns an error if writing fails.
+func (w *ResponseWriter) Blob(contentType string, data []byte) error {
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	h := w.Header()
+	h.Set("Content-Type", contentType)
+	h.Set("Content-Length", strconv.Itoa(len(data)))
+
+	_, err := w.Write(data)
+	return err
+}
+
+// Stream copies r to the response with the specified Content-Type, flushing after each chunk so
+// clients receive the data as it is read. Unlike JSON, the data is never wrapped in a JSONP callback.
+// Returns an error if reading from r or writing to the response fails; the client then receives
+// a truncated body.
+func (w *ResponseWriter) Stream(contentType string, r io.Reader) error {
+	h := w.Header()
+	h.Set("Content-Type", contentType)
+	h.Del("Content-Length")
+
+	buf := make([]byte, streamBufferSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if _, writeErr := w.Write(buf[:n]); writeErr != nil {
+				return writeErr
+			}
+			w.Flush()
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
 // NoContent sends a 204 No Content response with no body.
 func (w *ResponseWriter) NoContent() {
 	w.WriteHeader(http.StatusNoContent)
@@ -395,6 +769,8 @@ func (w *ResponseWriter) Redirect(req *Request, urlStr string, code int) {
 // ServeFileFS serves a file from the specified fs.FS at the given path.
 // The options parameter allows setting Content-Disposition headers for inline or attachment serving.
 // If options is nil, defaults to attachment serving with the original filename.
+// If fsys has a pre-compressed sibling of the file ("path.br" or "path.gz") accepted by the client's
+// Accept-Encoding header, it is served instead with the matching Content-Encoding, preferring Brotli.
 // Uses http.ServeFileFS to handle file serving.
 // The req parameter is the original request.
 func (w *ResponseWriter) ServeFileFS(req *Request, fsys fs.FS, path string, options *ServeFileOptions) {
@@ -414,6 +790,9 @@ func (w *ResponseWriter) ServeFileFS(req *Request, fsys fs.FS, path string, opti
 	}
 
 	w.Header().Set("Content-Disposition", disposition+"; filename=\""+filepath.Base(filename)+"\"")
+	if servePrecompressedFS(w.ResponseWriter, req.Request, fsys, path) {
+		return
+	}
 	http.ServeFileFS(w.ResponseWriter, req.Request, fsys, path)
 }
 
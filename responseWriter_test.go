@@ -8,12 +8,16 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"errors"
+	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"testing/fstest"
+	"testing/iotest"
+	"time"
 
 	"github.com/bondowe/webfram/internal/i18n"
 	"golang.org/x/text/language"
@@ -30,8 +34,8 @@ const testContextKey3 contextKey3 = "test-key"
 var testTemplatesFS embed.FS
 
 func setupResponseWriterTests() {
-	if appConfigured {
-		appConfigured = false
+	if defaultApp.configured {
+		defaultApp.configured = false
 	}
 
 	Configure(&Config{
@@ -40,9 +44,6 @@ func setupResponseWriterTests() {
 			Templates: &Templates{
 				Dir: "testdata/templates",
 			},
-			I18nMessages: &I18nMessages{
-				Dir: "testdata/locales",
-			},
 		},
 	})
 }
@@ -63,6 +64,142 @@ func TestResponseWriter_Error(t *testing.T) {
 	}
 }
 
+func TestResponseWriter_ErrorJSON(t *testing.T) {
+	w := httptest.NewRecorder()
+	statusCode := 0
+	rw := ResponseWriter{ResponseWriter: w, statusCode: &statusCode}
+
+	rw.ErrorJSON(http.StatusInternalServerError, "database unavailable")
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status code %d, got %d", http.StatusInternalServerError, w.Code)
+	}
+
+	if code, ok := rw.StatusCode(); !ok || code != http.StatusInternalServerError {
+		t.Errorf("Expected tracked status code 500, got %d", code)
+	}
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected Content-Type 'application/json', got %q", ct)
+	}
+
+	var body map[string]string
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to parse JSON body: %v", err)
+	}
+
+	if body["error"] != "database unavailable" {
+		t.Errorf("Expected error 'database unavailable', got %q", body["error"])
+	}
+}
+
+func TestResponseWriter_Problem(t *testing.T) {
+	w := httptest.NewRecorder()
+	rw := ResponseWriter{ResponseWriter: w}
+
+	rw.Problem(ProblemDetails{
+		Type:     "https://example.com/probs/out-of-credit",
+		Status:   http.StatusForbidden,
+		Detail:   "Your current balance is 30, but that costs 50.",
+		Instance: "/account/12345/msgs/abc",
+	})
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status code %d, got %d", http.StatusForbidden, w.Code)
+	}
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Errorf("Expected Content-Type 'application/problem+json', got %q", ct)
+	}
+
+	var body ProblemDetails
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to parse JSON body: %v", err)
+	}
+
+	if body.Title != "Forbidden" {
+		t.Errorf("Expected default title 'Forbidden', got %q", body.Title)
+	}
+
+	if body.Status != http.StatusForbidden || body.Instance != "/account/12345/msgs/abc" {
+		t.Errorf("Unexpected problem details: %+v", body)
+	}
+}
+
+func TestResponseWriter_Problem_DefaultStatus(t *testing.T) {
+	w := httptest.NewRecorder()
+	rw := ResponseWriter{ResponseWriter: w}
+
+	rw.Problem(ProblemDetails{Detail: "unexpected failure"})
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status code %d, got %d", http.StatusInternalServerError, w.Code)
+	}
+
+	if !strings.Contains(w.Body.String(), `"title":"Internal Server Error"`) {
+		t.Errorf("Expected default title in body, got %q", w.Body.String())
+	}
+}
+
+func TestResponseWriter_Vary(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []string
+		add      []string
+		expected string
+	}{
+		{"empty", nil, []string{"Accept"}, "Accept"},
+		{"multiple", nil, []string{"Accept", "Accept-Language"}, "Accept, Accept-Language"},
+		{"append", []string{"Accept"}, []string{"Accept-Language"}, "Accept, Accept-Language"},
+		{"no duplicates", []string{"Accept, Accept-Language"}, []string{"accept-language", "Accept"}, "Accept, Accept-Language"},
+		{"multiple header lines", []string{"Accept", "Origin"}, []string{"Origin"}, "Accept"},
+		{"wildcard", []string{"*"}, []string{"Accept"}, "*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			for _, v := range tt.existing {
+				w.Header().Add("Vary", v)
+			}
+			rw := ResponseWriter{ResponseWriter: w}
+
+			rw.Vary(tt.add...)
+
+			if got := w.Header().Get("Vary"); got != tt.expected {
+				t.Errorf("Expected Vary %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestResponseWriter_Vary_Idempotent(t *testing.T) {
+	w := httptest.NewRecorder()
+	rw := ResponseWriter{ResponseWriter: w}
+
+	rw.Vary("Accept-Language")
+	rw.Vary("Accept-Language")
+
+	if got := w.Header().Values("Vary"); len(got) != 1 || got[0] != "Accept-Language" {
+		t.Errorf("Expected single Vary value 'Accept-Language', got %v", got)
+	}
+}
+
+func TestI18nMiddleware_SetsVaryHeader(t *testing.T) {
+	handler := I18nMiddleware(nil)(HandlerFunc(func(w ResponseWriter, _ *Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set("Accept-Language", "fr")
+	handler.ServeHTTP(ResponseWriter{ResponseWriter: w}, &Request{req})
+
+	if got := w.Header().Get("Vary"); got != "Accept-Language" {
+		t.Errorf("Expected Vary 'Accept-Language', got %q", got)
+	}
+}
+
 func TestResponseWriter_Header(t *testing.T) {
 	w := httptest.NewRecorder()
 	rw := ResponseWriter{ResponseWriter: w}
@@ -294,8 +431,8 @@ func TestResponseWriter_Hijack(t *testing.T) {
 	// httptest.ResponseRecorder doesn't support Hijack
 	conn, buf, err := rw.Hijack()
 
-	if !errors.Is(err, http.ErrNotSupported) {
-		t.Errorf("Expected http.ErrNotSupported, got %v", err)
+	if !errors.Is(err, net.ErrClosed) {
+		t.Errorf("Expected net.ErrClosed, got %v", err)
 	}
 
 	if conn != nil {
@@ -425,6 +562,10 @@ func TestResponseWriter_JSON_JSONP(t *testing.T) {
 		t.Errorf("Expected Content-Type 'application/javascript', got %q", contentType)
 	}
 
+	if nosniff := w.Header().Get("X-Content-Type-Options"); nosniff != "nosniff" {
+		t.Errorf("Expected X-Content-Type-Options 'nosniff', got %q", nosniff)
+	}
+
 	body := w.Body.String()
 	if !strings.HasPrefix(body, "myCallback(") {
 		t.Errorf("Expected JSONP response to start with 'myCallback(', got %q", body)
@@ -434,6 +575,37 @@ func TestResponseWriter_JSON_JSONP(t *testing.T) {
 	}
 }
 
+func TestResponseWriter_JSON_JSONPHardening(t *testing.T) {
+	resetAppConfig()
+	Configure(&Config{
+		JSONPCallbackParamName: "callback",
+		JSONPContentType:       "text/javascript; charset=utf-8",
+		JSONPSafeCallback:      true,
+	})
+	defer resetAppConfig()
+
+	w := httptest.NewRecorder()
+	ctx := context.WithValue(context.Background(), jsonpCallbackMethodNameKey, "myCallback")
+	rw := ResponseWriter{ResponseWriter: w}
+
+	if err := rw.JSON(ctx, map[string]string{"message": "hello"}); err != nil {
+		t.Fatalf("JSON() returned error: %v", err)
+	}
+
+	if ct := w.Header().Get("Content-Type"); ct != "text/javascript; charset=utf-8" {
+		t.Errorf("Expected configured Content-Type, got %q", ct)
+	}
+
+	if nosniff := w.Header().Get("X-Content-Type-Options"); nosniff != "nosniff" {
+		t.Errorf("Expected X-Content-Type-Options 'nosniff', got %q", nosniff)
+	}
+
+	expected := `typeof myCallback === 'function' && myCallback({"message":"hello"});`
+	if body := w.Body.String(); body != expected {
+		t.Errorf("Expected body %q, got %q", expected, body)
+	}
+}
+
 func TestResponseWriter_XML(t *testing.T) {
 	type TestData struct {
 		XMLName xml.Name `xml:"data"`
@@ -868,7 +1040,7 @@ func TestResponseWriter_ServeFileFS(t *testing.T) {
 func TestI18nPrinterFunc(t *testing.T) {
 	setupResponseWriterTests()
 
-	printer := i18n.GetI18nPrinter(language.English)
+	printer := defaultApp.GetI18nPrinter(language.English)
 	fn := i18nPrinterFunc(printer)
 
 	result := fn("Hello %s", "World")
@@ -940,6 +1112,135 @@ func TestResponseWriter_Hijack_Supported(t *testing.T) {
 	}
 }
 
+func TestResponseWriter_Hijack_TCPServer(t *testing.T) {
+	resetAppConfig()
+
+	mux := NewServeMux()
+	mux.HandleFunc("GET /upgrade", func(w ResponseWriter, _ *Request) {
+		var hw http.ResponseWriter = &w
+
+		hj, ok := hw.(http.Hijacker)
+		if !ok {
+			t.Error("Expected ResponseWriter to implement http.Hijacker")
+			return
+		}
+
+		conn, buf, err := hj.Hijack()
+		if err != nil {
+			t.Errorf("Hijack() returned error: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		_, _ = buf.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 8\r\nConnection: close\r\n\r\nhijacked")
+		_ = buf.Flush()
+	})
+	registerHandlers(mux)
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/upgrade")
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read body: %v", err)
+	}
+
+	if string(body) != "hijacked" {
+		t.Errorf("Expected body 'hijacked', got %q", body)
+	}
+}
+
+func TestResponseWriter_Flush_TCPServer(t *testing.T) {
+	resetAppConfig()
+
+	mux := NewServeMux()
+	mux.HandleFunc("GET /stream", func(w ResponseWriter, _ *Request) {
+		var hw http.ResponseWriter = &w
+
+		if _, ok := hw.(http.Flusher); !ok {
+			t.Error("Expected ResponseWriter to implement http.Flusher")
+		}
+		if _, ok := hw.(http.Pusher); !ok {
+			t.Error("Expected ResponseWriter to implement http.Pusher")
+		}
+
+		_, _ = w.Write([]byte("chunk"))
+		w.Flush()
+	})
+	registerHandlers(mux)
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/stream")
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if len(resp.TransferEncoding) == 0 || resp.TransferEncoding[0] != "chunked" {
+		t.Errorf("Expected chunked response after Flush, got %v", resp.TransferEncoding)
+	}
+}
+
+func TestResponseWriter_HandlerValueImplementsOptionalInterfaces(t *testing.T) {
+	resetAppConfig()
+
+	mux := NewServeMux()
+	mux.HandleFunc("GET /value", func(w ResponseWriter, _ *Request) {
+		// Assert the value the handler receives, as middlewares and libraries given an any do.
+		var v any = w
+
+		if _, ok := v.(http.Flusher); !ok {
+			t.Error("Expected the ResponseWriter value to implement http.Flusher")
+		}
+		if _, ok := v.(http.Pusher); !ok {
+			t.Error("Expected the ResponseWriter value to implement http.Pusher")
+		}
+
+		hj, ok := v.(http.Hijacker)
+		if !ok {
+			t.Error("Expected the ResponseWriter value to implement http.Hijacker")
+			return
+		}
+
+		conn, buf, err := hj.Hijack()
+		if err != nil {
+			t.Errorf("Hijack() returned error: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		_, _ = buf.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 5\r\nConnection: close\r\n\r\nvalue")
+		_ = buf.Flush()
+	})
+	registerHandlers(mux)
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/value")
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read body: %v", err)
+	}
+
+	if string(body) != "value" {
+		t.Errorf("Expected body 'value', got %q", body)
+	}
+}
+
 func TestResponseWriter_Push_Supported(t *testing.T) {
 	w := httptest.NewRecorder()
 	pusher := &mockPusher{ResponseRecorder: w}
@@ -1165,6 +1466,121 @@ func TestResponseWriter_XMLArray_Validation(t *testing.T) {
 	}
 }
 
+func TestResponseWriter_XMLStream(t *testing.T) {
+	type Item struct {
+		XMLName xml.Name `xml:"item"`
+		ID      int      `xml:"id,attr"`
+	}
+
+	w := httptest.NewRecorder()
+	rw := ResponseWriter{ResponseWriter: w}
+
+	err := rw.XMLStream("items", func(yield func(any) bool) {
+		for i := 1; i <= 3; i++ {
+			if !yield(Item{ID: i}) {
+				return
+			}
+		}
+	})
+	if err != nil {
+		t.Fatalf("XMLStream() returned error: %v", err)
+	}
+
+	expected := xml.Header + `<items><item id="1"></item><item id="2"></item><item id="3"></item></items>`
+	if body := w.Body.String(); body != expected {
+		t.Errorf("Expected body %q, got %q", expected, body)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/xml" {
+		t.Errorf("Expected Content-Type 'application/xml', got %q", ct)
+	}
+	if !w.Flushed {
+		t.Error("Expected response to be flushed")
+	}
+}
+
+func TestResponseWriter_XMLStream_EncodeError(t *testing.T) {
+	w := httptest.NewRecorder()
+	rw := ResponseWriter{ResponseWriter: w}
+
+	yielded := 0
+	err := rw.XMLStream("items", func(yield func(any) bool) {
+		for _, item := range []any{"ok", make(chan int), "never"} {
+			yielded++
+			if !yield(item) {
+				return
+			}
+		}
+	})
+
+	if err == nil {
+		t.Fatal("Expected error for unsupported item type")
+	}
+	if yielded != 2 {
+		t.Errorf("Expected iteration to stop at the failing item, yielded %d items", yielded)
+	}
+}
+
+func TestResponseWriter_Blob(t *testing.T) {
+	w, rec := NewTestResponseWriter()
+	data := []byte(`{"name":"cached"}`)
+
+	if err := w.Blob("application/json", data); err != nil {
+		t.Fatalf("Blob failed: %v", err)
+	}
+
+	if rec.Body.String() != string(data) {
+		t.Errorf("Expected body %q, got %q", data, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected Content-Type application/json, got %q", ct)
+	}
+	if cl := rec.Header().Get("Content-Length"); cl != "17" {
+		t.Errorf("Expected Content-Length 17, got %q", cl)
+	}
+	if status, ok := w.StatusCode(); !ok || status != http.StatusOK {
+		t.Errorf("Expected tracked status 200, got %d", status)
+	}
+}
+
+func TestResponseWriter_Stream(t *testing.T) {
+	w, rec := NewTestResponseWriter()
+	w.Header().Set("Content-Length", "999")
+
+	if err := w.Stream("application/json", iotest.OneByteReader(strings.NewReader(`[1,2,3]`))); err != nil {
+		t.Fatalf("Stream failed: %v", err)
+	}
+
+	if rec.Body.String() != `[1,2,3]` {
+		t.Errorf("Expected body [1,2,3], got %q", rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected Content-Type application/json, got %q", ct)
+	}
+	if rec.Header().Get("Content-Length") != "" {
+		t.Error("Expected Content-Length header to be removed")
+	}
+	if !rec.Flushed {
+		t.Error("Expected response to be flushed")
+	}
+	if status, ok := w.StatusCode(); !ok || status != http.StatusOK {
+		t.Errorf("Expected tracked status 200, got %d", status)
+	}
+}
+
+func TestResponseWriter_Stream_ReadError(t *testing.T) {
+	w, rec := NewTestResponseWriter()
+	errRead := errors.New("read failed")
+
+	err := w.Stream("text/plain", io.MultiReader(strings.NewReader("partial"), iotest.ErrReader(errRead)))
+
+	if !errors.Is(err, errRead) {
+		t.Errorf("Expected read error, got %v", err)
+	}
+	if rec.Body.String() != "partial" {
+		t.Errorf("Expected data read before the error to be written, got %q", rec.Body.String())
+	}
+}
+
 func BenchmarkResponseWriter_XML(b *testing.B) {
 	type Data struct {
 		XMLName xml.Name `xml:"data"`
@@ -1214,6 +1630,50 @@ func BenchmarkResponseWriter_Bytes(b *testing.B) {
 	}
 }
 
+func TestResponseWriter_HTMLWithLayout(t *testing.T) {
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+
+	Configure(&Config{
+		Assets: &Assets{
+			FS: fstest.MapFS{
+				"templates/layout.go.html": {Data: []byte(`<public>{{template "content" .}}</public>`)},
+				"templates/admin.go.html":  {Data: []byte(`<admin>{{template "content" .}}</admin>`)},
+				"templates/page.go.html":   {Data: []byte(`{{define "content"}}{{.}}{{end}}`)},
+			},
+			Templates: &Templates{Dir: "templates", Layouts: []string{"admin"}},
+		},
+	})
+
+	tests := []struct {
+		layout    string
+		expected  string
+		wantError bool
+	}{
+		{"", "<public>hello</public>", false},
+		{"admin", "<admin>hello</admin>", false},
+		{"unknown", "", true},
+	}
+
+	for _, tt := range tests {
+		w, rec := NewTestResponseWriter()
+		err := w.HTMLWithLayout(context.Background(), tt.layout, "page", "hello")
+
+		if tt.wantError {
+			if err == nil {
+				t.Errorf("Expected error for layout %q", tt.layout)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("HTMLWithLayout(%q) failed: %v", tt.layout, err)
+		}
+		if rec.Body.String() != tt.expected {
+			t.Errorf("Expected %q for layout %q, got %q", tt.expected, tt.layout, rec.Body.String())
+		}
+	}
+}
+
 func TestResponseWriter_HTML(t *testing.T) {
 	setupResponseWriterTests()
 
@@ -1267,7 +1727,7 @@ func TestResponseWriter_HTML_WithI18n(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	// Create context with i18n printer
-	printer := i18n.GetI18nPrinter(language.English)
+	printer := defaultApp.GetI18nPrinter(language.English)
 	ctx := i18n.ContextWithI18nPrinter(context.Background(), printer)
 
 	rw := ResponseWriter{
@@ -1283,3 +1743,193 @@ func TestResponseWriter_HTML_WithI18n(t *testing.T) {
 		t.Errorf("Expected Content-Type 'text/html', got %q", ct)
 	}
 }
+
+func TestResponseWriter_RetryAfter(t *testing.T) {
+	tests := []struct {
+		delay    time.Duration
+		expected string
+	}{
+		{30 * time.Second, "30"},
+		{1500 * time.Millisecond, "2"},
+		{0, "1"},
+	}
+
+	for _, tt := range tests {
+		w, rec := NewTestResponseWriter()
+		w.RetryAfter(tt.delay)
+
+		if got := rec.Header().Get("Retry-After"); got != tt.expected {
+			t.Errorf("RetryAfter(%v) = %q, want %q", tt.delay, got, tt.expected)
+		}
+	}
+}
+
+func TestResponseWriter_NoStore(t *testing.T) {
+	w, rec := NewTestResponseWriter()
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	w.NoStore()
+
+	if got := rec.Header().Values("Cache-Control"); len(got) != 1 || got[0] != "no-store, no-cache" {
+		t.Errorf("Expected Cache-Control: no-store, no-cache, got %q", got)
+	}
+	if got := rec.Header().Get("Pragma"); got != "no-cache" {
+		t.Errorf("Expected Pragma: no-cache, got %q", got)
+	}
+}
+
+func TestResponseWriter_LinkPagination(t *testing.T) {
+	link := func(page int, rel string) string {
+		return fmt.Sprintf(`</users?page=%d&pageSize=20&sort=name>; rel="%s"`, page, rel)
+	}
+
+	tests := []struct {
+		name     string
+		page     int
+		total    int
+		expected []string
+	}{
+		{"middle page", 2, 95, []string{link(1, "first"), link(1, "prev"), link(3, "next"), link(5, "last")}},
+		{"first page", 1, 95, []string{link(1, "first"), link(2, "next"), link(5, "last")}},
+		{"last page", 5, 95, []string{link(1, "first"), link(4, "prev"), link(5, "last")}},
+		{"no items", 1, 0, []string{link(1, "first"), link(1, "last")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewTestRequest(http.MethodGet, "/users?sort=name&page=9&pageSize=20", nil)
+			w, rec := NewTestResponseWriter()
+
+			w.LinkPagination(r, tt.page, 20, tt.total)
+
+			if got := rec.Header().Get("Link"); got != strings.Join(tt.expected, ", ") {
+				t.Errorf("Unexpected Link header:\n got: %s\nwant: %s", got, strings.Join(tt.expected, ", "))
+			}
+		})
+	}
+}
+
+func TestResponseWriter_LinkPagination_InvalidPageSize(t *testing.T) {
+	w, rec := NewTestResponseWriter()
+	w.LinkPagination(NewTestRequest(http.MethodGet, "/users", nil), 1, 0, 10)
+
+	if got := rec.Header().Get("Link"); got != "" {
+		t.Errorf("Expected no Link header, got %q", got)
+	}
+}
+
+func TestResponseWriter_BindError(t *testing.T) {
+	resetAppConfig()
+	Configure(nil)
+
+	type payload struct {
+		Name string `json:"name" xml:"name"`
+	}
+
+	bindJSON := func(body string, limit int64) error {
+		r := NewTestRequest(http.MethodPost, "/", strings.NewReader(body))
+		if limit > 0 {
+			r.Body = http.MaxBytesReader(nil, r.Body, limit)
+		}
+		_, _, err := BindJSON[payload](r, false)
+		return err
+	}
+
+	patchWithContentType := func() error {
+		r := NewTestRequest(http.MethodPatch, "/", strings.NewReader(`[]`))
+		r.Header.Set("Content-Type", "application/json")
+		var p payload
+		_, err := PatchJSON(r, &p, false)
+		return err
+	}
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedError  string
+	}{
+		{"empty body", bindJSON("", 0), http.StatusBadRequest, "request body is empty"},
+		{"malformed JSON", bindJSON(`{"name":`, 0), http.StatusBadRequest, "malformed request body"},
+		{"unknown field", bindJSON(`{"age":1}`, 0), http.StatusBadRequest, "malformed request body"},
+		{"too large", bindJSON(`{"name":"`+strings.Repeat("x", 100)+`"}`, 16), http.StatusRequestEntityTooLarge, "request body too large"},
+		{"upload too large", ErrUploadTooLarge, http.StatusRequestEntityTooLarge, "request body too large"},
+		{"unsupported media type", patchWithContentType(), http.StatusUnsupportedMediaType, "unsupported media type"},
+		{"method not allowed", ErrMethodNotAllowed, http.StatusMethodNotAllowed, "method not allowed"},
+		{"deadline exceeded", context.DeadlineExceeded, http.StatusRequestTimeout, "timed out"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, rec := NewTestResponseWriter()
+			w.BindError(tt.err)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d (err: %v)", tt.expectedStatus, rec.Code, tt.err)
+			}
+
+			if rec.Header().Get("Content-Type") != "application/json" {
+				t.Errorf("Expected application/json, got %q", rec.Header().Get("Content-Type"))
+			}
+
+			var body map[string]string
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Expected JSON body, got %q", rec.Body.String())
+			}
+
+			if !strings.Contains(body["error"], tt.expectedError) {
+				t.Errorf("Expected error containing %q, got %q", tt.expectedError, body["error"])
+			}
+		})
+	}
+}
+
+func TestResponseWriter_BindError_Nil(t *testing.T) {
+	w, rec := NewTestResponseWriter()
+	w.BindError(nil)
+
+	if _, written := w.StatusCode(); written || rec.Body.Len() != 0 {
+		t.Errorf("Expected nothing to be written for a nil error, got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+// recordFlusher records the number of JSON sequence records written at every flush.
+type recordFlusher struct {
+	*httptest.ResponseRecorder
+
+	flushes []int
+}
+
+func (f *recordFlusher) Flush() {
+	f.flushes = append(f.flushes, strings.Count(f.Body.String(), string(jsonSeqRecordSeparator)))
+}
+
+func TestResponseWriter_JSONSeqWithOptions_FlushEvery(t *testing.T) {
+	tests := []struct {
+		name       string
+		flushEvery int
+		expected   []int
+	}{
+		{"default flushes every record", 0, []int{1, 2, 3, 4, 5}},
+		{"batches", 2, []int{2, 4, 5}},
+		{"negative flushes at the end", -1, []int{5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flusher := &recordFlusher{ResponseRecorder: httptest.NewRecorder()}
+			rw := ResponseWriter{ResponseWriter: flusher}
+
+			err := rw.JSONSeqWithOptions(context.Background(), []int{1, 2, 3, 4, 5}, JSONSeqOptions{FlushEvery: tt.flushEvery})
+			if err != nil {
+				t.Fatalf("JSONSeqWithOptions() returned error: %v", err)
+			}
+
+			if fmt.Sprint(flusher.flushes) != fmt.Sprint(tt.expected) {
+				t.Errorf("Expected flushes after records %v, got %v", tt.expected, flusher.flushes)
+			}
+			if ct := flusher.Header().Get("Content-Type"); ct != "application/json-seq" {
+				t.Errorf("Expected Content-Type 'application/json-seq', got %q", ct)
+			}
+		})
+	}
+}
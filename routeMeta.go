@@ -0,0 +1,35 @@
+package webfram
+
+import "context"
+
+// Meta sets the metadata value of key for this handler, e.g. Meta("audience", "public"). Middlewares read the
+// metadata of the route serving the request with RouteMetaFromContext, so that a cross-cutting feature can
+// behave differently for routes tagged a certain way, such as a stricter rate limit for public routes.
+// Setting a key again replaces its value.
+func (h *HandlerConfig) Meta(key, value string) *HandlerConfig {
+	if h.meta == nil {
+		h.meta = make(map[string]string)
+	}
+
+	h.meta[key] = value
+	return h
+}
+
+// RouteMetaFromContext returns the metadata of the route serving the request, set with HandlerConfig.Meta.
+// It is available to all the middlewares of the route, including the App and ServeMux ones, and to the
+// handler. The returned map must not be modified. Returns false if the route has no metadata.
+//
+// Example:
+//
+//	func auditInternal(next app.Handler) app.Handler {
+//		return app.HandlerFunc(func(w app.ResponseWriter, r *app.Request) {
+//			if meta, _ := app.RouteMetaFromContext(r.Context()); meta["audience"] == "internal" {
+//				audit(r)
+//			}
+//			next.ServeHTTP(w, r)
+//		})
+//	}
+func RouteMetaFromContext(ctx context.Context) (map[string]string, bool) {
+	meta, ok := ctx.Value(routeMetaKey).(map[string]string)
+	return meta, ok
+}
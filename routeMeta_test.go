@@ -0,0 +1,60 @@
+package webfram
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandlerConfig_Meta(t *testing.T) {
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+	Configure(nil)
+
+	mux := NewServeMux()
+	// A mux middleware behaving differently for public routes, as a rate limiter would.
+	mux.Use(func(next Handler) Handler {
+		return HandlerFunc(func(w ResponseWriter, r *Request) {
+			meta, ok := RouteMetaFromContext(r.Context())
+			if !ok {
+				w.Header().Set("X-Limit", "none")
+			} else if meta["audience"] == "public" {
+				w.Header().Set("X-Limit", "strict")
+			} else {
+				w.Header().Set("X-Limit", "relaxed")
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+
+	handler := func(w ResponseWriter, r *Request) {
+		meta, _ := RouteMetaFromContext(r.Context())
+		_, _ = w.Write([]byte(meta["owner"]))
+	}
+	mux.HandleFunc("GET /public", handler).Meta("audience", "public").Meta("owner", "web")
+	mux.HandleFunc("GET /internal", handler).Meta("audience", "public").Meta("audience", "internal")
+	mux.HandleFunc("GET /plain", handler)
+	registerHandlers(mux)
+
+	tests := []struct {
+		path  string
+		limit string
+		body  string
+	}{
+		{"/public", "strict", "web"},
+		{"/internal", "relaxed", ""},
+		{"/plain", "none", ""},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+
+		if got := rec.Header().Get("X-Limit"); got != tt.limit {
+			t.Errorf("%s: expected X-Limit %q, got %q", tt.path, tt.limit, got)
+		}
+		if rec.Body.String() != tt.body {
+			t.Errorf("%s: expected body %q, got %q", tt.path, tt.body, rec.Body.String())
+		}
+	}
+}
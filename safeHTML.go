@@ -0,0 +1,30 @@
+package webfram
+
+import (
+	"fmt"
+	htmlTemplate "html/template"
+)
+
+// safeFuncName is the name of the template function marking a value as trusted HTML.
+const safeFuncName = "safe"
+
+// SafeHTML marks s as trusted HTML, which HTML templates render as is instead of escaping it, e.g. for
+// rich text that was already sanitized:
+//
+//	w.HTML(ctx, "article", map[string]any{"Content": app.SafeHTML(sanitized)})
+//
+// Templates can do the same with the safe function: {{safe .Content}}.
+// s must come from a trusted source or have been sanitized with an HTML sanitizer allowing only safe
+// elements and attributes: user input rendered with SafeHTML is a cross-site scripting (XSS) vulnerability.
+func SafeHTML(s string) htmlTemplate.HTML {
+	return htmlTemplate.HTML(s) //nolint:gosec // trusted by the caller, as documented
+}
+
+// safeHTMLFunc is the safe template function, accepting strings and other values, which are formatted
+// as by fmt.Sprint. A nil value is rendered as an empty string.
+func safeHTMLFunc(value any) htmlTemplate.HTML {
+	if value == nil {
+		return ""
+	}
+	return SafeHTML(fmt.Sprint(value))
+}
@@ -0,0 +1,49 @@
+package webfram
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+)
+
+func TestSafeHTML_Templates(t *testing.T) {
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+
+	Configure(&Config{
+		Assets: &Assets{
+			FS: fstest.MapFS{
+				"templates/layout.go.html":  {Data: []byte(`{{template "content" .}}`)},
+				"templates/escaped.go.html": {Data: []byte(`{{define "content"}}{{.Content}}{{end}}`)},
+				"templates/safe.go.html":    {Data: []byte(`{{define "content"}}{{safe .Content}}{{end}}`)},
+			},
+			Templates: &Templates{Dir: "templates"},
+		},
+	})
+
+	const content = `<p>Hello <em>world</em></p>`
+
+	tests := []struct {
+		name     string
+		template string
+		data     map[string]any
+		expected string
+	}{
+		{"escaped by default", "escaped", map[string]any{"Content": content}, "&lt;p&gt;Hello &lt;em&gt;world&lt;/em&gt;&lt;/p&gt;"},
+		{"SafeHTML value", "escaped", map[string]any{"Content": SafeHTML(content)}, content},
+		{"safe function", "safe", map[string]any{"Content": content}, content},
+		{"safe function with nil", "safe", map[string]any{"Content": nil}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, rec := NewTestResponseWriter()
+			if err := w.HTML(context.Background(), tt.template, tt.data); err != nil {
+				t.Fatalf("HTML failed: %v", err)
+			}
+			if rec.Body.String() != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package webfram
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const defaultHSTSMaxAge = 365 * 24 * time.Hour
+
+// SecureTransportOptions configures the SecureTransport middleware.
+type SecureTransportOptions struct {
+	// TrustProxy makes the middleware honor the X-Forwarded-Proto and X-Forwarded-Host headers set by a
+	// TLS-terminating proxy. Only enable it when the app is exclusively reachable through such a proxy,
+	// otherwise clients can spoof these headers.
+	TrustProxy bool
+	// DisableRedirect serves plain HTTP requests instead of redirecting them to HTTPS.
+	DisableRedirect bool
+	// RedirectStatus is the status code of the redirect to HTTPS.
+	// Defaults to 308 Permanent Redirect, which preserves the request method and body.
+	RedirectStatus int
+	// HSTSMaxAge is the max-age of the Strict-Transport-Security header. Defaults to one year.
+	// A negative value disables the header.
+	HSTSMaxAge time.Duration
+	// HSTSIncludeSubdomains adds the includeSubDomains directive to the Strict-Transport-Security header.
+	HSTSIncludeSubdomains bool
+	// HSTSPreload adds the preload directive to the Strict-Transport-Security header.
+	HSTSPreload bool
+	// FrameOptions is the value of the X-Frame-Options header (e.g., "DENY" or "SAMEORIGIN").
+	// The header is not set if empty.
+	FrameOptions string
+	// ContentTypeNosniff sets the "X-Content-Type-Options: nosniff" header.
+	ContentTypeNosniff bool
+}
+
+// SecureTransport creates middleware that enforces HTTPS. Plain HTTP requests are redirected to the
+// same URL with the https scheme, and HTTPS responses get a Strict-Transport-Security header so browsers
+// keep using HTTPS. A request is considered secure if it was received over TLS or, when TrustProxy is set,
+// if the X-Forwarded-Proto header is "https". X-Frame-Options and X-Content-Type-Options are optionally
+// set on every response, including redirects.
+func SecureTransport(opts SecureTransportOptions) AppMiddleware {
+	opts.RedirectStatus = getValueOrDefault(opts.RedirectStatus, http.StatusPermanentRedirect)
+	opts.HSTSMaxAge = getValueOrDefault(opts.HSTSMaxAge, defaultHSTSMaxAge)
+	hsts := hstsHeaderValue(opts)
+
+	return func(next Handler) Handler {
+		return HandlerFunc(func(w ResponseWriter, r *Request) {
+			if opts.FrameOptions != "" {
+				w.Header().Set("X-Frame-Options", opts.FrameOptions)
+			}
+			if opts.ContentTypeNosniff {
+				w.Header().Set("X-Content-Type-Options", "nosniff")
+			}
+
+			if !isSecureRequest(r, opts.TrustProxy) {
+				if !opts.DisableRedirect {
+					w.Redirect(r, httpsURL(r, opts.TrustProxy), opts.RedirectStatus)
+					return
+				}
+			} else if hsts != "" {
+				w.Header().Set("Strict-Transport-Security", hsts)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func hstsHeaderValue(opts SecureTransportOptions) string {
+	if opts.HSTSMaxAge < 0 {
+		return ""
+	}
+
+	value := "max-age=" + strconv.FormatInt(int64(opts.HSTSMaxAge/time.Second), 10)
+	if opts.HSTSIncludeSubdomains {
+		value += "; includeSubDomains"
+	}
+	if opts.HSTSPreload {
+		value += "; preload"
+	}
+
+	return value
+}
+
+func isSecureRequest(r *Request, trustProxy bool) bool {
+	if trustProxy {
+		if proto := forwardedHeaderValue(r, "X-Forwarded-Proto"); proto != "" {
+			return proto == "https"
+		}
+	}
+
+	return r.TLS != nil
+}
+
+// httpsURL returns the URL of the request with the https scheme.
+func httpsURL(r *Request, trustProxy bool) string {
+	host := r.Host
+	if trustProxy {
+		if forwardedHost := forwardedHeaderValue(r, "X-Forwarded-Host"); forwardedHost != "" {
+			host = forwardedHost
+		}
+	}
+
+	return (&url.URL{
+		Scheme:   "https",
+		Host:     host,
+		Path:     r.URL.Path,
+		RawPath:  r.URL.RawPath,
+		RawQuery: r.URL.RawQuery,
+	}).String()
+}
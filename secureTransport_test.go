@@ -0,0 +1,111 @@
+package webfram
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func serveSecureTransport(opts SecureTransportOptions, r *Request) *httptest.ResponseRecorder {
+	handler := SecureTransport(opts)(HandlerFunc(func(w ResponseWriter, _ *Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	w, rec := NewTestResponseWriter()
+	handler.ServeHTTP(w, r)
+	return rec
+}
+
+func TestSecureTransport_RedirectsHTTP(t *testing.T) {
+	r := NewTestRequest(http.MethodPost, "http://example.com/users?page=2", nil)
+
+	rec := serveSecureTransport(SecureTransportOptions{}, r)
+
+	if rec.Code != http.StatusPermanentRedirect {
+		t.Errorf("Expected status 308, got %d", rec.Code)
+	}
+	if location := rec.Header().Get("Location"); location != "https://example.com/users?page=2" {
+		t.Errorf("Expected redirect to HTTPS URL, got %q", location)
+	}
+	if rec.Header().Get("Strict-Transport-Security") != "" {
+		t.Error("Expected no HSTS header on plain HTTP response")
+	}
+}
+
+func TestSecureTransport_TLS(t *testing.T) {
+	r := NewTestRequest(http.MethodGet, "https://example.com/", nil)
+	r.TLS = &tls.ConnectionState{}
+
+	rec := serveSecureTransport(SecureTransportOptions{
+		HSTSIncludeSubdomains: true,
+		HSTSPreload:           true,
+		FrameOptions:          "DENY",
+		ContentTypeNosniff:    true,
+	}, r)
+
+	if rec.Code != http.StatusOK || rec.Body.String() != "ok" {
+		t.Fatalf("Expected request to be served, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	expected := map[string]string{
+		"Strict-Transport-Security": "max-age=31536000; includeSubDomains; preload",
+		"X-Frame-Options":           "DENY",
+		"X-Content-Type-Options":    "nosniff",
+	}
+	for name, value := range expected {
+		if got := rec.Header().Get(name); got != value {
+			t.Errorf("Expected %s %q, got %q", name, value, got)
+		}
+	}
+}
+
+func TestSecureTransport_TrustProxy(t *testing.T) {
+	tests := []struct {
+		name       string
+		trustProxy bool
+		proto      string
+		expected   int
+		location   string
+	}{
+		{"forwarded https", true, "https", http.StatusOK, ""},
+		{"forwarded http", true, "http", http.StatusPermanentRedirect, "https://api.example.com/"},
+		{"untrusted forwarded https", false, "https", http.StatusPermanentRedirect, "https://internal/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewTestRequest(http.MethodGet, "http://internal/", nil)
+			r.Header.Set("X-Forwarded-Proto", tt.proto)
+			r.Header.Set("X-Forwarded-Host", "api.example.com")
+
+			rec := serveSecureTransport(SecureTransportOptions{TrustProxy: tt.trustProxy}, r)
+
+			if rec.Code != tt.expected {
+				t.Errorf("Expected status %d, got %d", tt.expected, rec.Code)
+			}
+			if location := rec.Header().Get("Location"); location != tt.location {
+				t.Errorf("Expected Location %q, got %q", tt.location, location)
+			}
+		})
+	}
+}
+
+func TestSecureTransport_Options(t *testing.T) {
+	r := NewTestRequest(http.MethodGet, "http://example.com/", nil)
+	rec := serveSecureTransport(SecureTransportOptions{DisableRedirect: true, FrameOptions: "SAMEORIGIN"}, r)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected request to be served with redirect disabled, got %d", rec.Code)
+	}
+	if rec.Header().Get("X-Frame-Options") != "SAMEORIGIN" {
+		t.Errorf("Expected X-Frame-Options on plain HTTP response, got %q", rec.Header().Get("X-Frame-Options"))
+	}
+
+	r = NewTestRequest(http.MethodGet, "https://example.com/", nil)
+	r.TLS = &tls.ConnectionState{}
+	rec = serveSecureTransport(SecureTransportOptions{HSTSMaxAge: -1}, r)
+
+	if rec.Header().Get("Strict-Transport-Security") != "" {
+		t.Errorf("Expected HSTS header to be disabled, got %q", rec.Header().Get("Strict-Transport-Security"))
+	}
+}
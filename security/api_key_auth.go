@@ -44,12 +44,12 @@ func APIKeyAuth(config APIKeyAuthConfig) func(http.Handler) http.Handler {
 					found = true
 				}
 			default:
-				unauthorizedAPIKey(w, config.UnauthorizedHandler)
+				unauthorizedAPIKey(w, r, config.UnauthorizedHandler)
 				return
 			}
 
 			if !found || !config.KeyValidator(key) {
-				unauthorizedAPIKey(w, config.UnauthorizedHandler)
+				unauthorizedAPIKey(w, r, config.UnauthorizedHandler)
 				return
 			}
 
@@ -58,12 +58,6 @@ func APIKeyAuth(config APIKeyAuthConfig) func(http.Handler) http.Handler {
 	}
 }
 
-func unauthorizedAPIKey(w http.ResponseWriter, handler http.Handler) {
-	if handler != nil {
-		handler.ServeHTTP(w, nil)
-		return
-	}
-
-	w.WriteHeader(http.StatusUnauthorized)
-	_, _ = w.Write([]byte("Unauthorized"))
+func unauthorizedAPIKey(w http.ResponseWriter, r *http.Request, handler http.Handler) {
+	writeUnauthorized(w, r, "", handler)
 }
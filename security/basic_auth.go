@@ -26,31 +26,31 @@ func BasicAuth(config BasicAuthConfig) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			auth := r.Header.Get("Authorization")
 			if auth == "" {
-				unauthorized(w, config.Realm, config.UnauthorizedHandler)
+				unauthorized(w, r, config.Realm, config.UnauthorizedHandler)
 				return
 			}
 
 			if !strings.HasPrefix(auth, "Basic ") {
-				unauthorized(w, config.Realm, config.UnauthorizedHandler)
+				unauthorized(w, r, config.Realm, config.UnauthorizedHandler)
 				return
 			}
 
 			encoded := strings.TrimPrefix(auth, "Basic ")
 			decoded, err := base64.StdEncoding.DecodeString(encoded)
 			if err != nil {
-				unauthorized(w, config.Realm, config.UnauthorizedHandler)
+				unauthorized(w, r, config.Realm, config.UnauthorizedHandler)
 				return
 			}
 
 			parts := strings.SplitN(string(decoded), ":", 2)
 			if len(parts) != 2 {
-				unauthorized(w, config.Realm, config.UnauthorizedHandler)
+				unauthorized(w, r, config.Realm, config.UnauthorizedHandler)
 				return
 			}
 
 			username, password := parts[0], parts[1]
 			if !config.Authenticator(username, password) {
-				unauthorized(w, config.Realm, config.UnauthorizedHandler)
+				unauthorized(w, r, config.Realm, config.UnauthorizedHandler)
 				return
 			}
 
@@ -59,13 +59,6 @@ func BasicAuth(config BasicAuthConfig) func(http.Handler) http.Handler {
 	}
 }
 
-func unauthorized(w http.ResponseWriter, realm string, handler http.Handler) {
-	if handler != nil {
-		handler.ServeHTTP(w, nil) // TODO: pass request?
-		return
-	}
-
-	w.Header().Set("WWW-Authenticate", `Basic realm="`+realm+`"`)
-	w.WriteHeader(http.StatusUnauthorized)
-	_, _ = w.Write([]byte("Unauthorized"))
+func unauthorized(w http.ResponseWriter, r *http.Request, realm string, handler http.Handler) {
+	writeUnauthorized(w, r, `Basic realm="`+realm+`"`, handler)
 }
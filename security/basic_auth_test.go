@@ -109,3 +109,48 @@ func TestOAuth2TokenAuth_Success(t *testing.T) {
 		t.Errorf("Expected status 200, got %d", w.Code)
 	}
 }
+
+func TestBasicAuth_Challenge(t *testing.T) {
+	middleware := BasicAuth(BasicAuthConfig{
+		Authenticator: func(_ string, _ string) bool { return false },
+		Realm:         "Test",
+	})
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if got := w.Header().Get("WWW-Authenticate"); got != `Basic realm="Test"` {
+		t.Errorf(`Expected WWW-Authenticate 'Basic realm="Test"', got %q`, got)
+	}
+}
+
+func TestBasicAuth_CustomUnauthorizedHandler(t *testing.T) {
+	middleware := BasicAuth(BasicAuthConfig{
+		Authenticator: func(_ string, _ string) bool { return false },
+		Realm:         "Test",
+		UnauthorizedHandler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r == nil {
+				t.Error("Expected request to be passed to UnauthorizedHandler")
+				return
+			}
+			w.Header().Set("WWW-Authenticate", `Basic realm="`+r.URL.Path+`"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		}),
+	})
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin", nil))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401, got %d", w.Code)
+	}
+	if got := w.Header().Get("WWW-Authenticate"); got != `Basic realm="/admin"` {
+		t.Errorf(`Expected overridden challenge 'Basic realm="/admin"', got %q`, got)
+	}
+}
@@ -9,6 +9,8 @@ import (
 type BearerAuthConfig struct {
 	// TokenValidator is called with the bearer token, should return true if valid
 	TokenValidator func(token string) bool
+	// Realm is the authentication realm sent in the WWW-Authenticate challenge (optional)
+	Realm string
 	// UnauthorizedHandler is called when authentication fails (optional)
 	UnauthorizedHandler http.Handler
 }
@@ -19,18 +21,18 @@ func BearerAuth(config BearerAuthConfig) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			auth := r.Header.Get("Authorization")
 			if auth == "" {
-				unauthorizedBearer(w, config.UnauthorizedHandler)
+				unauthorizedBearer(w, r, config.Realm, config.UnauthorizedHandler)
 				return
 			}
 
 			if !strings.HasPrefix(auth, "Bearer ") {
-				unauthorizedBearer(w, config.UnauthorizedHandler)
+				unauthorizedBearer(w, r, config.Realm, config.UnauthorizedHandler)
 				return
 			}
 
 			token := strings.TrimPrefix(auth, "Bearer ")
 			if !config.TokenValidator(token) {
-				unauthorizedBearer(w, config.UnauthorizedHandler)
+				unauthorizedBearer(w, r, config.Realm, config.UnauthorizedHandler)
 				return
 			}
 
@@ -39,12 +41,10 @@ func BearerAuth(config BearerAuthConfig) func(http.Handler) http.Handler {
 	}
 }
 
-func unauthorizedBearer(w http.ResponseWriter, handler http.Handler) {
-	if handler != nil {
-		handler.ServeHTTP(w, nil)
-		return
+func unauthorizedBearer(w http.ResponseWriter, r *http.Request, realm string, handler http.Handler) {
+	challenge := "Bearer"
+	if realm != "" {
+		challenge += ` realm="` + realm + `"`
 	}
-
-	w.WriteHeader(http.StatusUnauthorized)
-	_, _ = w.Write([]byte("Unauthorized"))
+	writeUnauthorized(w, r, challenge, handler)
 }
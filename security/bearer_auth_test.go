@@ -3,6 +3,7 @@ package security
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -103,3 +104,62 @@ func TestBearerAuth_WrongPrefix(t *testing.T) {
 		t.Errorf("Expected status 401, got %d", w.Code)
 	}
 }
+
+func TestBearerAuth_Challenge(t *testing.T) {
+	tests := []struct {
+		name  string
+		realm string
+		want  string
+	}{
+		{name: "without realm", want: "Bearer"},
+		{name: "with realm", realm: "api", want: `Bearer realm="api"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			middleware := BearerAuth(BearerAuthConfig{
+				TokenValidator: func(_ string) bool { return false },
+				Realm:          tt.realm,
+			})
+			handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if w.Code != http.StatusUnauthorized {
+				t.Errorf("Expected status 401, got %d", w.Code)
+			}
+			if got := w.Header().Get("WWW-Authenticate"); got != tt.want {
+				t.Errorf("Expected WWW-Authenticate %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBearerAuth_UnauthorizedJSON(t *testing.T) {
+	middleware := BearerAuth(BearerAuthConfig{
+		TokenValidator:      func(_ string) bool { return false },
+		UnauthorizedHandler: UnauthorizedJSON(),
+	})
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected Content-Type 'application/json', got %q", ct)
+	}
+	if body := strings.TrimSpace(w.Body.String()); body != `{"error":"unauthorized"}` {
+		t.Errorf("Unexpected body: %q", body)
+	}
+	if got := w.Header().Get("WWW-Authenticate"); got != "Bearer" {
+		t.Errorf("Expected WWW-Authenticate 'Bearer', got %q", got)
+	}
+}
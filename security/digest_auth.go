@@ -40,18 +40,18 @@ func DigestAuth(config DigestAuthConfig) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			auth := r.Header.Get("Authorization")
 			if auth == "" {
-				unauthorizedDigest(w, config.Realm, config.UnauthorizedHandler)
+				unauthorizedDigest(w, r, config.Realm, config.UnauthorizedHandler)
 				return
 			}
 
 			if !strings.HasPrefix(auth, "Digest ") {
-				unauthorizedDigest(w, config.Realm, config.UnauthorizedHandler)
+				unauthorizedDigest(w, r, config.Realm, config.UnauthorizedHandler)
 				return
 			}
 
 			params := parseDigestParams(strings.TrimPrefix(auth, "Digest "))
 			if !validateDigest(params, r.Method, r.URL.Path, config) {
-				unauthorizedDigest(w, config.Realm, config.UnauthorizedHandler)
+				unauthorizedDigest(w, r, config.Realm, config.UnauthorizedHandler)
 				return
 			}
 
@@ -137,19 +137,12 @@ func isValidNonce(nonce string, nonceTTL time.Duration) bool {
 	return true
 }
 
-func unauthorizedDigest(w http.ResponseWriter, realm string, handler http.Handler) {
-	if handler != nil {
-		handler.ServeHTTP(w, nil)
-		return
-	}
-
+func unauthorizedDigest(w http.ResponseWriter, r *http.Request, realm string, handler http.Handler) {
 	nonce := generateNonce()
 	nonceStore.Store(nonce, time.Now())
 
-	w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Digest realm="%s", nonce="%s", algorithm=MD5, qop="auth"`,
-		realm, nonce))
-	w.WriteHeader(http.StatusUnauthorized)
-	_, _ = w.Write([]byte("Unauthorized"))
+	writeUnauthorized(w, r, fmt.Sprintf(`Digest realm="%s", nonce="%s", algorithm=MD5, qop="auth"`, realm, nonce),
+		handler)
 }
 
 func generateNonce() string {
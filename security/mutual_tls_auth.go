@@ -18,13 +18,13 @@ func MutualTLSAuth(config MutualTLSAuthConfig) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
-				unauthorizedMutualTLS(w, config.UnauthorizedHandler)
+				unauthorizedMutualTLS(w, r, config.UnauthorizedHandler)
 				return
 			}
 
 			clientCert := r.TLS.PeerCertificates[0]
 			if !config.CertificateValidator(clientCert) {
-				unauthorizedMutualTLS(w, config.UnauthorizedHandler)
+				unauthorizedMutualTLS(w, r, config.UnauthorizedHandler)
 				return
 			}
 
@@ -33,12 +33,6 @@ func MutualTLSAuth(config MutualTLSAuthConfig) func(http.Handler) http.Handler {
 	}
 }
 
-func unauthorizedMutualTLS(w http.ResponseWriter, handler http.Handler) {
-	if handler != nil {
-		handler.ServeHTTP(w, nil)
-		return
-	}
-
-	w.WriteHeader(http.StatusUnauthorized)
-	_, _ = w.Write([]byte("Unauthorized"))
+func unauthorizedMutualTLS(w http.ResponseWriter, r *http.Request, handler http.Handler) {
+	writeUnauthorized(w, r, "", handler)
 }
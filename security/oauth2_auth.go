@@ -19,18 +19,18 @@ func OAuth2TokenAuth(config OAuth2TokenConfig) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			auth := r.Header.Get("Authorization")
 			if auth == "" {
-				unauthorizedOAuth2(w, config.UnauthorizedHandler)
+				unauthorizedOAuth2(w, r, config.UnauthorizedHandler)
 				return
 			}
 
 			if !strings.HasPrefix(auth, "Bearer ") {
-				unauthorizedOAuth2(w, config.UnauthorizedHandler)
+				unauthorizedOAuth2(w, r, config.UnauthorizedHandler)
 				return
 			}
 
 			token := strings.TrimPrefix(auth, "Bearer ")
 			if !config.TokenValidator(token) {
-				unauthorizedOAuth2(w, config.UnauthorizedHandler)
+				unauthorizedOAuth2(w, r, config.UnauthorizedHandler)
 				return
 			}
 
@@ -173,7 +173,7 @@ func handleAuthorizationCodeCallback(
 	// Exchange code for token
 	token, err := exchangeCodeForToken(config, code, state)
 	if err != nil {
-		unauthorizedOAuth2(w, config.UnauthorizedHandler)
+		unauthorizedOAuth2(w, r, config.UnauthorizedHandler)
 		return
 	}
 
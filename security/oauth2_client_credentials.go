@@ -59,7 +59,7 @@ func OAuth2ClientCredentialsAuth(config OAuth2ClientCredentialsConfig) func(http
 			// Exchange client credentials for new token
 			token, err := exchangeClientCredentialsForToken(config)
 			if err != nil {
-				unauthorizedOAuth2(w, config.UnauthorizedHandler)
+				unauthorizedOAuth2(w, r, config.UnauthorizedHandler)
 				return
 			}
 
@@ -195,15 +195,8 @@ func generateState() string {
 	return base64.URLEncoding.EncodeToString(bytes)
 }
 
-func unauthorizedOAuth2(w http.ResponseWriter, handler http.Handler) {
-	if handler != nil {
-		handler.ServeHTTP(w, nil)
-		return
-	}
-
-	w.Header().Set("WWW-Authenticate", `Bearer`)
-	w.WriteHeader(http.StatusUnauthorized)
-	_, _ = w.Write([]byte("Unauthorized"))
+func unauthorizedOAuth2(w http.ResponseWriter, r *http.Request, handler http.Handler) {
+	writeUnauthorized(w, r, "Bearer", handler)
 }
 
 // RequireAllScopes returns middleware that requires ALL of the specified scopes.
@@ -35,7 +35,7 @@ func OAuth2DeviceAuth(config OAuth2DeviceConfig) func(http.Handler) http.Handler
 			}
 
 			// For regular requests, require valid token
-			unauthorizedOAuth2(w, config.UnauthorizedHandler)
+			unauthorizedOAuth2(w, r, config.UnauthorizedHandler)
 		})
 	}
 }
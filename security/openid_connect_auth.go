@@ -77,18 +77,18 @@ func OpenIDConnectAuth(config OpenIDConnectAuthConfig) func(http.Handler) http.H
 			// Otherwise, just validate existing Bearer tokens
 			auth := r.Header.Get("Authorization")
 			if auth == "" {
-				unauthorizedOIDC(w, config.UnauthorizedHandler)
+				unauthorizedOIDC(w, r, config.UnauthorizedHandler)
 				return
 			}
 
 			if !strings.HasPrefix(auth, "Bearer ") {
-				unauthorizedOIDC(w, config.UnauthorizedHandler)
+				unauthorizedOIDC(w, r, config.UnauthorizedHandler)
 				return
 			}
 
 			token := strings.TrimPrefix(auth, "Bearer ")
 			if !config.TokenValidator(token) {
-				unauthorizedOIDC(w, config.UnauthorizedHandler)
+				unauthorizedOIDC(w, r, config.UnauthorizedHandler)
 				return
 			}
 
@@ -158,7 +158,7 @@ func handleOIDCCallback(w http.ResponseWriter, r *http.Request, config OpenIDCon
 	// Exchange code for tokens
 	token, err := exchangeOIDCCodeForTokens(config, code)
 	if err != nil {
-		unauthorizedOIDC(w, config.UnauthorizedHandler)
+		unauthorizedOIDC(w, r, config.UnauthorizedHandler)
 		return
 	}
 
@@ -316,13 +316,6 @@ func getOIDCScopes(scopes []string) []string {
 	return scopes
 }
 
-func unauthorizedOIDC(w http.ResponseWriter, handler http.Handler) {
-	if handler != nil {
-		handler.ServeHTTP(w, nil)
-		return
-	}
-
-	w.Header().Set("WWW-Authenticate", `Bearer`)
-	w.WriteHeader(http.StatusUnauthorized)
-	_, _ = w.Write([]byte("Unauthorized"))
+func unauthorizedOIDC(w http.ResponseWriter, r *http.Request, handler http.Handler) {
+	writeUnauthorized(w, r, "Bearer", handler)
 }
@@ -1,5 +1,10 @@
 package security
 
+import (
+	"encoding/json"
+	"net/http"
+)
+
 type (
 	Config struct {
 		// AllowAnonymousAuth indicates whether anonymous (unauthenticated) access is allowed.
@@ -26,3 +31,29 @@ type (
 		OpenIDConnectAuth *OpenIDConnectAuthConfig
 	}
 )
+
+// UnauthorizedJSON returns an UnauthorizedHandler that responds with 401 Unauthorized and a JSON body
+// of the form {"error": "unauthorized"}, keeping the WWW-Authenticate challenge set by the middleware.
+func UnauthorizedJSON() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "unauthorized"})
+	})
+}
+
+// writeUnauthorized sets the WWW-Authenticate challenge, if any, and calls handler, which can replace the
+// challenge and write its own response. Without handler, responds with 401 Unauthorized in plain text.
+func writeUnauthorized(w http.ResponseWriter, r *http.Request, challenge string, handler http.Handler) {
+	if challenge != "" {
+		w.Header().Set("WWW-Authenticate", challenge)
+	}
+
+	if handler != nil {
+		handler.ServeHTTP(w, r)
+		return
+	}
+
+	w.WriteHeader(http.StatusUnauthorized)
+	_, _ = w.Write([]byte("Unauthorized"))
+}
@@ -0,0 +1,201 @@
+package webfram
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+)
+
+// authorizedRequestKey holds the *http.Request set by a security scheme that lets a request through.
+const authorizedRequestKey contextKey = "authorizedRequest"
+
+type (
+	// requiredScheme is a named security scheme of Config.SecuritySchemes, enforced by handler.
+	requiredScheme struct {
+		name    string
+		handler http.Handler
+	}
+
+	// securitySchemeResult is the outcome of a security scheme for a request: the request it let
+	// through, if any, and the response it wrote.
+	securitySchemeResult struct {
+		authorized *http.Request
+		rec        *securityRecorder
+	}
+
+	// securityRecorder buffers the response written by a security scheme, which is only sent to the
+	// client if the request is rejected.
+	securityRecorder struct {
+		header http.Header
+		code   int
+		body   bytes.Buffer
+	}
+)
+
+// operationSecurity returns the security requirements of an operation, which default to those of the OpenAPI document.
+func (a *App) operationSecurity(op *OperationConfig) []map[string][]string {
+	if op.Security != nil {
+		return op.Security
+	}
+
+	if a.openAPIConfig != nil && a.openAPIConfig.Config != nil {
+		return a.openAPIConfig.Config.Security
+	}
+
+	return nil
+}
+
+// securityRequirementsMiddleware returns a middleware enforcing the security requirements of an operation
+// with the authentication configured in Config.SecuritySchemes, or nil if there is nothing to enforce.
+// As in OpenAPI, the requirements are alternatives and the schemes of a requirement must all succeed,
+// so [{A}, {B}] means A or B and [{A, B}] means A and B. An empty requirement makes authentication optional,
+// and an empty, non-nil Security list of the operation makes it public, whatever the mux and app configurations.
+// Panics if a requirement references a scheme missing from Config.SecuritySchemes.
+func (a *App) securityRequirementsMiddleware(op *OperationConfig, pathPattern string) AppMiddleware {
+	if op == nil {
+		return nil
+	}
+
+	if op.Security != nil && len(op.Security) == 0 {
+		return func(next Handler) Handler {
+			return next
+		}
+	}
+
+	if len(a.securitySchemes) == 0 {
+		return nil
+	}
+
+	requirements := a.operationSecurity(op)
+	if len(requirements) == 0 {
+		return nil
+	}
+
+	authorize := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		if authorized, ok := r.Context().Value(authorizedRequestKey).(**http.Request); ok {
+			*authorized = r
+		}
+	})
+
+	schemes := make([][]requiredScheme, len(requirements))
+	handlers := map[string]http.Handler{}
+
+	for i, requirement := range requirements {
+		for _, name := range slices.Sorted(maps.Keys(requirement)) {
+			if _, ok := handlers[name]; !ok {
+				cfg, ok := a.securitySchemes[name]
+				if !ok {
+					panic(fmt.Errorf("security scheme %q of %q is not configured in Config.SecuritySchemes", name, pathPattern))
+				}
+
+				var handler http.Handler = authorize
+				mdwrs := securityConfigMiddlewares(&cfg)
+				for j := len(mdwrs) - 1; j >= 0; j-- {
+					handler = mdwrs[j](handler)
+				}
+				handlers[name] = handler
+			}
+
+			schemes[i] = append(schemes[i], requiredScheme{name: name, handler: handlers[name]})
+		}
+	}
+
+	return adaptHTTPMiddleware(anySecurityRequirement(schemes))
+}
+
+// anySecurityRequirement returns a middleware that lets a request through if all the schemes of any of the
+// requirements do. The requirements are tried in order, and every scheme runs at most once per request,
+// against a recorder: a scheme shared by several requirements is not run again, so schemes reading the
+// body see it only once. The request passed to the next handler carries the context set by all the schemes
+// that succeeded, and the response headers they set, e.g. cookies, are kept. If all the requirements reject
+// the request, the response of the first scheme that failed is written, with the WWW-Authenticate challenges
+// of all of them.
+func anySecurityRequirement(requirements [][]requiredScheme) StandardMiddleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			results := make(map[string]*securitySchemeResult)
+			var failed []*securityRecorder
+
+			for _, schemes := range requirements {
+				satisfied := true
+
+				for _, scheme := range schemes {
+					result, ok := results[scheme.name]
+					if !ok {
+						result = runSecurityScheme(scheme.handler, r)
+						results[scheme.name] = result
+
+						if result.authorized != nil {
+							for key, values := range result.rec.header {
+								for _, value := range values {
+									w.Header().Add(key, value)
+								}
+							}
+							r = result.authorized
+						} else {
+							failed = append(failed, result.rec)
+						}
+					}
+
+					if result.authorized == nil {
+						satisfied = false
+						break
+					}
+				}
+
+				if satisfied {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			writeSecurityRejection(w, failed)
+		})
+	}
+}
+
+// runSecurityScheme runs the handler of a security scheme for r against a recorder.
+func runSecurityScheme(handler http.Handler, r *http.Request) *securitySchemeResult {
+	var authorized *http.Request
+	rec := &securityRecorder{header: make(http.Header)}
+	handler.ServeHTTP(rec, r.WithContext(context.WithValue(r.Context(), authorizedRequestKey, &authorized)))
+
+	return &securitySchemeResult{authorized: authorized, rec: rec}
+}
+
+// writeSecurityRejection writes the response of the first rejection, with the WWW-Authenticate challenges
+// of all of them.
+func writeSecurityRejection(w http.ResponseWriter, rejections []*securityRecorder) {
+	rejected := rejections[0]
+
+	for _, rec := range rejections[1:] {
+		for _, challenge := range rec.header.Values("WWW-Authenticate") {
+			if !slices.Contains(rejected.header.Values("WWW-Authenticate"), challenge) {
+				rejected.header.Add("WWW-Authenticate", challenge)
+			}
+		}
+	}
+
+	maps.Copy(w.Header(), rejected.header)
+	w.WriteHeader(cmp.Or(rejected.code, http.StatusOK))
+	_, _ = w.Write(rejected.body.Bytes())
+}
+
+func (rec *securityRecorder) Header() http.Header {
+	return rec.header
+}
+
+func (rec *securityRecorder) WriteHeader(statusCode int) {
+	if rec.code == 0 {
+		rec.code = statusCode
+	}
+}
+
+func (rec *securityRecorder) Write(b []byte) (int, error) {
+	rec.WriteHeader(http.StatusOK)
+	return rec.body.Write(b)
+}
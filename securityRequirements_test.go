@@ -0,0 +1,282 @@
+package webfram
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/bondowe/webfram/security"
+)
+
+func configureSecuritySchemes(t *testing.T) {
+	t.Helper()
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+
+	Configure(&Config{
+		SecuritySchemes: map[string]security.Config{
+			"apiKey": {
+				APIKeyAuth: &security.APIKeyAuthConfig{
+					KeyName:      "X-API-Key",
+					KeyValidator: func(key string) bool { return key == "key" },
+				},
+			},
+			"basic": {
+				BasicAuth: &security.BasicAuthConfig{
+					Authenticator: func(username, password string) bool {
+						return username == "admin" && password == "secret"
+					},
+				},
+			},
+		},
+	})
+}
+
+func serveWithCredentials(mux *ServeMux, path string, apiKey, basic bool) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+	if apiKey {
+		req.Header.Set("X-API-Key", "key")
+	}
+	if basic {
+		req.SetBasicAuth("admin", "secret")
+	}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestSecurityRequirements_AlternativesAreOR(t *testing.T) {
+	configureSecuritySchemes(t)
+
+	var scheme string
+
+	mux := NewServeMux()
+	mux.HandleFunc("GET /either", func(w ResponseWriter, r *Request) {
+		scheme, _ = r.AuthScheme()
+		w.NoContent()
+	}).OpenAPIOperation(OperationConfig{
+		Security: []map[string][]string{{"apiKey": {}}, {"basic": {}}},
+	})
+	registerHandlers(mux)
+
+	tests := []struct {
+		name       string
+		apiKey     bool
+		basic      bool
+		wantStatus int
+		wantScheme string
+	}{
+		{"none", false, false, http.StatusUnauthorized, ""},
+		{"apiKey only", true, false, http.StatusNoContent, AuthSchemeAPIKey},
+		{"basic only", false, true, http.StatusNoContent, AuthSchemeBasic},
+		{"both", true, true, http.StatusNoContent, AuthSchemeAPIKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme = ""
+			rec := serveWithCredentials(mux, "/either", tt.apiKey, tt.basic)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if scheme != tt.wantScheme {
+				t.Errorf("Expected AuthScheme %q, got %q", tt.wantScheme, scheme)
+			}
+		})
+	}
+}
+
+func TestSecurityRequirements_SchemesOfARequirementAreAND(t *testing.T) {
+	configureSecuritySchemes(t)
+
+	mux := NewServeMux()
+	mux.HandleFunc("GET /both", func(w ResponseWriter, _ *Request) {
+		w.NoContent()
+	}).OpenAPIOperation(OperationConfig{
+		Security: []map[string][]string{{"apiKey": {}, "basic": {}}},
+	})
+	registerHandlers(mux)
+
+	tests := []struct {
+		name       string
+		apiKey     bool
+		basic      bool
+		wantStatus int
+	}{
+		{"none", false, false, http.StatusUnauthorized},
+		{"apiKey only", true, false, http.StatusUnauthorized},
+		{"basic only", false, true, http.StatusUnauthorized},
+		{"both", true, true, http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveWithCredentials(mux, "/both", tt.apiKey, tt.basic)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestSecurityRequirements_RejectionCombinesChallenges(t *testing.T) {
+	configureSecuritySchemes(t)
+
+	mux := NewServeMux()
+	mux.HandleFunc("GET /either", func(w ResponseWriter, _ *Request) {
+		w.NoContent()
+	}).OpenAPIOperation(OperationConfig{
+		Security: []map[string][]string{{"basic": {}}, {"apiKey": {}}, {"basic": {}}},
+	})
+	registerHandlers(mux)
+
+	rec := serveWithCredentials(mux, "/either", false, false)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("Expected status 401, got %d", rec.Code)
+	}
+	challenges := rec.Header().Values("WWW-Authenticate")
+	if len(challenges) != 1 || challenges[0] != `Basic realm="Restricted"` {
+		t.Errorf("Expected a single Basic challenge, got %v", challenges)
+	}
+}
+
+func TestSecurityRequirements_PublicAndDocumentDefaults(t *testing.T) {
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+
+	Configure(&Config{
+		SecuritySchemes: map[string]security.Config{
+			"apiKey": {
+				APIKeyAuth: &security.APIKeyAuthConfig{
+					KeyName:      "X-API-Key",
+					KeyValidator: func(key string) bool { return key == "key" },
+				},
+			},
+		},
+		OpenAPI: &OpenAPI{
+			Enabled: true,
+			Config: &OpenAPIConfig{
+				Info:     &Info{Title: "Test", Version: "1.0.0"},
+				Security: []map[string][]string{{"apiKey": {}}},
+			},
+		},
+	})
+
+	mux := NewServeMux()
+	mux.HandleFunc("GET /default", func(w ResponseWriter, _ *Request) {
+		w.NoContent()
+	}).OpenAPIOperation(OperationConfig{})
+	mux.HandleFunc("GET /public", func(w ResponseWriter, _ *Request) {
+		w.NoContent()
+	}).OpenAPIOperation(OperationConfig{Security: []map[string][]string{}})
+	mux.HandleFunc("GET /optional", func(w ResponseWriter, _ *Request) {
+		w.NoContent()
+	}).OpenAPIOperation(OperationConfig{Security: []map[string][]string{{"apiKey": {}}, {}}})
+	registerHandlers(mux)
+
+	if rec := serveWithCredentials(mux, "/default", false, false); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected the document security to apply, got status %d", rec.Code)
+	}
+	if rec := serveWithCredentials(mux, "/default", true, false); rec.Code != http.StatusNoContent {
+		t.Errorf("Expected status 204 with an API key, got %d", rec.Code)
+	}
+	if rec := serveWithCredentials(mux, "/public", false, false); rec.Code != http.StatusNoContent {
+		t.Errorf("Expected an empty security list to make the route public, got status %d", rec.Code)
+	}
+	if rec := serveWithCredentials(mux, "/optional", false, false); rec.Code != http.StatusNoContent {
+		t.Errorf("Expected an empty requirement to make authentication optional, got status %d", rec.Code)
+	}
+}
+
+func TestSecurityRequirements_UnknownSchemePanics(t *testing.T) {
+	configureSecuritySchemes(t)
+
+	mux := NewServeMux()
+	mux.HandleFunc("GET /oauth", func(w ResponseWriter, _ *Request) {
+		w.NoContent()
+	}).OpenAPIOperation(OperationConfig{
+		Security: []map[string][]string{{"oauth": {"read"}}},
+	})
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for an unconfigured security scheme")
+		}
+	}()
+	registerHandlers(mux)
+}
+
+func TestSecurityRequirements_PublicOperationOverridesConfigSecurity(t *testing.T) {
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+
+	Configure(&Config{
+		Security: &security.Config{
+			APIKeyAuth: &security.APIKeyAuthConfig{
+				KeyName:      "X-API-Key",
+				KeyValidator: func(key string) bool { return key == "key" },
+			},
+		},
+	})
+
+	mux := NewServeMux()
+	mux.HandleFunc("GET /private", func(w ResponseWriter, _ *Request) {
+		w.NoContent()
+	}).OpenAPIOperation(OperationConfig{})
+	mux.HandleFunc("GET /public", func(w ResponseWriter, _ *Request) {
+		w.NoContent()
+	}).OpenAPIOperation(OperationConfig{Security: []map[string][]string{}})
+	registerHandlers(mux)
+
+	if rec := serveWithCredentials(mux, "/private", false, false); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected Config.Security to apply, got status %d", rec.Code)
+	}
+	if rec := serveWithCredentials(mux, "/public", false, false); rec.Code != http.StatusNoContent {
+		t.Errorf("Expected an empty security list to override Config.Security, got status %d", rec.Code)
+	}
+}
+
+func TestAnySecurityRequirement_RunsSchemesOnceAndKeepsHeaders(t *testing.T) {
+	calls := map[string]int{}
+
+	scheme := func(name string, ok bool) requiredScheme {
+		return requiredScheme{name: name, handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls[name]++
+			if !ok {
+				w.Header().Set("WWW-Authenticate", name)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Add("Set-Cookie", name+"=1")
+			*r.Context().Value(authorizedRequestKey).(**http.Request) = r
+		})}
+	}
+
+	// [{a, b}, {a, c}]: a succeeds, b fails and c succeeds.
+	a, b, c := scheme("a", true), scheme("b", false), scheme("c", true)
+	handler := anySecurityRequirement([][]requiredScheme{{a, b}, {a, c}})(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d", rec.Code)
+	}
+	if calls["a"] != 1 || calls["b"] != 1 || calls["c"] != 1 {
+		t.Errorf("Expected every scheme to run once, got %v", calls)
+	}
+	if cookies := rec.Header().Values("Set-Cookie"); !slices.Equal(cookies, []string{"a=1", "c=1"}) {
+		t.Errorf("Expected the cookies of the succeeded schemes, got %v", cookies)
+	}
+	if challenges := rec.Header().Values("WWW-Authenticate"); len(challenges) != 0 {
+		t.Errorf("Expected no challenge for an authorized request, got %v", challenges)
+	}
+}
@@ -0,0 +1,141 @@
+package webfram
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const serverTimingKey contextKey = "serverTiming"
+
+// serverTimingTotal is the name of the metric measuring the request until the headers are written.
+const serverTimingTotal = "total"
+
+type (
+	// serverTimings collects the phases timed by Request.Timing for the ServerTiming middleware.
+	serverTimings struct {
+		mu      sync.Mutex
+		start   time.Time
+		metrics []serverTimingMetric
+	}
+
+	serverTimingMetric struct {
+		name string
+		dur  time.Duration
+	}
+
+	// serverTimingWriter sets the Server-Timing header when the response headers are written.
+	serverTimingWriter struct {
+		http.ResponseWriter
+
+		timings     *serverTimings
+		wroteHeader bool
+	}
+)
+
+// ServerTiming creates middleware emitting a Server-Timing response header with the phases timed by
+// Request.Timing and the total time spent before the response headers were written, so browser developer
+// tools show where the backend spent its time. Phases still running when the headers are written, e.g.
+// while streaming a response, are not reported. Upgrade requests are passed through.
+// The header discloses internal timings: enable it in development, or for trusted clients only.
+func ServerTiming() AppMiddleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(w ResponseWriter, r *Request) {
+			if r.Header.Get("Upgrade") != "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			timings := &serverTimings{start: time.Now()}
+			r = &Request{r.WithContext(context.WithValue(r.Context(), serverTimingKey, timings))}
+			tw := &serverTimingWriter{ResponseWriter: w.ResponseWriter, timings: timings}
+
+			next.ServeHTTP(ResponseWriter{ResponseWriter: tw, statusCode: w.statusCode, request: r}, r)
+
+			// Headers of an empty response are written once the handler returns.
+			tw.setHeader()
+		})
+	}
+}
+
+// Timing starts timing a phase of the request named name, such as "db" or "render", and returns the function
+// stopping it. The phase is reported in the Server-Timing header by the ServerTiming middleware:
+//
+//	defer r.Timing("db")()
+//
+// name must be an HTTP token, without spaces or separators. Does nothing if ServerTiming is not used.
+func (r *Request) Timing(name string) func() {
+	timings, ok := r.Context().Value(serverTimingKey).(*serverTimings)
+	if !ok {
+		return func() {}
+	}
+
+	start := time.Now()
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			timings.add(name, time.Since(start))
+		})
+	}
+}
+
+func (t *serverTimings) add(name string, dur time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.metrics = append(t.metrics, serverTimingMetric{name: name, dur: dur})
+}
+
+// header returns the value of the Server-Timing header, e.g. "db;dur=12.3, total;dur=20.1".
+func (t *serverTimings) header() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	values := make([]string, 0, len(t.metrics)+1)
+	for _, m := range t.metrics {
+		values = append(values, m.String())
+	}
+	values = append(values, serverTimingMetric{name: serverTimingTotal, dur: time.Since(t.start)}.String())
+
+	return strings.Join(values, ", ")
+}
+
+// String returns the metric in Server-Timing format, with the duration in milliseconds.
+func (m serverTimingMetric) String() string {
+	return m.name + ";dur=" + strconv.FormatFloat(float64(m.dur)/float64(time.Millisecond), 'f', 1, 64)
+}
+
+func (tw *serverTimingWriter) setHeader() {
+	if tw.wroteHeader {
+		return
+	}
+	tw.wroteHeader = true
+	tw.Header().Set("Server-Timing", tw.timings.header())
+}
+
+func (tw *serverTimingWriter) WriteHeader(statusCode int) {
+	tw.setHeader()
+	tw.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (tw *serverTimingWriter) Write(b []byte) (int, error) {
+	tw.setHeader()
+	return tw.ResponseWriter.Write(b)
+}
+
+// Flush writes the headers, including Server-Timing, and flushes the response.
+func (tw *serverTimingWriter) Flush() {
+	tw.setHeader()
+	if flusher, ok := tw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the underlying http.ResponseWriter.
+func (tw *serverTimingWriter) Unwrap() http.ResponseWriter {
+	return tw.ResponseWriter
+}
@@ -0,0 +1,54 @@
+package webfram
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+)
+
+func TestServerTiming_EmitsHeader(t *testing.T) {
+	handler := ServerTiming()(HandlerFunc(func(w ResponseWriter, r *Request) {
+		stop := r.Timing("db")
+		stop()
+		stop() // stopping twice records the phase once
+
+		r.Timing("render")()
+		_ = r.Timing("unfinished")
+
+		_, _ = w.Write([]byte("ok"))
+	}))
+
+	w, rec := NewTestResponseWriter()
+	handler.ServeHTTP(w, NewTestRequest(http.MethodGet, "/", nil))
+
+	header := rec.Header().Get("Server-Timing")
+	pattern := regexp.MustCompile(`^db;dur=\d+\.\d, render;dur=\d+\.\d, total;dur=\d+\.\d$`)
+	if !pattern.MatchString(header) {
+		t.Errorf("Unexpected Server-Timing header %q", header)
+	}
+}
+
+func TestServerTiming_EmptyResponse(t *testing.T) {
+	handler := ServerTiming()(HandlerFunc(func(_ ResponseWriter, r *Request) {
+		r.Timing("db")()
+	}))
+
+	w, rec := NewTestResponseWriter()
+	handler.ServeHTTP(w, NewTestRequest(http.MethodGet, "/", nil))
+
+	if header := rec.Header().Get("Server-Timing"); !regexp.MustCompile(`^db;dur=.*, total;dur=`).MatchString(header) {
+		t.Errorf("Expected Server-Timing header for an empty response, got %q", header)
+	}
+}
+
+func TestRequest_Timing_WithoutMiddleware(t *testing.T) {
+	w, rec := NewTestResponseWriter()
+	HandlerFunc(func(w ResponseWriter, r *Request) {
+		r.Timing("db")()
+		w.WriteHeader(http.StatusNoContent)
+	}).ServeHTTP(w, NewTestRequest(http.MethodGet, "/", nil))
+
+	if header := rec.Header().Get("Server-Timing"); header != "" {
+		t.Errorf("Expected no Server-Timing header, got %q", header)
+	}
+}
@@ -0,0 +1,198 @@
+package webfram
+
+import (
+	"bytes"
+	"maps"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// singleflightMaxBody is the largest response body shared with waiting requests.
+const singleflightMaxBody = 1 << 20
+
+type (
+	// singleflightCall is a GET request being computed, waited on by identical requests.
+	singleflightCall struct {
+		done     chan struct{}
+		response *singleflightResponse // nil if the response cannot be shared
+	}
+
+	singleflightResponse struct {
+		status int
+		header http.Header
+		body   []byte
+	}
+
+	// teeRecorder writes a response to the client and records it, e.g. for requests waiting on it.
+	// Recording stops once the response is flushed or its body exceeds maxBody bytes.
+	teeRecorder struct {
+		http.ResponseWriter
+
+		maxBody  int
+		status   int
+		header   http.Header
+		body     bytes.Buffer
+		streamed bool
+	}
+)
+
+// Singleflight creates middleware coalescing identical in-flight GET requests: while a request is
+// being computed, requests with the same host and URL wait for it and receive a copy of its response
+// instead of running the handler again. This protects expensive endpoints from cache-miss storms.
+// Requests are also told apart by the values of varyHeaders. Requests with an Authorization or Cookie header
+// are not coalesced unless it is one of varyHeaders, so responses are only shared between the requests of a
+// caller: pass "Authorization" or "Cookie" to coalesce them per credentials.
+// Requests accepting text/event-stream and upgrade requests are never coalesced. Waiting requests run
+// the handler themselves if the response cannot be shared: it was flushed or streamed, it sets cookies,
+// its body is larger than 1 MiB, or the handler panicked.
+func Singleflight(varyHeaders ...string) AppMiddleware {
+	var mu sync.Mutex
+	calls := make(map[string]*singleflightCall)
+
+	return func(next Handler) Handler {
+		return HandlerFunc(func(w ResponseWriter, r *Request) {
+			if !isSingleflightRequest(r, varyHeaders) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := requestKey(r, varyHeaders)
+
+			mu.Lock()
+			if call, ok := calls[key]; ok {
+				mu.Unlock()
+
+				select {
+				case <-call.done:
+				case <-r.Context().Done():
+					return
+				}
+
+				if call.response != nil {
+					call.response.writeTo(&w)
+					return
+				}
+
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			call := &singleflightCall{done: make(chan struct{})}
+			calls[key] = call
+			mu.Unlock()
+
+			rec := &teeRecorder{ResponseWriter: w.ResponseWriter, maxBody: singleflightMaxBody}
+			completed := false
+
+			defer func() {
+				if completed {
+					call.response = sharedResponse(rec)
+				}
+
+				mu.Lock()
+				delete(calls, key)
+				mu.Unlock()
+				close(call.done)
+			}()
+
+			next.ServeHTTP(ResponseWriter{ResponseWriter: rec, statusCode: w.statusCode, request: w.request}, r)
+			completed = true
+		})
+	}
+}
+
+func isSingleflightRequest(r *Request, varyHeaders []string) bool {
+	if r.Method != http.MethodGet || r.Header.Get("Upgrade") != "" {
+		return false
+	}
+	// Responses to requests with credentials are only shared per credentials.
+	for _, name := range []string{"Authorization", "Cookie"} {
+		if r.Header.Get(name) != "" && !containsHeaderName(varyHeaders, name) {
+			return false
+		}
+	}
+
+	return !strings.Contains(r.Header.Get("Accept"), mediaTypeTextEventStream)
+}
+
+// requestKey identifies identical requests by host, URL and the values of the vary headers.
+func requestKey(r *Request, varyHeaders []string) string {
+	var key strings.Builder
+	key.WriteString(r.Host)
+	key.WriteString(r.URL.RequestURI())
+
+	for _, name := range varyHeaders {
+		key.WriteString("\n")
+		key.WriteString(name)
+		key.WriteString(": ")
+		key.WriteString(strings.Join(r.Header.Values(name), ", "))
+	}
+
+	return key.String()
+}
+
+func (rec *teeRecorder) WriteHeader(statusCode int) {
+	if rec.status == 0 {
+		rec.status = statusCode
+		rec.header = rec.Header().Clone()
+	}
+	rec.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (rec *teeRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.WriteHeader(http.StatusOK)
+	}
+	if !rec.streamed {
+		rec.body.Write(b)
+		rec.streamed = rec.body.Len() > rec.maxBody
+	}
+	return rec.ResponseWriter.Write(b)
+}
+
+// Flush marks the response as streamed, which stops recording.
+func (rec *teeRecorder) Flush() {
+	rec.streamed = true
+	if flusher, ok := rec.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the underlying http.ResponseWriter.
+func (rec *teeRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
+
+// recorded returns the status code and headers of the response, defaulting to 200 and the current
+// headers if the handler wrote nothing.
+func (rec *teeRecorder) recorded() (int, http.Header) {
+	if rec.status == 0 {
+		return http.StatusOK, rec.Header().Clone()
+	}
+	return rec.status, rec.header
+}
+
+// sharedResponse returns the recorded response, or nil if it cannot be shared with other clients.
+func sharedResponse(rec *teeRecorder) *singleflightResponse {
+	if rec.streamed {
+		return nil
+	}
+
+	status, header := rec.recorded()
+
+	contentType := header.Get("Content-Type")
+	if len(header.Values("Set-Cookie")) > 0 ||
+		strings.HasPrefix(contentType, mediaTypeTextEventStream) ||
+		strings.HasPrefix(contentType, mediaTypeJSONSeq) {
+		return nil
+	}
+
+	return &singleflightResponse{status: status, header: header, body: rec.body.Bytes()}
+}
+
+func (resp *singleflightResponse) writeTo(w *ResponseWriter) {
+	maps.Copy(w.Header(), resp.header.Clone())
+	w.WriteHeader(resp.status)
+	_, _ = w.Write(resp.body)
+}
@@ -0,0 +1,199 @@
+package webfram
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// serveConcurrently serves n identical requests built by newRequest, releasing the handler once they are
+// all in flight, and returns the recorded responses.
+func serveConcurrently(
+	handler Handler,
+	n int,
+	release chan struct{},
+	newRequest func() *Request,
+) []*httptest.ResponseRecorder {
+	recs := make([]*httptest.ResponseRecorder, n)
+	var wg sync.WaitGroup
+
+	for i := range n {
+		w, rec := NewTestResponseWriter()
+		recs[i] = rec
+		req := newRequest()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			handler.ServeHTTP(w, req)
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	return recs
+}
+
+func TestSingleflight_CoalescesIdenticalGets(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+
+	handler := Singleflight()(HandlerFunc(func(w ResponseWriter, _ *Request) {
+		calls.Add(1)
+		<-release
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("computed"))
+	}))
+
+	recs := serveConcurrently(handler, 5, release, func() *Request {
+		return NewTestRequest(http.MethodGet, "/report?id=1", nil)
+	})
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("Expected handler to run once, ran %d times", got)
+	}
+
+	for i, rec := range recs {
+		if rec.Code != http.StatusCreated || rec.Body.String() != "computed" {
+			t.Errorf("Response %d: expected 201 'computed', got %d %q", i, rec.Code, rec.Body.String())
+		}
+		if got := rec.Header().Get("Content-Type"); got != "text/plain" {
+			t.Errorf("Response %d: expected Content-Type 'text/plain', got %q", i, got)
+		}
+	}
+}
+
+func TestSingleflight_KeysByVaryHeaders(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+
+	handler := Singleflight("Authorization")(HandlerFunc(func(w ResponseWriter, r *Request) {
+		calls.Add(1)
+		<-release
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+
+	var n atomic.Int32
+	recs := serveConcurrently(handler, 2, release, func() *Request {
+		req := NewTestRequest(http.MethodGet, "/me", nil)
+		if n.Add(1) == 1 {
+			req.Header.Set("Authorization", "Bearer alice")
+		} else {
+			req.Header.Set("Authorization", "Bearer bob")
+		}
+		return req
+	})
+
+	if got := calls.Load(); got != 2 {
+		t.Errorf("Expected handler to run for each Authorization value, ran %d times", got)
+	}
+	if recs[0].Body.String() != "Bearer alice" || recs[1].Body.String() != "Bearer bob" {
+		t.Errorf("Expected per-caller responses, got %q and %q", recs[0].Body.String(), recs[1].Body.String())
+	}
+}
+
+func TestSingleflight_DoesNotShareResponsesBetweenCallers(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+
+	handler := Singleflight()(HandlerFunc(func(w ResponseWriter, r *Request) {
+		calls.Add(1)
+		<-release
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+
+	var n atomic.Int32
+	recs := serveConcurrently(handler, 2, release, func() *Request {
+		req := NewTestRequest(http.MethodGet, "/me", nil)
+		if n.Add(1) == 1 {
+			req.Header.Set("Authorization", "Bearer alice")
+		} else {
+			req.Header.Set("Authorization", "Bearer bob")
+		}
+		return req
+	})
+
+	if got := calls.Load(); got != 2 {
+		t.Errorf("Expected handler to run for each caller, ran %d times", got)
+	}
+	if recs[0].Body.String() != "Bearer alice" || recs[1].Body.String() != "Bearer bob" {
+		t.Errorf("Expected per-caller responses, got %q and %q", recs[0].Body.String(), recs[1].Body.String())
+	}
+}
+
+func TestSingleflight_SkipsNonCoalescableRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		accept string
+	}{
+		{"post", http.MethodPost, ""},
+		{"event stream", http.MethodGet, "text/event-stream"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			release := make(chan struct{})
+
+			handler := Singleflight()(HandlerFunc(func(w ResponseWriter, _ *Request) {
+				calls.Add(1)
+				<-release
+				w.NoContent()
+			}))
+
+			serveConcurrently(handler, 3, release, func() *Request {
+				req := NewTestRequest(tt.method, "/events", nil)
+				req.Header.Set("Accept", tt.accept)
+				return req
+			})
+
+			if got := calls.Load(); got != 3 {
+				t.Errorf("Expected handler to run for every request, ran %d times", got)
+			}
+		})
+	}
+}
+
+func TestSingleflight_DoesNotShareUnshareableResponses(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(w ResponseWriter)
+	}{
+		{"flushed", func(w ResponseWriter) {
+			_, _ = w.Write([]byte("chunk"))
+			w.Flush()
+		}},
+		{"sets cookie", func(w ResponseWriter) {
+			http.SetCookie(&w, &http.Cookie{Name: "session", Value: "secret"})
+			_, _ = w.Write([]byte("ok"))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			release := make(chan struct{})
+
+			handler := Singleflight()(HandlerFunc(func(w ResponseWriter, _ *Request) {
+				calls.Add(1)
+				<-release
+				tt.write(w)
+			}))
+
+			serveConcurrently(handler, 3, release, func() *Request {
+				return NewTestRequest(http.MethodGet, "/private", nil)
+			})
+
+			if got := calls.Load(); got != 3 {
+				t.Errorf("Expected handler to run for every request, ran %d times", got)
+			}
+		})
+	}
+}
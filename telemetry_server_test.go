@@ -0,0 +1,132 @@
+package webfram
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func configureTelemetryTest(t *testing.T, addr string) *ServeMux {
+	t.Helper()
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+
+	Configure(&Config{
+		Telemetry: &Telemetry{Enabled: true, Addr: addr},
+	})
+
+	return NewServeMux()
+}
+
+func TestSameListenAddr(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected bool
+	}{
+		{":8080", ":8080", true},
+		{"0.0.0.0:8080", ":8080", true},
+		{"[::]:8080", "127.0.0.1:8080", true},
+		{"127.0.0.1:8080", "127.0.0.1:8080", true},
+		{"127.0.0.1:8080", "10.0.0.1:8080", false},
+		{":9090", ":8080", false},
+		{"invalid", ":8080", false},
+	}
+
+	for _, tt := range tests {
+		if got := sameListenAddr(tt.a, tt.b); got != tt.expected {
+			t.Errorf("sameListenAddr(%q, %q) = %v, expected %v", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
+
+func TestSetupTelemetry_MainServer(t *testing.T) {
+	for _, addr := range []string{"", ":8080", "0.0.0.0:8080"} {
+		t.Run(addr, func(t *testing.T) {
+			mux := configureTelemetryTest(t, addr)
+
+			server, separate := setupTelemetry(":8080", mux)
+			if separate || server != nil {
+				t.Fatal("Expected telemetry on the main server")
+			}
+
+			// A second setup must not register the endpoint twice, which would panic.
+			setupTelemetry(":8080", mux)
+			registerHandlers(mux)
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+
+			if rec.Code != http.StatusOK {
+				t.Errorf("Expected status 200 for /metrics, got %d", rec.Code)
+			}
+		})
+	}
+}
+
+func TestSetupTelemetry_MainServerKeepsRegisteredHandler(t *testing.T) {
+	mux := configureTelemetryTest(t, "")
+	mux.HandleFunc("GET /metrics", func(w ResponseWriter, _ *Request) {
+		_, _ = w.Write([]byte("custom"))
+	})
+
+	setupTelemetry(":8080", mux)
+	registerHandlers(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+
+	if rec.Body.String() != "custom" {
+		t.Errorf("Expected the registered handler to serve /metrics, got %q", rec.Body.String())
+	}
+}
+
+func TestSetupTelemetry_SeparateServerServesMetrics(t *testing.T) {
+	mux := configureTelemetryTest(t, ":9090")
+
+	server, separate := setupTelemetry(":8080", mux)
+	if !separate || server == nil {
+		t.Fatal("Expected a separate telemetry server")
+	}
+	if server.Addr != ":9090" {
+		t.Errorf("Expected telemetry server address ':9090', got %q", server.Addr)
+	}
+
+	registerHandlers(mux)
+
+	rec := httptest.NewRecorder()
+	server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status 200 from telemetry server, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 from main server, got %d", rec.Code)
+	}
+}
+
+func TestListenAndServe_TelemetryAddrInUse(t *testing.T) {
+	occupied, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer occupied.Close()
+
+	mux := configureTelemetryTest(t, occupied.Addr().String())
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("Expected ListenAndServe to panic")
+		}
+		err, ok := r.(error)
+		if !ok || !strings.Contains(err.Error(), "telemetry server") {
+			t.Errorf("Expected telemetry server listen error, got %v", r)
+		}
+	}()
+
+	ListenAndServe("127.0.0.1:0", mux, nil)
+}
@@ -0,0 +1,33 @@
+package webfram
+
+import (
+	"io"
+	"net/http/httptest"
+
+	"github.com/bondowe/webfram/internal/i18n"
+)
+
+// NewTestRequest returns a new incoming server Request, suitable for passing to a Handler in tests.
+// It wraps httptest.NewRequest and, when i18n messages of the default App are configured, injects the printer
+// for the first supported language and its language into the request context, as I18nMiddleware would.
+func NewTestRequest(method, target string, body io.Reader) *Request {
+	req := httptest.NewRequest(method, target, body)
+
+	if i18nConfig, ok := defaultApp.i18nCatalogs.Configuration(); ok && len(i18nConfig.SupportedLanguages) > 0 {
+		printer := defaultApp.GetI18nPrinter(i18nConfig.SupportedLanguages[0])
+		ctx := i18n.ContextWithI18nPrinter(req.Context(), printer)
+		req = req.WithContext(i18n.ContextWithLanguage(ctx, i18nConfig.SupportedLanguages[0]))
+	}
+
+	return &Request{req}
+}
+
+// NewTestResponseWriter returns a ResponseWriter backed by an httptest.ResponseRecorder,
+// suitable for passing to a Handler in tests. The recorder is returned for inspecting the response.
+// The ResponseWriter tracks the written status code, so StatusCode works as in a real server.
+func NewTestResponseWriter() (ResponseWriter, *httptest.ResponseRecorder) {
+	rec := httptest.NewRecorder()
+	statusCode := 0
+
+	return ResponseWriter{ResponseWriter: rec, statusCode: &statusCode}, rec
+}
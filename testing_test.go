@@ -0,0 +1,69 @@
+package webfram
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/bondowe/webfram/internal/i18n"
+	"golang.org/x/text/language"
+)
+
+func TestNewTestResponseWriter(t *testing.T) {
+	w, rec := NewTestResponseWriter()
+
+	w.WriteHeader(http.StatusCreated)
+	_, _ = w.Write([]byte("created"))
+
+	if rec.Code != http.StatusCreated {
+		t.Errorf("Expected recorder status 201, got %d", rec.Code)
+	}
+
+	if code, ok := w.StatusCode(); !ok || code != http.StatusCreated {
+		t.Errorf("Expected tracked status 201, got %d", code)
+	}
+
+	if rec.Body.String() != "created" {
+		t.Errorf("Expected body 'created', got %q", rec.Body.String())
+	}
+}
+
+func TestNewTestRequest(t *testing.T) {
+	resetAppConfig()
+	Configure(nil)
+
+	r := NewTestRequest(http.MethodPost, "/users?page=2", strings.NewReader(`{"name":"John"}`))
+
+	if r.Method != http.MethodPost || r.QueryInt("page", 0) != 2 {
+		t.Errorf("Unexpected request: %s %s", r.Method, r.URL)
+	}
+
+	type user struct {
+		Name string `json:"name"`
+	}
+
+	u, _, err := BindJSON[user](r, false)
+	if err != nil || u.Name != "John" {
+		t.Errorf("Expected body to be bindable, got %+v (err: %v)", u, err)
+	}
+}
+
+func TestNewTestRequest_WithI18n(t *testing.T) {
+	setupTestConfig(t)
+
+	r := NewTestRequest(http.MethodGet, "/", http.NoBody)
+
+	printer, ok := i18n.PrinterFromContext(r.Context())
+	if !ok {
+		t.Fatal("Expected i18n printer in request context")
+	}
+
+	i18nConfig, _ := defaultApp.i18nCatalogs.Configuration()
+	if expected := defaultApp.GetI18nPrinter(i18nConfig.SupportedLanguages[0]); printer != expected {
+		t.Error("Expected printer for the first supported language")
+	}
+
+	if i18nConfig.SupportedLanguages[0] == language.Und {
+		t.Error("Expected a defined default language")
+	}
+}
@@ -0,0 +1,144 @@
+package webfram
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// ErrUploadTooLarge is returned by FormFile and SaveUploadedFile when the request body exceeds MaxUploadSize.
+var ErrUploadTooLarge = errors.New("upload too large")
+
+// FormFile returns the first file for the given multipart form field.
+// Unlike http.Request.FormFile, the request body is limited to the configured MaxUploadSize and
+// at most MultipartMaxMemory bytes are kept in memory; the rest is stored in temporary files.
+// Returns ErrUploadTooLarge if the limit is exceeded, or http.ErrMissingFile if the field has no file.
+func (r *Request) FormFile(field string) (multipart.File, *multipart.FileHeader, error) {
+	if err := r.parseMultipartForm(); err != nil {
+		return nil, nil, err
+	}
+
+	return r.Request.FormFile(field)
+}
+
+// MultipartValues returns the non-file fields of the multipart form, as sent by the client.
+// It is safe to call after BindForm or FormFile parsed the form, and does not read the body again.
+// Returns nil if the request body has not been parsed as a multipart form.
+func (r *Request) MultipartValues() url.Values {
+	if r.MultipartForm == nil {
+		return nil
+	}
+	return r.MultipartForm.Value
+}
+
+// MultipartFiles returns the files of the multipart form by field name.
+// It is safe to call after BindForm or FormFile parsed the form, and does not read the body again.
+// Returns nil if the request body has not been parsed as a multipart form.
+func (r *Request) MultipartFiles() map[string][]*multipart.FileHeader {
+	if r.MultipartForm == nil {
+		return nil
+	}
+	return r.MultipartForm.File
+}
+
+// parseMultipartForm parses the multipart body once, limited to the configured MaxUploadSize and
+// keeping at most MultipartMaxMemory bytes in memory.
+func (r *Request) parseMultipartForm() error {
+	if r.MultipartForm != nil {
+		return nil
+	}
+
+	app := appFromContext(r.Context())
+	r.Body = http.MaxBytesReader(nil, r.Body, app.maxUploadSize)
+
+	if err := r.ParseMultipartForm(app.multipartMaxMemory); err != nil {
+		return uploadError(err)
+	}
+	return nil
+}
+
+// isMultipartRequest reports whether the request body is a multipart/form-data form.
+func isMultipartRequest(r *Request) bool {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return mediaType == "multipart/form-data"
+}
+
+// SaveUploadedFile streams the first file of the given multipart form field to dstPath and returns
+// the number of bytes written. The file is copied directly from the request body without buffering
+// the form, so other parts of the form are not available afterwards; call FormFile first if they are
+// needed. The request body is limited to the configured MaxUploadSize. If the upload fails, the
+// partially written file is removed.
+// Returns ErrUploadTooLarge if the limit is exceeded, or http.ErrMissingFile if the field has no file.
+func (r *Request) SaveUploadedFile(field, dstPath string) (int64, error) {
+	if r.MultipartForm != nil {
+		file, _, err := r.Request.FormFile(field)
+		if err != nil {
+			return 0, err
+		}
+		defer file.Close()
+
+		return saveFile(dstPath, file)
+	}
+
+	r.Body = http.MaxBytesReader(nil, r.Body, appFromContext(r.Context()).maxUploadSize)
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return 0, http.ErrMissingFile
+		}
+		if err != nil {
+			return 0, uploadError(err)
+		}
+
+		if part.FormName() == field && part.FileName() != "" {
+			n, err := saveFile(dstPath, part)
+			_ = part.Close()
+			return n, err
+		}
+
+		_ = part.Close()
+	}
+}
+
+// saveFile copies src to a new file at dstPath, removing the file if copying fails.
+func saveFile(dstPath string, src io.Reader) (int64, error) {
+	dst, err := os.Create(dstPath)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := io.Copy(dst, src)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.Remove(dstPath)
+		return 0, uploadError(err)
+	}
+
+	return n, nil
+}
+
+// uploadError reports errors caused by the MaxUploadSize limit as ErrUploadTooLarge, also wrapping ErrBodyTooLarge.
+func uploadError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return &bodyError{
+			cause: ErrBodyTooLarge,
+			err:   fmt.Errorf("%w: limit is %d bytes", ErrUploadTooLarge, maxBytesErr.Limit),
+		}
+	}
+	return err
+}
@@ -0,0 +1,220 @@
+package webfram
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newUploadRequest(t *testing.T, field, filename, content string) *Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+
+	if err := mw.WriteField("title", "report"); err != nil {
+		t.Fatal(err)
+	}
+
+	fw, err := mw.CreateFormFile(field, filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = io.WriteString(fw, content); err != nil {
+		t.Fatal(err)
+	}
+	if err = mw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewTestRequest(http.MethodPost, "/upload", &body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	return r
+}
+
+func TestRequest_FormFile(t *testing.T) {
+	resetAppConfig()
+	Configure(nil)
+
+	r := newUploadRequest(t, "document", "report.txt", "file content")
+
+	file, header, err := r.FormFile("document")
+	if err != nil {
+		t.Fatalf("FormFile failed: %v", err)
+	}
+	defer file.Close()
+
+	if header.Filename != "report.txt" {
+		t.Errorf("Expected filename 'report.txt', got %q", header.Filename)
+	}
+
+	content, _ := io.ReadAll(file)
+	if string(content) != "file content" {
+		t.Errorf("Expected 'file content', got %q", content)
+	}
+
+	if r.FormValue("title") != "report" {
+		t.Errorf("Expected other form fields to be available, got %q", r.FormValue("title"))
+	}
+
+	if _, _, err = r.FormFile("missing"); !errors.Is(err, http.ErrMissingFile) {
+		t.Errorf("Expected http.ErrMissingFile, got %v", err)
+	}
+}
+
+func TestRequest_FormFile_TooLarge(t *testing.T) {
+	resetAppConfig()
+	Configure(&Config{MaxUploadSize: 128})
+
+	r := newUploadRequest(t, "document", "report.txt", strings.Repeat("x", 1024))
+
+	if _, _, err := r.FormFile("document"); !errors.Is(err, ErrUploadTooLarge) {
+		t.Errorf("Expected ErrUploadTooLarge, got %v", err)
+	}
+}
+
+func TestRequest_SaveUploadedFile(t *testing.T) {
+	resetAppConfig()
+	Configure(nil)
+
+	dst := filepath.Join(t.TempDir(), "upload.txt")
+	r := newUploadRequest(t, "document", "report.txt", "streamed content")
+
+	n, err := r.SaveUploadedFile("document", dst)
+	if err != nil {
+		t.Fatalf("SaveUploadedFile failed: %v", err)
+	}
+
+	if n != int64(len("streamed content")) {
+		t.Errorf("Expected %d bytes written, got %d", len("streamed content"), n)
+	}
+
+	content, _ := os.ReadFile(dst)
+	if string(content) != "streamed content" {
+		t.Errorf("Expected 'streamed content', got %q", content)
+	}
+}
+
+func TestRequest_SaveUploadedFile_AfterFormFile(t *testing.T) {
+	resetAppConfig()
+	Configure(nil)
+
+	dst := filepath.Join(t.TempDir(), "upload.txt")
+	r := newUploadRequest(t, "document", "report.txt", "parsed content")
+
+	if _, _, err := r.FormFile("document"); err != nil {
+		t.Fatalf("FormFile failed: %v", err)
+	}
+
+	if _, err := r.SaveUploadedFile("document", dst); err != nil {
+		t.Fatalf("SaveUploadedFile failed: %v", err)
+	}
+
+	content, _ := os.ReadFile(dst)
+	if string(content) != "parsed content" {
+		t.Errorf("Expected 'parsed content', got %q", content)
+	}
+}
+
+func TestRequest_SaveUploadedFile_Missing(t *testing.T) {
+	resetAppConfig()
+	Configure(nil)
+
+	dst := filepath.Join(t.TempDir(), "upload.txt")
+	r := newUploadRequest(t, "document", "report.txt", "content")
+
+	if _, err := r.SaveUploadedFile("attachment", dst); !errors.Is(err, http.ErrMissingFile) {
+		t.Errorf("Expected http.ErrMissingFile, got %v", err)
+	}
+
+	if _, err := os.Stat(dst); !os.IsNotExist(err) {
+		t.Error("Expected no file to be created")
+	}
+}
+
+func TestRequest_SaveUploadedFile_TooLarge(t *testing.T) {
+	resetAppConfig()
+	Configure(&Config{MaxUploadSize: 512})
+
+	dst := filepath.Join(t.TempDir(), "upload.txt")
+	r := newUploadRequest(t, "document", "report.txt", strings.Repeat("x", 4096))
+
+	if _, err := r.SaveUploadedFile("document", dst); !errors.Is(err, ErrUploadTooLarge) {
+		t.Errorf("Expected ErrUploadTooLarge, got %v", err)
+	}
+
+	if _, err := os.Stat(dst); !os.IsNotExist(err) {
+		t.Error("Expected partially written file to be removed")
+	}
+}
+
+func TestConfigureUploads(t *testing.T) {
+	resetAppConfig()
+	Configure(&Config{MaxUploadSize: 1 << 30, MultipartMaxMemory: 1 << 20})
+
+	if defaultApp.maxUploadSize != 1<<30 || defaultApp.multipartMaxMemory != 1<<20 {
+		t.Errorf("Expected configured upload limits, got %d and %d", defaultApp.maxUploadSize, defaultApp.multipartMaxMemory)
+	}
+
+	resetAppConfig()
+	Configure(nil)
+
+	if defaultApp.maxUploadSize != defaultMaxUploadSize || defaultApp.multipartMaxMemory != defaultMultipartMaxMemory {
+		t.Errorf("Expected default upload limits, got %d and %d", defaultApp.maxUploadSize, defaultApp.multipartMaxMemory)
+	}
+}
+
+func TestBindForm_Multipart(t *testing.T) {
+	resetAppConfig()
+	Configure(nil)
+
+	type uploadForm struct {
+		Title string `form:"title" validate:"required"`
+	}
+
+	r := newUploadRequest(t, "document", "report.txt", "file content")
+
+	form, valErrs, err := BindForm[uploadForm](r)
+	if err != nil {
+		t.Fatalf("BindForm failed: %v", err)
+	}
+	if valErrs.Any() {
+		t.Fatalf("Expected no validation errors, got %v", valErrs.Errors)
+	}
+	if form.Title != "report" {
+		t.Errorf("Expected title 'report', got %q", form.Title)
+	}
+
+	if got := r.MultipartValues().Get("title"); got != "report" {
+		t.Errorf("Expected multipart value 'report', got %q", got)
+	}
+
+	files := r.MultipartFiles()["document"]
+	if len(files) != 1 || files[0].Filename != "report.txt" {
+		t.Fatalf("Expected the uploaded document, got %v", files)
+	}
+
+	file, _, err := r.FormFile("document")
+	if err != nil {
+		t.Fatalf("FormFile after BindForm failed: %v", err)
+	}
+	defer file.Close()
+
+	if content, _ := io.ReadAll(file); string(content) != "file content" {
+		t.Errorf("Expected 'file content', got %q", content)
+	}
+}
+
+func TestRequest_MultipartValues_NotParsed(t *testing.T) {
+	r := NewTestRequest(http.MethodPost, "/upload", strings.NewReader("title=report"))
+
+	if r.MultipartValues() != nil || r.MultipartFiles() != nil {
+		t.Error("Expected nil multipart values and files before the form is parsed")
+	}
+}
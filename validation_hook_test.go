@@ -0,0 +1,147 @@
+package webfram
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// setupValidationHook configures the default app with an OnValidationError hook recording the reported errors.
+func setupValidationHook(t *testing.T) *[]*ValidationErrors {
+	t.Helper()
+	resetAppConfig()
+	t.Cleanup(resetAppConfig)
+
+	var reported []*ValidationErrors
+	Configure(&Config{
+		OnValidationError: func(_ *Request, errs *ValidationErrors) {
+			reported = append(reported, errs)
+		},
+	})
+
+	return &reported
+}
+
+func TestOnValidationError_Binders(t *testing.T) {
+	type user struct {
+		Name string `json:"name" form:"name" validate:"required"`
+	}
+
+	newJSONRequest := func(body string) *Request {
+		r := NewTestRequest(http.MethodPost, "/", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		return r
+	}
+
+	tests := []struct {
+		name          string
+		bind          func() *ValidationErrors
+		expectedCalls int
+	}{
+		{"BindJSON invalid", func() *ValidationErrors {
+			_, errs, _ := BindJSON[user](newJSONRequest(`{}`), true)
+			return errs
+		}, 1},
+		{"BindJSON valid", func() *ValidationErrors {
+			_, errs, _ := BindJSON[user](newJSONRequest(`{"name":"Ada"}`), true)
+			return errs
+		}, 0},
+		{"BindJSON without validation", func() *ValidationErrors {
+			_, errs, _ := BindJSON[user](newJSONRequest(`{}`), false)
+			return errs
+		}, 0},
+		{"BindJSON malformed", func() *ValidationErrors {
+			_, errs, _ := BindJSON[user](newJSONRequest(`{`), true)
+			return errs
+		}, 0},
+		{"BindQuery invalid", func() *ValidationErrors {
+			_, errs, _ := BindQuery[user](NewTestRequest(http.MethodGet, "/", http.NoBody))
+			return errs
+		}, 1},
+		{"ValidateOnly invalid", func() *ValidationErrors {
+			errs, _ := ValidateOnly[user](newJSONRequest(`{}`))
+			return errs
+		}, 1},
+		{"BindAll invalid path, query and body", func() *ValidationErrors {
+			r := newBindAllRequest("/items/0?page=0", "application/json", `{}`)
+			r.SetPathValue("id", "0")
+			_, _, _, errs, _ := BindAll[bindAllPath, bindAllQuery, bindAllBody](r, true)
+			return errs
+		}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reported := setupValidationHook(t)
+
+			errs := tt.bind()
+
+			if len(*reported) != tt.expectedCalls {
+				t.Fatalf("Expected %d calls, got %d", tt.expectedCalls, len(*reported))
+			}
+			if tt.expectedCalls == 1 && (*reported)[0] != errs {
+				t.Errorf("Expected the returned validation errors to be reported, got %+v", (*reported)[0])
+			}
+		})
+	}
+}
+
+func TestOnValidationError_BindAllAggregates(t *testing.T) {
+	reported := setupValidationHook(t)
+
+	r := newBindAllRequest("/items/0?page=0", "application/json", `{}`)
+	r.SetPathValue("id", "0")
+	_, _, _, _, _ = BindAll[bindAllPath, bindAllQuery, bindAllBody](r, true)
+
+	if len(*reported) != 1 {
+		t.Fatalf("Expected a single call, got %d", len(*reported))
+	}
+
+	var fields []string
+	for _, ve := range (*reported)[0].Errors {
+		fields = append(fields, ve.Field)
+	}
+	if got := strings.Join(fields, ","); got != "path.ID,query.Page,body.name" {
+		t.Errorf("Expected errors of the path, query and body, got %q", got)
+	}
+}
+
+func TestOnValidationError_PatchJSON(t *testing.T) {
+	reported := setupValidationHook(t)
+
+	type user struct {
+		Name string `json:"name" validate:"required"`
+	}
+
+	r := NewTestRequest(http.MethodPatch, "/", strings.NewReader(`[{"op":"replace","path":"/name","value":""}]`))
+	r.Header.Set("Content-Type", "application/json-patch+json")
+
+	u := user{Name: "Ada"}
+	valErrors, err := PatchJSON(r, &u, true)
+	if err != nil {
+		t.Fatalf("PatchJSON failed: %v", err)
+	}
+
+	if len(*reported) != 1 || len((*reported)[0].Errors) != len(valErrors) {
+		t.Errorf("Expected the %d validation errors to be reported once, got %+v", len(valErrors), *reported)
+	}
+}
+
+func TestOnValidationError_BindCSV(t *testing.T) {
+	reported := setupValidationHook(t)
+
+	type row struct {
+		Name string `csv:"name" validate:"required"`
+	}
+
+	r := NewTestRequest(http.MethodPost, "/", strings.NewReader("name\nAda\n\"\"\nGrace\n"))
+	r.Header.Set("Content-Type", "text/csv")
+
+	if err := BindCSV(r, func(row, int) error { return nil }); err == nil {
+		t.Fatal("Expected an error for the invalid row")
+	}
+
+	if len(*reported) != 1 {
+		t.Errorf("Expected a single call for the invalid row, got %d", len(*reported))
+	}
+}
@@ -0,0 +1,125 @@
+package webfram
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultWebhookSignatureHeader = "X-Webhook-Signature"
+	defaultWebhookMaxRetries      = 3
+	defaultWebhookBackoff         = time.Second
+	defaultWebhookTimeout         = 10 * time.Second
+)
+
+// ErrWebhookDelivery is returned by SendWebhook when the receiver does not accept the webhook.
+var ErrWebhookDelivery = errors.New("webhook delivery failed")
+
+// WebhookSendOptions configures SendWebhook.
+type WebhookSendOptions struct {
+	// Secret signs the payload with HMAC-SHA256. The signature is sent in SignatureHeader as
+	// "sha256=<hex digest of the body>". The payload is not signed if Secret is empty.
+	Secret string
+	// SignatureHeader is the header carrying the signature (default: "X-Webhook-Signature").
+	SignatureHeader string
+	// Headers are additional request headers, e.g. an event type.
+	Headers map[string]string
+	// MaxRetries is the number of retries after a 5xx response or a network error (default: 3).
+	// A negative value disables retries.
+	MaxRetries int
+	// Backoff is the delay before the first retry, doubled for every further retry (default: 1s).
+	Backoff time.Duration
+	// Client sends the requests (default: a client with a 10s timeout).
+	Client *http.Client
+}
+
+// SendWebhook POSTs payload as JSON to url, signed with an HMAC header when a secret is configured.
+// Responses with a 5xx status code and network errors are retried with exponential backoff, waiting
+// for ctx between attempts; other responses are not retried.
+// Returns nil on a 2xx response, an error wrapping ErrWebhookDelivery with the status code of the last
+// response otherwise, or the error of the last attempt. Returns ctx.Err() if ctx is done before delivery.
+func SendWebhook(ctx context.Context, url string, payload any, opts WebhookSendOptions) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("webhook payload: %w", err)
+	}
+
+	signatureHeader := getValueOrDefault(opts.SignatureHeader, defaultWebhookSignatureHeader)
+	backoff := getValueOrDefault(opts.Backoff, defaultWebhookBackoff)
+	maxRetries := getValueOrDefault(opts.MaxRetries, defaultWebhookMaxRetries)
+	client := opts.Client
+	if client == nil {
+		client = &http.Client{Timeout: defaultWebhookTimeout}
+	}
+
+	for attempt := 0; ; attempt++ {
+		retry, err := sendWebhookAttempt(ctx, client, url, body, signatureHeader, opts)
+		if !retry || attempt >= maxRetries {
+			return err
+		}
+
+		timer := time.NewTimer(backoff << attempt)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// sendWebhookAttempt sends the webhook once and reports whether the attempt can be retried.
+func sendWebhookAttempt(
+	ctx context.Context,
+	client *http.Client,
+	url string,
+	body []byte,
+	signatureHeader string,
+	opts WebhookSendOptions,
+) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range opts.Headers {
+		req.Header.Set(name, value)
+	}
+	if opts.Secret != "" {
+		req.Header.Set(signatureHeader, "sha256="+webhookSignature(opts.Secret, body))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return false, ctxErr
+		}
+		return true, err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return false, nil
+	}
+
+	err = fmt.Errorf("%w: %s responded with status %d", ErrWebhookDelivery, url, resp.StatusCode)
+	return resp.StatusCode >= http.StatusInternalServerError, err
+}
+
+// webhookSignature returns the hex encoded HMAC-SHA256 of body with secret.
+func webhookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
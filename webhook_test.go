@@ -0,0 +1,128 @@
+package webfram
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSendWebhook_SignsPayload(t *testing.T) {
+	var gotBody []byte
+	var gotSignature, gotContentType, gotEvent string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSignature = r.Header.Get("X-Webhook-Signature")
+		gotContentType = r.Header.Get("Content-Type")
+		gotEvent = r.Header.Get("X-Event")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	err := SendWebhook(context.Background(), srv.URL, map[string]string{"id": "42"}, WebhookSendOptions{
+		Secret:  "secret",
+		Headers: map[string]string{"X-Event": "order.created"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if string(gotBody) != `{"id":"42"}` {
+		t.Errorf("Unexpected body %s", gotBody)
+	}
+	if gotContentType != "application/json" {
+		t.Errorf("Expected Content-Type application/json, got %q", gotContentType)
+	}
+	if gotEvent != "order.created" {
+		t.Errorf("Expected X-Event header, got %q", gotEvent)
+	}
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(gotBody)
+	if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); gotSignature != want {
+		t.Errorf("Expected signature %q, got %q", want, gotSignature)
+	}
+}
+
+func TestSendWebhook_RetriesServerErrors(t *testing.T) {
+	var attempts atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	err := SendWebhook(context.Background(), srv.URL, "ping", WebhookSendOptions{Backoff: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("Expected 3 attempts, got %d", got)
+	}
+}
+
+func TestSendWebhook_GivesUp(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		maxRetries int
+		attempts   int32
+	}{
+		{"client error is not retried", http.StatusBadRequest, 3, 1},
+		{"server error until retries are exhausted", http.StatusInternalServerError, 2, 3},
+		{"retries disabled", http.StatusInternalServerError, -1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				attempts.Add(1)
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			err := SendWebhook(context.Background(), srv.URL, "ping", WebhookSendOptions{
+				MaxRetries: tt.maxRetries,
+				Backoff:    time.Millisecond,
+			})
+			if !errors.Is(err, ErrWebhookDelivery) {
+				t.Errorf("Expected ErrWebhookDelivery, got %v", err)
+			}
+			if got := attempts.Load(); got != tt.attempts {
+				t.Errorf("Expected %d attempts, got %d", tt.attempts, got)
+			}
+		})
+	}
+}
+
+func TestSendWebhook_HonorsContextCancellation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := SendWebhook(ctx, srv.URL, "ping", WebhookSendOptions{Backoff: time.Hour})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected SendWebhook to stop waiting on cancellation, took %v", elapsed)
+	}
+}